@@ -20,16 +20,19 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
 )
 
 // +kubebuilder:object:root=true
 
 // +kubebuilder:resource:scope=Cluster,shortName={acr,acrs}
+// +kubebuilder:subresource:status
 // AvailableCapacityReservation is the Schema for the availablecapacitiereservations API
 type AvailableCapacityReservation struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 	Spec              api.AvailableCapacityReservation `json:"spec,omitempty"`
+	Status            apiV1.CRStatus                   `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -51,4 +54,5 @@ func (in *AvailableCapacityReservation) DeepCopyInto(out *AvailableCapacityReser
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 }
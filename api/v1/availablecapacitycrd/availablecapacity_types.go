@@ -20,15 +20,18 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
 )
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:scope=Cluster,shortName={ac,acs}
+// +kubebuilder:subresource:status
 // AvailableCapacity is the Schema for the availablecapacities API
 type AvailableCapacity struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 	Spec              api.AvailableCapacity `json:"spec,omitempty"`
+	Status            apiV1.CRStatus        `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -50,4 +53,5 @@ func (in *AvailableCapacity) DeepCopyInto(out *AvailableCapacity) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 }
@@ -0,0 +1,72 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacityhistorycrd
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CapacitySample is the snapshot of capacity of the storage class on the node
+type CapacitySample struct {
+	// Time is the moment when snapshot was taken
+	Time metav1.Time `json:"time"`
+	// Used is the size of capacity in bytes which is taken by volumes
+	Used int64 `json:"used"`
+	// Free is the size of available capacity in bytes
+	Free int64 `json:"free"`
+}
+
+// StorageClassHistory keeps snapshots and high-water mark of capacity of the storage class on the node
+type StorageClassHistory struct {
+	StorageClass string `json:"storageClass"`
+	// HighWaterMark is the maximal used size in bytes which was ever observed
+	HighWaterMark int64 `json:"highWaterMark"`
+	// HighWaterMarkTime is the moment when HighWaterMark was observed
+	HighWaterMarkTime metav1.Time `json:"highWaterMarkTime,omitempty"`
+	// Samples are ordered by time, the oldest ones are dropped when their amount exceeds the limit
+	Samples []CapacitySample `json:"samples,omitempty"`
+}
+
+// CapacityHistorySpec contains capacity history of the node
+type CapacityHistorySpec struct {
+	NodeID         string                `json:"nodeID"`
+	StorageClasses []StorageClassHistory `json:"storageClasses,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// +kubebuilder:resource:scope=Cluster
+// CapacityHistory is the Schema for the CapacityHistories API
+type CapacityHistory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              CapacityHistorySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CapacityHistoryList contains a list of CapacityHistory
+// +kubebuilder:object:generate=true
+type CapacityHistoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CapacityHistory `json:"items"`
+}
+
+func init() {
+	SchemeBuilderCapacityHistory.Register(&CapacityHistory{}, &CapacityHistoryList{})
+}
@@ -23,6 +23,11 @@ const (
 	LVGKind                          = "LVG"
 	DriveKind                        = "Drive"
 	CSIBMNodeKind                    = "Node"
+	CSIBMDeploymentKind              = "CSIBMDeployment"
+	CapacityHistoryKind              = "CapacityHistory"
+	InventoryKind                    = "Inventory"
+	VolumeMoveKind                   = "VolumeMove"
+	StorageGroupKind                 = "StorageGroup"
 
 	Version = "v1"
 	// TODO: change value, https://github.com/dell/csi-baremetal/issues/134
@@ -35,6 +40,7 @@ const (
 	Removing           = "removing"
 	Removed            = "removed"
 	Failed             = "failed"
+	Retained           = "retained"
 	Empty              = ""
 
 	// Health statuses
@@ -76,11 +82,28 @@ const (
 	ModeRAW = "RAW"
 	ModeFS  = "FS"
 
+	// Volume partition layout on drive
+	PartitionLayoutSingle    = "SINGLE"     // the only GPT partition takes the whole drive, is used if layout is empty
+	PartitionLayoutWholeDisk = "WHOLE_DISK" // volume takes the whole drive without partition table
+	PartitionLayoutMulti     = "MULTI"      // one of GPT partitions of volume size, drive is shared by volumes
+
 	// Volume location type
 	LocationTypeDrive = "DRIVE"
 	LocationTypeLVM   = "LVM"
 	LocationTypeNVMe  = "NVME"
 
+	// LVG failure domain, drives of LVG must not share it
+	FailureDomainEnclosure  = "enclosure"
+	FailureDomainController = "controller"
+
+	// VolumeMove phases, empty phase means that move wasn't started yet
+	VolumeMoveProvisioning = "PROVISIONING" // target volume is being created
+	VolumeMoveReceiving    = "RECEIVING"    // target node prepares receiver of data
+	VolumeMoveCopying      = "COPYING"      // source node sends data to target node
+	VolumeMoveRebinding    = "REBINDING"    // PVC is being bound to PV of target volume
+	VolumeMoveCompleted    = "COMPLETED"
+	VolumeMoveFailed       = "FAILED"
+
 	// CSI StorageClass
 	StorageClassAny       = "ANY"
 	StorageClassHDD       = "HDD"
@@ -97,4 +120,42 @@ const (
 
 	LocateStatusOn  = int32(1)
 	LocateStatusOff = int32(0)
+
+	// DriveLocateAnnotationKey is set on Drive CR to request drive LED locate on the node, values are start and stop
+	DriveLocateAnnotationKey = "drive.csi-baremetal.dell.com/locate"
+	DriveLocateStart         = "start"
+	DriveLocateStop          = "stop"
+	// DriveReplacementAnnotationKey is set on Drive CR to request drive release before physical replacement
+	DriveReplacementAnnotationKey = "drive.csi-baremetal.dell.com/replacement"
+	// DriveBenchmarkAnnotationKey is set on Drive CR to request I/O benchmark of unallocated drive,
+	// value is an optional duration of benchmark in seconds
+	DriveBenchmarkAnnotationKey = "drive.csi-baremetal.dell.com/benchmark"
+	// DriveBenchmarkResultAnnotationKey is set on Drive CR by node when benchmark is finished,
+	// value is JSON with IOPS, throughput and latency or with the reason of failure
+	DriveBenchmarkResultAnnotationKey = "drive.csi-baremetal.dell.com/benchmark-result"
+	// VolumeImportAnnotationKey is set on Volume CR in empty status to adopt pre-existing partition or LV,
+	// value is the name of StorageClass which is set in created PV
+	VolumeImportAnnotationKey = "volume.csi-baremetal.dell.com/import"
+	// VolumeDataRemovalAnnotationKey is set on Volume CR to allow removal of the volume which file system
+	// isn't empty when deletion protection is enabled on the node
+	VolumeDataRemovalAnnotationKey = "volume.csi-baremetal.dell.com/allow-data-removal"
+	// VolumeRetainedClaimAnnotationKey is set on Volume CR in Retained status by controller,
+	// value is <namespace>/<name> of PVC which used the volume
+	VolumeRetainedClaimAnnotationKey = "volume.csi-baremetal.dell.com/retained-claim"
+	// VolumeRebindAnnotationKey is set on Volume CR in Retained status to bind its PV to another PVC,
+	// value is <namespace>/<name> of PVC
+	VolumeRebindAnnotationKey = "volume.csi-baremetal.dell.com/rebind"
+	// VolumeReleaseAnnotationKey is set on Volume CR in Retained status to remove the volume and its PV
+	VolumeReleaseAnnotationKey = "volume.csi-baremetal.dell.com/release"
+	// ProvisioningPriorityAnnotationKey is set on PVC to order creation of its volume among requests which wait
+	// for the same node, value is one of critical, normal (default) and background
+	ProvisioningPriorityAnnotationKey = "volume.csi-baremetal.dell.com/provisioning-priority"
+	ProvisioningPriorityCritical      = "critical"
+	ProvisioningPriorityNormal        = "normal"
+	ProvisioningPriorityBackground    = "background"
+	// StorageGroupLabelKey is set on Drive CR by controller, value is the name of StorageGroup which selects the drive
+	StorageGroupLabelKey = "drive.csi-baremetal.dell.com/storage-group"
+	// NodeUpgradeAnnotationKey is set on k8s Node by operator while plugin pod on the node is being updated,
+	// controller doesn't place new volumes on such node until the annotation is removed
+	NodeUpgradeAnnotationKey = "node.csi-baremetal.dell.com/upgrade"
 )
@@ -0,0 +1,68 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploymentcrd
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+)
+
+// CSIBMDeploymentSpec describes installation of the driver
+type CSIBMDeploymentSpec struct {
+	// Registry is the docker registry from which images of the driver and sidecars are pulled
+	Registry string `json:"registry,omitempty"`
+	// Version is the tag of images of the driver, the driver is upgraded when it is changed
+	Version string `json:"version"`
+	// PullPolicy is the pull policy of images, Always by default
+	PullPolicy string `json:"pullPolicy,omitempty"`
+	// NodeSelector restricts nodes on which node service of the driver is deployed
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// StorageClasses is the list of storage types, e.g. ANY, HDD or SSDLVG, for which StorageClasses are created
+	StorageClasses []string `json:"storageClasses,omitempty"`
+	// HWManagerEndpoint is the gRPC endpoint of drive manager,
+	// drive manager based on system utils is run inside node service if it is empty
+	HWManagerEndpoint string `json:"hwManagerEndpoint,omitempty"`
+	// LogLevel is the log level of the driver components, one of info, debug, trace
+	LogLevel string `json:"logLevel,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// CSIBMDeployment is the Schema for the CSIBMDeployment API
+type CSIBMDeployment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              CSIBMDeploymentSpec `json:"spec,omitempty"`
+	Status            apiV1.CRStatus      `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CSIBMDeploymentList contains a list of CSIBMDeployment
+//+kubebuilder:object:generate=true
+type CSIBMDeploymentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CSIBMDeployment `json:"items"`
+}
+
+func init() {
+	SchemeBuilderCSIBMDeployment.Register(&CSIBMDeployment{}, &CSIBMDeploymentList{})
+}
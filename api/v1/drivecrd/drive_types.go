@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -28,13 +29,16 @@ import (
 // +kubebuilder:object:root=true
 
 // Drive is the Schema for the drives API
+// +kubebuilder:storageversion
 //kubebuilder:object:generate=false
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
 type Drive struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec api.Drive `json:"spec,omitempty"`
+	Spec   api.Drive      `json:"spec,omitempty"`
+	Status apiV1.CRStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -53,6 +57,7 @@ func (in *Drive) DeepCopyInto(out *Drive) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 func init() {
@@ -68,5 +73,15 @@ func (in *Drive) Equals(drive *api.Drive) bool {
 		in.Spec.Health == drive.Health &&
 		in.Spec.Type == drive.Type &&
 		in.Spec.Size == drive.Size &&
-		in.Spec.Path == drive.Path
+		in.Spec.Path == drive.Path &&
+		in.Spec.Firmware == drive.Firmware &&
+		in.Spec.WWN == drive.WWN &&
+		in.Spec.LinkSpeed == drive.LinkSpeed &&
+		in.Spec.Interface == drive.Interface &&
+		in.Spec.Controller == drive.Controller &&
+		in.Spec.MediaErrors == drive.MediaErrors &&
+		in.Spec.HealthScore == drive.HealthScore &&
+		in.Spec.Enclosure == drive.Enclosure &&
+		in.Spec.Slot == drive.Slot &&
+		in.Spec.Bay == drive.Bay
 }
@@ -0,0 +1,79 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventorycrd
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InventoryDrive describes hardware of the drive on the node
+type InventoryDrive struct {
+	UUID         string `json:"uuid"`
+	SerialNumber string `json:"serialNumber"`
+	VID          string `json:"vid,omitempty"`
+	PID          string `json:"pid,omitempty"`
+	Type         string `json:"type,omitempty"`
+	// Size of the drive in bytes
+	Size      int64  `json:"size,omitempty"`
+	Firmware  string `json:"firmware,omitempty"`
+	WWN       string `json:"wwn,omitempty"`
+	Enclosure string `json:"enclosure,omitempty"`
+	Slot      string `json:"slot,omitempty"`
+	Bay       string `json:"bay,omitempty"`
+	LinkSpeed string `json:"linkSpeed,omitempty"`
+	Interface string `json:"interface,omitempty"`
+	// Controller is the storage controller (HBA) which the drive is connected to
+	Controller string `json:"controller,omitempty"`
+	Path       string `json:"path,omitempty"`
+	Health     string `json:"health,omitempty"`
+	Status     string `json:"status,omitempty"`
+	IsSystem   bool   `json:"isSystem,omitempty"`
+}
+
+// InventorySpec contains drives of the node ordered by serial number
+type InventorySpec struct {
+	NodeID string `json:"nodeID"`
+	// TotalDrives is the amount of drives on the node
+	TotalDrives int `json:"totalDrives"`
+	// TotalSize is the summary size of drives on the node in bytes
+	TotalSize int64            `json:"totalSize"`
+	Drives    []InventoryDrive `json:"drives,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// +kubebuilder:resource:scope=Cluster
+// Inventory is the Schema for the Inventories API
+type Inventory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              InventorySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// InventoryList contains a list of Inventory
+// +kubebuilder:object:generate=true
+type InventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Inventory `json:"items"`
+}
+
+func init() {
+	SchemeBuilderInventory.Register(&Inventory{}, &InventoryList{})
+}
@@ -0,0 +1,166 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition types
+const (
+	// ConditionReady means that object is ready to be used
+	ConditionReady = "Ready"
+	// ConditionProvisioned means that underlying storage of the object exists on the node
+	ConditionProvisioned = "Provisioned"
+	// ConditionPublished means that volume is mounted into the pod
+	ConditionPublished = "Published"
+	// ConditionDegraded means that object or its underlying storage has health problems
+	ConditionDegraded = "Degraded"
+	// ConditionProgressing means that controller is creating or removing underlying storage of the object,
+	// reason of the condition shows the current phase
+	ConditionProgressing = "Progressing"
+	// ConditionMounted means that published volume is mounted to its target path and the mount is accessible
+	ConditionMounted = "Mounted"
+	// ConditionWritable means that file system of published volume accepts writes, it is False when kernel
+	// remounted file system read-only because of I/O errors
+	ConditionWritable = "Writable"
+)
+
+// Reasons of Progressing condition
+const (
+	// ReasonWaitingForLVG means that volume waits for underlying LVG to be created
+	ReasonWaitingForLVG = "WaitingForLVG"
+	// ReasonRetrying means that the last attempt failed and operation will be retried after backoff
+	ReasonRetrying = "Retrying"
+)
+
+// Reasons of Mounted condition
+const (
+	// ReasonMountLost means that target path isn't a mount point anymore or it isn't accessible
+	ReasonMountLost = "MountLost"
+	// ReasonRemounted means that lost mount was restored by the node
+	ReasonRemounted = "Remounted"
+	// ReasonMountVerified means that mount was verified successfully
+	ReasonMountVerified = "MountVerified"
+)
+
+// Reasons of Writable condition
+const (
+	// ReasonRemountedReadOnly means that kernel remounted file system read-only because of errors
+	ReasonRemountedReadOnly = "RemountedReadOnly"
+	// ReasonReadWrite means that file system is mounted read-write
+	ReasonReadWrite = "ReadWrite"
+)
+
+// Condition describes state of the custom resource at a certain point
+type Condition struct {
+	// Type of condition, one of Ready, Provisioned, Published, Degraded, Progressing, Mounted, Writable
+	Type string `json:"type"`
+	// Status of the condition, one of True, False, Unknown
+	Status coreV1.ConditionStatus `json:"status"`
+	// Reason is a machine readable reason of the last transition, it is CamelCase string
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable details about the last transition
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is the time when condition changed its status
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// CRStatus is the status of custom resources of the driver
+type CRStatus struct {
+	// ObservedGeneration is the generation of the custom resource that was handled by controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions is a list of the current states of the custom resource
+	Conditions []Condition `json:"conditions,omitempty"`
+	// Retries is the number of failed attempts of the current operation with the custom resource
+	Retries int32 `json:"retries,omitempty"`
+}
+
+// GetCondition returns condition with provided type or nil if there is no such condition
+func (s *CRStatus) GetCondition(conditionType string) *Condition {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == conditionType {
+			return &s.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsConditionTrue checks whether condition with provided type exists and has True status
+func (s *CRStatus) IsConditionTrue(conditionType string) bool {
+	c := s.GetCondition(conditionType)
+	return c != nil && c.Status == coreV1.ConditionTrue
+}
+
+// SetCondition adds condition or updates existing one with the same type.
+// LastTransitionTime is changed only if status of the condition was changed
+// Returns true if status was modified
+func (s *CRStatus) SetCondition(conditionType string, status coreV1.ConditionStatus, reason, message string) bool {
+	c := s.GetCondition(conditionType)
+	if c == nil {
+		s.Conditions = append(s.Conditions, Condition{
+			Type:               conditionType,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: metav1.Now(),
+		})
+		return true
+	}
+
+	if c.Status == status && c.Reason == reason && c.Message == message {
+		return false
+	}
+	if c.Status != status {
+		c.LastTransitionTime = metav1.Now()
+	}
+	c.Status = status
+	c.Reason = reason
+	c.Message = message
+	return true
+}
+
+// SetObservedGeneration sets ObservedGeneration field
+// Returns true if status was modified
+func (s *CRStatus) SetObservedGeneration(generation int64) bool {
+	if s.ObservedGeneration == generation {
+		return false
+	}
+	s.ObservedGeneration = generation
+	return true
+}
+
+// DeepCopyInto copies all properties of this object into another object of the same type
+func (s *CRStatus) DeepCopyInto(out *CRStatus) {
+	*out = *s
+	if s.Conditions != nil {
+		out.Conditions = make([]Condition, len(s.Conditions))
+		for i := range s.Conditions {
+			out.Conditions[i] = s.Conditions[i]
+			s.Conditions[i].LastTransitionTime.DeepCopyInto(&out.Conditions[i].LastTransitionTime)
+		}
+	}
+}
+
+// ConditionStatusFromBool converts bool value to the condition status
+func ConditionStatusFromBool(value bool) coreV1.ConditionStatus {
+	if value {
+		return coreV1.ConditionTrue
+	}
+	return coreV1.ConditionFalse
+}
@@ -0,0 +1,69 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+)
+
+func TestCRStatus_SetCondition(t *testing.T) {
+	st := &CRStatus{}
+
+	// new condition
+	assert.True(t, st.SetCondition(ConditionReady, coreV1.ConditionFalse, "Creating", ""))
+	assert.Len(t, st.Conditions, 1)
+	assert.False(t, st.IsConditionTrue(ConditionReady))
+	transitionTime := st.GetCondition(ConditionReady).LastTransitionTime
+
+	// nothing changed
+	assert.False(t, st.SetCondition(ConditionReady, coreV1.ConditionFalse, "Creating", ""))
+
+	// reason changed, transition time remains
+	assert.True(t, st.SetCondition(ConditionReady, coreV1.ConditionFalse, "Failed", "error"))
+	assert.Equal(t, transitionTime, st.GetCondition(ConditionReady).LastTransitionTime)
+	assert.Equal(t, "error", st.GetCondition(ConditionReady).Message)
+
+	// status changed
+	assert.True(t, st.SetCondition(ConditionReady, coreV1.ConditionTrue, "Created", ""))
+	assert.True(t, st.IsConditionTrue(ConditionReady))
+	assert.Len(t, st.Conditions, 1)
+
+	assert.Nil(t, st.GetCondition(ConditionDegraded))
+}
+
+func TestCRStatus_SetObservedGeneration(t *testing.T) {
+	st := &CRStatus{}
+
+	assert.True(t, st.SetObservedGeneration(2))
+	assert.False(t, st.SetObservedGeneration(2))
+	assert.Equal(t, int64(2), st.ObservedGeneration)
+}
+
+func TestCRStatus_DeepCopyInto(t *testing.T) {
+	st := &CRStatus{ObservedGeneration: 1}
+	st.SetCondition(ConditionReady, coreV1.ConditionTrue, "Created", "")
+
+	out := &CRStatus{}
+	st.DeepCopyInto(out)
+	assert.Equal(t, st, out)
+
+	out.Conditions[0].Reason = "Failed"
+	assert.Equal(t, "Created", st.Conditions[0].Reason)
+}
@@ -0,0 +1,73 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagegroupcrd
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DriveSelector selects drives of the storage group, drive is selected if it matches all non-empty criteria,
+// drive matches list criterion if its value is in the list. Empty selector doesn't select drives
+type DriveSelector struct {
+	// SerialNumbers of selected drives
+	SerialNumbers []string `json:"serialNumbers,omitempty"`
+	// Slots of selected drives in their enclosures
+	Slots []string `json:"slots,omitempty"`
+	// Types of selected drives: HDD, SSD or NVME
+	Types []string `json:"types,omitempty"`
+	// NodeIDs of nodes which drives are selected
+	NodeIDs []string `json:"nodeIDs,omitempty"`
+	// MatchLabels are labels which Drive CR of selected drive must have
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+}
+
+// StorageGroupSpec describes the pool of drives which is used by volumes with the same storage group
+// in parameters of their StorageClass, volumes without storage group don't use drives of any pool
+type StorageGroupSpec struct {
+	DriveSelector DriveSelector `json:"driveSelector"`
+}
+
+// StorageGroupStatus is reported by controller
+type StorageGroupStatus struct {
+	// Drives is the amount of drives in the storage group
+	Drives int `json:"drives"`
+}
+
+// +kubebuilder:object:root=true
+
+// +kubebuilder:resource:scope=Cluster
+// StorageGroup is the Schema for the StorageGroups API
+type StorageGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              StorageGroupSpec   `json:"spec,omitempty"`
+	Status            StorageGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// StorageGroupList contains a list of StorageGroup
+// +kubebuilder:object:generate=true
+type StorageGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StorageGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilderStorageGroup.Register(&StorageGroup{}, &StorageGroupList{})
+}
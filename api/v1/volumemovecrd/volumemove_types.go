@@ -0,0 +1,65 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumemovecrd
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VolumeMoveSpec describes copying of data of the volume to the new volume on another node,
+// the progress is reported by controller and nodes in Phase
+type VolumeMoveSpec struct {
+	// SourceVolumeID is the ID of the volume which data is copied, it is the name of its PV
+	SourceVolumeID string `json:"sourceVolumeID"`
+	// TargetNodeID is the ID of the node on which the new volume is provisioned, if it is empty
+	// controller chooses the node with the most free capacity of the storage class of the source volume
+	TargetNodeID string `json:"targetNodeID,omitempty"`
+	// TargetVolumeID is the ID of the new volume, it is set by controller
+	TargetVolumeID string `json:"targetVolumeID,omitempty"`
+	// Phase is empty for the new move, Completed or Failed for the finished one
+	Phase string `json:"phase,omitempty"`
+	// ReceiverAddress is the address on which target node waits for data, it is set by target node
+	ReceiverAddress string `json:"receiverAddress,omitempty"`
+	// ReceiverToken authenticates the source node on the receiver, it is set by target node
+	ReceiverToken string `json:"receiverToken,omitempty"`
+	// Message contains the reason of failure
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// +kubebuilder:resource:scope=Cluster
+// VolumeMove is the Schema for the VolumeMoves API
+type VolumeMove struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              VolumeMoveSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VolumeMoveList contains a list of VolumeMove
+// +kubebuilder:object:generate=true
+type VolumeMoveList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VolumeMove `json:"items"`
+}
+
+func init() {
+	SchemeBuilderVolumeMove.Register(&VolumeMove{}, &VolumeMoveList{})
+}
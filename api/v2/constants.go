@@ -0,0 +1,28 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v2 contains constants of v2 version of custom resources of the driver. In v2 the runtime state
+// of Volume, Drive and LVG is kept in status instead of spec, v1 remains the storage version
+package v2
+
+import apiV1 "github.com/dell/csi-baremetal/api/v1"
+
+const (
+	// Version is the version of v2 custom resources
+	Version = "v2"
+	// APIV2Version is the apiVersion of v2 custom resources
+	APIV2Version = apiV1.CSICRsGroupVersion + "/" + Version
+)
@@ -0,0 +1,94 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drivecrd
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// Drive is the Schema for the drives API
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+type Drive struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DriveSpec   `json:"spec,omitempty"`
+	Status DriveStatus `json:"status,omitempty"`
+}
+
+// DriveSpec describes the drive reported by drive manager, field names are the same as in v1
+type DriveSpec struct {
+	UUID         string `json:"UUID,omitempty"`
+	VID          string `json:"VID,omitempty"`
+	PID          string `json:"PID,omitempty"`
+	SerialNumber string `json:"SerialNumber,omitempty"`
+	Type         string `json:"Type,omitempty"`
+	// size in bytes
+	Size   int64  `json:"Size,omitempty"`
+	NodeId string `json:"NodeId,omitempty"`
+	// path to the device. may not be set by drivemgr.
+	Path      string `json:"Path,omitempty"`
+	Enclosure string `json:"Enclosure,omitempty"`
+	Slot      string `json:"Slot,omitempty"`
+	Bay       string `json:"Bay,omitempty"`
+	Firmware  string `json:"Firmware,omitempty"`
+	Endurance int64  `json:"Endurance,omitempty"`
+	LEDState  string `json:"LEDState,omitempty"`
+	IsSystem  bool   `json:"IsSystem,omitempty"`
+	// World Wide Name of the drive
+	WWN string `json:"WWN,omitempty"`
+	// negotiated link speed, for example 6.0 Gb/s
+	LinkSpeed string `json:"LinkSpeed,omitempty"`
+	// negotiated interface (transport protocol), for example SATA, SAS or NVMe
+	Interface string `json:"Interface,omitempty"`
+	// storage controller (HBA) which the drive is connected to, for example host0
+	Controller string `json:"Controller,omitempty"`
+}
+
+// DriveStatus is the observed state of the drive, it contains fields which are kept in spec in v1
+type DriveStatus struct {
+	apiV1.CRStatus `json:",inline"`
+
+	Health string `json:"health,omitempty"`
+	// State is ONLINE or OFFLINE, it is kept in Status field of spec in v1
+	State             string `json:"state,omitempty"`
+	OperationalStatus string `json:"operationalStatus,omitempty"`
+	// amount of I/O errors of the drive which were found in kernel log by node
+	IOErrors int64 `json:"ioErrors,omitempty"`
+	// amount of bad sectors of SATA and SAS drives or media errors of NVMe drives reported by SMART
+	MediaErrors int64 `json:"mediaErrors,omitempty"`
+	// health score calculated by node from SMART, kernel log and latency, 0 means that problems weren't found
+	HealthScore int64 `json:"healthScore,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DriveList contains a list of Drive
+type DriveList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Drive `json:"items"`
+}
+
+func init() {
+	SchemeBuilderDrive.Register(&Drive{}, &DriveList{})
+}
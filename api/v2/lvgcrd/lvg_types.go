@@ -0,0 +1,67 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lvgcrd
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// LVG is the Schema for the LVGs API
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+type LVG struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              LVGSpec   `json:"spec,omitempty"`
+	Status            LVGStatus `json:"status,omitempty"`
+}
+
+// LVGSpec is the desired state of the LVG, field names are the same as in v1
+type LVGSpec struct {
+	Name       string   `json:"Name,omitempty"`
+	Node       string   `json:"Node,omitempty"`
+	Locations  []string `json:"Locations,omitempty"`
+	Size       int64    `json:"Size,omitempty"`
+	VolumeRefs []string `json:"VolumeRefs,omitempty"`
+	// drives of LVG must be placed in distinct failure domains: enclosure or controller, empty means no restriction
+	FailureDomain string `json:"FailureDomain,omitempty"`
+}
+
+// LVGStatus is the observed state of the LVG, it contains fields which are kept in spec in v1
+type LVGStatus struct {
+	apiV1.CRStatus `json:",inline"`
+
+	// State is the status of LVG creation or removal, it is kept in Status field of spec in v1
+	State string `json:"state,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LVGList contains a list of LVG
+type LVGList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LVG `json:"items"`
+}
+
+func init() {
+	SchemeBuilderLVG.Register(&LVG{}, &LVGList{})
+}
@@ -0,0 +1,78 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumecrd
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// Volume is the Schema for the volumes API
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+type Volume struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VolumeSpec   `json:"spec,omitempty"`
+	Status VolumeStatus `json:"status,omitempty"`
+}
+
+// VolumeSpec is the desired state of the volume, field names are the same as in v1
+type VolumeSpec struct {
+	Id              string   `json:"Id,omitempty"`
+	Location        string   `json:"Location,omitempty"`
+	LocationType    string   `json:"LocationType,omitempty"`
+	StorageClass    string   `json:"StorageClass,omitempty"`
+	StorageGroup    string   `json:"StorageGroup,omitempty"`
+	NodeId          string   `json:"NodeId,omitempty"`
+	Owners          []string `json:"Owners,omitempty"`
+	Size            int64    `json:"Size,omitempty"`
+	Mode            string   `json:"Mode,omitempty"`
+	Type            string   `json:"Type,omitempty"`
+	Ephemeral       bool     `json:"Ephemeral,omitempty"`
+	ReadOnly        bool     `json:"ReadOnly,omitempty"`
+	PartitionLayout string   `json:"PartitionLayout,omitempty"`
+}
+
+// VolumeStatus is the observed state of the volume, it contains fields which are kept in spec in v1
+type VolumeStatus struct {
+	apiV1.CRStatus `json:",inline"`
+
+	CSIStatus         string `json:"csiStatus,omitempty"`
+	Health            string `json:"health,omitempty"`
+	OperationalStatus string `json:"operationalStatus,omitempty"`
+	// paths from the last NodePublishVolume request, they are used for verification of mounts
+	StagingTargetPath string `json:"stagingTargetPath,omitempty"`
+	TargetPath        string `json:"targetPath,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VolumeList contains a list of Volume
+type VolumeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Volume `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Volume{}, &VolumeList{})
+}
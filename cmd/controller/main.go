@@ -18,25 +18,46 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"net"
+	"net/http"
+	"os"
 	"strconv"
+	"time"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 
 	// +kubebuilder:scaffold:imports
 
 	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
 	"github.com/dell/csi-baremetal/pkg/base/featureconfig"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
 	"github.com/dell/csi-baremetal/pkg/base/rpc"
 	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/common"
 	"github.com/dell/csi-baremetal/pkg/controller"
+	"github.com/dell/csi-baremetal/pkg/controller/acreconcile"
+	"github.com/dell/csi-baremetal/pkg/controller/capacityhistory"
+	"github.com/dell/csi-baremetal/pkg/controller/chargeback"
+	"github.com/dell/csi-baremetal/pkg/controller/gc"
+	"github.com/dell/csi-baremetal/pkg/controller/leader"
+	"github.com/dell/csi-baremetal/pkg/controller/rebalance"
+	"github.com/dell/csi-baremetal/pkg/controller/retention"
+	"github.com/dell/csi-baremetal/pkg/controller/storagegroup"
+	"github.com/dell/csi-baremetal/pkg/controller/volumemove"
+	"github.com/dell/csi-baremetal/pkg/crdconversion"
+	"github.com/dell/csi-baremetal/pkg/events"
 )
 
+const componentName = "baremetal-csi-controller"
+
 var (
 	namespace  = flag.String("namespace", "", "Namespace in which controller service run")
 	healthIP   = flag.String("healthip", base.DefaultHealthIP, "IP for health service")
@@ -45,15 +66,95 @@ var (
 	logPath    = flag.String("logpath", "", "Log path for Controller service")
 	useACRs    = flag.Bool("extender", false,
 		"Whether controller should read AvailableCapacityReservation CR during CreateVolume request or not")
+	subDrive = flag.Bool("subdrive", false,
+		"Whether several volumes with HDD or SSD storage class could be placed on partitions of one drive or not")
+	orphanTimeout = flag.Duration("orphantimeout", 0,
+		"Timeout after which custom resources of the node removed from cluster are deleted, 0 disables removal")
+	anyThreshold = flag.String("anythreshold", "100Gi",
+		"Size up to which volume with ANY storage class is considered as a small one")
+	anySmallPriority = flag.String("anysmallpriority", "NVME,SSD,HDD",
+		"Order in which storage classes are tried for small volumes with ANY storage class")
+	anyLargePriority = flag.String("anylargepriority", "HDD,SSD,NVME",
+		"Order in which storage classes are tried for large volumes with ANY storage class")
+	anyMinFree = flag.Int("anyminfree", 0,
+		"Percent of free capacity of storage class which shouldn't be consumed by volume with ANY storage class")
+	sizePolicy = flag.String("sizepolicy", "",
+		"Comma separated min, max and rounding of volume size per storage class, e.g. HDD=1Gi:10Ti:1Gi,HDDLVG=::4Mi")
+	maxVolumesPerDrive = flag.Int("maxvolumesperdrive", 0,
+		"Maximal amount of sub-drive volumes on one drive, 0 means that only partition table restricts it")
+	maxLVsPerVG = flag.Int("maxlvspervg", 0,
+		"Maximal amount of logical volumes in one volume group, 0 means unlimited")
+	onlineExpansion = flag.String("onlineexpansion", controller.DefaultOnlineExpansionFS,
+		"Comma separated file systems which could be expanded while volume is published, others are expanded offline")
+	ephemeralCleanup = flag.Bool("ephemeralcleanup", true,
+		"Whether controller should delete volumes of generic ephemeral PVCs which were released or not")
+	retentionEnabled = flag.Bool("retention", true,
+		"Whether controller should keep volumes of released PVs with Retain reclaim policy in Retained status or not")
+	rebalanceEnabled = flag.Bool("rebalance", false,
+		"Whether controller should send events with capacity rebalancing recommendations or not")
+	rebalanceHigh = flag.Int("rebalancehigh", 90,
+		"Percent of used capacity of storage class above which node is considered as overloaded")
+	rebalanceLow = flag.Int("rebalancelow", 30,
+		"Percent of used capacity of storage class below which node is considered as underloaded")
+	capacityHistoryEnabled = flag.Bool("capacityhistory", false,
+		"Whether controller should record history of capacity usage in CapacityHistory CRs or not")
+	capacityHistoryInterval = flag.Duration("capacityhistoryinterval", capacityhistory.DefaultInterval,
+		"Interval between two snapshots of capacity usage")
+	capacityHistorySamples = flag.Int("capacityhistorysamples", capacityhistory.DefaultMaxSamples,
+		"Amount of snapshots of capacity usage which are kept per storage class on the node")
+	conversionWebhook = flag.Bool("conversionwebhook", false,
+		"Whether controller should serve conversion webhook of Volume, Drive and LVG CRDs and enable it in CRDs or not")
+	webhookPort    = flag.Int("webhookport", 8443, "Port on which conversion webhook is served")
+	webhookCert    = flag.String("webhookcert", "", "Path to TLS certificate of conversion webhook")
+	webhookKey     = flag.String("webhookkey", "", "Path to private key of TLS certificate of conversion webhook")
+	webhookCA      = flag.String("webhookca", "", "Path to CA certificate which signed TLS certificate of conversion webhook")
+	webhookService = flag.String("webhookservice", "baremetal-csi-controller-webhook",
+		"Name of the service in controller namespace through which kube-apiserver calls conversion webhook")
+	storageMigration = flag.Bool("storagemigration", false,
+		"Whether controller should rewrite Volume, Drive and LVG custom resources in the storage version or not")
+	leaderElection = flag.Bool("leaderelection", false,
+		"Whether replicas of controller should elect the leader through Lease which runs background components or not")
+	volumeMoveEnabled = flag.Bool("volumemove", false,
+		"Whether controller should handle VolumeMove CRs which copy volumes to other nodes or not")
+	storageGroupsEnabled = flag.Bool("storagegroups", false,
+		"Whether controller should assign drives to StorageGroup CRs according to their drive selectors or not")
+	nodeOperationsLimit = flag.Int("nodeoperationslimit", 10,
+		"Maximal amount of volumes which are created or deleted concurrently on one node, 0 doesn't set restriction")
+	pvcMetadataKeys = flag.String("pvcmetadatakeys", "app,team,cost-center",
+		"Comma separated keys of PVC labels and annotations which are copied to Volume CR on its creation")
+	acBatchInterval = flag.Duration("acbatchinterval", common.DefaultACBatchInterval,
+		"Interval between writes of accumulated changes of AvailableCapacity sizes, 0 writes them on each change")
+	acReconcileInterval = flag.Duration("acreconcileinterval", acreconcile.DefaultInterval,
+		"Interval between corrections of AvailableCapacity sizes according to volumes, 0 disables corrections")
+	chargebackEnabled = flag.Bool("chargeback", false,
+		"Whether controller should report capacity provisioned per namespace and per PVC metadata keys or not")
+	chargebackPort = flag.Int("chargebackport", 8890,
+		"Port on which the last chargeback report is served in JSON")
+	chargebackInterval = flag.Duration("chargebackinterval", chargeback.DefaultInterval,
+		"Interval between two chargeback reports")
+	crCache = flag.Bool("crcache", true,
+		"Whether Volume, Drive, LVG and AvailableCapacity CRs are read from informers instead of API server or not")
+	kubeQPS = flag.Float64("kubeqps", 50,
+		"Maximal rate of requests to API server per second, 0 keeps default of client-go")
+	kubeBurst = flag.Int("kubeburst", 100,
+		"Maximal burst of requests to API server above the rate, 0 keeps default of client-go")
+	kubeThrottleRetries = flag.Int("kubethrottleretries", 5,
+		"Amount of retries of requests which are rejected by API server because of its load, 0 disables retries")
 	logLevel = flag.String("loglevel", base.InfoLevel,
 		fmt.Sprintf("Log level, support values are %s, %s, %s", base.InfoLevel, base.DebugLevel, base.TraceLevel))
 )
 
 func main() {
 	flag.Parse()
+	k8s.SetClientOptions(k8s.ClientOptions{
+		QPS:             float32(*kubeQPS),
+		Burst:           *kubeBurst,
+		ThrottleRetries: *kubeThrottleRetries,
+	})
 
 	featureConf := featureconfig.NewFeatureConfig()
 	featureConf.Update(featureconfig.FeatureACReservation, *useACRs)
+	featureConf.Update(featureconfig.FeatureSubDriveAllocation, *subDrive)
 
 	logger, err := base.InitLogger(*logPath, *logLevel)
 	if err != nil {
@@ -64,12 +165,50 @@ func main() {
 
 	csiControllerServer := rpc.NewServerRunner(nil, *endpoint, logger)
 
-	k8SClient, err := k8s.GetK8SClient()
+	k8SClient, err := k8s.GetK8SClientWithCache(*crCache, logger)
 	if err != nil {
 		logger.Fatalf("fail to create kubernetes client, error: %v", err)
 	}
 	kubeClient := k8s.NewKubeClient(k8SClient, logger, *namespace)
-	controllerService := controller.NewControllerService(kubeClient, logger, featureConf)
+	anyPolicy, err := capacityplanner.NewAnyPolicy(*anyThreshold, *anySmallPriority, *anyLargePriority, *anyMinFree)
+	if err != nil {
+		logger.Fatalf("fail to parse placement policy for ANY storage class: %v", err)
+	}
+	volumeSizePolicy, err := capacityplanner.NewSizePolicy(*sizePolicy)
+	if err != nil {
+		logger.Fatalf("fail to parse volume size policy: %v", err)
+	}
+	densityPolicy, err := capacityplanner.NewDensityPolicy(*maxVolumesPerDrive, *maxLVsPerVG)
+	if err != nil {
+		logger.Fatalf("fail to create volume density policy: %v", err)
+	}
+	controllerService := controller.NewControllerService(kubeClient, logger, featureConf, anyPolicy, volumeSizePolicy,
+		controller.NewExpansionPolicy(*onlineExpansion), densityPolicy)
+	controllerService.SetNodeOperationsLimit(*nodeOperationsLimit)
+	controllerService.SetPVCMetadataKeys(*pvcMetadataKeys)
+	var acBatcher *common.ACBatcher
+	if *acBatchInterval > 0 {
+		acBatcher = common.NewACBatcher(kubeClient, logger, *acBatchInterval)
+		controllerService.SetACBatcher(acBatcher)
+		acBatcher.Run()
+	}
+	// chargeback report only reads CRs, so it is generated by each replica
+	if *chargebackEnabled {
+		runChargebackReporter(kubeClient, logger)
+	}
+	// conversion webhook is served by each replica, kube-apiserver calls it through the service
+	if *conversionWebhook {
+		runConversionWebhook(logger)
+	}
+	// background components change CRs without coordination, therefore only the leader runs them
+	startComponents := func() {
+		runComponents(kubeClient, controllerService, acBatcher, logger)
+	}
+	if *leaderElection {
+		runLeaderElection(*namespace, logger, startComponents)
+	} else {
+		startComponents()
+	}
 	handler := util.NewSignalHandler(logger)
 	go handler.SetupSIGTERMHandler(csiControllerServer)
 
@@ -78,7 +217,7 @@ func main() {
 	go func() {
 		logger.Info("Starting Controller Health server ...")
 		if err := util.SetupAndStartHealthCheckServer(
-			controllerService, logger,
+			controllerService, nil, logger,
 			"tcp://"+net.JoinHostPort(*healthIP, strconv.Itoa(*healthPort))); err != nil {
 			logger.Fatalf("Controller service failed with error: %v", err)
 		}
@@ -89,3 +228,122 @@ func main() {
 	}
 	logger.Info("Got SIGTERM signal")
 }
+
+// runComponents starts background components of controller which are enabled by flags
+func runComponents(kubeClient *k8s.KubeClient, controllerService *controller.CSIControllerService,
+	acBatcher *common.ACBatcher, logger *logrus.Logger) {
+	if *conversionWebhook {
+		caBundle, err := ioutil.ReadFile(*webhookCA)
+		if err != nil {
+			logger.Fatalf("fail to read CA certificate of conversion webhook: %v", err)
+		}
+		if err = crdconversion.EnableWebhook(context.Background(), kubeClient, *namespace, *webhookService,
+			caBundle); err != nil {
+			logger.Fatalf("fail to enable conversion webhook in CRDs: %v", err)
+		}
+	}
+	if *storageMigration {
+		crdconversion.NewMigrator(kubeClient, logger).Run()
+	}
+	if *orphanTimeout > 0 {
+		gc.NewOrphanCollector(kubeClient, logger, *orphanTimeout).Run()
+	}
+	if *ephemeralCleanup {
+		gc.NewEphemeralCollector(kubeClient, controllerService, logger).Run()
+	}
+	if *retentionEnabled {
+		retention.NewKeeper(kubeClient, controllerService, logger).Run()
+	}
+	if *rebalanceEnabled {
+		eventRecorder, err := prepareEventRecorder(logger)
+		if err != nil {
+			logger.Fatalf("fail to prepare event recorder: %v", err)
+		}
+		rebalance.NewAnalyzer(kubeClient, eventRecorder, logger, *rebalanceHigh, *rebalanceLow).Run()
+	}
+	if *capacityHistoryEnabled {
+		capacityhistory.NewRecorder(kubeClient, logger, *capacityHistoryInterval, *capacityHistorySamples).Run()
+	}
+	if *acReconcileInterval > 0 {
+		acreconcile.NewReconciler(kubeClient, acBatcher, logger, *acReconcileInterval).Run()
+	}
+	if *volumeMoveEnabled {
+		volumemove.NewMover(kubeClient, controllerService, logger, volumemove.DefaultInterval).Run()
+	}
+	if *storageGroupsEnabled {
+		storagegroup.NewLabeler(kubeClient, logger, storagegroup.DefaultInterval).Run()
+	}
+}
+
+// runChargebackReporter spawns goroutines which periodically generate chargeback reports and serve the last one
+func runChargebackReporter(kubeClient *k8s.KubeClient, logger *logrus.Logger) {
+	reporter := chargeback.NewReporter(kubeClient, logger, *pvcMetadataKeys, *chargebackInterval)
+	reporter.Run()
+	mux := http.NewServeMux()
+	mux.Handle(chargeback.ReportPattern, reporter)
+	go func() {
+		logger.Infof("Starting chargeback report server on port %d ...", *chargebackPort)
+		if err := http.ListenAndServe(fmt.Sprintf(":%d", *chargebackPort), mux); err != nil {
+			logger.Fatalf("Chargeback report server failed with error: %v", err)
+		}
+	}()
+}
+
+// runConversionWebhook spawns goroutine which serves conversion webhook of CRDs over TLS
+func runConversionWebhook(logger *logrus.Logger) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(crdconversion.ConvertPattern, crdconversion.NewWebhook(logger).ConvertHandler)
+	go func() {
+		logger.Infof("Starting conversion webhook on port %d ...", *webhookPort)
+		if err := http.ListenAndServeTLS(fmt.Sprintf(":%d", *webhookPort), *webhookCert, *webhookKey,
+			mux); err != nil {
+			logger.Fatalf("Conversion webhook failed with error: %v", err)
+		}
+	}()
+}
+
+// runLeaderElection spawns goroutine which waits until the replica becomes the leader and calls onStartedLeading,
+// the replica exits if leadership is lost, so it is restarted in standby mode
+func runLeaderElection(namespace string, logger *logrus.Logger, onStartedLeading func()) {
+	k8SClientset, err := k8s.GetK8SClientset()
+	if err != nil {
+		logger.Fatalf("fail to create kubernetes client, error: %v", err)
+	}
+	identity, err := os.Hostname()
+	if err != nil {
+		logger.Fatalf("fail to determine identity of the replica: %v", err)
+	}
+	elector := leader.NewElector(k8SClientset, namespace, identity, logger)
+	go func() {
+		err := elector.Run(context.Background(), func(context.Context) {
+			onStartedLeading()
+		}, func() {
+			logger.Fatal("Leadership is lost, exiting")
+		})
+		if err != nil {
+			logger.Fatalf("fail to run leader election: %v", err)
+		}
+	}()
+}
+
+// prepareEventRecorder helper which makes all the work to get EventRecorder
+func prepareEventRecorder(logger *logrus.Logger) (*events.Recorder, error) {
+	// clientset needed to send events
+	k8SClientset, err := k8s.GetK8SClientset()
+	if err != nil {
+		return nil, fmt.Errorf("fail to create kubernetes client, error: %s", err)
+	}
+	eventInter := k8SClientset.CoreV1().Events("")
+
+	scheme, err := k8s.PrepareScheme()
+	if err != nil {
+		return nil, fmt.Errorf("fail to prepare kubernetes scheme, error: %s", err)
+	}
+
+	opt := events.Options{Logger: logger.WithField("componentName", "Events")}
+	eventRecorder, err := events.New(componentName, "", eventInter, scheme, opt)
+	if err != nil {
+		return nil, fmt.Errorf("fail to create events recorder, error: %s", err)
+	}
+	return eventRecorder, nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package for main function of csibm command line tool, it could be used as kubectl plugin (kubectl-csibm)
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/cli"
+)
+
+const usage = `Usage: csibm [global flags] <command> [command flags]
+
+Commands:
+  drives   [--node <name>]       list drives with their health and usage
+  volumes  [--node <name>]       list volumes with their physical location
+  capacity [--node <name>]       list free capacity per node and storage class
+  placement --size <size> [--sc <storage class>] [--node <name>]
+                                 check on which nodes volume could be placed without creating it
+  locate   [--stop] <drive UUID> start or stop drive LED locate
+  replace  <drive UUID>          release drive before its physical replacement
+
+Global flags:
+`
+
+var (
+	namespace = flag.String("namespace", "default", "Namespace in which driver is installed")
+	logLevel  = flag.String("loglevel", base.InfoLevel,
+		fmt.Sprintf("Log level, support values are %s, %s, %s", base.InfoLevel, base.DebugLevel, base.TraceLevel))
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	logger, _ := base.InitLogger("", *logLevel)
+	if logger == nil {
+		fmt.Println("Unable to initialize logger")
+		os.Exit(1)
+	}
+
+	k8sClient, err := k8s.GetK8SClient()
+	if err != nil {
+		logger.Fatalf("Unable to create k8s client: %v", err)
+	}
+	inspector := cli.NewInspector(k8s.NewKubeClient(k8sClient, logger, *namespace), os.Stdout, logger)
+
+	cmd, args := flag.Arg(0), flag.Args()[1:]
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	node := fs.String("node", "", "Name or ID of the node, all nodes are used if empty")
+	stop := fs.Bool("stop", false, "Stop drive LED locate")
+	size := fs.String("size", "", "Size of the volume for placement check, e.g. 10Gi")
+	sc := fs.String("sc", apiV1.StorageClassAny, "Storage class of the volume for placement check")
+	_ = fs.Parse(args)
+
+	switch cmd {
+	case "drives":
+		err = inspector.PrintDrives(*node)
+	case "volumes":
+		err = inspector.PrintVolumes(*node)
+	case "capacity":
+		err = inspector.PrintCapacity(*node)
+	case "placement":
+		var bytes int64
+		if bytes, err = util.StrToBytes(*size); err == nil {
+			err = inspector.CheckPlacement(bytes, *sc, *node)
+		}
+	case "locate", "replace":
+		if fs.NArg() != 1 {
+			fmt.Fprintf(os.Stderr, "Drive UUID must be provided for %s command\n", cmd)
+			os.Exit(2)
+		}
+		if cmd == "locate" {
+			err = inspector.Locate(fs.Arg(0), *stop)
+		} else {
+			err = inspector.Replace(fs.Arg(0))
+		}
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Command %s failed: %v\n", cmd, err)
+		os.Exit(1)
+	}
+}
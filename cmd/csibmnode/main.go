@@ -27,9 +27,13 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	nodecrd "github.com/dell/csi-baremetal/api/v1/csibmnodecrd"
+	"github.com/dell/csi-baremetal/api/v1/deploymentcrd"
 	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
 	"github.com/dell/csi-baremetal/pkg/crcontrollers/csibmnode"
+	"github.com/dell/csi-baremetal/pkg/crcontrollers/deployment"
+	"github.com/dell/csi-baremetal/pkg/crcontrollers/storageclass"
+	"github.com/dell/csi-baremetal/pkg/webhook"
 )
 
 var (
@@ -39,6 +43,17 @@ var (
 		fmt.Sprintf("Log level, support values are %s, %s, %s", base.InfoLevel, base.DebugLevel, base.TraceLevel))
 	logFormat = flag.String("logformat", base.LogFormatText,
 		fmt.Sprintf("Log level, supported value is %s. Json format is used by default", base.LogFormatText))
+	bootstrapSC = flag.Bool("bootstrapsc", false,
+		"Whether controller should create and keep in sync StorageClasses of the driver or not")
+	scPrefix     = flag.String("scprefix", "baremetal-csi-sc", "Name prefix for StorageClasses of the driver")
+	scFsType     = flag.String("scfstype", base.DefaultFsType, "FS type which is set in StorageClasses of the driver")
+	scIsDefault  = flag.Bool("scdefault", true, "Whether StorageClass with ANY storage type is a default one or not")
+	deployDriver = flag.Bool("deploydriver", false,
+		"Whether controller should deploy and upgrade the driver according to CSIBMDeployment CR or not")
+	useWebhook  = flag.Bool("webhook", false, "Whether admission webhooks of the driver should be served or not")
+	webhookPort = flag.Int("webhookport", 9443, "Port on which admission webhooks are served")
+	certDir     = flag.String("webhookcertdir", "/tmp/k8s-webhook-server/serving-certs",
+		"Directory with tls.crt and tls.key files for admission webhooks server")
 )
 
 func main() {
@@ -77,6 +92,32 @@ func main() {
 		logger.Fatal(err)
 	}
 
+	// bind K8s Controller Manager as a controller for StorageClasses of the driver
+	if *bootstrapSC {
+		scCtrl := storageclass.NewController(kubeClient, storageclass.Config{
+			NamePrefix:    *scPrefix,
+			FsType:        *scFsType,
+			SetDefault:    *scIsDefault,
+			AutoProvision: *scAutoProvision,
+		}, logger)
+		if err = scCtrl.SetupWithManager(mgr); err != nil {
+			logger.Fatal(err)
+		}
+	}
+
+	// bind K8s Controller Manager as a controller for CSIBMDeployment CR
+	if *deployDriver {
+		if err = deployment.NewController(kubeClient, logger).SetupWithManager(mgr); err != nil {
+			logger.Fatal(err)
+		}
+	}
+
+	// serve admission webhooks which validate CRs of the driver and fill defaults in StorageClasses and PVCs
+	if *useWebhook {
+		webhook.NewCRValidator(logger).SetupWithManager(mgr)
+		webhook.NewMutator(kubeClient, logger).SetupWithManager(mgr)
+	}
+
 	logger.Info("Starting CSIBMNode Controller Manager ...")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		logger.Fatalf("CRD Controller Manager failed with error: %v", err)
@@ -98,9 +139,16 @@ func prepareK8sRuntimeManager() (ctrl.Manager, error) {
 		return nil, err
 	}
 
+	// register CSIBMDeployment CRD
+	if err = deploymentcrd.AddToSchemeCSIBMDeployment(scheme); err != nil {
+		return nil, err
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:    scheme,
 		Namespace: *namespace,
+		Port:      *webhookPort,
+		CertDir:   *certDir,
 	})
 
 	if err != nil {
@@ -30,6 +30,9 @@ import (
 var (
 	endpoint = flag.String("drivemgrendpoint", base.DefaultDriveMgrEndpoint, "DriveManager Endpoint")
 	logPath  = flag.String("logpath", "", "log path for DriveManager")
+	tlsCert  = flag.String("tlscert", "", "Path to TLS certificate of DriveManager, server is insecure if it is empty")
+	tlsKey   = flag.String("tlskey", "", "Path to private key of TLS certificate")
+	tlsCA    = flag.String("tlsca", "", "Path to CA certificate which is used for verification of client certificates")
 	logLevel = flag.String("loglevel", base.InfoLevel,
 		fmt.Sprintf("Log level, support values are %s, %s, %s", base.InfoLevel, base.DebugLevel, base.TraceLevel))
 )
@@ -42,8 +45,8 @@ func main() {
 		logger.Warnf("Can't set logger's output to %s. Using stdout instead.\n", *logPath)
 	}
 
-	// Server is insecure for now because credentials are nil
-	serverRunner := rpc.NewServerRunner(nil, *endpoint, logger)
+	serverRunner := dmsetup.NewServerRunner(*endpoint,
+		rpc.TLSConfig{CertFile: *tlsCert, KeyFile: *tlsKey, CAFile: *tlsCA}, logger)
 
 	e := &command.Executor{}
 	e.SetLogger(logger)
@@ -20,6 +20,7 @@ package dmsetup
 import (
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
 	"github.com/dell/csi-baremetal/pkg/base/rpc"
@@ -27,6 +28,19 @@ import (
 	"github.com/dell/csi-baremetal/pkg/drivemgr"
 )
 
+// NewServerRunner creates gRPC server runner for DriveMgr endpoint
+// Server uses mTLS if certificate is provided in tlsConfig or it is insecure otherwise
+func NewServerRunner(endpoint string, tlsConfig rpc.TLSConfig, logger *logrus.Logger) *rpc.ServerRunner {
+	var creds credentials.TransportCredentials
+	if tlsConfig.IsEnabled() {
+		var err error
+		if creds, err = rpc.NewServerCredentials(tlsConfig, logger); err != nil {
+			logger.Fatalf("Failed to prepare TLS credentials: %v", err)
+		}
+	}
+	return rpc.NewServerRunner(creds, endpoint, logger)
+}
+
 // SetupAndRunDriveMgr setups and start/stop particular drive manager
 func SetupAndRunDriveMgr(d drivemgr.DriveManager, sr *rpc.ServerRunner, cleanupFn func(), logger *logrus.Logger) {
 	logger.Info("Start DriveManager")
@@ -23,14 +23,19 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/fsnotify/fsnotify"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
@@ -42,12 +47,20 @@ import (
 	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
 	"github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+	"github.com/dell/csi-baremetal/pkg/base/chaos"
+	"github.com/dell/csi-baremetal/pkg/base/command"
 	"github.com/dell/csi-baremetal/pkg/base/featureconfig"
+	"github.com/dell/csi-baremetal/pkg/base/healthscore"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/uevent"
 	"github.com/dell/csi-baremetal/pkg/base/rpc"
 	"github.com/dell/csi-baremetal/pkg/base/util"
 	csibmnodeconst "github.com/dell/csi-baremetal/pkg/crcontrollers/csibmnode/common"
 	"github.com/dell/csi-baremetal/pkg/crcontrollers/lvg"
+	"github.com/dell/csi-baremetal/pkg/drivemgr"
+	"github.com/dell/csi-baremetal/pkg/drivemgr/basemgr"
+	"github.com/dell/csi-baremetal/pkg/drivemgr/loopbackmgr"
 	"github.com/dell/csi-baremetal/pkg/events"
 	"github.com/dell/csi-baremetal/pkg/node"
 )
@@ -58,7 +71,7 @@ const (
 
 var (
 	namespace        = flag.String("namespace", "", "Namespace in which Node Service service run")
-	driveMgrEndpoint = flag.String("drivemgrendpoint", base.DefaultDriveMgrEndpoint, "Hardware Manager endpoint")
+	driveMgrEndpoint = flag.String("drivemgrendpoint", base.DefaultDriveMgrEndpoint, "Hardware Manager endpoints, comma separated")
 	healthIP         = flag.String("healthip", base.DefaultHealthIP, "Node health server ip")
 	csiEndpoint      = flag.String("csiendpoint", "unix:///tmp/csi.sock", "CSI endpoint")
 	nodeName         = flag.String("nodename", "", "node identification by k8s")
@@ -68,16 +81,89 @@ var (
 		"Whether node svc should read AvailableCapacityReservation CR during NodePublish request for ephemeral volumes or not")
 	useNodeAnnotation = flag.Bool("usenodeannotation", false,
 		"Whether node svc should read id from node annotation and use it as id for all CRs or not")
+	subDrive = flag.Bool("subdrive", false,
+		"Whether several volumes with HDD or SSD storage class could be placed on partitions of one drive or not")
+	anyThreshold = flag.String("anythreshold", "100Gi",
+		"Size up to which volume with ANY storage class is considered as a small one")
+	anySmallPriority = flag.String("anysmallpriority", "NVME,SSD,HDD",
+		"Order in which storage classes are tried for small volumes with ANY storage class")
+	anyLargePriority = flag.String("anylargepriority", "HDD,SSD,NVME",
+		"Order in which storage classes are tried for large volumes with ANY storage class")
+	anyMinFree = flag.Int("anyminfree", 0,
+		"Percent of free capacity of storage class which shouldn't be consumed by volume with ANY storage class")
+	useUevents = flag.Bool("uevents", true,
+		"Whether node svc should run discovery on kernel uevents of drives hotplug and removal or not")
+	storageNodeSelector = flag.String("storagenodeselector", "",
+		"Label selector of k8s nodes which participate in the storage pool, all nodes participate if it is empty")
+	storageExcludeTaints = flag.String("storageexcludetaints", "",
+		"Comma separated keys of taints which exclude k8s node from the storage pool")
+	maxVolumesPerDrive = flag.Int("maxvolumesperdrive", 0,
+		"Maximal amount of sub-drive volumes on one drive, 0 means that only partition table restricts it")
+	maxLVsPerVG = flag.Int("maxlvspervg", 0,
+		"Maximal amount of logical volumes in one volume group, 0 means unlimited")
+	inlineDefaultSize = flag.String("inlinedefaultsize", "1Gi",
+		"Size of inline volume which volume context doesn't contain size")
+	maxVolumesPerNode = flag.Int64("maxvolumespernode", 0,
+		"Maximum amount of volumes which can be published on the node, "+
+			"0 means that it is calculated based on drives and LVGs")
+	trimEnabled = flag.Bool("trim", false,
+		"Whether node should periodically discard unused blocks of volumes based on SSD and NVMe drives or not")
+	trimInterval = flag.Duration("triminterval", node.DefaultTrimInterval,
+		"Interval between two trims of volumes based on SSD and NVMe drives")
+	mountCheckEnabled = flag.Bool("mountcheck", false,
+		"Whether node should periodically verify that published volumes are mounted to their target paths or not")
+	mountCheckInterval = flag.Duration("mountcheckinterval", node.DefaultMountCheckInterval,
+		"Interval between two verifications of mounts of published volumes")
+	remountLost = flag.Bool("remount", false,
+		"Whether node should restore lost mounts of published volumes or only report them")
+	ioErrorsEnabled = flag.Bool("ioerrors", false,
+		"Whether node should count I/O errors of drives from kernel log in Drive CRs or not")
+	healthPolicy = flag.String("healthpolicy", "",
+		fmt.Sprintf("Policy which calculates health score of drives from media errors, I/O errors and benchmark "+
+			"latency, health reported by drive manager is used as is if it is empty, e.g. %s", healthscore.DefaultPolicy))
+	evacuateSuspect = flag.Bool("evacuatesuspect", false,
+		"Whether volumes of drives which became SUSPECT should be moved to other nodes through VolumeMove CRs or not")
+	deletionProtection = flag.Bool("deletionprotection", false,
+		"Whether removal of volumes which file system isn't empty requires annotation on Volume CR or not")
+	volumeMoveEnabled = flag.Bool("volumemove", false,
+		"Whether node should send and receive data of volumes moved through VolumeMove CRs or not")
+	volumeMoveIP = flag.String("volumemoveip", "",
+		"IP on which node receives data of moved volumes, it must be reachable from other nodes")
+	driveMgrBackend = flag.String("drivemgrbackend", drivemgr.BackendGRPC,
+		fmt.Sprintf("Hardware Manager backend, support values are %s - separate service called through gRPC, "+
+			"%s - in-process manager based on system utils, %s - in-process manager of loopback devices for development",
+			drivemgr.BackendGRPC, drivemgr.BackendBaseMgr, drivemgr.BackendLoopback))
+	tlsCert = flag.String("tlscert", "",
+		"Path to TLS certificate which is used by health server and by client of DriveMgr, gRPC is insecure if it is empty")
+	tlsKey   = flag.String("tlskey", "", "Path to private key of TLS certificate")
+	tlsCA    = flag.String("tlsca", "", "Path to CA certificate which is used for verification of peer certificates")
 	logLevel = flag.String("loglevel", base.InfoLevel,
 		fmt.Sprintf("Log level, support values are %s, %s, %s", base.InfoLevel, base.DebugLevel, base.TraceLevel))
+	faults = flag.String("faults", os.Getenv(chaos.EnvName),
+		"Faults injected for resilience testing, e.g. mount=0.5,lsblk=10s,drivemgr=0.2,drivegone=SN1|SN2, "+
+			"must not be set in production")
+	crCache = flag.Bool("crcache", true,
+		"Whether Volume, Drive, LVG and AvailableCapacity CRs are read from informers instead of API server or not")
+	kubeQPS = flag.Float64("kubeqps", 50,
+		"Maximal rate of requests to API server per second, 0 keeps default of client-go")
+	kubeBurst = flag.Int("kubeburst", 100,
+		"Maximal burst of requests to API server above the rate, 0 keeps default of client-go")
+	kubeThrottleRetries = flag.Int("kubethrottleretries", 5,
+		"Amount of retries of requests which are rejected by API server because of its load, 0 disables retries")
 )
 
 func main() {
 	flag.Parse()
+	k8s.SetClientOptions(k8s.ClientOptions{
+		QPS:             float32(*kubeQPS),
+		Burst:           *kubeBurst,
+		ThrottleRetries: *kubeThrottleRetries,
+	})
 
 	featureConf := featureconfig.NewFeatureConfig()
 	featureConf.Update(featureconfig.FeatureACReservation, *useACRs)
 	featureConf.Update(featureconfig.FeatureNodeIDFromAnnotation, *useNodeAnnotation)
+	featureConf.Update(featureconfig.FeatureSubDriveAllocation, *subDrive)
 
 	logger, err := base.InitLogger(*logPath, *logLevel)
 	if err != nil {
@@ -86,17 +172,35 @@ func main() {
 
 	logger.Info("Starting Node Service")
 
-	// gRPC client for communication with DriveMgr via TCP socket
-	gRPCClient, err := rpc.NewClient(nil, *driveMgrEndpoint, logger)
+	tlsConfig := rpc.TLSConfig{CertFile: *tlsCert, KeyFile: *tlsKey, CAFile: *tlsCA}
+	var serverCreds, clientCreds credentials.TransportCredentials
+	if tlsConfig.IsEnabled() {
+		if serverCreds, err = rpc.NewServerCredentials(tlsConfig, logger); err != nil {
+			logger.Fatalf("fail to prepare TLS credentials for health server: %v", err)
+		}
+		if clientCreds, err = rpc.NewClientCredentials(tlsConfig, logger); err != nil {
+			logger.Fatalf("fail to prepare TLS credentials for DriveMgr client: %v", err)
+		}
+	}
+
+	clientToDriveMgr, err := prepareDriveMgrClient(*driveMgrBackend, clientCreds, logger)
 	if err != nil {
-		logger.Fatalf("fail to create grpc client for endpoint %s, error: %v", *driveMgrEndpoint, err)
+		logger.Fatalf("fail to create client for DriveMgr: %v", err)
+	}
+	if *faults != "" {
+		injected, err := chaos.ParseFaults(*faults)
+		if err != nil {
+			logger.Fatalf("fail to parse injected faults: %v", err)
+		}
+		logger.Warnf("Fault injection is enabled: %s", *faults)
+		chaos.Enable(injected)
+		clientToDriveMgr = chaos.NewDriveMgrClient(clientToDriveMgr, logger)
 	}
-	clientToDriveMgr := api.NewDriveServiceClient(gRPCClient.GRPCClient)
 
 	// gRPC server that will serve requests (node CSI) from k8s via unix socket
 	csiUDSServer := rpc.NewServerRunner(nil, *csiEndpoint, logger)
 
-	k8SClient, err := k8s.GetK8SClient()
+	k8SClient, err := k8s.GetK8SClientWithCache(*crCache, logger)
 	if err != nil {
 		logger.Fatalf("fail to create kubernetes client, error: %v", err)
 	}
@@ -113,14 +217,52 @@ func main() {
 	// Wait till all events are sent/handled
 	defer eventRecorder.Wait()
 
+	anyPolicy, err := capacityplanner.NewAnyPolicy(*anyThreshold, *anySmallPriority, *anyLargePriority, *anyMinFree)
+	if err != nil {
+		logger.Fatalf("fail to parse placement policy for ANY storage class: %v", err)
+	}
+
+	densityPolicy, err := capacityplanner.NewDensityPolicy(*maxVolumesPerDrive, *maxLVsPerVG)
+	if err != nil {
+		logger.Fatalf("fail to create volume density policy: %v", err)
+	}
+
+	inlineSize, err := util.StrToBytes(*inlineDefaultSize)
+	if err != nil {
+		logger.Fatalf("fail to parse default size of inline volume: %v", err)
+	}
+
 	k8sClientForVolume := k8s.NewKubeClient(k8SClient, logger, *namespace)
 	k8sClientForLVG := k8s.NewKubeClient(k8SClient, logger, *namespace)
 	csiNodeService := node.NewCSINodeService(
-		clientToDriveMgr, nodeID, logger, k8sClientForVolume, eventRecorder, featureConf)
+		clientToDriveMgr, nodeID, logger, k8sClientForVolume, eventRecorder, featureConf, anyPolicy, densityPolicy)
+	csiNodeService.SetInlineDefaultSize(inlineSize)
+	csiNodeService.SetDriveMgrBackend(*driveMgrBackend)
+	csiNodeService.SetMaxVolumesPerNode(*maxVolumesPerNode)
+	csiNodeService.SetDeletionProtection(*deletionProtection)
+	if *healthPolicy != "" {
+		policy, err := healthscore.NewPolicy(*healthPolicy)
+		if err != nil {
+			logger.Fatalf("fail to parse drive health policy: %v", err)
+		}
+		csiNodeService.SetHealthPolicy(policy, *evacuateSuspect)
+	}
+
+	if *storageNodeSelector != "" || *storageExcludeTaints != "" {
+		selector, err := labels.Parse(*storageNodeSelector)
+		if err != nil {
+			logger.Fatalf("fail to parse storage node selector: %v", err)
+		}
+		filter := &node.StoragePoolFilter{NodeName: *nodeName, Selector: selector}
+		if *storageExcludeTaints != "" {
+			filter.ExcludeTaints = strings.Split(*storageExcludeTaints, ",")
+		}
+		csiNodeService.SetStoragePoolFilter(filter)
+	}
 
 	mgr := prepareCRDControllerManagers(
 		csiNodeService,
-		lvg.NewController(k8sClientForLVG, nodeID, logger),
+		lvg.NewController(k8sClientForLVG, nodeID, eventRecorder, logger),
 		logger)
 
 	// register CSI calls handler
@@ -132,7 +274,7 @@ func main() {
 	go func() {
 		logger.Info("Starting Node Health server ...")
 		if err := util.SetupAndStartHealthCheckServer(
-			csiNodeService, logger,
+			csiNodeService, serverCreds, logger,
 			"tcp://"+net.JoinHostPort(*healthIP, strconv.Itoa(base.DefaultHealthPort))); err != nil {
 			logger.Fatalf("Node service failed with error: %v", err)
 		}
@@ -143,7 +285,30 @@ func main() {
 			logger.Fatalf("CRD Controller Manager failed with error: %v", err)
 		}
 	}()
-	go Discovering(csiNodeService, logger)
+	diskEvents := make(chan uevent.Event)
+	if *useUevents {
+		go func() {
+			if err := uevent.NewListener(logger).ListenDisks(diskEvents); err != nil {
+				logger.Errorf("Uevents listener failed, discovery is performed periodically only: %v", err)
+			}
+		}()
+	}
+	go Discovering(csiNodeService, diskEvents, logger)
+	if *trimEnabled {
+		csiNodeService.RunTrimming(*trimInterval)
+	}
+	if *mountCheckEnabled {
+		csiNodeService.RunMountChecking(*mountCheckInterval, *remountLost)
+	}
+	if *ioErrorsEnabled {
+		csiNodeService.RunIOErrorWatching(node.DefaultIOErrorsFlushInterval)
+	}
+	if *volumeMoveEnabled {
+		if *volumeMoveIP == "" {
+			logger.Fatal("IP for receiving data of moved volumes must be set")
+		}
+		csiNodeService.RunVolumeMoving(*volumeMoveIP)
+	}
 
 	logger.Info("Starting handle CSI calls ...")
 	if err := csiUDSServer.RunServer(); err != nil && err != grpc.ErrServerStopped {
@@ -154,12 +319,18 @@ func main() {
 }
 
 // Discovering performs Discover method of the Node each 30 seconds
-func Discovering(c *node.CSINodeService, logger *logrus.Logger) {
+// or right after kernel uevent of drive hotplug or removal
+func Discovering(c *node.CSINodeService, diskEvents <-chan uevent.Event, logger *logrus.Logger) {
 	var err error
 	discoveringWaitTime := 10 * time.Second
 	checker := c.GetLivenessHelper()
 	for {
-		time.Sleep(discoveringWaitTime)
+		select {
+		case <-time.After(discoveringWaitTime):
+		case event := <-diskEvents:
+			logger.Infof("Got %s event for drive %s, start discovering", event.Action, event.DevName)
+			waitForEventsBurst(diskEvents)
+		}
 		if err = c.Discover(); err != nil {
 			checker.Fail()
 			logger.Errorf("Discover finished with error: %v", err)
@@ -172,6 +343,50 @@ func Discovering(c *node.CSINodeService, logger *logrus.Logger) {
 	}
 }
 
+// waitForEventsBurst skips events which come one after another, e.g. when several drives are inserted at once,
+// so single discovery handles all of them
+func waitForEventsBurst(diskEvents <-chan uevent.Event) {
+	const burstInterval = 2 * time.Second
+	for {
+		select {
+		case <-diskEvents:
+		case <-time.After(burstInterval):
+			return
+		}
+	}
+}
+
+// prepareDriveMgrClient creates client for DriveMgr backend
+// For gRPC backend client communicates with DriveMgr via TCP socket and fails over to standby DriveMgr endpoints
+// For loopback backend loopback devices are created from sparse files according to config of LoopBackManager
+func prepareDriveMgrClient(backend string, creds credentials.TransportCredentials,
+	logger *logrus.Logger) (api.DriveServiceClient, error) {
+	switch backend {
+	case drivemgr.BackendGRPC:
+		client, err := drivemgr.NewFailoverClient(*driveMgrEndpoint, creds, logger)
+		if err != nil {
+			return nil, err
+		}
+		client.Run()
+		return client, nil
+	case drivemgr.BackendBaseMgr:
+		e := &command.Executor{}
+		e.SetLogger(logger)
+		return drivemgr.NewLocalClient(logger, basemgr.New(e, logger)), nil
+	case drivemgr.BackendLoopback:
+		e := &command.Executor{}
+		e.SetLogger(logger)
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			return nil, fmt.Errorf("unable to create fs watcher: %v", err)
+		}
+		mgr := loopbackmgr.NewLoopBackManager(e, logger)
+		go mgr.UpdateOnConfigChange(watcher)
+		return drivemgr.NewLocalClient(logger, mgr), nil
+	}
+	return nil, fmt.Errorf("unsupported DriveMgr backend %s", backend)
+}
+
 // prepareCRDControllerManagers prepares CRD ControllerManagers to work with CSI custom resources
 func prepareCRDControllerManagers(volumeCtrl *node.CSINodeService, lvgCtrl *lvg.Controller,
 	logger *logrus.Logger) manager.Manager {
@@ -193,7 +408,7 @@ func prepareCRDControllerManagers(volumeCtrl *node.CSINodeService, lvgCtrl *lvg.
 		logrus.Fatal(err)
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	mgr, err := ctrl.NewManager(k8s.GetRESTConfig(), ctrl.Options{
 		Scheme:    scheme,
 		Namespace: *namespace,
 	})
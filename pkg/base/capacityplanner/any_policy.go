@@ -0,0 +1,132 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacityplanner
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/dell/csi-baremetal/api/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+)
+
+// DefaultSmallVolumeThreshold is the size up to which volume is considered as a small one
+const DefaultSmallVolumeThreshold = 100 * int64(util.GBYTE)
+
+// AnyPolicy resolves ANY storage class into concrete one based on requested size and available capacity
+type AnyPolicy struct {
+	// SmallVolumeThreshold is the size in bytes up to which volume is considered as a small one
+	SmallVolumeThreshold int64
+	// SmallVolumePriority is the order in which storage classes are tried for small volumes
+	SmallVolumePriority []string
+	// LargeVolumePriority is the order in which storage classes are tried for large volumes
+	LargeVolumePriority []string
+	// MinFreePercent is the percent of free capacity of the storage class which shouldn't be consumed by volume,
+	// storage class is skipped if volume leaves less free capacity, 0 disables the check
+	MinFreePercent int
+}
+
+// NewDefaultAnyPolicy returns AnyPolicy which prefers fast drives for small volumes and HDD for large ones
+func NewDefaultAnyPolicy() *AnyPolicy {
+	return &AnyPolicy{
+		SmallVolumeThreshold: DefaultSmallVolumeThreshold,
+		SmallVolumePriority:  []string{v1.StorageClassNVMe, v1.StorageClassSSD, v1.StorageClassHDD},
+		LargeVolumePriority:  []string{v1.StorageClassHDD, v1.StorageClassSSD, v1.StorageClassNVMe},
+	}
+}
+
+// NewAnyPolicy builds AnyPolicy from string representation of its parameters
+// Receives size threshold for small volumes (e.g. 100Gi), comma separated priority lists for small and large volumes
+// and percent of free capacity which shouldn't be consumed
+// Returns an instance of AnyPolicy or error if parameters are malformed
+func NewAnyPolicy(threshold, smallPriority, largePriority string, minFreePercent int) (*AnyPolicy, error) {
+	var (
+		policy = &AnyPolicy{MinFreePercent: minFreePercent}
+		err    error
+	)
+	if minFreePercent < 0 || minFreePercent >= 100 {
+		return nil, fmt.Errorf("min free percent must be in [0, 100), got %d", minFreePercent)
+	}
+	if policy.SmallVolumeThreshold, err = util.StrToBytes(threshold); err != nil {
+		return nil, err
+	}
+	if policy.SmallVolumePriority, err = ParseStorageClassPriority(smallPriority); err != nil {
+		return nil, err
+	}
+	if policy.LargeVolumePriority, err = ParseStorageClassPriority(largePriority); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// ParseStorageClassPriority parses comma separated list of storage classes, e.g. "SSD,HDD"
+// Returns list of storage classes or error if list contains unknown or ANY storage class
+func ParseStorageClassPriority(str string) ([]string, error) {
+	var res []string
+	for _, s := range strings.Split(str, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		sc := util.ConvertStorageClass(s)
+		if sc == v1.StorageClassAny {
+			return nil, fmt.Errorf("storage class %s can't be used in priority list", s)
+		}
+		res = append(res, sc)
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("priority list %q is empty", str)
+	}
+	return res, nil
+}
+
+// Resolve chooses concrete storage class for volume with ANY storage class
+// Receives requested size of the volume, available capacity and node ID, all nodes are used if node ID is empty
+// Returns the first storage class from priority list which has AC with enough size
+// or ANY if there is no such storage class
+func (p *AnyPolicy) Resolve(size int64, capacity []accrd.AvailableCapacity, nodeID string) string {
+	priority := p.LargeVolumePriority
+	if size <= p.SmallVolumeThreshold {
+		priority = p.SmallVolumePriority
+	}
+
+	// key - storage class, value - total free size and largest AC size
+	free := make(map[string]int64)
+	largest := make(map[string]int64)
+	for _, ac := range capacity {
+		if nodeID != "" && ac.Spec.NodeId != nodeID {
+			continue
+		}
+		sc := ac.Spec.StorageClass
+		free[sc] += ac.Spec.Size
+		if ac.Spec.Size > largest[sc] {
+			largest[sc] = ac.Spec.Size
+		}
+	}
+
+	for _, sc := range priority {
+		if largest[sc] < size {
+			continue
+		}
+		if p.MinFreePercent > 0 && (free[sc]-size)*100 < free[sc]*int64(p.MinFreePercent) {
+			continue
+		}
+		return sc
+	}
+	return v1.StorageClassAny
+}
@@ -0,0 +1,69 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacityplanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+)
+
+func TestAnyPolicy_Resolve(t *testing.T) {
+	policy := &AnyPolicy{
+		SmallVolumeThreshold: testSmallSize,
+		SmallVolumePriority:  []string{apiV1.StorageClassSSD, apiV1.StorageClassHDD},
+		LargeVolumePriority:  []string{apiV1.StorageClassHDD, apiV1.StorageClassSSD},
+	}
+	capacity := []accrd.AvailableCapacity{
+		*getTestAC(testNode1, testSmallSize, apiV1.StorageClassSSD),
+		*getTestAC(testNode1, testLargeSize, apiV1.StorageClassHDD),
+		*getTestAC(testNode2, testLargeSize*2, apiV1.StorageClassSSD),
+	}
+
+	// small volume prefers SSD, large one prefers HDD
+	assert.Equal(t, apiV1.StorageClassSSD, policy.Resolve(testSmallSize, capacity, ""))
+	assert.Equal(t, apiV1.StorageClassHDD, policy.Resolve(testLargeSize, capacity, ""))
+	// HDD doesn't have enough capacity
+	assert.Equal(t, apiV1.StorageClassSSD, policy.Resolve(testLargeSize*2, capacity, ""))
+	// only capacity of the node is used
+	assert.Equal(t, apiV1.StorageClassAny, policy.Resolve(testLargeSize*2, capacity, testNode1))
+	assert.Equal(t, apiV1.StorageClassAny, policy.Resolve(testSmallSize, nil, ""))
+
+	// SSD on node 1 would be exhausted
+	policy.MinFreePercent = 50
+	assert.Equal(t, apiV1.StorageClassHDD, policy.Resolve(testSmallSize, capacity, testNode1))
+}
+
+func TestNewAnyPolicy(t *testing.T) {
+	policy, err := NewAnyPolicy("10Gi", "ssd, hdd", "HDD", 10)
+	assert.Nil(t, err)
+	assert.Equal(t, testSmallSize, policy.SmallVolumeThreshold)
+	assert.Equal(t, []string{apiV1.StorageClassSSD, apiV1.StorageClassHDD}, policy.SmallVolumePriority)
+	assert.Equal(t, []string{apiV1.StorageClassHDD}, policy.LargeVolumePriority)
+
+	_, err = NewAnyPolicy("10Gi", "SSD,FLOPPY", "HDD", 0)
+	assert.NotNil(t, err)
+	_, err = NewAnyPolicy("10Gi", "SSD", "", 0)
+	assert.NotNil(t, err)
+	_, err = NewAnyPolicy("ten", "SSD", "HDD", 0)
+	assert.NotNil(t, err)
+	_, err = NewAnyPolicy("10Gi", "SSD", "HDD", 100)
+	assert.NotNil(t, err)
+}
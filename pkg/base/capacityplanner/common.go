@@ -38,3 +38,26 @@ func AlignSizeByPE(size int64) int64 {
 	}
 	return size + alignement
 }
+
+// SubDrivePartitionOverhead is the space which is taken from drive AC in addition to the size of each sub-drive
+// partition, it covers GPT metadata and partitions alignment
+const SubDrivePartitionOverhead = int64(util.MBYTE) // 1MB
+
+// MaxSubDrivePartitions is the amount of entries in default GPT partition table, it limits amount of sub-drive
+// volumes on the drive
+const MaxSubDrivePartitions = 128
+
+// AlignSizeBySubDrivePartition make size aligned with MB since partitions are aligned with 1MB boundary
+func AlignSizeBySubDrivePartition(size int64) int64 {
+	var alignement int64
+	reminder := size % int64(util.MBYTE)
+	if reminder != 0 {
+		alignement = int64(util.MBYTE) - reminder
+	}
+	return size + alignement
+}
+
+// SubDriveAllocatedSize returns space of drive AC which is taken by sub-drive partition of provided size
+func SubDriveAllocatedSize(size int64) int64 {
+	return AlignSizeBySubDrivePartition(size) + SubDrivePartitionOverhead
+}
@@ -0,0 +1,78 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacityplanner
+
+import "fmt"
+
+// DensityPolicy restricts amount of volumes which share one drive or one volume group,
+// it protects file systems and LVM metadata from pathological fan-out
+type DensityPolicy struct {
+	// maxVolumesPerDrive is the maximal amount of sub-drive volumes on one drive, 0 means that only
+	// MaxSubDrivePartitions restricts it
+	maxVolumesPerDrive int
+	// maxLVsPerVG is the maximal amount of logical volumes in one volume group, 0 means unlimited
+	maxLVsPerVG int
+}
+
+// NewDensityPolicy is the constructor for DensityPolicy struct
+// Receives maximal amount of sub-drive volumes on one drive and maximal amount of logical volumes in one volume group,
+// zero value means that restriction isn't set
+// Returns an instance of DensityPolicy or error if limits are negative
+func NewDensityPolicy(maxVolumesPerDrive, maxLVsPerVG int) (*DensityPolicy, error) {
+	if maxVolumesPerDrive < 0 || maxLVsPerVG < 0 {
+		return nil, fmt.Errorf("density limits must not be negative, volumes per drive: %d, LVs per VG: %d",
+			maxVolumesPerDrive, maxLVsPerVG)
+	}
+	return &DensityPolicy{maxVolumesPerDrive: maxVolumesPerDrive, maxLVsPerVG: maxLVsPerVG}, nil
+}
+
+// MaxVolumesPerDrive returns maximal amount of sub-drive volumes on one drive,
+// it never exceeds amount of entries in GPT partition table
+func (p *DensityPolicy) MaxVolumesPerDrive() int {
+	if p == nil || p.maxVolumesPerDrive == 0 || p.maxVolumesPerDrive > MaxSubDrivePartitions {
+		return MaxSubDrivePartitions
+	}
+	return p.maxVolumesPerDrive
+}
+
+// MaxLVsPerVG returns maximal amount of logical volumes in one volume group, 0 means unlimited
+func (p *DensityPolicy) MaxLVsPerVG() int {
+	if p == nil {
+		return 0
+	}
+	return p.maxLVsPerVG
+}
+
+// CheckSubDrive checks whether one more sub-drive volume could be placed on the drive
+// Receives amount of sub-drive volumes which are already placed on the drive
+// Returns error if limit of volumes per drive is reached
+func (p *DensityPolicy) CheckSubDrive(volumesOnDrive int) error {
+	if max := p.MaxVolumesPerDrive(); volumesOnDrive >= max {
+		return fmt.Errorf("drive already has %d volumes, limit is %d", volumesOnDrive, max)
+	}
+	return nil
+}
+
+// CheckLVG checks whether one more logical volume could be created in the volume group
+// Receives amount of logical volumes which are already created in the volume group
+// Returns error if limit of logical volumes per volume group is reached
+func (p *DensityPolicy) CheckLVG(lvsInVG int) error {
+	if max := p.MaxLVsPerVG(); max > 0 && lvsInVG >= max {
+		return fmt.Errorf("volume group already has %d logical volumes, limit is %d", lvsInVG, max)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacityplanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDensityPolicy(t *testing.T) {
+	policy, err := NewDensityPolicy(10, 100)
+	assert.Nil(t, err)
+	assert.Equal(t, 10, policy.MaxVolumesPerDrive())
+	assert.Equal(t, 100, policy.MaxLVsPerVG())
+
+	_, err = NewDensityPolicy(-1, 0)
+	assert.Error(t, err)
+	_, err = NewDensityPolicy(0, -1)
+	assert.Error(t, err)
+
+	// amount of partitions is restricted by partition table
+	policy, err = NewDensityPolicy(0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, MaxSubDrivePartitions, policy.MaxVolumesPerDrive())
+	policy, err = NewDensityPolicy(MaxSubDrivePartitions+1, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, MaxSubDrivePartitions, policy.MaxVolumesPerDrive())
+
+	var nilPolicy *DensityPolicy
+	assert.Equal(t, MaxSubDrivePartitions, nilPolicy.MaxVolumesPerDrive())
+	assert.Equal(t, 0, nilPolicy.MaxLVsPerVG())
+}
+
+func TestDensityPolicy_Check(t *testing.T) {
+	policy, err := NewDensityPolicy(2, 3)
+	assert.Nil(t, err)
+
+	assert.Nil(t, policy.CheckSubDrive(1))
+	assert.Error(t, policy.CheckSubDrive(2))
+	assert.Nil(t, policy.CheckLVG(2))
+	assert.Error(t, policy.CheckLVG(3))
+
+	// LVs aren't restricted
+	policy, err = NewDensityPolicy(0, 0)
+	assert.Nil(t, err)
+	assert.Nil(t, policy.CheckLVG(10000))
+}
@@ -0,0 +1,149 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacityplanner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+
+	genV1 "github.com/dell/csi-baremetal/api/generated/v1"
+	v1 "github.com/dell/csi-baremetal/api/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+)
+
+// NodePlacement is a result of placement check for a single node
+type NodePlacement struct {
+	NodeID string
+	// AC which would be used for volume, nil if volume can't be placed on the node
+	AC *accrd.AvailableCapacity
+	// Reason describes why volume can't be placed on the node
+	Reason string
+}
+
+// Suitable checks whether volume could be placed on the node
+func (np NodePlacement) Suitable() bool {
+	return np.AC != nil
+}
+
+// NewPlacementChecker returns instance of PlacementChecker
+func NewPlacementChecker(logger *logrus.Entry, capReader CapacityReader) *PlacementChecker {
+	return &PlacementChecker{
+		logger:    logger,
+		capReader: capReader,
+	}
+}
+
+// PlacementChecker performs dry-run of volume placement, it uses the same AC selection as CapacityManager
+// but doesn't modify or reserve any capacity and explains why nodes were rejected
+type PlacementChecker struct {
+	logger    *logrus.Entry
+	capReader CapacityReader
+}
+
+// CheckPlacement checks on which nodes volume could be placed
+// Receives golang context, volume with requested size and storage class and list of node IDs,
+// all nodes which have AC are checked if nodes list is empty
+// Returns placement results sorted by node ID or error if capacity wasn't read
+func (pc *PlacementChecker) CheckPlacement(ctx context.Context, volume *genV1.Volume,
+	nodes []string) ([]NodePlacement, error) {
+	logger := util.AddCommonFields(ctx, pc.logger, "PlacementChecker.CheckPlacement")
+
+	capacity, err := pc.capReader.ReadCapacity(ctx)
+	if err != nil {
+		logger.Errorf("Failed to read capacity: %s", err.Error())
+		return nil, err
+	}
+
+	nodesCapacity := map[string]*nodeCapacity{}
+	for _, node := range nodes {
+		nodesCapacity[node] = &nodeCapacity{capacity: ACMap{}}
+	}
+	for i := range capacity {
+		nodeID := capacity[i].Spec.NodeId
+		nc, ok := nodesCapacity[nodeID]
+		if !ok {
+			if len(nodes) != 0 {
+				continue
+			}
+			nc = &nodeCapacity{capacity: ACMap{}}
+			nodesCapacity[nodeID] = nc
+		}
+		// selectACForVolume modifies ACs, copy is used to keep capReader cache untouched
+		nc.registerAC(capacity[i].DeepCopy())
+	}
+
+	res := make([]NodePlacement, 0, len(nodesCapacity))
+	for nodeID, nc := range nodesCapacity {
+		placement := NodePlacement{NodeID: nodeID}
+		// reason is computed before selection because selection modifies node capacity
+		reason := rejectionReason(nc, volume)
+		if ac := nc.selectACForVolume(volume); ac != nil {
+			placement.AC = ac
+		} else {
+			placement.Reason = reason
+		}
+		logger.Debugf("Placement on node %s: suitable - %v, reason - %s", nodeID, placement.Suitable(), placement.Reason)
+		res = append(res, placement)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].NodeID < res[j].NodeID
+	})
+	return res, nil
+}
+
+// rejectionReason explains why volume can't be placed on the node,
+// result makes sense only if AC for volume wasn't selected
+func rejectionReason(nc *nodeCapacity, vol *genV1.Volume) string {
+	if len(nc.capacity) == 0 {
+		return "node has no available capacity"
+	}
+
+	scM := nc.getStorageClassToACMapping()
+	candidates := []string{vol.StorageClass}
+	switch {
+	case vol.StorageClass == v1.StorageClassAny:
+		candidates = candidates[:0]
+		for sc := range scM {
+			candidates = append(candidates, sc)
+		}
+	case util.IsStorageClassLVG(vol.StorageClass):
+		candidates = append(candidates, util.GetSubStorageClass(vol.StorageClass))
+	}
+
+	var largest int64
+	for _, sc := range candidates {
+		for _, ac := range scM[sc] {
+			if ac.Spec.Size > largest {
+				largest = ac.Spec.Size
+			}
+		}
+	}
+	if largest == 0 {
+		return fmt.Sprintf("node has no available capacity of storage class %s", vol.StorageClass)
+	}
+
+	required := vol.GetSize()
+	if util.IsStorageClassLVG(vol.StorageClass) {
+		required = AlignSizeByPE(required)
+	}
+	return fmt.Sprintf("largest available capacity of storage class %s is %d bytes, %d bytes are required",
+		vol.StorageClass, largest, required)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacityplanner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+)
+
+func TestPlacementChecker_CheckPlacement(t *testing.T) {
+	logger := testLogger.WithField("component", "test")
+	ctx := context.Background()
+
+	t.Run("Failed to read capacity", func(t *testing.T) {
+		pc := NewPlacementChecker(logger, getCapReaderMock(nil, testErr))
+		res, err := pc.CheckPlacement(ctx, getTestVol("", testSmallSize, apiV1.StorageClassHDD), nil)
+		assert.Nil(t, res)
+		assert.Error(t, err)
+	})
+
+	t.Run("Nodes are checked", func(t *testing.T) {
+		ac := getTestAC(testNode1, testLargeSize, apiV1.StorageClassHDD)
+		testACs := []*accrd.AvailableCapacity{
+			ac,
+			getTestAC(testNode2, testSmallSize, apiV1.StorageClassHDD),
+			getTestAC(testNode2, testLargeSize, apiV1.StorageClassSSD),
+		}
+		pc := NewPlacementChecker(logger, getCapReaderMock(testACs, nil))
+		res, err := pc.CheckPlacement(ctx, getTestVol("", testLargeSize, apiV1.StorageClassHDD), nil)
+		assert.Nil(t, err)
+		assert.Len(t, res, 2)
+		for _, p := range res {
+			switch p.NodeID {
+			case testNode1:
+				assert.True(t, p.Suitable())
+				assert.Equal(t, ac.Name, p.AC.Name)
+			case testNode2:
+				assert.False(t, p.Suitable())
+				assert.Contains(t, p.Reason, "largest available capacity")
+			}
+		}
+		// capacity isn't modified by check
+		assert.Equal(t, testLargeSize, ac.Spec.Size)
+	})
+
+	t.Run("Requested nodes only", func(t *testing.T) {
+		testACs := []*accrd.AvailableCapacity{
+			getTestAC(testNode1, testLargeSize, apiV1.StorageClassSSD),
+			getTestAC(testNode2, testLargeSize, apiV1.StorageClassHDD),
+		}
+		pc := NewPlacementChecker(logger, getCapReaderMock(testACs, nil))
+		res, err := pc.CheckPlacement(ctx, getTestVol("", testSmallSize, apiV1.StorageClassHDD),
+			[]string{testNode1, "missing-node"})
+		assert.Nil(t, err)
+		assert.Len(t, res, 2)
+		for _, p := range res {
+			assert.False(t, p.Suitable())
+			if p.NodeID == testNode1 {
+				assert.Contains(t, p.Reason, "no available capacity of storage class HDD")
+			} else {
+				assert.Equal(t, "node has no available capacity", p.Reason)
+			}
+		}
+	})
+}
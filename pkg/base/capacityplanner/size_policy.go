@@ -0,0 +1,107 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacityplanner
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dell/csi-baremetal/pkg/base/util"
+)
+
+// SizeLimits holds size restrictions of volumes with one storage class, zero value means that restriction isn't set
+type SizeLimits struct {
+	// Min is the minimal size of volume in bytes
+	Min int64
+	// Max is the maximal size of volume in bytes
+	Max int64
+	// Round is the value in bytes by which requested size is rounded up
+	Round int64
+}
+
+// SizePolicy restricts and rounds requested size of volumes per storage class
+type SizePolicy struct {
+	limits map[string]SizeLimits
+}
+
+// NewSizePolicy builds SizePolicy from its string representation
+// Receives comma separated list of <storage class>=<min>:<max>:<round> items, any size could be omitted,
+// e.g. "HDD=1Gi:10Ti:1Gi,HDDLVG=::4Mi"
+// Returns an instance of SizePolicy or error if string is malformed
+func NewSizePolicy(str string) (*SizePolicy, error) {
+	policy := &SizePolicy{limits: make(map[string]SizeLimits)}
+	for _, item := range strings.Split(str, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		scAndSizes := strings.SplitN(item, "=", 2)
+		if len(scAndSizes) != 2 {
+			return nil, fmt.Errorf("size policy %q must be in <storage class>=<min>:<max>:<round> format", item)
+		}
+		sc := util.ConvertStorageClass(strings.TrimSpace(scAndSizes[0]))
+		sizes := strings.Split(scAndSizes[1], ":")
+		if len(sizes) != 3 {
+			return nil, fmt.Errorf("size policy %q must be in <storage class>=<min>:<max>:<round> format", item)
+		}
+
+		var (
+			values [3]int64
+			err    error
+		)
+		for i, s := range sizes {
+			if s = strings.TrimSpace(s); s == "" {
+				continue
+			}
+			if values[i], err = util.StrToBytes(s); err != nil {
+				return nil, fmt.Errorf("unable to parse size policy %q: %v", item, err)
+			}
+		}
+		limits := SizeLimits{Min: values[0], Max: values[1], Round: values[2]}
+		if limits.Max > 0 && limits.Min > limits.Max {
+			return nil, fmt.Errorf("min size is greater than max size in size policy %q", item)
+		}
+		policy.limits[sc] = limits
+	}
+	return policy, nil
+}
+
+// Apply rounds requested size of volume and checks it against limits of storage class
+// Receives storage class and requested size in bytes, zero size means that size isn't specified and isn't changed
+// Returns rounded size or error if size violates limits of storage class
+func (p *SizePolicy) Apply(sc string, size int64) (int64, error) {
+	if p == nil || size == 0 {
+		return size, nil
+	}
+	limits, ok := p.limits[sc]
+	if !ok {
+		return size, nil
+	}
+
+	if limits.Round > 0 && size%limits.Round != 0 {
+		size += limits.Round - size%limits.Round
+	}
+	if size < limits.Min {
+		return 0, fmt.Errorf("requested size %d is less than minimal size %d of storage class %s",
+			size, limits.Min, sc)
+	}
+	if limits.Max > 0 && size > limits.Max {
+		return 0, fmt.Errorf("requested size %d is greater than maximal size %d of storage class %s",
+			size, limits.Max, sc)
+	}
+	return size, nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacityplanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+)
+
+func TestNewSizePolicy(t *testing.T) {
+	policy, err := NewSizePolicy("hdd=1Gi:10Ti:1Gi, HDDLVG=::4Mi")
+	assert.Nil(t, err)
+	assert.Equal(t, SizeLimits{Min: int64(util.GBYTE), Max: 10 * int64(util.TBYTE), Round: int64(util.GBYTE)},
+		policy.limits[apiV1.StorageClassHDD])
+	assert.Equal(t, SizeLimits{Round: 4 * int64(util.MBYTE)}, policy.limits[apiV1.StorageClassHDDLVG])
+
+	policy, err = NewSizePolicy("")
+	assert.Nil(t, err)
+	assert.Empty(t, policy.limits)
+
+	for _, str := range []string{"HDD", "HDD=1Gi", "HDD=1Gi:2Gi", "HDD=abc::", "HDD=2Gi:1Gi:"} {
+		_, err = NewSizePolicy(str)
+		assert.Error(t, err, str)
+	}
+}
+
+func TestSizePolicy_Apply(t *testing.T) {
+	policy, err := NewSizePolicy("HDD=1Gi:10Gi:1Gi")
+	assert.Nil(t, err)
+
+	size, err := policy.Apply(apiV1.StorageClassHDD, int64(util.GBYTE)+1)
+	assert.Nil(t, err)
+	assert.Equal(t, 2*int64(util.GBYTE), size)
+
+	// size isn't specified
+	size, err = policy.Apply(apiV1.StorageClassHDD, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), size)
+
+	// storage class without limits
+	size, err = policy.Apply(apiV1.StorageClassSSD, int64(util.MBYTE))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(util.MBYTE), size)
+
+	_, err = policy.Apply(apiV1.StorageClassHDD, 11*int64(util.GBYTE))
+	assert.Error(t, err)
+
+	policy.limits[apiV1.StorageClassHDD] = SizeLimits{Min: int64(util.GBYTE)}
+	_, err = policy.Apply(apiV1.StorageClassHDD, int64(util.MBYTE))
+	assert.Error(t, err)
+
+	// nil policy doesn't restrict size
+	size, err = (*SizePolicy)(nil).Apply(apiV1.StorageClassHDD, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), size)
+}
@@ -0,0 +1,175 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package chaos contains fault injection hooks which simulate failures of system utils and DriveMgr,
+// they are used to test resilience of reconcilers in e2e without breaking real hardware
+package chaos
+
+import (
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// EnvName is the name of environment variable with faults which are injected when flag isn't set
+	EnvName = "CSI_BAREMETAL_FAULTS"
+
+	// MountFault is the probability from 0 to 1 that mount command fails
+	MountFault = "mount"
+	// LsblkFault is the delay of lsblk command
+	LsblkFault = "lsblk"
+	// DriveMgrFault is the probability from 0 to 1 that call of DriveMgr times out
+	DriveMgrFault = "drivemgr"
+	// DriveGoneFault is the list of serial numbers separated by | of drives which disappear from DriveMgr response
+	DriveGoneFault = "drivegone"
+
+	mountCmd = "mount"
+	lsblkCmd = "lsblk"
+)
+
+// Faults holds parameters of injected faults, zero values mean that fault isn't injected
+type Faults struct {
+	MountFailRate       float64
+	LsblkDelay          time.Duration
+	DriveMgrTimeoutRate float64
+	MissingDrives       map[string]struct{}
+}
+
+var (
+	mu     sync.Mutex
+	active *Faults
+	random = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// ParseFaults parses faults in format <fault>=<value>,... e.g. mount=0.5,lsblk=10s,drivemgr=0.2,drivegone=SN1|SN2
+// Returns Faults or error if format is invalid
+func ParseFaults(str string) (*Faults, error) {
+	faults := &Faults{MissingDrives: make(map[string]struct{})}
+	for _, pair := range strings.Split(str, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("fault %s must be in format <fault>=<value>", pair)
+		}
+		var err error
+		switch kv[0] {
+		case MountFault:
+			faults.MountFailRate, err = parseRate(kv[1])
+		case LsblkFault:
+			faults.LsblkDelay, err = time.ParseDuration(kv[1])
+		case DriveMgrFault:
+			faults.DriveMgrTimeoutRate, err = parseRate(kv[1])
+		case DriveGoneFault:
+			for _, sn := range strings.Split(kv[1], "|") {
+				if sn != "" {
+					faults.MissingDrives[sn] = struct{}{}
+				}
+			}
+		default:
+			return nil, fmt.Errorf("unknown fault %s", kv[0])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of fault %s: %v", kv[0], err)
+		}
+	}
+	return faults, nil
+}
+
+// parseRate parses probability which must be in range from 0 to 1
+func parseRate(str string) (float64, error) {
+	rate, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 0, err
+	}
+	if rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("probability %s isn't in range from 0 to 1", str)
+	}
+	return rate, nil
+}
+
+// Enable activates provided faults in the process, nil disables injection
+func Enable(faults *Faults) {
+	mu.Lock()
+	defer mu.Unlock()
+	active = faults
+}
+
+// Enabled returns true if faults are injected in the process
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return active != nil
+}
+
+// BeforeCmd is called before system command with provided name or path is executed, it sleeps if command
+// should be slow and returns error if command should fail, in that case command isn't executed
+func BeforeCmd(name string) error {
+	mu.Lock()
+	faults := active
+	mu.Unlock()
+	if faults == nil {
+		return nil
+	}
+	switch filepath.Base(name) {
+	case mountCmd:
+		if happens(faults.MountFailRate) {
+			return fmt.Errorf("injected fault: %s failed", mountCmd)
+		}
+	case lsblkCmd:
+		if faults.LsblkDelay > 0 {
+			time.Sleep(faults.LsblkDelay)
+		}
+	}
+	return nil
+}
+
+// happens returns true with provided probability
+func happens(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	return random.Float64() < rate
+}
+
+// isDriveMissing returns true if drive with provided serial number should disappear
+func isDriveMissing(serialNumber string) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	if active == nil {
+		return false
+	}
+	_, ok := active.MissingDrives[serialNumber]
+	return ok
+}
+
+// driveMgrTimeoutRate returns probability of DriveMgr call timeout
+func driveMgrTimeoutRate() float64 {
+	mu.Lock()
+	defer mu.Unlock()
+	if active == nil {
+		return 0
+	}
+	return active.DriveMgrTimeoutRate
+}
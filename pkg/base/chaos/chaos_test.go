@@ -0,0 +1,87 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	"github.com/dell/csi-baremetal/pkg/mocks"
+)
+
+func TestParseFaults(t *testing.T) {
+	faults, err := ParseFaults("mount=0.5, lsblk=10s,drivemgr=1,drivegone=SN1|SN2")
+	assert.Nil(t, err)
+	assert.Equal(t, &Faults{
+		MountFailRate:       0.5,
+		LsblkDelay:          10 * time.Second,
+		DriveMgrTimeoutRate: 1,
+		MissingDrives:       map[string]struct{}{"SN1": {}, "SN2": {}},
+	}, faults)
+
+	faults, err = ParseFaults("")
+	assert.Nil(t, err)
+	assert.Equal(t, &Faults{MissingDrives: map[string]struct{}{}}, faults)
+
+	for _, str := range []string{"mount", "mount=2", "lsblk=fast", "unknown=1"} {
+		_, err = ParseFaults(str)
+		assert.NotNil(t, err, str)
+	}
+}
+
+func TestBeforeCmd(t *testing.T) {
+	defer Enable(nil)
+
+	assert.Nil(t, BeforeCmd("mount"))
+
+	Enable(&Faults{MountFailRate: 1, LsblkDelay: time.Millisecond})
+	assert.NotNil(t, BeforeCmd("mount"))
+	assert.NotNil(t, BeforeCmd("/usr/bin/mount"))
+	assert.Nil(t, BeforeCmd("umount"))
+	assert.Nil(t, BeforeCmd("lsblk"))
+}
+
+func TestDriveMgrClient(t *testing.T) {
+	defer Enable(nil)
+	var (
+		ctx    = context.Background()
+		client = NewDriveMgrClient(
+			mocks.NewMockDriveMgrClient([]*api.Drive{{SerialNumber: "SN1"}, {SerialNumber: "SN2"}}), logrus.New())
+	)
+
+	resp, err := client.GetDrivesList(ctx, &api.DrivesRequest{})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Disks, 2)
+
+	Enable(&Faults{MissingDrives: map[string]struct{}{"SN1": {}}})
+	resp, err = client.GetDrivesList(ctx, &api.DrivesRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []*api.Drive{{SerialNumber: "SN2"}}, resp.Disks)
+
+	Enable(&Faults{DriveMgrTimeoutRate: 1})
+	_, err = client.GetDrivesList(ctx, &api.DrivesRequest{})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	_, err = client.Locate(ctx, &api.DriveLocateRequest{})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
@@ -0,0 +1,85 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+)
+
+// DriveMgrClient is the implementation of api.DriveServiceClient which injects timeouts of DriveMgr calls and
+// hides drives from DriveMgr response according to active faults
+type DriveMgrClient struct {
+	client api.DriveServiceClient
+	log    *logrus.Entry
+}
+
+// NewDriveMgrClient is the constructor for DriveMgrClient struct
+// Receives DriveMgr client which is called when fault isn't injected and logrus logger
+// Returns an instance of DriveMgrClient
+func NewDriveMgrClient(client api.DriveServiceClient, logger *logrus.Logger) *DriveMgrClient {
+	return &DriveMgrClient{
+		client: client,
+		log:    logger.WithField("component", "ChaosDriveMgrClient"),
+	}
+}
+
+// GetDrivesList calls DriveMgr and removes missing drives from its response
+func (c *DriveMgrClient) GetDrivesList(ctx context.Context, in *api.DrivesRequest,
+	opts ...grpc.CallOption) (*api.DrivesResponse, error) {
+	if err := c.injectTimeout("GetDrivesList"); err != nil {
+		return nil, err
+	}
+	resp, err := c.client.GetDrivesList(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	disks := make([]*api.Drive, 0, len(resp.Disks))
+	for _, drive := range resp.Disks {
+		if isDriveMissing(drive.SerialNumber) {
+			c.log.Warnf("Injected fault: drive %s disappeared", drive.SerialNumber)
+			continue
+		}
+		disks = append(disks, drive)
+	}
+	resp.Disks = disks
+	return resp, nil
+}
+
+// Locate calls DriveMgr Locate unless timeout is injected
+func (c *DriveMgrClient) Locate(ctx context.Context, in *api.DriveLocateRequest,
+	opts ...grpc.CallOption) (*api.DriveLocateResponse, error) {
+	if err := c.injectTimeout("Locate"); err != nil {
+		return nil, err
+	}
+	return c.client.Locate(ctx, in, opts...)
+}
+
+// injectTimeout returns DeadlineExceeded error with probability of DriveMgr timeout
+func (c *DriveMgrClient) injectTimeout(method string) error {
+	if !happens(driveMgrTimeoutRate()) {
+		return nil
+	}
+	c.log.Warnf("Injected fault: %s call of DriveMgr timed out", method)
+	return status.Errorf(codes.DeadlineExceeded, "injected fault: %s call of DriveMgr timed out", method)
+}
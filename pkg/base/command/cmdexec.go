@@ -24,6 +24,8 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/dell/csi-baremetal/pkg/base/chaos"
 )
 
 // CmdExecutor is the interface for executor that runs linux commands with RunCmd
@@ -80,9 +82,17 @@ func (e *Executor) RunCmdWithAttempts(cmd interface{}, attempts int, timeout tim
 // Returns stdout as string, stderr as string and golang error if something went wrong
 func (e *Executor) RunCmd(cmd interface{}) (string, string, error) {
 	if cmdStr, ok := cmd.(string); ok {
+		if fields := strings.Fields(cmdStr); len(fields) > 0 {
+			if err := chaos.BeforeCmd(fields[0]); err != nil {
+				return "", err.Error(), err
+			}
+		}
 		return e.runCmdFromStr(cmdStr)
 	}
 	if cmdObj, ok := cmd.(*exec.Cmd); ok {
+		if err := chaos.BeforeCmd(cmdObj.Path); err != nil {
+			return "", err.Error(), err
+		}
 		return e.runCmdFromCmdObj(cmdObj)
 	}
 	return "", "", fmt.Errorf("could not interpret command from %v", cmd)
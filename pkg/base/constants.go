@@ -19,6 +19,14 @@ package base
 
 import "time"
 
+// Version of the plugin is set at build time through -ldflags "-X", see LDFLAGS in variables.mk
+var (
+	// PluginVersion is a version of current CSI plugin
+	PluginVersion = "0.0.11"
+	// PluginGitCommit is the git commit from which current CSI plugin is built
+	PluginGitCommit = "unknown"
+)
+
 // CtxKey variable type uses for keys in context WithValue
 type CtxKey string
 
@@ -27,9 +35,6 @@ const (
 	RequestUUID CtxKey = "RequestUUID"
 	// PluginName is a name of current CSI plugin
 	PluginName = "baremetal-csi"
-	// PluginVersion is a version of current CSI plugin
-	// TODO: get rid of hardcoded value https://github.com/dell/csi-baremetal/issues/79
-	PluginVersion = "0.0.11"
 	// DefaultDriveMgrEndpoint is the default gRPC endpoint for drivemgr
 	DefaultDriveMgrEndpoint = "tcp://:8888"
 	// DefaultHealthIP is the default gRPC IP for Health server
@@ -54,6 +59,16 @@ const (
 	// DefaultRequeueForVolume is the interval for volume reconcile
 	DefaultRequeueForVolume = 5 * time.Second
 
+	// MaxRequeueForVolume is the upper bound of exponential backoff between failed attempts of volume reconcile
+	MaxRequeueForVolume = 2 * time.Minute
+
+	// DefaultVolumeRetries is the number of failed attempts after which volume reaches Failed status
+	DefaultVolumeRetries = 5
+
+	// DefaultStatusWaitTimeout is the time during which CSI call waits for volume status,
+	// Aborted error is returned after it and CO retries the call
+	DefaultStatusWaitTimeout = 30 * time.Second
+
 	// SystemDriveAsLocation is the const to fill Location field in CRs if the location based on system drive
 	SystemDriveAsLocation = "system drive"
 
@@ -62,6 +77,11 @@ const (
 
 	// StorageTypeKey key from volume_context in CreateVolumeRequest of NodePublishVolumeRequest
 	StorageTypeKey = "storageType"
+	// StorageGroupKey key from StorageClass parameters or volume_context of inline volume, volume is placed only
+	// on drives of StorageGroup with that name
+	StorageGroupKey = "storageGroup"
 	// SizeKey key from volume_context in CreateVolumeRequest of NodePublishVolumeRequest
 	SizeKey = "size"
+	// FsTypeKey key from StorageClass parameters or volume_context of NodePublishVolumeRequest for inline volume
+	FsTypeKey = "fsType"
 )
@@ -23,6 +23,8 @@ const (
 	FeatureACReservation = "ACReservation"
 	// FeatureNodeIDFromAnnotation store name for NodeIDFromAnnotation feature
 	FeatureNodeIDFromAnnotation = "NodeIDFromAnnotation"
+	// FeatureSubDriveAllocation store name for SubDriveAllocation feature
+	FeatureSubDriveAllocation = "SubDriveAllocation"
 )
 
 // FeatureChecker is a "read" interface for FeatureConfig
@@ -0,0 +1,161 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package healthscore contains policy which combines SMART, kernel log and latency signals of drives
+// into a health score and maps the score to health of the drive
+package healthscore
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+)
+
+// DefaultPolicy is the string representation of policy which is used by default
+const DefaultPolicy = "mediaerror=1,ioerror=1,latency=10,latencythreshold=100ms,suspect=10,bad=100"
+
+// Signals are the inputs of health score of the drive
+type Signals struct {
+	// MediaErrors is the amount of bad sectors or media errors reported by SMART
+	MediaErrors int64
+	// IOErrors is the amount of I/O errors of the drive found in kernel log
+	IOErrors int64
+	// Latency is the mean latency of the slowest operation from the last benchmark, 0 if it is unknown
+	Latency time.Duration
+}
+
+// Policy calculates health score of the drive as a weighted sum of its signals,
+// drive becomes SUSPECT when score reaches SuspectScore and BAD when it reaches BadScore
+type Policy struct {
+	// MediaErrorWeight is the amount of points per bad sector or media error
+	MediaErrorWeight int64
+	// IOErrorWeight is the amount of points per I/O error from kernel log
+	IOErrorWeight int64
+	// LatencyWeight is the amount of points which are added when latency exceeds LatencyThreshold
+	LatencyWeight int64
+	// LatencyThreshold is the maximal latency of healthy drive, 0 disables latency signal
+	LatencyThreshold time.Duration
+	// SuspectScore is the score from which drive is considered SUSPECT
+	SuspectScore int64
+	// BadScore is the score from which drive is considered BAD
+	BadScore int64
+}
+
+// NewPolicy builds Policy from its string representation
+// Receives comma separated list of <key>=<value> items, keys are mediaerror, ioerror, latency, latencythreshold,
+// suspect and bad, omitted weights are 0, e.g. "mediaerror=1,ioerror=1,latency=10,latencythreshold=100ms,suspect=10,bad=100"
+// Returns an instance of Policy or error if string is malformed
+func NewPolicy(str string) (*Policy, error) {
+	policy := &Policy{}
+	for _, item := range strings.Split(str, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		keyAndValue := strings.SplitN(item, "=", 2)
+		if len(keyAndValue) != 2 {
+			return nil, fmt.Errorf("health policy item %q must be in <key>=<value> format", item)
+		}
+		key, value := strings.TrimSpace(keyAndValue[0]), strings.TrimSpace(keyAndValue[1])
+
+		var err error
+		switch key {
+		case "latencythreshold":
+			policy.LatencyThreshold, err = time.ParseDuration(value)
+		case "mediaerror":
+			policy.MediaErrorWeight, err = parsePoints(value)
+		case "ioerror":
+			policy.IOErrorWeight, err = parsePoints(value)
+		case "latency":
+			policy.LatencyWeight, err = parsePoints(value)
+		case "suspect":
+			policy.SuspectScore, err = parsePoints(value)
+		case "bad":
+			policy.BadScore, err = parsePoints(value)
+		default:
+			return nil, fmt.Errorf("unknown key %s in health policy", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse health policy item %q: %v", item, err)
+		}
+	}
+
+	if policy.SuspectScore <= 0 || policy.BadScore <= 0 {
+		return nil, fmt.Errorf("suspect and bad scores of health policy must be positive")
+	}
+	if policy.SuspectScore > policy.BadScore {
+		return nil, fmt.Errorf("suspect score %d is greater than bad score %d",
+			policy.SuspectScore, policy.BadScore)
+	}
+	return policy, nil
+}
+
+// parsePoints parses non-negative amount of points
+func parsePoints(str string) (int64, error) {
+	points, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if points < 0 {
+		return 0, fmt.Errorf("points must not be negative")
+	}
+	return points, nil
+}
+
+// Score calculates health score of the drive, 0 means that problems weren't found
+func (p *Policy) Score(s Signals) int64 {
+	score := s.MediaErrors*p.MediaErrorWeight + s.IOErrors*p.IOErrorWeight
+	if p.LatencyThreshold > 0 && s.Latency > p.LatencyThreshold {
+		score += p.LatencyWeight
+	}
+	return score
+}
+
+// Health maps health score to health of the drive: GOOD, SUSPECT or BAD
+func (p *Policy) Health(score int64) string {
+	switch {
+	case score >= p.BadScore:
+		return apiV1.HealthBad
+	case score >= p.SuspectScore:
+		return apiV1.HealthSuspect
+	default:
+		return apiV1.HealthGood
+	}
+}
+
+// Worse returns the worst of two healths, GOOD is better than UNKNOWN which is better than SUSPECT and BAD
+func Worse(first, second string) string {
+	if rank(second) > rank(first) {
+		return second
+	}
+	return first
+}
+
+func rank(health string) int {
+	switch health {
+	case apiV1.HealthGood:
+		return 0
+	case apiV1.HealthSuspect:
+		return 2
+	case apiV1.HealthBad:
+		return 3
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthscore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+)
+
+func TestNewPolicy(t *testing.T) {
+	policy, err := NewPolicy(DefaultPolicy)
+	assert.Nil(t, err)
+	assert.Equal(t, &Policy{MediaErrorWeight: 1, IOErrorWeight: 1, LatencyWeight: 10,
+		LatencyThreshold: 100 * time.Millisecond, SuspectScore: 10, BadScore: 100}, policy)
+
+	policy, err = NewPolicy(" ioerror=5, suspect=5,bad=5 ")
+	assert.Nil(t, err)
+	assert.Equal(t, &Policy{IOErrorWeight: 5, SuspectScore: 5, BadScore: 5}, policy)
+
+	for _, str := range []string{"", "suspect=1", "suspect=10,bad=1", "ioerror", "ioerror=-1,suspect=1,bad=1",
+		"latencythreshold=abc,suspect=1,bad=1", "unknown=1,suspect=1,bad=1"} {
+		_, err = NewPolicy(str)
+		assert.Error(t, err, str)
+	}
+}
+
+func TestPolicy_Score(t *testing.T) {
+	policy, err := NewPolicy(DefaultPolicy)
+	assert.Nil(t, err)
+
+	assert.Equal(t, int64(0), policy.Score(Signals{}))
+	assert.Equal(t, int64(5), policy.Score(Signals{MediaErrors: 2, IOErrors: 3, Latency: time.Millisecond}))
+	assert.Equal(t, int64(10), policy.Score(Signals{Latency: time.Second}))
+
+	// latency signal is disabled
+	policy.LatencyThreshold = 0
+	assert.Equal(t, int64(0), policy.Score(Signals{Latency: time.Second}))
+}
+
+func TestPolicy_Health(t *testing.T) {
+	policy, err := NewPolicy(DefaultPolicy)
+	assert.Nil(t, err)
+
+	assert.Equal(t, apiV1.HealthGood, policy.Health(9))
+	assert.Equal(t, apiV1.HealthSuspect, policy.Health(10))
+	assert.Equal(t, apiV1.HealthBad, policy.Health(100))
+}
+
+func TestWorse(t *testing.T) {
+	assert.Equal(t, apiV1.HealthSuspect, Worse(apiV1.HealthGood, apiV1.HealthSuspect))
+	assert.Equal(t, apiV1.HealthBad, Worse(apiV1.HealthBad, apiV1.HealthSuspect))
+	assert.Equal(t, apiV1.HealthUnknown, Worse(apiV1.HealthUnknown, apiV1.HealthGood))
+	assert.Equal(t, apiV1.HealthSuspect, Worse(apiV1.HealthUnknown, apiV1.HealthSuspect))
+}
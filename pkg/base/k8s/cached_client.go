@@ -0,0 +1,191 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/runtime"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+)
+
+// CachedClient is k8s client which reads Volume, Drive, LVG and AC CRs from shared informers, other objects
+// are read from API server, all objects are written to API server. These CRs are cluster scoped,
+// so namespace of requests is ignored for them
+type CachedClient struct {
+	k8sCl.Client
+	cache cache.Cache
+	// key - type of cached object or of its list
+	cached map[reflect.Type]struct{}
+
+	mu sync.Mutex
+	// key - type of cached object, value - channel which is closed on the next change of objects of that type
+	changed map[reflect.Type]chan struct{}
+
+	log *logrus.Entry
+}
+
+// cachedObjects returns objects and lists of objects which are read from informers
+func cachedObjects() ([]runtime.Object, []runtime.Object) {
+	return []runtime.Object{&volumecrd.Volume{}, &drivecrd.Drive{}, &lvgcrd.LVG{}, &accrd.AvailableCapacity{}},
+		[]runtime.Object{&volumecrd.VolumeList{}, &drivecrd.DriveList{}, &lvgcrd.LVGList{},
+			&accrd.AvailableCapacityList{}}
+}
+
+// NewCachedClient is the constructor for CachedClient struct, informers are started and synced before return
+// and work until the process exits, Volume CRs are indexed by fields from volumeIndexes
+// Receives logrus logger
+// Returns an instance of CachedClient or error if informers weren't synced
+func NewCachedClient(logger *logrus.Logger) (*CachedClient, error) {
+	scheme, err := PrepareScheme()
+	if err != nil {
+		return nil, err
+	}
+	cfg := GetRESTConfig()
+	cl, err := k8sCl.New(cfg, k8sCl.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+	cl = withThrottleRetries(cl)
+	informers, err := cache.New(cfg, cache.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+
+	c := newCachedClient(cl, informers, logger)
+	objects, _ := cachedObjects()
+	for _, obj := range objects {
+		informer, err := informers.GetInformer(obj)
+		if err != nil {
+			return nil, err
+		}
+		objType := reflect.TypeOf(obj)
+		informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc:    func(interface{}) { c.notify(objType) },
+			UpdateFunc: func(interface{}, interface{}) { c.notify(objType) },
+			DeleteFunc: func(interface{}) { c.notify(objType) },
+		})
+	}
+
+	for field, extract := range volumeIndexes() {
+		extract := extract
+		err := informers.IndexField(&volumecrd.Volume{}, field, func(obj runtime.Object) []string {
+			return []string{extract(obj.(*volumecrd.Volume))}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stop := make(chan struct{})
+	go func() {
+		if err := informers.Start(stop); err != nil {
+			c.log.Errorf("Informers are stopped with error: %v", err)
+		}
+	}()
+	c.log.Info("Waiting for sync of informers")
+	if !informers.WaitForCacheSync(stop) {
+		close(stop)
+		return nil, fmt.Errorf("unable to sync informers")
+	}
+	return c, nil
+}
+
+func newCachedClient(cl k8sCl.Client, informers cache.Cache, logger *logrus.Logger) *CachedClient {
+	c := &CachedClient{
+		Client:  cl,
+		cache:   informers,
+		cached:  make(map[reflect.Type]struct{}),
+		changed: make(map[reflect.Type]chan struct{}),
+		log:     logger.WithField("component", "CachedClient"),
+	}
+	objects, lists := cachedObjects()
+	for _, obj := range append(objects, lists...) {
+		c.cached[reflect.TypeOf(obj)] = struct{}{}
+	}
+	return c
+}
+
+// Get reads object from informer if it is cached, otherwise from API server
+func (c *CachedClient) Get(ctx context.Context, key k8sCl.ObjectKey, obj runtime.Object) error {
+	if !c.isCached(obj) {
+		return c.Client.Get(ctx, key, obj)
+	}
+	key.Namespace = ""
+	return c.cache.Get(ctx, key, obj)
+}
+
+// List reads list of objects from informer if they are cached, otherwise from API server
+func (c *CachedClient) List(ctx context.Context, list runtime.Object, opts ...k8sCl.ListOption) error {
+	if !c.isCached(list) {
+		return c.Client.List(ctx, list, opts...)
+	}
+	listOpts := &k8sCl.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	return c.cache.List(ctx, list, &k8sCl.ListOptions{
+		LabelSelector: listOpts.LabelSelector,
+		FieldSelector: listOpts.FieldSelector,
+	})
+}
+
+// APIReader returns reader which reads objects from API server bypassing informers
+func (c *CachedClient) APIReader() k8sCl.Reader {
+	return c.Client
+}
+
+// Notify returns channel which is closed on the next change of any object of the same type as provided one,
+// nil channel is returned for objects which aren't cached
+func (c *CachedClient) Notify(obj runtime.Object) <-chan struct{} {
+	if !c.isCached(obj) {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	objType := reflect.TypeOf(obj)
+	ch, ok := c.changed[objType]
+	if !ok {
+		ch = make(chan struct{})
+		c.changed[objType] = ch
+	}
+	return ch
+}
+
+// notify wakes up waiters of changes of objects of provided type
+func (c *CachedClient) notify(objType reflect.Type) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if ch, ok := c.changed[objType]; ok {
+		close(ch)
+		delete(c.changed, objType)
+	}
+}
+
+func (c *CachedClient) isCached(obj runtime.Object) bool {
+	_, ok := c.cached[reflect.TypeOf(obj)]
+	return ok
+}
@@ -0,0 +1,97 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	vcrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
+)
+
+// fakeCache serves reads of informers from another client
+type fakeCache struct {
+	cache.Cache
+	reader k8sCl.Reader
+}
+
+func (c *fakeCache) Get(ctx context.Context, key k8sCl.ObjectKey, obj runtime.Object) error {
+	return c.reader.Get(ctx, key, obj)
+}
+
+func (c *fakeCache) List(ctx context.Context, list runtime.Object, opts ...k8sCl.ListOption) error {
+	return c.reader.List(ctx, list, opts...)
+}
+
+func TestCachedClient(t *testing.T) {
+	apiServer, err := GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+	informers, err := GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+	kubeClient := NewKubeClient(newCachedClient(apiServer.Client, &fakeCache{reader: informers.Client}, testLogger),
+		testLogger, testNs)
+
+	// cluster scoped CR is known by informers only
+	volume := &vcrd.Volume{
+		TypeMeta:   k8smetav1.TypeMeta{Kind: "Volume", APIVersion: apiV1.APIV1Version},
+		ObjectMeta: k8smetav1.ObjectMeta{Name: testID},
+		Spec:       api.Volume{Id: testID},
+	}
+	assert.Nil(t, informers.Create(testCtx, volume))
+
+	assert.Nil(t, kubeClient.ReadCR(testCtx, testID, &vcrd.Volume{}))
+	volumes := &vcrd.VolumeList{}
+	assert.Nil(t, kubeClient.ReadList(testCtx, volumes))
+	assert.Len(t, volumes.Items, 1)
+	err = kubeClient.ReadCRUncached(testCtx, testID, &vcrd.Volume{})
+	assert.True(t, k8sError.IsNotFound(err))
+
+	// other objects are read from API server
+	pod := &coreV1.Pod{ObjectMeta: k8smetav1.ObjectMeta{Name: "pod", Namespace: testNs}}
+	assert.Nil(t, apiServer.Create(testCtx, pod))
+	assert.Nil(t, kubeClient.ReadCR(testCtx, pod.Name, &coreV1.Pod{}))
+}
+
+func TestCachedClient_Notify(t *testing.T) {
+	apiServer, err := GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+	c := newCachedClient(apiServer.Client, &fakeCache{reader: apiServer.Client}, testLogger)
+
+	assert.Nil(t, c.Notify(&coreV1.Pod{}))
+
+	changed := c.Notify(&vcrd.Volume{})
+	select {
+	case <-changed:
+		t.Fatal("channel is closed before change")
+	default:
+	}
+	c.notify(reflect.TypeOf(&vcrd.Volume{}))
+	<-changed
+	// the next change is waited on new channel
+	assert.NotEqual(t, changed, c.Notify(&vcrd.Volume{}))
+}
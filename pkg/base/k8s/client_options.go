@@ -0,0 +1,164 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"time"
+
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// maxThrottleDelay is the maximal delay before retry of throttled request if API server doesn't suggest it
+	maxThrottleDelay = 5 * time.Second
+	// minThrottleDelay is the delay before the first retry of throttled request if API server doesn't suggest it
+	minThrottleDelay = 100 * time.Millisecond
+)
+
+// ClientOptions configures clients which are created by GetK8SClient, GetK8SClientWithCache, GetK8SClientset
+// and REST config returned by GetRESTConfig
+type ClientOptions struct {
+	// QPS and Burst limit requests to API server on client side, defaults of client-go are used if they are zero
+	QPS   float32
+	Burst int
+	// ThrottleRetries is amount of retries of requests which are rejected by API server with 429 status because of
+	// its load, e.g. by API Priority and Fairness, delay suggested by API server is respected. 0 disables retries
+	ThrottleRetries int
+}
+
+var clientOptions ClientOptions
+
+// SetClientOptions sets options of clients which are created afterwards, it should be called before any client
+// is created
+func SetClientOptions(opts ClientOptions) {
+	clientOptions = opts
+}
+
+// GetRESTConfig returns config of connection to API server with rate limits set by SetClientOptions,
+// process exits if config can't be found
+func GetRESTConfig() *rest.Config {
+	return applyRateLimits(ctrl.GetConfigOrDie())
+}
+
+func applyRateLimits(cfg *rest.Config) *rest.Config {
+	if clientOptions.QPS > 0 {
+		cfg.QPS = clientOptions.QPS
+	}
+	if clientOptions.Burst > 0 {
+		cfg.Burst = clientOptions.Burst
+	}
+	return cfg
+}
+
+// withThrottleRetries wraps client, so throttled requests are retried according to options set by SetClientOptions
+func withThrottleRetries(cl k8sCl.Client) k8sCl.Client {
+	if clientOptions.ThrottleRetries <= 0 {
+		return cl
+	}
+	return &throttleRetryClient{Client: cl, retries: clientOptions.ThrottleRetries}
+}
+
+// throttleRetryClient retries requests which are rejected by API server because of its load. Such requests
+// aren't processed by API server, so it is safe to retry all of them including creations
+type throttleRetryClient struct {
+	k8sCl.Client
+	retries int
+}
+
+// Get is a wrapper around Get method which retries throttled requests
+func (c *throttleRetryClient) Get(ctx context.Context, key k8sCl.ObjectKey, obj runtime.Object) error {
+	return retryThrottled(ctx, c.retries, func() error { return c.Client.Get(ctx, key, obj) })
+}
+
+// List is a wrapper around List method which retries throttled requests
+func (c *throttleRetryClient) List(ctx context.Context, list runtime.Object, opts ...k8sCl.ListOption) error {
+	return retryThrottled(ctx, c.retries, func() error { return c.Client.List(ctx, list, opts...) })
+}
+
+// Create is a wrapper around Create method which retries throttled requests
+func (c *throttleRetryClient) Create(ctx context.Context, obj runtime.Object, opts ...k8sCl.CreateOption) error {
+	return retryThrottled(ctx, c.retries, func() error { return c.Client.Create(ctx, obj, opts...) })
+}
+
+// Delete is a wrapper around Delete method which retries throttled requests
+func (c *throttleRetryClient) Delete(ctx context.Context, obj runtime.Object, opts ...k8sCl.DeleteOption) error {
+	return retryThrottled(ctx, c.retries, func() error { return c.Client.Delete(ctx, obj, opts...) })
+}
+
+// Update is a wrapper around Update method which retries throttled requests
+func (c *throttleRetryClient) Update(ctx context.Context, obj runtime.Object, opts ...k8sCl.UpdateOption) error {
+	return retryThrottled(ctx, c.retries, func() error { return c.Client.Update(ctx, obj, opts...) })
+}
+
+// Patch is a wrapper around Patch method which retries throttled requests
+func (c *throttleRetryClient) Patch(ctx context.Context, obj runtime.Object,
+	patch k8sCl.Patch, opts ...k8sCl.PatchOption) error {
+	return retryThrottled(ctx, c.retries, func() error { return c.Client.Patch(ctx, obj, patch, opts...) })
+}
+
+// Status returns StatusWriter which retries throttled requests
+func (c *throttleRetryClient) Status() k8sCl.StatusWriter {
+	return &throttleRetryStatusWriter{StatusWriter: c.Client.Status(), retries: c.retries}
+}
+
+// throttleRetryStatusWriter retries updates of status subresource which are rejected by API server
+type throttleRetryStatusWriter struct {
+	k8sCl.StatusWriter
+	retries int
+}
+
+// Update is a wrapper around Update method which retries throttled requests
+func (w *throttleRetryStatusWriter) Update(ctx context.Context, obj runtime.Object,
+	opts ...k8sCl.UpdateOption) error {
+	return retryThrottled(ctx, w.retries, func() error { return w.StatusWriter.Update(ctx, obj, opts...) })
+}
+
+// Patch is a wrapper around Patch method which retries throttled requests
+func (w *throttleRetryStatusWriter) Patch(ctx context.Context, obj runtime.Object, patch k8sCl.Patch,
+	opts ...k8sCl.PatchOption) error {
+	return retryThrottled(ctx, w.retries, func() error { return w.StatusWriter.Patch(ctx, obj, patch, opts...) })
+}
+
+// retryThrottled calls request until it isn't throttled, amount of retries is exceeded or context is done.
+// Delay before retry is suggested by API server or grows exponentially
+// Returns error of the last call
+func retryThrottled(ctx context.Context, retries int, request func() error) error {
+	delay := minThrottleDelay
+	for i := 0; ; i++ {
+		err := request()
+		if err == nil || !k8sError.IsTooManyRequests(err) || i == retries {
+			return err
+		}
+		wait := delay
+		if seconds, ok := k8sError.SuggestsClientDelay(err); ok && seconds > 0 {
+			wait = time.Duration(seconds) * time.Second
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		if delay *= 2; delay > maxThrottleDelay {
+			delay = maxThrottleDelay
+		}
+	}
+}
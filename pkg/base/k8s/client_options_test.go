@@ -0,0 +1,80 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
+)
+
+func TestRetryThrottled(t *testing.T) {
+	calls := 0
+	throttled := func() error {
+		calls++
+		if calls < 3 {
+			return k8sError.NewTooManyRequests("too many requests", 0)
+		}
+		return nil
+	}
+	assert.Nil(t, retryThrottled(context.Background(), 5, throttled))
+	assert.Equal(t, 3, calls)
+
+	// amount of retries is exceeded
+	calls = 0
+	err := retryThrottled(context.Background(), 1, throttled)
+	assert.True(t, k8sError.IsTooManyRequests(err))
+	assert.Equal(t, 2, calls)
+
+	// other errors aren't retried
+	calls = 0
+	err = retryThrottled(context.Background(), 5, func() error {
+		calls++
+		return errors.New("error")
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, calls)
+
+	// done context stops retries
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	calls = 0
+	err = retryThrottled(ctx, 5, func() error {
+		calls++
+		return k8sError.NewTooManyRequests("too many requests", 60)
+	})
+	assert.True(t, k8sError.IsTooManyRequests(err))
+	assert.Equal(t, 1, calls)
+}
+
+func TestApplyRateLimits(t *testing.T) {
+	defer SetClientOptions(ClientOptions{})
+
+	cfg := applyRateLimits(&rest.Config{QPS: 5, Burst: 10})
+	assert.Equal(t, float32(5), cfg.QPS)
+	assert.Equal(t, 10, cfg.Burst)
+
+	SetClientOptions(ClientOptions{QPS: 50, Burst: 100})
+	cfg = applyRateLimits(&rest.Config{QPS: 5, Burst: 10})
+	assert.Equal(t, float32(50), cfg.QPS)
+	assert.Equal(t, 100, cfg.Burst)
+}
@@ -131,6 +131,25 @@ func (cs *CRHelper) GetVolumeByLocation(location string) *volumecrd.Volume {
 	return nil
 }
 
+// GetVolumesByLocation reads the whole list of Volume CRs from a cluster and searches volumes with provided location,
+// several volumes could be placed on one drive when it is shared by sub-drive partitions
+// Receives location name which should be equal to Volume.Spec.Location
+// Returns slice of volumecrd.Volume or error if list of volumes wasn't read
+func (cs *CRHelper) GetVolumesByLocation(location string) ([]volumecrd.Volume, error) {
+	volList := &volumecrd.VolumeList{}
+	if err := cs.k8sClient.ReadList(context.Background(), volList); err != nil {
+		return nil, err
+	}
+
+	volumes := make([]volumecrd.Volume, 0)
+	for _, v := range volList.Items {
+		if strings.EqualFold(v.Spec.Location, location) {
+			volumes = append(volumes, v)
+		}
+	}
+	return volumes, nil
+}
+
 // UpdateVolumesOpStatusOnNode updates operational status of volumes on a node without taking into account current state
 // Receives unique identifier of the node and operational status to be set
 // Returns error or nil
@@ -183,27 +202,16 @@ func (cs *CRHelper) GetVolumeByID(volID string) *volumecrd.Volume {
 // if node isn't provided - return all volume CRs
 // if error occurs - return nil and error
 func (cs *CRHelper) GetVolumeCRs(node ...string) ([]volumecrd.Volume, error) {
-	var (
-		vList = &volumecrd.VolumeList{}
-		err   error
-	)
-
-	if err = cs.k8sClient.ReadList(context.Background(), vList); err != nil {
-		return nil, err
+	if len(node) > 0 {
+		// if node was provided, collect volumes that are on that node
+		return cs.k8sClient.ReadVolumesByField(context.Background(), VolumeNodeIDField, node[0])
 	}
 
-	if len(node) == 0 {
-		return vList.Items, nil
-	}
-
-	// if node was provided, collect volumes that are on that node
-	res := make([]volumecrd.Volume, 0)
-	for _, v := range vList.Items {
-		if v.Spec.NodeId == node[0] {
-			res = append(res, v)
-		}
+	vList := &volumecrd.VolumeList{}
+	if err := cs.k8sClient.ReadList(context.Background(), vList); err != nil {
+		return nil, err
 	}
-	return res, nil
+	return vList.Items, nil
 }
 
 // UpdateDrivesStatusOnNode updates status of drives on a node without taking into account current state
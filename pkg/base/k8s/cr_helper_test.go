@@ -62,6 +62,23 @@ func TestCRHelper_GetVolumeByLocation(t *testing.T) {
 	assert.Nil(t, ch.GetVolumeByLocation(""))
 }
 
+func TestCRHelper_GetVolumesByLocation(t *testing.T) {
+	ch := setup()
+	vol1, vol2 := testVolumeCR, testVolumeCR
+	vol2.Name = "volume-2"
+	vol2.Spec.Id = vol2.Name
+	assert.Nil(t, ch.k8sClient.CreateCR(testCtx, vol1.Name, &vol1))
+	assert.Nil(t, ch.k8sClient.CreateCR(testCtx, vol2.Name, &vol2))
+
+	volumes, err := ch.GetVolumesByLocation(testVolumeCR.Spec.Location)
+	assert.Nil(t, err)
+	assert.Len(t, volumes, 2)
+
+	volumes, err = ch.GetVolumesByLocation("")
+	assert.Nil(t, err)
+	assert.Empty(t, volumes)
+}
+
 func TestCRHelper_GetVolumeByID(t *testing.T) {
 	ch := setup()
 	expectedV := testVolumeCR
@@ -28,17 +28,21 @@ import (
 	apisV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
-	ctrl "sigs.k8s.io/controller-runtime"
 	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
 	crdV1 "github.com/dell/csi-baremetal/api/v1"
 	acrcrd "github.com/dell/csi-baremetal/api/v1/acreservationcrd"
 	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/api/v1/capacityhistorycrd"
 	nodecrd "github.com/dell/csi-baremetal/api/v1/csibmnodecrd"
+	"github.com/dell/csi-baremetal/api/v1/deploymentcrd"
 	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/inventorycrd"
 	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
+	"github.com/dell/csi-baremetal/api/v1/storagegroupcrd"
 	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/api/v1/volumemovecrd"
 	"github.com/dell/csi-baremetal/pkg/base"
 )
 
@@ -112,6 +116,26 @@ func (k *KubeClient) ReadList(ctx context.Context, obj runtime.Object) error {
 	return k.List(ctx, obj, k8sCl.InNamespace(k.Namespace))
 }
 
+// ReadCRUncached reads specified resource from API server even if client reads it from informers, it is used
+// when decision depends on the latest state of resource
+// Receives golang context, name of the read object, and object pointer where to read
+// Returns error if something went wrong
+func (k *KubeClient) ReadCRUncached(ctx context.Context, name string, obj runtime.Object) error {
+	if cached, ok := k.Client.(*CachedClient); ok {
+		return cached.APIReader().Get(ctx, k8sCl.ObjectKey{Name: name, Namespace: k.Namespace}, obj)
+	}
+	return k.ReadCR(ctx, name, obj)
+}
+
+// Notify returns channel which is closed on the next change of any resource of the same type as provided one,
+// it is nil if client doesn't read such resources from informers
+func (k *KubeClient) Notify(obj runtime.Object) <-chan struct{} {
+	if cached, ok := k.Client.(*CachedClient); ok {
+		return cached.Notify(obj)
+	}
+	return nil
+}
+
 // UpdateCR updates provided resource on k8s cluster
 // Receives golang context and updated object that implements k8s runtime.Object interface
 // Returns error if something went wrong
@@ -129,6 +153,23 @@ func (k *KubeClient) UpdateCR(ctx context.Context, obj runtime.Object) error {
 	return k.Update(ctx, obj)
 }
 
+// UpdateCRStatus updates status subresource of provided resource on k8s cluster
+// Receives golang context and updated object that implements k8s runtime.Object interface
+// Returns error if something went wrong
+func (k *KubeClient) UpdateCRStatus(ctx context.Context, obj runtime.Object) error {
+	requestUUID := ctx.Value(base.RequestUUID)
+	if requestUUID == nil {
+		requestUUID = DefaultVolumeID
+	}
+
+	k.log.WithFields(logrus.Fields{
+		"method":      "UpdateCRStatus",
+		"requestUUID": requestUUID.(string),
+	}).Debugf("Updating status of CR %s, %v", obj.GetObjectKind().GroupVersionKind().Kind, obj)
+
+	return k.Status().Update(ctx, obj)
+}
+
 // DeleteCR deletes provided resource from k8s cluster
 // Receives golang context and removable object that implements k8s runtime.Object interface
 // Returns error if something went wrong
@@ -365,14 +406,28 @@ func GetK8SClient() (k8sCl.Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	cl, err := k8sCl.New(ctrl.GetConfigOrDie(), k8sCl.Options{
+	cl, err := k8sCl.New(GetRESTConfig(), k8sCl.Options{
 		Scheme: scheme,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return cl, err
+	return withThrottleRetries(cl), err
+}
+
+// GetK8SClientWithCache returns k8s client which reads Volume, Drive, LVG and AC CRs from informers if cache is true,
+// otherwise it returns the same client as GetK8SClient
+// Returns controller-runtime/pkg/Client which can work with CSI CRs or error if something went wrong
+func GetK8SClientWithCache(cache bool, logger *logrus.Logger) (k8sCl.Client, error) {
+	if !cache {
+		return GetK8SClient()
+	}
+	cl, err := NewCachedClient(logger)
+	if err != nil {
+		return nil, err
+	}
+	return cl, nil
 }
 
 // PrepareScheme registers CSI custom resources to runtime.Scheme
@@ -411,5 +466,30 @@ func PrepareScheme() (*runtime.Scheme, error) {
 		return nil, err
 	}
 
+	// register csi deployment crd
+	if err := deploymentcrd.AddToSchemeCSIBMDeployment(scheme); err != nil {
+		return nil, err
+	}
+
+	// register capacity history crd
+	if err := capacityhistorycrd.AddToSchemeCapacityHistory(scheme); err != nil {
+		return nil, err
+	}
+
+	// register inventory crd
+	if err := inventorycrd.AddToSchemeInventory(scheme); err != nil {
+		return nil, err
+	}
+
+	// register volume move crd
+	if err := volumemovecrd.AddToSchemeVolumeMove(scheme); err != nil {
+		return nil, err
+	}
+
+	// register storage group crd
+	if err := storagegroupcrd.AddToSchemeStorageGroup(scheme); err != nil {
+		return nil, err
+	}
+
 	return scheme, nil
 }
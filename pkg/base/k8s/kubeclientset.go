@@ -30,7 +30,7 @@ func GetK8SClientset() (*kubernetes.Clientset, error) {
 	}
 
 	// creates the clientset
-	clientset, err := kubernetes.NewForConfig(config)
+	clientset, err := kubernetes.NewForConfig(applyRateLimits(config))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,110 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+)
+
+const (
+	// VolumeNodeIDField is the indexed field of Volume CR with ID of the node where volume is placed
+	VolumeNodeIDField = "spec.NodeId"
+	// VolumeLocationField is the indexed field of Volume CR with location (drive UUID or LVG name) of volume
+	VolumeLocationField = "spec.Location"
+	// VolumeStorageClassField is the indexed field of Volume CR with storage class of volume
+	VolumeStorageClassField = "spec.StorageClass"
+
+	// DefaultPageSize is the amount of objects which are read from API server by one request of paginated list
+	DefaultPageSize int64 = 500
+)
+
+// volumeIndexes returns functions which extract values of indexed fields of Volume CR, key - name of the field
+func volumeIndexes() map[string]func(*volumecrd.Volume) string {
+	return map[string]func(*volumecrd.Volume) string{
+		VolumeNodeIDField:       func(v *volumecrd.Volume) string { return v.Spec.NodeId },
+		VolumeLocationField:     func(v *volumecrd.Volume) string { return v.Spec.Location },
+		VolumeStorageClassField: func(v *volumecrd.Volume) string { return v.Spec.StorageClass },
+	}
+}
+
+// ReadVolumesByField reads Volume CRs which have provided value of indexed field. Client which reads from informers
+// looks up the index, otherwise volumes are read page by page and filtered, so the whole list isn't kept in memory
+// Receives golang context, one of Volume*Field constants and value of the field
+// Returns slice of volumecrd.Volume or error if volumes weren't read
+func (k *KubeClient) ReadVolumesByField(ctx context.Context, field, value string) ([]volumecrd.Volume, error) {
+	if _, ok := k.Client.(*CachedClient); ok {
+		volumes := &volumecrd.VolumeList{}
+		if err := k.List(ctx, volumes, k8sCl.MatchingFields{field: value}); err != nil {
+			return nil, err
+		}
+		return volumes.Items, nil
+	}
+
+	extract := volumeIndexes()[field]
+	result := make([]volumecrd.Volume, 0)
+	volumes := &volumecrd.VolumeList{}
+	err := k.ReadListPaged(ctx, volumes, DefaultPageSize, func() error {
+		for i := range volumes.Items {
+			// unknown field matches nothing
+			if extract != nil && extract(&volumes.Items[i]) == value {
+				result = append(result, volumes.Items[i])
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ReadListPaged reads a list of specified resources page by page, list is overwritten by each page
+// Receives golang context, List object pointer where to read, size of page (DefaultPageSize if it isn't positive)
+// and function which handles the page placed into the list
+// Returns error of request or of the handler, reading is stopped on the first error
+func (k *KubeClient) ReadListPaged(ctx context.Context, list runtime.Object, pageSize int64,
+	handle func() error) error {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	continueToken := ""
+	for {
+		opts := []k8sCl.ListOption{k8sCl.InNamespace(k.Namespace), k8sCl.Limit(pageSize)}
+		if continueToken != "" {
+			opts = append(opts, k8sCl.Continue(continueToken))
+		}
+		if err := k.List(ctx, list, opts...); err != nil {
+			return err
+		}
+		if err := handle(); err != nil {
+			return err
+		}
+		listMeta, err := meta.ListAccessor(list)
+		if err != nil {
+			return err
+		}
+		if continueToken = listMeta.GetContinue(); continueToken == "" {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	vcrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
+)
+
+// indexedCache serves lists of informers from another client and keeps the last field selector
+type indexedCache struct {
+	fakeCache
+	selector fields.Selector
+}
+
+func (c *indexedCache) List(ctx context.Context, list runtime.Object, opts ...k8sCl.ListOption) error {
+	listOpts := &k8sCl.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	c.selector = listOpts.FieldSelector
+	return c.reader.List(ctx, list)
+}
+
+func createTestVolumes(t *testing.T, kubeClient *KubeClient) {
+	for _, spec := range []api.Volume{
+		{Id: "volume-1", NodeId: "node-1", Location: "drive-1", StorageClass: apiV1.StorageClassHDD},
+		{Id: "volume-2", NodeId: "node-1", Location: "lvg-1", StorageClass: apiV1.StorageClassHDDLVG},
+		{Id: "volume-3", NodeId: "node-2", Location: "lvg-2", StorageClass: apiV1.StorageClassHDDLVG},
+	} {
+		volume := &vcrd.Volume{
+			TypeMeta:   k8smetav1.TypeMeta{Kind: "Volume", APIVersion: apiV1.APIV1Version},
+			ObjectMeta: k8smetav1.ObjectMeta{Name: spec.Id, Namespace: testNs},
+			Spec:       spec,
+		}
+		assert.Nil(t, kubeClient.CreateCR(testCtx, spec.Id, volume))
+	}
+}
+
+func TestKubeClient_ReadVolumesByField(t *testing.T) {
+	kubeClient, err := GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+	createTestVolumes(t, kubeClient)
+
+	volumes, err := kubeClient.ReadVolumesByField(testCtx, VolumeNodeIDField, "node-1")
+	assert.Nil(t, err)
+	assert.Len(t, volumes, 2)
+
+	volumes, err = kubeClient.ReadVolumesByField(testCtx, VolumeLocationField, "lvg-2")
+	assert.Nil(t, err)
+	assert.Len(t, volumes, 1)
+	assert.Equal(t, "volume-3", volumes[0].Spec.Id)
+
+	volumes, err = kubeClient.ReadVolumesByField(testCtx, VolumeStorageClassField, apiV1.StorageClassHDDLVG)
+	assert.Nil(t, err)
+	assert.Len(t, volumes, 2)
+
+	volumes, err = kubeClient.ReadVolumesByField(testCtx, "spec.Unknown", "node-1")
+	assert.Nil(t, err)
+	assert.Empty(t, volumes)
+}
+
+func TestKubeClient_ReadVolumesByFieldCached(t *testing.T) {
+	informers, err := GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+	createTestVolumes(t, informers)
+	indexed := &indexedCache{fakeCache: fakeCache{reader: informers.Client}}
+	kubeClient := NewKubeClient(newCachedClient(informers.Client, indexed, testLogger), testLogger, testNs)
+
+	_, err = kubeClient.ReadVolumesByField(testCtx, VolumeNodeIDField, "node-1")
+	assert.Nil(t, err)
+	// volumes are looked up by index of informers
+	assert.Equal(t, fields.OneTermEqualSelector(VolumeNodeIDField, "node-1").String(), indexed.selector.String())
+}
+
+func TestKubeClient_ReadListPaged(t *testing.T) {
+	kubeClient, err := GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+	createTestVolumes(t, kubeClient)
+
+	var (
+		volumes = &vcrd.VolumeList{}
+		pages   int
+		read    int
+	)
+	err = kubeClient.ReadListPaged(testCtx, volumes, 0, func() error {
+		pages++
+		read += len(volumes.Items)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, pages)
+	assert.Equal(t, 3, read)
+
+	// error of handler stops reading
+	handlerErr := errors.New("handler error")
+	err = kubeClient.ReadListPaged(testCtx, volumes, DefaultPageSize, func() error {
+		return handlerErr
+	})
+	assert.Equal(t, handlerErr, err)
+}
+
+func TestVolumeIndexes(t *testing.T) {
+	volume := &vcrd.Volume{Spec: api.Volume{NodeId: "node", Location: "location", StorageClass: "class"}}
+	indexes := volumeIndexes()
+	assert.Equal(t, "node", indexes[VolumeNodeIDField](volume))
+	assert.Equal(t, "location", indexes[VolumeLocationField](volume))
+	assert.Equal(t, "class", indexes[VolumeStorageClassField](volume))
+}
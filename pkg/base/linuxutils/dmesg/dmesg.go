@@ -0,0 +1,187 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dmesg contains code for reading kernel ring buffer with system dmesg util
+package dmesg
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"syscall"
+
+	"github.com/dell/csi-baremetal/pkg/base/command"
+)
+
+const (
+	// DmesgCmdImpl is a base CMD for dmesg util
+	DmesgCmdImpl = "dmesg"
+	// ErrorsCmd is a CMD which prints kernel messages of warning and higher levels without timestamps
+	ErrorsCmd = DmesgCmdImpl + " --notime --level=emerg,alert,crit,err,warn"
+	// KmsgFile is the device of kernel ring buffer, each read returns one record
+	KmsgFile = "/dev/kmsg"
+	// maxRecordSize is the maximal size of kmsg record
+	maxRecordSize = 8192
+)
+
+// deviceErrorPatterns are patterns of kernel messages about I/O errors, the first group is kernel name of the device
+var deviceErrorPatterns = []*regexp.Regexp{
+	// blk_update_request: I/O error, dev sdb, sector 2048 op 0x0:(READ) flags 0x0 phys_seg 1 prio class 0
+	// blk_update_request: critical medium error, dev sdb, sector 2048 op 0x0:(READ) flags 0x0 phys_seg 1 prio class 0
+	regexp.MustCompile(`(?:I/O|critical \w+) error, dev (\w+),`),
+	// Buffer I/O error on dev sdb1, logical block 0, async page read
+	regexp.MustCompile(`Buffer I/O error on dev(?:ice)? (\w+),`),
+	// sd 2:0:0:1: [sdb] tag#0 FAILED Result: hostbyte=DID_OK driverbyte=DRIVER_SENSE
+	regexp.MustCompile(`^sd [\d:]+: \[(\w+)\] .*FAILED Result`),
+	// nvme nvme0: I/O 123 QID 4 timeout, aborting
+	regexp.MustCompile(`^nvme (nvme\d+): I/O \d+ QID \d+ timeout`),
+}
+
+// WrapDmesg is an interface that encapsulates operation with system dmesg util
+type WrapDmesg interface {
+	GetFSMessages(device string) ([]string, error)
+}
+
+// Dmesg is a wrap for system dmesg util
+type Dmesg struct {
+	e command.CmdExecutor
+}
+
+// NewDmesg is a constructor for Dmesg
+func NewDmesg(e command.CmdExecutor) *Dmesg {
+	return &Dmesg{e: e}
+}
+
+// GetFSMessages returns kernel messages of file system on the device, e.g.
+// EXT4-fs (sdb1): Remounting filesystem read-only
+// EXT4-fs error (device sdb1): ext4_find_entry:1455: inode #2: comm ls: reading directory lblock 0
+// XFS (dm-3): Filesystem has been shut down due to log error (0x2).
+// Receives path of the device, symlinks such as /dev/mapper/<vg>-<lv> are resolved to kernel name of the device
+// Returns messages in order of appearance or error if dmesg failed
+func (d *Dmesg) GetFSMessages(device string) ([]string, error) {
+	if resolved, err := filepath.EvalSymlinks(device); err == nil {
+		device = resolved
+	}
+	name := filepath.Base(device)
+
+	stdout, _, err := d.e.RunCmd(ErrorsCmd)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]string, 0)
+	for _, line := range strings.Split(stdout, "\n") {
+		if strings.Contains(line, "("+name+")") || strings.Contains(line, "(device "+name+")") {
+			messages = append(messages, strings.TrimSpace(line))
+		}
+	}
+	return messages, nil
+}
+
+// FollowDeviceErrors reads records which are added to kernel ring buffer after its call and sends kernel names
+// of devices from messages about I/O errors to the channel, it blocks until ring buffer can be read
+// Receives path of kmsg device which is KmsgFile and channel for names of devices
+// Returns error if ring buffer can't be read
+func FollowDeviceErrors(path string, devices chan<- string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// only new records are read, errors which happened before are already reflected in health of drives
+	if _, err = f.Seek(0, io.SeekEnd); err != nil {
+		return err
+	}
+	buf := make([]byte, maxRecordSize)
+	for {
+		n, err := f.Read(buf)
+		if err != nil {
+			// records were overwritten before they were read, reading continues from the next available one
+			if errors.Is(err, syscall.EPIPE) {
+				continue
+			}
+			return err
+		}
+		if device, ok := ParseDeviceError(parseRecord(string(buf[:n]))); ok {
+			devices <- device
+		}
+	}
+}
+
+// parseRecord returns message of kmsg record, e.g.
+// 3,1542,2853151416,-;blk_update_request: I/O error, dev sdb, sector 2048
+// Continuation lines with device properties which start with space are dropped
+func parseRecord(record string) string {
+	record = strings.SplitN(record, "\n", 2)[0]
+	if i := strings.Index(record, ";"); i >= 0 {
+		return record[i+1:]
+	}
+	return record
+}
+
+// ParseDeviceError checks whether kernel message is about I/O error of the device
+// Returns kernel name of the device and true if message matches one of the known patterns
+func ParseDeviceError(message string) (string, bool) {
+	for _, pattern := range deviceErrorPatterns {
+		if match := pattern.FindStringSubmatch(message); match != nil {
+			return match[1], true
+		}
+	}
+	return "", false
+}
+
+// IsDeviceOfDrive checks whether kernel name of the device from kernel message belongs to the drive,
+// it could be name of the drive itself, its partition or NVMe controller of the drive
+// Receives kernel name, e.g. sdb1 or nvme0, and path of the drive, e.g. /dev/sdb or /dev/nvme0n1
+func IsDeviceOfDrive(name, drivePath string) bool {
+	if drivePath == "" || name == "" {
+		return false
+	}
+	driveName := filepath.Base(drivePath)
+	if name == driveName {
+		return true
+	}
+	// namespace of NVMe controller
+	if strings.HasPrefix(driveName, name+"n") && isDigits(driveName[len(name)+1:]) {
+		return true
+	}
+	// partition, NVMe partitions have p before the number
+	suffix := strings.TrimPrefix(name, driveName)
+	if suffix == name {
+		return false
+	}
+	if strings.HasPrefix(driveName, "nvme") {
+		return strings.HasPrefix(suffix, "p") && isDigits(suffix[1:])
+	}
+	return isDigits(suffix)
+}
+
+// isDigits checks that string is not empty and contains only digits
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
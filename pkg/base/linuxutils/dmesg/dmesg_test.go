@@ -0,0 +1,87 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dmesg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dell/csi-baremetal/pkg/mocks"
+)
+
+func TestDmesg_GetFSMessages(t *testing.T) {
+	output := "sd 2:0:0:1: [sdb] tag#0 FAILED Result: hostbyte=DID_OK driverbyte=DRIVER_SENSE\n" +
+		"EXT4-fs error (device sdb1): ext4_find_entry:1455: inode #2: comm ls: reading directory lblock 0\n" +
+		"EXT4-fs (sdb1): Remounting filesystem read-only\n" +
+		"EXT4-fs (sdb11): mounting ext3 file system using the ext4 subsystem\n" +
+		"XFS (sdc1): Filesystem has been shut down due to log error (0x2).\n"
+	e := &mocks.GoMockExecutor{}
+	d := NewDmesg(e)
+
+	e.On("RunCmd", ErrorsCmd).Return(output, "", nil).Once()
+	messages, err := d.GetFSMessages("/dev/sdb1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"EXT4-fs error (device sdb1): ext4_find_entry:1455: inode #2: comm ls: reading directory lblock 0",
+		"EXT4-fs (sdb1): Remounting filesystem read-only",
+	}, messages)
+
+	e.On("RunCmd", ErrorsCmd).Return("", "", errors.New("error")).Once()
+	_, err = d.GetFSMessages("/dev/sdb1")
+	assert.NotNil(t, err)
+}
+
+func TestParseDeviceError(t *testing.T) {
+	testCases := []struct {
+		record string
+		device string
+	}{
+		{"3,1542,2853151416,-;blk_update_request: I/O error, dev sdb, sector 2048 op 0x0:(READ) flags 0x0",
+			"sdb"},
+		{"3,1543,2853151417,-;blk_update_request: critical medium error, dev sdc, sector 2048", "sdc"},
+		{"3,1544,2853151418,-;Buffer I/O error on dev sdb1, logical block 0, async page read\n SUBSYSTEM=block",
+			"sdb1"},
+		{"6,1545,2853151419,-;sd 2:0:0:1: [sdd] tag#0 FAILED Result: hostbyte=DID_OK driverbyte=DRIVER_SENSE",
+			"sdd"},
+		{"4,1546,2853151420,-;nvme nvme0: I/O 123 QID 4 timeout, aborting", "nvme0"},
+		{"3,1547,2853151421,-;print_req_error: I/O error, dev nvme1n1, sector 0", "nvme1n1"},
+		{"6,1548,2853151422,-;sd 2:0:0:1: [sdd] Attached SCSI disk", ""},
+		{"6,1549,2853151423,-;EXT4-fs (sdb1): mounted filesystem with ordered data mode", ""},
+	}
+
+	for _, tc := range testCases {
+		device, ok := ParseDeviceError(parseRecord(tc.record))
+		assert.Equal(t, tc.device != "", ok, tc.record)
+		assert.Equal(t, tc.device, device, tc.record)
+	}
+}
+
+func TestIsDeviceOfDrive(t *testing.T) {
+	assert.True(t, IsDeviceOfDrive("sdb", "/dev/sdb"))
+	assert.True(t, IsDeviceOfDrive("sdb1", "/dev/sdb"))
+	assert.True(t, IsDeviceOfDrive("nvme0", "/dev/nvme0n1"))
+	assert.True(t, IsDeviceOfDrive("nvme0n1", "/dev/nvme0n1"))
+	assert.True(t, IsDeviceOfDrive("nvme0n1p2", "/dev/nvme0n1"))
+
+	assert.False(t, IsDeviceOfDrive("sdbb", "/dev/sdb"))
+	assert.False(t, IsDeviceOfDrive("sdb", "/dev/sdbb"))
+	assert.False(t, IsDeviceOfDrive("nvme1", "/dev/nvme10n1"))
+	assert.False(t, IsDeviceOfDrive("nvme0n11", "/dev/nvme0n1"))
+	assert.False(t, IsDeviceOfDrive("sdb", ""))
+}
@@ -0,0 +1,129 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fio contains code for running I/O benchmarks with system fio util
+package fio
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dell/csi-baremetal/pkg/base/command"
+)
+
+const (
+	// FioCmdImpl is a base CMD for fio util
+	FioCmdImpl = "fio"
+	// BenchmarkCmdTmpl is a CMD which runs time based random read/write benchmark against device
+	// and produces output in JSON format, add device and runtime in seconds
+	BenchmarkCmdTmpl = FioCmdImpl + " --name=benchmark --filename=%s --direct=1 --ioengine=libaio --rw=randrw " +
+		"--bs=4k --iodepth=32 --time_based --runtime=%d --output-format=json"
+	// DefaultRuntime is the default duration of benchmark
+	DefaultRuntime = 60 * time.Second
+	// MaxRuntime is the maximal duration of benchmark
+	MaxRuntime = 10 * time.Minute
+)
+
+// WrapFio is an interface that encapsulates operation with system fio util
+type WrapFio interface {
+	Benchmark(device string, runtime time.Duration) (*BenchmarkResult, error)
+}
+
+// OperationResult contains results of benchmark for one type of operations
+type OperationResult struct {
+	// IOPS is the amount of I/O operations per second
+	IOPS float64 `json:"iops"`
+	// Bandwidth is the throughput in KiB/s
+	Bandwidth int64 `json:"bandwidthKiB"`
+	// Latency is the mean completion latency in microseconds
+	Latency float64 `json:"latencyUs"`
+}
+
+// BenchmarkResult contains results of benchmark
+type BenchmarkResult struct {
+	Read  OperationResult `json:"read"`
+	Write OperationResult `json:"write"`
+	// Runtime is the duration of benchmark in seconds
+	Runtime int64 `json:"runtimeSeconds"`
+}
+
+// fioOutput is a part of fio JSON output which is used for BenchmarkResult
+type fioOutput struct {
+	Jobs []struct {
+		Read  fioOperation `json:"read"`
+		Write fioOperation `json:"write"`
+	} `json:"jobs"`
+}
+
+type fioOperation struct {
+	IOPS  float64 `json:"iops"`
+	BW    int64   `json:"bw"`
+	LatNs struct {
+		Mean float64 `json:"mean"`
+	} `json:"clat_ns"`
+}
+
+// FIO is a wrap for system fio util
+type FIO struct {
+	e command.CmdExecutor
+}
+
+// NewFIO is a constructor for FIO
+func NewFIO(e command.CmdExecutor) *FIO {
+	return &FIO{e: e}
+}
+
+// Benchmark runs random read/write benchmark against device, data on the device is overwritten
+// Receives path of the device and duration of benchmark, DefaultRuntime is used if it isn't positive
+// and MaxRuntime bounds it
+// Returns results of benchmark or error if something went wrong
+func (f *FIO) Benchmark(device string, runtime time.Duration) (*BenchmarkResult, error) {
+	if runtime <= 0 {
+		runtime = DefaultRuntime
+	}
+	if runtime > MaxRuntime {
+		runtime = MaxRuntime
+	}
+	seconds := int64(runtime / time.Second)
+
+	strOut, _, err := f.e.RunCmd(fmt.Sprintf(BenchmarkCmdTmpl, device, seconds))
+	if err != nil {
+		return nil, err
+	}
+	output := &fioOutput{}
+	if err = json.Unmarshal([]byte(strOut), output); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal fio output: %v", err)
+	}
+	if len(output.Jobs) == 0 {
+		return nil, fmt.Errorf("fio output doesn't contain jobs: %s", strOut)
+	}
+
+	job := output.Jobs[0]
+	return &BenchmarkResult{
+		Read:    toOperationResult(job.Read),
+		Write:   toOperationResult(job.Write),
+		Runtime: seconds,
+	}, nil
+}
+
+func toOperationResult(op fioOperation) OperationResult {
+	return OperationResult{
+		IOPS:      op.IOPS,
+		Bandwidth: op.BW,
+		Latency:   op.LatNs.Mean / float64(time.Microsecond),
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fio
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dell/csi-baremetal/pkg/mocks"
+)
+
+func TestFIO_Benchmark(t *testing.T) {
+	output := `{
+		"fio version": "fio-3.1",
+		"jobs": [{
+			"jobname": "benchmark",
+			"read": {"iops": 1500.5, "bw": 6002, "clat_ns": {"mean": 20000.0}},
+			"write": {"iops": 1490.0, "bw": 5960, "clat_ns": {"mean": 1500.0}}
+		}]
+	}`
+	e := &mocks.GoMockExecutor{}
+	f := NewFIO(e)
+
+	e.On("RunCmd", fmt.Sprintf(BenchmarkCmdTmpl, "/dev/sdb", 60)).Return(output, "", nil)
+	res, err := f.Benchmark("/dev/sdb", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1500.5, res.Read.IOPS)
+	assert.Equal(t, int64(6002), res.Read.Bandwidth)
+	assert.Equal(t, 20.0, res.Read.Latency)
+	assert.Equal(t, 1490.0, res.Write.IOPS)
+	assert.Equal(t, 1.5, res.Write.Latency)
+	assert.Equal(t, int64(60), res.Runtime)
+
+	// runtime is bounded
+	e.On("RunCmd", fmt.Sprintf(BenchmarkCmdTmpl, "/dev/sdb", 600)).Return(output, "", nil)
+	res, err = f.Benchmark("/dev/sdb", time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(600), res.Runtime)
+}
+
+func TestFIO_BenchmarkFails(t *testing.T) {
+	e := &mocks.GoMockExecutor{}
+	f := NewFIO(e)
+
+	e.On("RunCmd", fmt.Sprintf(BenchmarkCmdTmpl, "/dev/sdb", 10)).Return("", "", fmt.Errorf("error")).Once()
+	_, err := f.Benchmark("/dev/sdb", 10*time.Second)
+	assert.NotNil(t, err)
+
+	e.On("RunCmd", fmt.Sprintf(BenchmarkCmdTmpl, "/dev/sdb", 10)).Return(`{"jobs": []}`, "", nil).Once()
+	_, err = f.Benchmark("/dev/sdb", 10*time.Second)
+	assert.NotNil(t, err)
+
+	e.On("RunCmd", fmt.Sprintf(BenchmarkCmdTmpl, "/dev/sdb", 10)).Return("not json", "", nil).Once()
+	_, err = f.Benchmark("/dev/sdb", 10*time.Second)
+	assert.NotNil(t, err)
+}
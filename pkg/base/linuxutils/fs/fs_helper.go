@@ -50,6 +50,10 @@ const (
 	MkDirCmdTmpl = "mkdir -p %s"
 	// RmDirCmdTmpl rm template
 	RmDirCmdTmpl = "rm -rf %s"
+	// XFSGrowFSCmdTmpl cmd for growing xfs up to the size of device, xfs could be grown only when it is mounted
+	XFSGrowFSCmdTmpl = "xfs_growfs %s" // add mount point
+	// ExtResizeCmdTmpl cmd for growing ext3 or ext4 up to the size of device, it works for mounted and unmounted FS
+	ExtResizeCmdTmpl = "resize2fs %s" // add device
 	// WipeFSCmdTmpl cmd for wiping FS on device
 	WipeFSCmdTmpl = wipefs + "-af %s"
 	// GetFSTypeCmdTmpl cmd for retrieving FS type
@@ -58,12 +62,18 @@ const (
 	MountInfoFile = "/proc/self/mountinfo"
 	// FindMntCmdTmpl find source device for target mount path cmd
 	FindMntCmdTmpl = "findmnt --target %s --output SOURCE --noheadings" // add target path
+	// FindMntTargetCmdTmpl find first mount point of source device cmd
+	FindMntTargetCmdTmpl = "findmnt --source %s --output TARGET --noheadings --first-only" // add source device
+	// FSTrimCmdTmpl cmd for discarding unused blocks of mounted file system
+	FSTrimCmdTmpl = "fstrim %s" // add mount point
 	// MountCmdTmpl mount cmd template, add "src" "dst" and "opts" (could be omitted)
 	MountCmdTmpl = "mount %s %s %s"
 	// UnmountCmdTmpl unmount path template
 	UnmountCmdTmpl = "umount %s"
 	// BindOption option for mount operation
 	BindOption = "--bind"
+	// ReadOnlyOption option for read-only mount, bind mount is remounted read-only by mount util
+	ReadOnlyOption = "-o ro"
 )
 
 // WrapFS is an interface that encapsulates operation with file systems
@@ -72,11 +82,15 @@ type WrapFS interface {
 	MkDir(src string) error
 	RmDir(src string) error
 	CreateFS(fsType FileSystem, device string) error
+	GrowFS(fsType FileSystem, device, mountPoint string) error
 	WipeFS(device string) error
 	GetFSType(device string) (FileSystem, error)
 	// Mount operations
 	IsMounted(src string) (bool, error)
 	FindMountPoint(target string) (string, error)
+	FindMountTarget(device string) (string, error)
+	IsReadOnlyMount(path string) (bool, error)
+	Trim(mountPoint string) error
 	Mount(src, dst string, opts ...string) error
 	Unmount(src string) error
 }
@@ -169,6 +183,30 @@ func (h *WrapFSImpl) CreateFS(fsType FileSystem, device string) error {
 	return nil
 }
 
+// GrowFS grows specified file system up to the size of the device using xfs_growfs or resize2fs
+// Receives file system as a var of FileSystem type, path of the device and mount point of the file system,
+// mount point is required for xfs only
+// Returns error if something went wrong
+func (h *WrapFSImpl) GrowFS(fsType FileSystem, device, mountPoint string) error {
+	var cmd string
+	switch fsType {
+	case XFS:
+		if mountPoint == "" {
+			return fmt.Errorf("xfs on %s could be grown only when it is mounted", device)
+		}
+		cmd = fmt.Sprintf(XFSGrowFSCmdTmpl, mountPoint)
+	case EXT3, EXT4:
+		cmd = fmt.Sprintf(ExtResizeCmdTmpl, device)
+	default:
+		return fmt.Errorf("unsupported file system %v", fsType)
+	}
+
+	if _, _, err := h.e.RunCmd(cmd); err != nil {
+		return fmt.Errorf("failed to grow file system on %s: %v", device, err)
+	}
+	return nil
+}
+
 // WipeFS deletes file system from the provided device using wipefs
 // Receives file path of the device as a string
 // Returns error if something went wrong
@@ -220,6 +258,49 @@ func (h *WrapFSImpl) IsMounted(path string) (bool, error) {
 	return false, nil
 }
 
+// IsReadOnlyMount checks whether mount point or file system mounted to it is read-only, file system is remounted
+// read-only by kernel on I/O errors, in this case mount options are kept while super block options are changed
+// Receives path of the mount point
+// Returns true if path is mounted read-only or error if path isn't mounted or mountinfo can't be read
+func (h *WrapFSImpl) IsReadOnlyMount(path string) (bool, error) {
+	h.opMutex.Lock()
+	defer h.opMutex.Unlock()
+
+	procMounts, err := util.ConsistentRead(MountInfoFile, 5, time.Millisecond)
+	if err != nil || len(procMounts) == 0 {
+		return false, fmt.Errorf("unable to read mount options of %s, error: %v", path, err)
+	}
+	readOnly, found := parseReadOnlyMount(string(procMounts), path)
+	if !found {
+		return false, fmt.Errorf("%s isn't mounted", path)
+	}
+	return readOnly, nil
+}
+
+// parseReadOnlyMount searches mount point in /proc/self/mountinfo content, e.g.
+// 36 35 98:0 /mnt1 /mnt2 rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+// where the 6th field is options of the mount point and the last one is options of the super block
+// Returns whether mount point is read-only and whether it was found, the last mount of the path wins
+func parseReadOnlyMount(mountInfo, path string) (readOnly bool, found bool) {
+	for _, line := range strings.Split(mountInfo, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 6 || fields[4] != path {
+			continue
+		}
+		found = true
+		opts := strings.Split(fields[5], ",")
+		// optional fields are terminated by a single hyphen, super block options are the last field
+		for i := 6; i < len(fields)-1; i++ {
+			if fields[i] == "-" {
+				opts = append(opts, strings.Split(fields[len(fields)-1], ",")...)
+				break
+			}
+		}
+		readOnly = util.ContainsString(opts, "ro")
+	}
+	return readOnly, found
+}
+
 // FindMountPoint returns source of mount point for target
 // Receives path of a mount point as target
 // Returns mount point or empty string and error
@@ -241,6 +322,28 @@ func (h *WrapFSImpl) FindMountPoint(target string) (string, error) {
 	return strings.TrimSpace(strOut), nil
 }
 
+// FindMountTarget returns mount point of the device
+// Receives path of the device
+// Returns first mount point of the device or empty string if device isn't mounted, or error
+func (h *WrapFSImpl) FindMountTarget(device string) (string, error) {
+	// findmnt exits with code 1 and empty output if device isn't mounted
+	stdout, _, err := h.e.RunCmd(fmt.Sprintf(FindMntTargetCmdTmpl, device))
+	if err != nil && stdout != "" {
+		return "", err
+	}
+	return strings.TrimSpace(stdout), nil
+}
+
+// Trim discards unused blocks of mounted file system using fstrim
+// Receives mount point of the file system
+// Returns error if something went wrong
+func (h *WrapFSImpl) Trim(mountPoint string) error {
+	if _, _, err := h.e.RunCmd(fmt.Sprintf(FSTrimCmdTmpl, mountPoint)); err != nil {
+		return fmt.Errorf("failed to trim file system mounted to %s: %v", mountPoint, err)
+	}
+	return nil
+}
+
 // Mount mounts source path to the destination directory
 // Receives source path and destination dir and also opts parameters that are used for mount command for example --bind
 // Returns error if something went wrong
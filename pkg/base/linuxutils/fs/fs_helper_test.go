@@ -55,6 +55,41 @@ func TestFindMountPoint(t *testing.T) {
 	assert.Equal(t, expectedErr, err)
 }
 
+func TestFindMountTarget(t *testing.T) {
+	var (
+		e      = &mocks.GoMockExecutor{}
+		fh     = NewFSImpl(e)
+		device = "/dev/sda1"
+		cmd    = fmt.Sprintf(FindMntTargetCmdTmpl, device)
+	)
+
+	e.OnCommand(cmd).Return("/mnt/sda1\n", "", nil).Times(1)
+	target, err := fh.FindMountTarget(device)
+	assert.Nil(t, err)
+	assert.Equal(t, "/mnt/sda1", target)
+
+	// device isn't mounted
+	e.OnCommand(cmd).Return("", "", testError).Times(1)
+	target, err = fh.FindMountTarget(device)
+	assert.Nil(t, err)
+	assert.Equal(t, "", target)
+}
+
+func TestTrim(t *testing.T) {
+	var (
+		e          = &mocks.GoMockExecutor{}
+		fh         = NewFSImpl(e)
+		mountPoint = "/mnt/sda1"
+		cmd        = fmt.Sprintf(FSTrimCmdTmpl, mountPoint)
+	)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, fh.Trim(mountPoint))
+
+	e.OnCommand(cmd).Return("", "", testError).Times(1)
+	assert.NotNil(t, fh.Trim(mountPoint))
+}
+
 func TestGetFSSpace_Fail(t *testing.T) {
 	var (
 		mockexec = &mocks.GoMockExecutor{}
@@ -169,6 +204,33 @@ func TestCreateFS(t *testing.T) {
 	assert.Contains(t, err.Error(), "unsupported file system")
 }
 
+func TestGrowFS(t *testing.T) {
+	var (
+		e          = &mocks.GoMockExecutor{}
+		fh         = NewFSImpl(e)
+		device     = "/dev/sda1"
+		mountPoint = "/mnt/sda1"
+		err        error
+	)
+
+	e.OnCommand(fmt.Sprintf(XFSGrowFSCmdTmpl, mountPoint)).Return("", "", nil).Times(1)
+	err = fh.GrowFS(XFS, device, mountPoint)
+	assert.Nil(t, err)
+
+	// xfs isn't mounted
+	err = fh.GrowFS(XFS, device, "")
+	assert.NotNil(t, err)
+
+	e.OnCommand(fmt.Sprintf(ExtResizeCmdTmpl, device)).Return("", "", testError).Times(1)
+	err = fh.GrowFS(EXT4, device, "")
+	assert.NotNil(t, err)
+
+	// unsupported FS
+	err = fh.GrowFS("anotherFS", device, mountPoint)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unsupported file system")
+}
+
 func TestWipeFS(t *testing.T) {
 	var (
 		e      = &mocks.GoMockExecutor{}
@@ -248,3 +310,26 @@ func TestUnmount(t *testing.T) {
 	err = fh.Unmount(path)
 	assert.NotNil(t, err)
 }
+
+func TestParseReadOnlyMount(t *testing.T) {
+	mountInfo := "22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw,errors=remount-ro\n" +
+		"36 22 8:17 / /staging/pvc-1 rw,noatime shared:2 - ext4 /dev/sdb1 ro,errors=remount-ro\n" +
+		"37 22 8:17 / /target/pvc-1 ro,noatime shared:2 - ext4 /dev/sdb1 rw\n" +
+		"38 22 8:33 / /target/pvc-2 rw,noatime - xfs /dev/sdc1 rw,attr2\n"
+
+	// super block was remounted read-only by kernel
+	readOnly, found := parseReadOnlyMount(mountInfo, "/staging/pvc-1")
+	assert.True(t, found)
+	assert.True(t, readOnly)
+	// mount point is read-only
+	readOnly, found = parseReadOnlyMount(mountInfo, "/target/pvc-1")
+	assert.True(t, found)
+	assert.True(t, readOnly)
+
+	readOnly, found = parseReadOnlyMount(mountInfo, "/target/pvc-2")
+	assert.True(t, found)
+	assert.False(t, readOnly)
+
+	_, found = parseReadOnlyMount(mountInfo, "/target")
+	assert.False(t, found)
+}
@@ -51,6 +51,8 @@ const (
 	VGFreeSpaceCmdTmpl = "vgs %s --options vg_free --units b --noheadings" // add VG name
 	// LVCreateCmdTmpl create LV on provided VG cmd
 	LVCreateCmdTmpl = lvmPath + "lvcreate --yes --name %s --size %s %s" // add LV name, size and VG name
+	// LVExpandCmdTmpl expand LV up to the provided size cmd
+	LVExpandCmdTmpl = lvmPath + "lvextend --size %s %s" // add size and full LV name
 	// LVRemoveCmdTmpl remove LV cmd
 	LVRemoveCmdTmpl = lvmPath + "lvremove --yes %s" // add full LV name
 	// LVsInVGCmdTmpl print LVs in VG cmd
@@ -67,6 +69,7 @@ type WrapLVM interface {
 	VGRemove(name string) error
 	LVCreate(name, size, vgName string) error
 	LVRemove(fullLVName string) error
+	LVExpand(fullLVName, size string) error
 	IsVGContainsLVs(vgName string) bool
 	RemoveOrphanPVs() error
 	FindVgNameByLvName(lvName string) (string, error)
@@ -158,6 +161,18 @@ func (l *LVM) LVRemove(fullLVName string) error {
 	return err
 }
 
+// LVExpand expands logical volume up to the provided size, ignore error if LV already has such size
+// Receives fullLVName that is a path to LV and size which is a string like 1.2G, 100M
+// Returns error if something went wrong
+func (l *LVM) LVExpand(fullLVName, size string) error {
+	cmd := fmt.Sprintf(LVExpandCmdTmpl, size, fullLVName)
+	_, stdErr, err := l.e.RunCmd(cmd)
+	if err != nil && strings.Contains(stdErr, "matches existing size") {
+		return nil
+	}
+	return err
+}
+
 // IsVGContainsLVs checks whether VG vgName contains any LVs or no
 // Receives Volume Group name to check
 // Returns true in case of error to prevent mistaken VG remove
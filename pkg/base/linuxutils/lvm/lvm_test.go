@@ -168,6 +168,30 @@ func TestLinuxUtils_LVRemove(t *testing.T) {
 	assert.Equal(t, expectedErr, err)
 }
 
+func TestLinuxUtils_LVExpand(t *testing.T) {
+	var (
+		e           = &mocks.GoMockExecutor{}
+		l           = NewLVM(e, testLogger)
+		fullLVName  = "/dev/test-lvg/test-lv"
+		size        = "200m"
+		cmd         = fmt.Sprintf(LVExpandCmdTmpl, size, fullLVName)
+		err         error
+		expectedErr = errors.New("error")
+	)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	err = l.LVExpand(fullLVName, size)
+	assert.Nil(t, err)
+
+	e.OnCommand(cmd).Return("", "New size (50 extents) matches existing size (50 extents).", expectedErr).Times(1)
+	err = l.LVExpand(fullLVName, size)
+	assert.Nil(t, err)
+
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	err = l.LVExpand(fullLVName, size)
+	assert.Equal(t, expectedErr, err)
+}
+
 func TestLinuxUtilsIs_VGContainsLVs(t *testing.T) {
 	var (
 		e           = &mocks.GoMockExecutor{}
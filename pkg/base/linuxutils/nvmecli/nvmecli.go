@@ -54,11 +54,14 @@ type NVMDevice struct {
 	// Can VID be string for nvme?
 	Vendor int `json:"vid,omitempty"`
 	Health string
+	// MediaErrors is the amount of unrecovered data integrity errors from SMART log
+	MediaErrors int64
 }
 
 // SMARTLog represents SMART information for NVMe devices
 type SMARTLog struct {
-	CriticalWarning int `json:"critical_warning,omitempty"`
+	CriticalWarning int   `json:"critical_warning,omitempty"`
+	MediaErrors     int64 `json:"media_errors,omitempty"`
 }
 
 // NVMECLI is a wrap for system nvem_cli util
@@ -93,35 +96,36 @@ func (na *NVMECLI) GetNVMDevices() ([]NVMDevice, error) {
 		return nil, fmt.Errorf("unexpected nvme list output format")
 	}
 	for i, d := range devs {
-		devs[i].Health = na.getNVMDeviceHealth(d.DevicePath)
+		devs[i].Health, devs[i].MediaErrors = na.getNVMDeviceHealth(d.DevicePath)
 		na.fillNVMDeviceVendor(&devs[i])
 	}
 	return devs, nil
 }
 
 // getNVMDeviceHealth gets information about device health based on critical_warning SMART attribute using nvme_cli smart-log util
-func (na *NVMECLI) getNVMDeviceHealth(path string) string {
+// Returns health and amount of media errors of the device
+func (na *NVMECLI) getNVMDeviceHealth(path string) (string, int64) {
 	ll := na.log.WithField("method", "getNVMDeviceHealth")
 	cmd := fmt.Sprintf(NVMeHealthCmdImpl, path)
 	strOut, _, err := na.e.RunCmd(cmd)
 	if err != nil {
 		ll.Errorf("%s failed, set health as %s", cmd, apiV1.HealthUnknown)
-		return apiV1.HealthUnknown
+		return apiV1.HealthUnknown, 0
 	}
 	smartLog := &SMARTLog{}
 	err = json.Unmarshal([]byte(strOut), &smartLog)
 	if err != nil {
 		ll.Errorf("unable to unmarshal output to SMARTLog, set health as %s", apiV1.HealthUnknown)
-		return apiV1.HealthUnknown
+		return apiV1.HealthUnknown, 0
 	}
 	health := smartLog.CriticalWarning
 	if na.isOneOfBitsSet(uint64(health), 0, 3) {
-		return apiV1.HealthSuspect
+		return apiV1.HealthSuspect, smartLog.MediaErrors
 	}
 	if na.isOneOfBitsSet(uint64(health), 2, 4, 5) {
-		return apiV1.HealthBad
+		return apiV1.HealthBad, smartLog.MediaErrors
 	}
-	return apiV1.HealthGood
+	return apiV1.HealthGood, smartLog.MediaErrors
 }
 
 // fillNVMDeviceVendor gets information about device vendor id
@@ -161,7 +161,7 @@ func TestNVMECLI_getNVMDeviceHealthBad(t *testing.T) {
 	}
 	`
 	e.On("RunCmd", fmt.Sprintf(NVMeHealthCmdImpl, testPath)).Return(health, "", nil)
-	deviceHealth := l.getNVMDeviceHealth(testPath)
+	deviceHealth, _ := l.getNVMDeviceHealth(testPath)
 	assert.Equal(t, apiV1.HealthBad, deviceHealth)
 }
 func TestNVMECLI_getNVMDeviceHealthSuspect(t *testing.T) {
@@ -172,7 +172,7 @@ func TestNVMECLI_getNVMDeviceHealthSuspect(t *testing.T) {
 	}
 	`
 	e.On("RunCmd", fmt.Sprintf(NVMeHealthCmdImpl, testPath)).Return(health, "", nil)
-	deviceHealth := l.getNVMDeviceHealth(testPath)
+	deviceHealth, _ := l.getNVMDeviceHealth(testPath)
 	assert.Equal(t, apiV1.HealthSuspect, deviceHealth)
 }
 
@@ -180,12 +180,14 @@ func TestNVMECLI_getNVMDeviceHealthGood(t *testing.T) {
 	e := &mocks.GoMockExecutor{}
 	l := NewNVMECLI(e, testLogger)
 	health := `{
-  		"critical_warning" : 0
+  		"critical_warning" : 0,
+  		"media_errors" : 3
 	}
 	`
 	e.On("RunCmd", fmt.Sprintf(NVMeHealthCmdImpl, testPath)).Return(health, "", nil)
-	deviceHealth := l.getNVMDeviceHealth(testPath)
+	deviceHealth, mediaErrors := l.getNVMDeviceHealth(testPath)
 	assert.Equal(t, apiV1.HealthGood, deviceHealth)
+	assert.Equal(t, int64(3), mediaErrors)
 }
 
 func TestNVMECLI_getNVMDeviceHealthUnmarshallError(t *testing.T) {
@@ -197,7 +199,7 @@ func TestNVMECLI_getNVMDeviceHealthUnmarshallError(t *testing.T) {
 	}
 	`
 	e.On("RunCmd", fmt.Sprintf(NVMeHealthCmdImpl, testPath)).Return(health, "", nil)
-	deviceHealth := l.getNVMDeviceHealth(testPath)
+	deviceHealth, _ := l.getNVMDeviceHealth(testPath)
 	assert.Equal(t, apiV1.HealthUnknown, deviceHealth)
 }
 
@@ -205,7 +207,7 @@ func TestNVMECLI_getNVMDeviceHealthCMDError(t *testing.T) {
 	e := &mocks.GoMockExecutor{}
 	l := NewNVMECLI(e, testLogger)
 	e.On("RunCmd", fmt.Sprintf(NVMeHealthCmdImpl, testPath)).Return("", "", fmt.Errorf("error"))
-	deviceHealth := l.getNVMDeviceHealth(testPath)
+	deviceHealth, _ := l.getNVMDeviceHealth(testPath)
 	assert.Equal(t, apiV1.HealthUnknown, deviceHealth)
 }
 
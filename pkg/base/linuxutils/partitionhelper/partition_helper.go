@@ -36,6 +36,7 @@ type WrapPartition interface {
 	GetPartitionTableType(device string) (ptType string, err error)
 	CreatePartitionTable(device, partTableType string) (err error)
 	CreatePartition(device, label string) (err error)
+	CreatePartitionWithSize(device, label, partUUID string, size int64) (err error)
 	DeletePartition(device, partNum string) (err error)
 	SetPartitionUUID(device, partNum, partUUID string) error
 	GetPartitionUUID(device, partNum string) (string, error)
@@ -52,17 +53,26 @@ const (
 	partprobe = "partprobe "
 	// sgdisk is a name of system util
 	sgdisk = "sgdisk "
+	// udevadm is a name of system util
+	udevadm = "udevadm "
 
 	// PartprobeDeviceCmdTmpl check that device has partition cmd
 	PartprobeDeviceCmdTmpl = partprobe + "-d -s %s"
 	// PartprobeCmdTmpl check device has partition with partprobe cmd
 	PartprobeCmdTmpl = partprobe + "%s"
+	// UdevSettleCmdTmpl waits until udev processes events of partition table change, fill timeout in seconds
+	UdevSettleCmdTmpl = udevadm + "settle --timeout=%d"
+	// UdevSettleTimeout is the timeout in seconds for udev events processing
+	UdevSettleTimeout = 10
 
 	// CreatePartitionTableCmdTmpl create partition table on provided device of provided type cmd template
 	// fill device and partition table type
 	CreatePartitionTableCmdTmpl = parted + "-s %s mklabel %s"
 	// CreatePartitionCmdTmpl create partition on provided device cmd template, fill device and partition label
 	CreatePartitionCmdTmpl = parted + "-s %s mkpart --align optimal %s 0%% 100%%"
+	// CreatePartitionWithSizeCmdTmpl create partition in the largest free block of provided device and set its label
+	// and GUID cmd template, fill device, size in KiB, label and part UUID
+	CreatePartitionWithSizeCmdTmpl = sgdisk + "%s --new=0:0:+%dK --change-name=0:%s --partition-guid=0:%s"
 	// DeletePartitionCmdTmpl delete partition from provided device cmd template, fill device and partition number
 	DeletePartitionCmdTmpl = parted + "-s %s rm %s"
 
@@ -176,6 +186,27 @@ func (p *WrapPartitionImpl) CreatePartition(device, label string) error {
 	return nil
 }
 
+// CreatePartitionWithSize creates partition of provided size in bytes with label and partUUID on a device,
+// partition is placed in the largest free block of the device, so several partitions could be created on it
+// Receives device path, partition label, partition GUID and size
+// Returns error if something went wrong
+func (p *WrapPartitionImpl) CreatePartitionWithSize(device, label, partUUID string, size int64) error {
+	// sgdisk sizes are aligned to KiB, round size up to not create partition less than requested
+	sizeKiB := (size + 1023) / 1024
+	cmd := fmt.Sprintf(CreatePartitionWithSizeCmdTmpl, device, sizeKiB, label, partUUID)
+
+	p.opMutex.Lock()
+	_, stderr, err := p.e.RunCmd(cmd)
+	p.opMutex.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("unable to create partition of size %d on device %s: %s, error: %v",
+			size, device, stderr, err)
+	}
+
+	return nil
+}
+
 // DeletePartition removes partition partNum from a provided device
 // Receives device path and it's partition which should be deleted
 // Returns error if something went wrong
@@ -243,21 +274,21 @@ func (p *WrapPartitionImpl) GetPartitionUUID(device, partNum string) (string, er
 	return "", fmt.Errorf("unable to get partition GUID for device %s", device)
 }
 
-// SyncPartitionTable syncs partition table for specific device
+// SyncPartitionTable syncs partition table for specific device and waits until udev creates partition devices
 // Receives device path to sync with partprobe, device could be an empty string (sync for all devices in the system)
 // Returns error if something went wrong
 func (p *WrapPartitionImpl) SyncPartitionTable(device string) error {
 	cmd := fmt.Sprintf(PartprobeCmdTmpl, device)
 
 	p.opMutex.Lock()
-	_, _, err := p.e.RunCmd(cmd)
-	p.opMutex.Unlock()
+	defer p.opMutex.Unlock()
 
-	if err != nil {
+	if _, _, err := p.e.RunCmd(cmd); err != nil {
 		return err
 	}
 
-	return nil
+	_, _, err := p.e.RunCmd(fmt.Sprintf(UdevSettleCmdTmpl, UdevSettleTimeout))
+	return err
 }
 
 // GetPartitionNameByUUID gets partition name by it's UUID
@@ -87,6 +87,15 @@ func TestCreatePartitionFail(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestCreatePartitionWithSize(t *testing.T) {
+	// size is rounded up to KiB
+	err := testPartitioner.CreatePartitionWithSize("/dev/sde", testCSILabel, testPartUUID, 1023*1024+1)
+	assert.Nil(t, err)
+
+	err = testPartitioner.CreatePartitionWithSize("/dev/sdf", testCSILabel, testPartUUID, 1024*1024)
+	assert.NotNil(t, err)
+}
+
 func TestDeletePartition(t *testing.T) {
 	err := testPartitioner.DeletePartition("/dev/sda", testPartNum)
 	assert.Nil(t, err)
@@ -0,0 +1,164 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sgses contains code for discovery of SCSI enclosures and for manipulation of their slots LEDs
+// with system sg_ses util
+package sgses
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dell/csi-baremetal/pkg/base/command"
+)
+
+const (
+	// SgSesCmdImpl is a base CMD for sg_ses util
+	SgSesCmdImpl = "sg_ses"
+	// SetIdentCmdTmpl is a CMD which turns on locate LED of enclosure slot, add slot number and enclosure sg device
+	SetIdentCmdTmpl = SgSesCmdImpl + " --dev-slot-num=%s --set=ident %s"
+	// ClearIdentCmdTmpl is a CMD which turns off locate LED of enclosure slot, add slot number and enclosure sg device
+	ClearIdentCmdTmpl = SgSesCmdImpl + " --dev-slot-num=%s --clear=ident %s"
+	// GetIdentCmdTmpl is a CMD which prints 1 if locate LED of enclosure slot is on and 0 otherwise
+	GetIdentCmdTmpl = SgSesCmdImpl + " --dev-slot-num=%s --get=ident %s"
+
+	// EnclosureSysfsPath is a directory where kernel exposes SES enclosures and their slots
+	EnclosureSysfsPath = "/sys/class/enclosure"
+)
+
+// WrapSgSes is an interface that encapsulates operation with enclosures
+type WrapSgSes interface {
+	GetSlots() ([]*Slot, error)
+	SetLocate(slot *Slot, on bool) error
+	GetLocate(slot *Slot) (bool, error)
+}
+
+// Slot represents slot of SCSI enclosure which is occupied by drive
+type Slot struct {
+	// Enclosure is the logical identifier of the enclosure (chassis), for example 0x500056b3a4f1e4ff
+	Enclosure string
+	// EnclosureDevice is the SCSI generic device of the enclosure, for example /dev/sg5
+	EnclosureDevice string
+	// Number is the slot number which is used for LED manipulation
+	Number string
+	// Name is the slot name from enclosure descriptor, for example Slot 03
+	Name string
+	// DevicePath is the block device in the slot, for example /dev/sdc
+	DevicePath string
+}
+
+// SGSES is a wrap for enclosures which are exposed by kernel and for system sg_ses util
+type SGSES struct {
+	e command.CmdExecutor
+	// root directory of enclosures in sysfs, it differs in UTs
+	sysfsPath string
+	log       *logrus.Entry
+}
+
+var slotNumberRegexp = regexp.MustCompile(`\d+`)
+
+// NewSGSES is a constructor for SGSES
+func NewSGSES(e command.CmdExecutor, logger *logrus.Logger) *SGSES {
+	return &SGSES{
+		e:         e,
+		sysfsPath: EnclosureSysfsPath,
+		log:       logger.WithField("component", "SGSES"),
+	}
+}
+
+// GetSlots returns slots of all enclosures on the node which are occupied by block devices.
+// Enclosures which don't have SCSI generic device are skipped because their LEDs can't be managed
+// Returns slice of Slot or error if enclosures can't be listed
+func (s *SGSES) GetSlots() ([]*Slot, error) {
+	ll := s.log.WithField("method", "GetSlots")
+
+	enclosures, err := ioutil.ReadDir(s.sysfsPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list enclosures in %s: %v", s.sysfsPath, err)
+	}
+
+	slots := make([]*Slot, 0)
+	for _, enc := range enclosures {
+		encPath := filepath.Join(s.sysfsPath, enc.Name())
+		sgDevices, err := ioutil.ReadDir(filepath.Join(encPath, "device", "scsi_generic"))
+		if err != nil || len(sgDevices) == 0 {
+			ll.Warnf("Unable to find SCSI generic device of enclosure %s: %v", enc.Name(), err)
+			continue
+		}
+		id := readAttr(encPath, "id")
+		if id == "" {
+			id = enc.Name()
+		}
+
+		elements, err := ioutil.ReadDir(encPath)
+		if err != nil {
+			ll.Errorf("Unable to list slots of enclosure %s: %v", enc.Name(), err)
+			continue
+		}
+		for _, el := range elements {
+			blocks, err := ioutil.ReadDir(filepath.Join(encPath, el.Name(), "device", "block"))
+			if err != nil || len(blocks) == 0 {
+				// element isn't a slot or slot is empty
+				continue
+			}
+			number := readAttr(filepath.Join(encPath, el.Name()), "slot")
+			if number == "" {
+				number = slotNumberRegexp.FindString(el.Name())
+			}
+			slots = append(slots, &Slot{
+				Enclosure:       id,
+				EnclosureDevice: "/dev/" + sgDevices[0].Name(),
+				Number:          number,
+				Name:            el.Name(),
+				DevicePath:      "/dev/" + blocks[0].Name(),
+			})
+		}
+	}
+	return slots, nil
+}
+
+// SetLocate turns on or turns off locate LED of the slot
+func (s *SGSES) SetLocate(slot *Slot, on bool) error {
+	cmd := ClearIdentCmdTmpl
+	if on {
+		cmd = SetIdentCmdTmpl
+	}
+	_, _, err := s.e.RunCmd(fmt.Sprintf(cmd, slot.Number, slot.EnclosureDevice))
+	return err
+}
+
+// GetLocate returns true if locate LED of the slot is on
+func (s *SGSES) GetLocate(slot *Slot) (bool, error) {
+	strOut, _, err := s.e.RunCmd(fmt.Sprintf(GetIdentCmdTmpl, slot.Number, slot.EnclosureDevice))
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(strOut) == "1", nil
+}
+
+// readAttr returns trimmed content of sysfs attribute or empty string if it can't be read
+func readAttr(dir, name string) string {
+	data, err := ioutil.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
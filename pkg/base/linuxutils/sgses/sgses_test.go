@@ -0,0 +1,97 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sgses
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dell/csi-baremetal/pkg/mocks"
+)
+
+var testLogger = logrus.New()
+
+// prepareSysfs creates enclosure with two occupied slots with and without slot attribute and one empty slot
+func prepareSysfs(t *testing.T) string {
+	root, err := ioutil.TempDir("", "enclosure")
+	assert.Nil(t, err)
+
+	enc := filepath.Join(root, "0:0:8:0")
+	for _, dir := range []string{
+		filepath.Join(enc, "device", "scsi_generic", "sg5"),
+		filepath.Join(enc, "Slot01", "device", "block", "sdb"),
+		filepath.Join(enc, "Slot 03", "device", "block", "sdc"),
+		filepath.Join(enc, "Slot04"),
+	} {
+		assert.Nil(t, os.MkdirAll(dir, 0755))
+	}
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(enc, "id"), []byte("0x500056b3a4f1e4ff\n"), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(enc, "Slot01", "slot"), []byte("0\n"), 0644))
+
+	// enclosure without SCSI generic device is skipped
+	assert.Nil(t, os.MkdirAll(filepath.Join(root, "1:0:8:0", "Slot01", "device", "block", "sdd"), 0755))
+	return root
+}
+
+func TestSGSES_GetSlots(t *testing.T) {
+	root := prepareSysfs(t)
+	defer os.RemoveAll(root)
+
+	s := NewSGSES(&mocks.GoMockExecutor{}, testLogger)
+	s.sysfsPath = root
+
+	slots, err := s.GetSlots()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(slots))
+	assert.Equal(t, &Slot{
+		Enclosure:       "0x500056b3a4f1e4ff",
+		EnclosureDevice: "/dev/sg5",
+		Number:          "3",
+		Name:            "Slot 03",
+		DevicePath:      "/dev/sdc",
+	}, slots[0])
+	assert.Equal(t, "0", slots[1].Number)
+	assert.Equal(t, "/dev/sdb", slots[1].DevicePath)
+
+	s.sysfsPath = filepath.Join(root, "not-exist")
+	_, err = s.GetSlots()
+	assert.NotNil(t, err)
+}
+
+func TestSGSES_Locate(t *testing.T) {
+	var (
+		e    = &mocks.GoMockExecutor{}
+		s    = NewSGSES(e, testLogger)
+		slot = &Slot{EnclosureDevice: "/dev/sg5", Number: "3"}
+	)
+
+	e.On("RunCmd", fmt.Sprintf(SetIdentCmdTmpl, "3", "/dev/sg5")).Return("", "", nil)
+	e.On("RunCmd", fmt.Sprintf(ClearIdentCmdTmpl, "3", "/dev/sg5")).Return("", "", fmt.Errorf("error"))
+	e.On("RunCmd", fmt.Sprintf(GetIdentCmdTmpl, "3", "/dev/sg5")).Return("1\n", "", nil)
+
+	assert.Nil(t, s.SetLocate(slot, true))
+	assert.NotNil(t, s.SetLocate(slot, false))
+	on, err := s.GetLocate(slot)
+	assert.Nil(t, err)
+	assert.True(t, on)
+}
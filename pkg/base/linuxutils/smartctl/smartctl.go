@@ -19,6 +19,7 @@ package smartctl
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/dell/csi-baremetal/pkg/base/command"
 )
@@ -26,8 +27,8 @@ import (
 const (
 	// SmartctlCmdImpl is a base CMD for smartctl util
 	SmartctlCmdImpl = "smartctl"
-	// SmartctlDeviceInfoCmdImpl is a CMD to get basic SMART information and health about device in JSON format
-	SmartctlDeviceInfoCmdImpl = SmartctlCmdImpl + " --info --json %s"
+	// SmartctlDeviceInfoCmdImpl is a CMD to get basic SMART information and attributes of device in JSON format
+	SmartctlDeviceInfoCmdImpl = SmartctlCmdImpl + " --info --attributes --json %s"
 	// SmartctlHealthCmdImpl is a CMD to get  SMART status of device in JSON format
 	SmartctlHealthCmdImpl = SmartctlCmdImpl + " --health --json %s"
 )
@@ -39,9 +40,86 @@ type WrapSmartctl interface {
 
 // DeviceSMARTInfo represents SMART information about device
 type DeviceSMARTInfo struct {
-	SerialNumber string          `json:"serial_number"`
-	SmartStatus  map[string]bool `json:"smart_status"`
-	Rotation     int             `json:"rotation_rate"`
+	SerialNumber    string          `json:"serial_number"`
+	SmartStatus     map[string]bool `json:"smart_status"`
+	Rotation        int             `json:"rotation_rate"`
+	FirmwareVersion string          `json:"firmware_version"`
+	Device          struct {
+		Protocol string `json:"protocol"`
+	} `json:"device"`
+	// WWN is reported for ATA devices
+	WWN struct {
+		NAA uint64 `json:"naa"`
+		OUI uint64 `json:"oui"`
+		ID  uint64 `json:"id"`
+	} `json:"wwn"`
+	// LogicalUnitID is reported for SCSI devices
+	LogicalUnitID  string `json:"logical_unit_id"`
+	InterfaceSpeed struct {
+		Current struct {
+			String string `json:"string"`
+		} `json:"current"`
+	} `json:"interface_speed"`
+	ScsiTransportProtocol struct {
+		Name string `json:"name"`
+	} `json:"scsi_transport_protocol"`
+	// AtaSmartAttributes is reported for ATA devices
+	AtaSmartAttributes struct {
+		Table []struct {
+			ID  int `json:"id"`
+			Raw struct {
+				Value int64 `json:"value"`
+			} `json:"raw"`
+		} `json:"table"`
+	} `json:"ata_smart_attributes"`
+	// ScsiGrownDefectList is reported for SCSI devices
+	ScsiGrownDefectList int64 `json:"scsi_grown_defect_list"`
+}
+
+// ATA SMART attributes which count bad sectors of the device
+const (
+	reallocatedSectorCountID = 5
+	currentPendingSectorID   = 197
+	offlineUncorrectableID   = 198
+)
+
+// GetWWN returns World Wide Name of the device in hex format or empty string if it is unknown
+func (d *DeviceSMARTInfo) GetWWN() string {
+	if d.WWN.NAA != 0 || d.WWN.OUI != 0 || d.WWN.ID != 0 {
+		return fmt.Sprintf("0x%x%06x%09x", d.WWN.NAA, d.WWN.OUI, d.WWN.ID)
+	}
+	return d.LogicalUnitID
+}
+
+// GetLinkSpeed returns negotiated link speed of the device, for example 6.0 Gb/s
+func (d *DeviceSMARTInfo) GetLinkSpeed() string {
+	return d.InterfaceSpeed.Current.String
+}
+
+// GetInterface returns interface of the device: SATA, SAS, SCSI or NVMe
+func (d *DeviceSMARTInfo) GetInterface() string {
+	switch d.Device.Protocol {
+	case "ATA":
+		return "SATA"
+	case "SCSI":
+		if strings.HasPrefix(d.ScsiTransportProtocol.Name, "SAS") {
+			return "SAS"
+		}
+	}
+	return d.Device.Protocol
+}
+
+// GetMediaErrors returns amount of bad sectors of the device: sum of reallocated, pending and uncorrectable
+// sectors for ATA devices and size of grown defect list for SCSI devices
+func (d *DeviceSMARTInfo) GetMediaErrors() int64 {
+	var res int64
+	for _, attr := range d.AtaSmartAttributes.Table {
+		switch attr.ID {
+		case reallocatedSectorCountID, currentPendingSectorID, offlineUncorrectableID:
+			res += attr.Raw.Value
+		}
+	}
+	return res + d.ScsiGrownDefectList
 }
 
 // SMARTCTL is a wrap for system smartctl util
@@ -53,6 +53,52 @@ func TestSMARCTL_GetDriveInfoByPath(t *testing.T) {
 	assert.Equal(t, smartInfo.SmartStatus, map[string]bool{"passed": true})
 }
 
+func TestSMARCTL_GetDriveInfoByPathInventory(t *testing.T) {
+	output := `{
+				"serial_number": "29P4K65PF9NF",
+				"firmware_version": "SN04",
+				"device": {"name": "/dev/sdd", "type": "sat", "protocol": "ATA"},
+				"wwn": {"naa": 5, "oui": 3152, "id": 11053508096},
+				"interface_speed": {"current": {"string": "6.0 Gb/s"}},
+				"ata_smart_attributes": {"table": [
+					{"id": 5, "name": "Reallocated_Sector_Ct", "raw": {"value": 8, "string": "8"}},
+					{"id": 9, "name": "Power_On_Hours", "raw": {"value": 12000, "string": "12000"}},
+					{"id": 197, "name": "Current_Pending_Sector", "raw": {"value": 2, "string": "2"}},
+					{"id": 198, "name": "Offline_Uncorrectable", "raw": {"value": 1, "string": "1"}}
+				]}
+			}`
+	outputSAS := `{
+				"serial_number": "ZA1B2C3D",
+				"device": {"name": "/dev/sde", "type": "scsi", "protocol": "SCSI"},
+				"logical_unit_id": "0x5000c500a1b2c3d4",
+				"scsi_transport_protocol": {"name": "SAS (SPL-3)"},
+				"scsi_grown_defect_list": 4
+			}`
+	outputHealth := `{"smart_status": {"passed": true}}`
+	e := &mocks.GoMockExecutor{}
+	l := NewSMARTCTL(e)
+
+	e.On("RunCmd", fmt.Sprintf(SmartctlDeviceInfoCmdImpl, "/dev/sdd")).Return(output, "", nil)
+	e.On("RunCmd", fmt.Sprintf(SmartctlHealthCmdImpl, "/dev/sdd")).Return(outputHealth, "", nil)
+	e.On("RunCmd", fmt.Sprintf(SmartctlDeviceInfoCmdImpl, "/dev/sde")).Return(outputSAS, "", nil)
+	e.On("RunCmd", fmt.Sprintf(SmartctlHealthCmdImpl, "/dev/sde")).Return(outputHealth, "", nil)
+
+	smartInfo, err := l.GetDriveInfoByPath("/dev/sdd")
+	assert.Nil(t, err)
+	assert.Equal(t, "SN04", smartInfo.FirmwareVersion)
+	assert.Equal(t, "0x5000c50292d72600", smartInfo.GetWWN())
+	assert.Equal(t, "6.0 Gb/s", smartInfo.GetLinkSpeed())
+	assert.Equal(t, "SATA", smartInfo.GetInterface())
+	assert.Equal(t, int64(11), smartInfo.GetMediaErrors())
+
+	smartInfo, err = l.GetDriveInfoByPath("/dev/sde")
+	assert.Nil(t, err)
+	assert.Equal(t, "0x5000c500a1b2c3d4", smartInfo.GetWWN())
+	assert.Equal(t, "", smartInfo.GetLinkSpeed())
+	assert.Equal(t, "SAS", smartInfo.GetInterface())
+	assert.Equal(t, int64(4), smartInfo.GetMediaErrors())
+}
+
 func TestSMARCTL_GetDriveInfoByPathFails(t *testing.T) {
 	cmd := fmt.Sprintf(SmartctlDeviceInfoCmdImpl, "/dev/sdd")
 	e := &mocks.GoMockExecutor{}
@@ -0,0 +1,148 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package uevent contains listener of kernel uevents which is used for detection of drives hotplug and removal
+package uevent
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ActionAdd is the uevent action when device is added
+	ActionAdd = "add"
+	// ActionRemove is the uevent action when device is removed
+	ActionRemove = "remove"
+	// ActionChange is the uevent action when device is changed, e.g. media is inserted
+	ActionChange = "change"
+
+	// SubsystemBlock is the subsystem of block devices
+	SubsystemBlock = "block"
+	// DevTypeDisk is the type of whole block device, partitions have "partition" type
+	DevTypeDisk = "disk"
+
+	// kernelGroup is the netlink multicast group of uevents which are sent by kernel (udev daemon uses group 2)
+	kernelGroup = 1
+	// bufferSize is the size of buffer for single uevent message
+	bufferSize = 64 * 1024
+)
+
+// Event is the kernel uevent
+type Event struct {
+	Action    string
+	DevPath   string
+	DevName   string
+	DevType   string
+	Subsystem string
+}
+
+// IsDisk returns true if event is related to whole block device
+func (e Event) IsDisk() bool {
+	return e.Subsystem == SubsystemBlock && e.DevType == DevTypeDisk
+}
+
+// Listener receives kernel uevents through netlink socket
+type Listener struct {
+	log *logrus.Entry
+}
+
+// NewListener is the constructor for Listener struct
+// Receives logrus logger
+// Returns an instance of Listener
+func NewListener(logger *logrus.Logger) *Listener {
+	return &Listener{
+		log: logger.WithField("component", "UeventListener"),
+	}
+}
+
+// ListenDisks opens netlink socket and sends add, remove and change uevents of whole block devices into channel
+// It blocks until socket error occurs
+// Returns error if netlink socket can't be opened or read
+func (l *Listener) ListenDisks(events chan<- Event) error {
+	ll := l.log.WithField("method", "ListenDisks")
+
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW, syscall.NETLINK_KOBJECT_UEVENT)
+	if err != nil {
+		return fmt.Errorf("unable to open netlink socket: %v", err)
+	}
+	defer syscall.Close(fd)
+
+	addr := &syscall.SockaddrNetlink{
+		Family: syscall.AF_NETLINK,
+		Pid:    uint32(os.Getpid()),
+		Groups: kernelGroup,
+	}
+	if err = syscall.Bind(fd, addr); err != nil {
+		return fmt.Errorf("unable to bind netlink socket: %v", err)
+	}
+
+	ll.Info("Listening for uevents of block devices")
+	buf := make([]byte, bufferSize)
+	for {
+		n, _, err := syscall.Recvfrom(fd, buf, 0)
+		if err != nil {
+			if err == syscall.EINTR {
+				continue
+			}
+			return fmt.Errorf("unable to read netlink socket: %v", err)
+		}
+		event, ok := ParseEvent(buf[:n])
+		if !ok || !event.IsDisk() {
+			continue
+		}
+		switch event.Action {
+		case ActionAdd, ActionRemove, ActionChange:
+			ll.Debugf("Got uevent %+v", event)
+			events <- event
+		}
+	}
+}
+
+// ParseEvent parses kernel uevent message, e.g. "add@/devices/...\x00ACTION=add\x00DEVNAME=sdb\x00..."
+// Returns event and true or false if message isn't kernel uevent
+func ParseEvent(msg []byte) (Event, bool) {
+	fields := bytes.Split(msg, []byte{0})
+	if len(fields) < 2 || !bytes.Contains(fields[0], []byte("@")) {
+		return Event{}, false
+	}
+
+	var event Event
+	for _, field := range fields[1:] {
+		kv := bytes.SplitN(field, []byte("="), 2)
+		if len(kv) != 2 {
+			continue
+		}
+		value := string(kv[1])
+		switch string(kv[0]) {
+		case "ACTION":
+			event.Action = value
+		case "DEVPATH":
+			event.DevPath = value
+		case "DEVNAME":
+			event.DevName = value
+		case "DEVTYPE":
+			event.DevType = value
+		case "SUBSYSTEM":
+			event.Subsystem = value
+		}
+	}
+	return event, event.Action != ""
+}
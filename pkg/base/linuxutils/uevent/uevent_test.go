@@ -0,0 +1,55 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uevent
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEvent(t *testing.T) {
+	msg := strings.Join([]string{
+		"add@/devices/pci0000:00/0000:00:1f.2/ata2/host1/target1:0:0/1:0:0:0/block/sdb",
+		"ACTION=add",
+		"DEVPATH=/devices/pci0000:00/0000:00:1f.2/ata2/host1/target1:0:0/1:0:0:0/block/sdb",
+		"SUBSYSTEM=block",
+		"MAJOR=8",
+		"MINOR=16",
+		"DEVNAME=sdb",
+		"DEVTYPE=disk",
+		"SEQNUM=2345",
+		"",
+	}, "\x00")
+
+	event, ok := ParseEvent([]byte(msg))
+	assert.True(t, ok)
+	assert.Equal(t, ActionAdd, event.Action)
+	assert.Equal(t, "sdb", event.DevName)
+	assert.True(t, event.IsDisk())
+
+	// partition
+	msg = strings.Replace(msg, "DEVTYPE=disk", "DEVTYPE=partition", 1)
+	event, ok = ParseEvent([]byte(msg))
+	assert.True(t, ok)
+	assert.False(t, event.IsDisk())
+
+	// message of udev daemon
+	_, ok = ParseEvent([]byte("libudev\x00\xfe\xed\xca\xfe"))
+	assert.False(t, ok)
+}
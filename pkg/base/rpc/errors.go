@@ -0,0 +1,87 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Domain errors which could be wrapped with fmt.Errorf("...: %w", err) and are translated into CSI status codes
+var (
+	// ErrNotFound means that requested object (volume, drive, capacity) doesn't exist
+	ErrNotFound = errors.New("not found")
+	// ErrAlreadyExists means that object exists and is incompatible with request
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrCapacityExhausted means that there is no free capacity to satisfy request
+	ErrCapacityExhausted = errors.New("capacity exhausted")
+	// ErrFailedPrecondition means that object is in state which doesn't allow to perform request
+	ErrFailedPrecondition = errors.New("failed precondition")
+	// ErrConflict means that object has been changed concurrently and request could be retried
+	ErrConflict = errors.New("conflict")
+)
+
+// StatusCode returns CSI status code which corresponds to error
+// Receives error returned by service, gRPC status error, domain error, k8s API error or context error
+// Returns codes.OK for nil error and codes.Internal for unknown errors
+func StatusCode(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	if s, ok := status.FromError(err); ok {
+		return s.Code()
+	}
+
+	switch {
+	case errors.Is(err, ErrNotFound), k8sError.IsNotFound(err):
+		return codes.NotFound
+	case errors.Is(err, ErrAlreadyExists), k8sError.IsAlreadyExists(err):
+		return codes.AlreadyExists
+	case errors.Is(err, ErrCapacityExhausted):
+		return codes.ResourceExhausted
+	case errors.Is(err, ErrFailedPrecondition):
+		return codes.FailedPrecondition
+	case errors.Is(err, ErrConflict), k8sError.IsConflict(err):
+		return codes.Aborted
+	case errors.Is(err, context.DeadlineExceeded), k8sError.IsTimeout(err), k8sError.IsServerTimeout(err):
+		return codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case k8sError.IsTooManyRequests(err), k8sError.IsServiceUnavailable(err):
+		return codes.Unavailable
+	}
+	return codes.Internal
+}
+
+// ToStatus converts error into gRPC status error with code of error and provided message,
+// gRPC status errors are returned as is to keep their codes and messages
+// Receives error and format with arguments of message which is returned to caller
+// Returns nil for nil error or gRPC status error
+func ToStatus(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(StatusCode(err), fmt.Sprintf(format, args...))
+}
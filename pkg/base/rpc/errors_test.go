@@ -0,0 +1,57 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestStatusCode(t *testing.T) {
+	resource := schema.GroupResource{Resource: "volumes"}
+
+	assert.Equal(t, codes.OK, StatusCode(nil))
+	assert.Equal(t, codes.Unavailable, StatusCode(status.Error(codes.Unavailable, "error")))
+	assert.Equal(t, codes.NotFound, StatusCode(fmt.Errorf("volume: %w", ErrNotFound)))
+	assert.Equal(t, codes.NotFound, StatusCode(k8sError.NewNotFound(resource, "volume")))
+	assert.Equal(t, codes.AlreadyExists, StatusCode(fmt.Errorf("volume: %w", ErrAlreadyExists)))
+	assert.Equal(t, codes.AlreadyExists, StatusCode(k8sError.NewAlreadyExists(resource, "volume")))
+	assert.Equal(t, codes.ResourceExhausted, StatusCode(fmt.Errorf("drive: %w", ErrCapacityExhausted)))
+	assert.Equal(t, codes.FailedPrecondition, StatusCode(fmt.Errorf("volume: %w", ErrFailedPrecondition)))
+	assert.Equal(t, codes.Aborted, StatusCode(k8sError.NewConflict(resource, "volume", errors.New("error"))))
+	assert.Equal(t, codes.DeadlineExceeded, StatusCode(fmt.Errorf("ctx: %w", context.DeadlineExceeded)))
+	assert.Equal(t, codes.Canceled, StatusCode(context.Canceled))
+	assert.Equal(t, codes.Internal, StatusCode(errors.New("error")))
+}
+
+func TestToStatus(t *testing.T) {
+	assert.Nil(t, ToStatus(nil, "message"))
+
+	statusErr := status.Error(codes.InvalidArgument, "invalid")
+	assert.Equal(t, statusErr, ToStatus(statusErr, "message"))
+
+	err := ToStatus(fmt.Errorf("volume %w", ErrNotFound), "unable to find volume %s", "vol1")
+	assert.Equal(t, status.Error(codes.NotFound, "unable to find volume vol1"), err)
+}
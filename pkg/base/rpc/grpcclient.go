@@ -17,8 +17,11 @@ limitations under the License.
 package rpc
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"net/url"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
@@ -64,11 +67,20 @@ func (c *Client) initClient() error {
 	}
 
 	c.log.Infof("Initialize client for endpoint \"%s\"", endpoint)
+	var opts []grpc.DialOption
 	if c.Creds != nil {
-		c.GRPCClient, err = grpc.Dial(endpoint, grpc.WithTransportCredentials(c.Creds))
+		opts = append(opts, grpc.WithTransportCredentials(c.Creds))
 	} else {
-		c.GRPCClient, err = grpc.Dial(endpoint, grpc.WithInsecure())
+		opts = append(opts, grpc.WithInsecure())
 	}
+	if strings.HasPrefix(c.Endpoint, unix+"://") {
+		// gRPC dials TCP by default, unix socket path is passed as is to dialer
+		opts = append(opts, grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, unix, addr)
+		}))
+	}
+	c.GRPCClient, err = grpc.Dial(endpoint, opts...)
 	if err != nil {
 		return err
 	}
@@ -20,6 +20,7 @@ import (
 	"net"
 	"net/url"
 	"os"
+	"path/filepath"
 
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
@@ -29,6 +30,11 @@ import (
 const (
 	tcp  string = "tcp"
 	unix string = "unix"
+
+	// unixSocketPerm is the permission of unix socket, only owner and group of server process are able to connect
+	unixSocketPerm os.FileMode = 0660
+	// unixSocketDirPerm is the permission of directory which is created for unix socket
+	unixSocketDirPerm os.FileMode = 0750
 )
 
 // ServerRunner encapsulates logic for creating/starting/stopping gRPC server
@@ -62,12 +68,19 @@ func (sr *ServerRunner) SetLogger(logger *logrus.Logger) {
 }
 
 // init initializes GRPCServer field of ServerRunner struct
+// Server recovers panics in handlers, logs requests and limits rate of requests per client
 func (sr *ServerRunner) init() {
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			RecoveryInterceptor(sr.log),
+			LoggingInterceptor(sr.log),
+			NewRateLimiter(DefaultRateLimit, DefaultRateBurst, sr.log).Interceptor()),
+		grpc.ChainStreamInterceptor(StreamRecoveryInterceptor(sr.log)),
+	}
 	if sr.Creds != nil {
-		sr.GRPCServer = grpc.NewServer(grpc.Creds(sr.Creds))
-	} else {
-		sr.GRPCServer = grpc.NewServer()
+		opts = append(opts, grpc.Creds(sr.Creds))
 	}
+	sr.GRPCServer = grpc.NewServer(opts...)
 }
 
 // RunServer creates Listener and starts gRPC server on endpoint
@@ -78,12 +91,23 @@ func (sr *ServerRunner) RunServer() error {
 	if socket == unix {
 		// try to remove
 		_ = os.Remove(endpoint)
+		if err = os.MkdirAll(filepath.Dir(endpoint), unixSocketDirPerm); err != nil {
+			sr.log.Errorf("failed to create directory for unix socket %s: %v", endpoint, err)
+			return err
+		}
 	}
 	sr.listener, err = net.Listen(socket, endpoint)
 	if err != nil {
 		sr.log.Errorf("failed to create listener for endpoint %s: %v", endpoint, err)
 		return err
 	}
+	if socket == unix {
+		if err = os.Chmod(endpoint, unixSocketPerm); err != nil {
+			sr.log.Errorf("failed to set permissions for unix socket %s: %v", endpoint, err)
+			_ = sr.listener.Close()
+			return err
+		}
+	}
 	sr.log.Infof("Starting gRPC server for endpoint %s and socket %s", endpoint, socket)
 	return sr.GRPCServer.Serve(sr.listener)
 }
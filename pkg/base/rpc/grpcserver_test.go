@@ -17,13 +17,20 @@ limitations under the License.
 package rpc
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 
 	basenet "github.com/dell/csi-baremetal/pkg/base/net"
 )
@@ -93,3 +100,31 @@ func TestServerRunner_StopServer(t *testing.T) {
 	// stop server
 	nonSecureSR.StopServer()
 }
+
+func TestServerRunner_RunServer_Unix(t *testing.T) {
+	dir, err := ioutil.TempDir("", "grpc")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "drivemgr", "drivemgr.sock")
+	unixSrv := NewServerRunner(nil, "unix://"+socket, serverLogger)
+	grpc_health_v1.RegisterHealthServer(unixSrv.GRPCServer, health.NewServer())
+	go func() {
+		_ = unixSrv.RunServer()
+	}()
+	defer unixSrv.StopServer()
+
+	client, err := NewClient(nil, "unix://"+socket, clientLogger)
+	assert.Nil(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = grpc_health_v1.NewHealthClient(client.GRPCClient).Check(ctx, &grpc_health_v1.HealthCheckRequest{},
+		grpc.WaitForReady(true))
+	assert.Nil(t, err)
+
+	info, err := os.Stat(socket)
+	assert.Nil(t, err)
+	assert.Equal(t, unixSocketPerm, info.Mode().Perm())
+}
@@ -0,0 +1,149 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rpc
+
+import (
+	"context"
+	"net"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultRateLimit is the number of requests per second which are allowed for single client
+	DefaultRateLimit = 50
+	// DefaultRateBurst is the number of requests which single client is able to send at once
+	DefaultRateBurst = 100
+)
+
+// RecoveryInterceptor returns unary interceptor which converts panic in handler into Internal error
+func RecoveryInterceptor(log *logrus.Entry) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.WithField("method", info.FullMethod).Errorf("Panic during request handling: %v\n%s",
+					r, debug.Stack())
+				err = status.Errorf(codes.Internal, "panic during %s handling: %v", info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecoveryInterceptor returns stream interceptor which converts panic in handler into Internal error
+func StreamRecoveryInterceptor(log *logrus.Entry) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.WithField("method", info.FullMethod).Errorf("Panic during stream handling: %v\n%s",
+					r, debug.Stack())
+				err = status.Errorf(codes.Internal, "panic during %s handling: %v", info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// LoggingInterceptor returns unary interceptor which logs requests and responses with latency
+func LoggingInterceptor(log *logrus.Entry) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		ll := log.WithField("method", info.FullMethod)
+		ll.Tracef("Request: %v", req)
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		latency := time.Since(start)
+
+		if err != nil {
+			ll.Debugf("Failed in %s with code %s: %v", latency, status.Code(err), err)
+		} else {
+			ll.Debugf("Succeeded in %s", latency)
+			ll.Tracef("Response: %v", resp)
+		}
+		return resp, err
+	}
+}
+
+// RateLimiter limits rate of requests per client, client is identified by host of peer address
+type RateLimiter struct {
+	limit    rate.Limit
+	burst    int
+	limiters map[string]*rate.Limiter
+	mu       sync.Mutex
+	log      *logrus.Entry
+}
+
+// NewRateLimiter is the constructor for RateLimiter struct
+// Receives number of requests per second and burst which are allowed for single client and logrus entry
+// Returns an instance of RateLimiter
+func NewRateLimiter(limit float64, burst int, log *logrus.Entry) *RateLimiter {
+	return &RateLimiter{
+		limit:    rate.Limit(limit),
+		burst:    burst,
+		limiters: make(map[string]*rate.Limiter),
+		log:      log,
+	}
+}
+
+// Interceptor returns unary interceptor which rejects requests of client which exceeded rate limit
+// with ResourceExhausted error
+func (l *RateLimiter) Interceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		client := clientFromContext(ctx)
+		if !l.allow(client) {
+			l.log.WithField("method", info.FullMethod).Warnf("Rate limit is exceeded by client %s", client)
+			return nil, status.Errorf(codes.ResourceExhausted, "rate limit is exceeded for %s", info.FullMethod)
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (l *RateLimiter) allow(client string) bool {
+	l.mu.Lock()
+	limiter, ok := l.limiters[client]
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.limiters[client] = limiter
+	}
+	l.mu.Unlock()
+	return limiter.Allow()
+}
+
+// clientFromContext returns host of peer address, all clients of unix socket are considered as the same client
+func clientFromContext(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	addr := p.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
@@ -0,0 +1,101 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	testInfo = &grpc.UnaryServerInfo{FullMethod: "/csi.v1.Node/NodePublishVolume"}
+	testLog  = serverLogger.WithField("component", "test")
+	testCtx  = context.Background()
+)
+
+func TestRecoveryInterceptor(t *testing.T) {
+	interceptor := RecoveryInterceptor(testLog)
+
+	_, err := interceptor(testCtx, nil, testInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("malformed request")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Contains(t, err.Error(), "malformed request")
+
+	resp, err := interceptor(testCtx, nil, testInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "ok", resp)
+
+	streamInterceptor := StreamRecoveryInterceptor(testLog)
+	err = streamInterceptor(nil, nil, &grpc.StreamServerInfo{FullMethod: "/grpc.health.v1.Health/Watch"},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			panic("malformed request")
+		})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestLoggingInterceptor(t *testing.T) {
+	interceptor := LoggingInterceptor(testLog)
+	testErr := status.Error(codes.NotFound, "not found")
+
+	_, err := interceptor(testCtx, nil, testInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, testErr
+	})
+	assert.Equal(t, testErr, err)
+}
+
+func TestRateLimiter_Interceptor(t *testing.T) {
+	var (
+		interceptor = NewRateLimiter(1, 2, testLog).Interceptor()
+		handler     = func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+		abusive     = peer.NewContext(context.Background(),
+			&peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 40000}})
+		other = peer.NewContext(context.Background(),
+			&peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 40000}})
+	)
+
+	for i := 0; i < 2; i++ {
+		_, err := interceptor(abusive, nil, testInfo, handler)
+		assert.Nil(t, err)
+	}
+	_, err := interceptor(abusive, nil, testInfo, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// other client isn't limited
+	_, err = interceptor(other, nil, testInfo, handler)
+	assert.Nil(t, err)
+}
+
+func TestClientFromContext(t *testing.T) {
+	assert.Equal(t, "", clientFromContext(context.Background()))
+
+	ctx := peer.NewContext(context.Background(),
+		&peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 40000}})
+	assert.Equal(t, "10.0.0.1", clientFromContext(ctx))
+
+	ctx = peer.NewContext(context.Background(), &peer.Peer{Addr: &net.UnixAddr{Name: "@", Net: "unix"}})
+	assert.Equal(t, "@", clientFromContext(ctx))
+}
@@ -0,0 +1,181 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/credentials"
+)
+
+// TLSConfig holds paths to PEM encoded files which are used for TLS of gRPC channels,
+// usually they are mounted from kubernetes Secret
+type TLSConfig struct {
+	// CertFile is the certificate of server or client
+	CertFile string
+	// KeyFile is the private key of certificate
+	KeyFile string
+	// CAFile is the CA certificate which is used for verification of peer certificate,
+	// server requires and verifies client certificate if it is set
+	CAFile string
+	// ServerName is used by client for verification of server certificate instead of endpoint host name
+	ServerName string
+}
+
+// IsEnabled returns true if certificate and private key are provided
+func (c TLSConfig) IsEnabled() bool {
+	return c.CertFile != "" && c.KeyFile != ""
+}
+
+// certReloader holds certificate and CA pool and reloads them when files are changed, e.g. when Secret is updated
+type certReloader struct {
+	cfg     TLSConfig
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	caPool  *x509.CertPool
+	modTime time.Time
+	log     *logrus.Entry
+}
+
+func newCertReloader(cfg TLSConfig, logger *logrus.Logger) (*certReloader, error) {
+	r := &certReloader{
+		cfg: cfg,
+		log: logger.WithField("component", "certReloader"),
+	}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// get returns current certificate and CA pool, they are reloaded if files were changed
+// previous certificate and CA pool are used if reload failed
+func (r *certReloader) get() (*tls.Certificate, *x509.CertPool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.reload(); err != nil {
+		r.log.WithField("method", "get").Errorf("Unable to reload certificates, previous ones are used: %v", err)
+	}
+	return r.cert, r.caPool
+}
+
+// reload reads certificate, private key and CA certificate if some of files is newer than loaded ones
+func (r *certReloader) reload() error {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return err
+	}
+	if r.cert != nil && !modTime.After(r.modTime) {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("unable to load certificate %s: %v", r.cfg.CertFile, err)
+	}
+	var caPool *x509.CertPool
+	if r.cfg.CAFile != "" {
+		ca, err := ioutil.ReadFile(r.cfg.CAFile)
+		if err != nil {
+			return fmt.Errorf("unable to read CA certificate %s: %v", r.cfg.CAFile, err)
+		}
+		caPool = x509.NewCertPool()
+		if !caPool.AppendCertsFromPEM(ca) {
+			return fmt.Errorf("unable to parse CA certificate %s", r.cfg.CAFile)
+		}
+	}
+
+	if r.cert != nil {
+		r.log.WithField("method", "reload").Infof("Certificate %s was reloaded", r.cfg.CertFile)
+	}
+	r.cert, r.caPool, r.modTime = &cert, caPool, modTime
+	return nil
+}
+
+func (r *certReloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, file := range []string{r.cfg.CertFile, r.cfg.KeyFile, r.cfg.CAFile} {
+		if file == "" {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return latest, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// NewServerCredentials creates TLS credentials for gRPC server, certificate and CA are reloaded on each handshake
+// when their files are changed
+// Receives TLSConfig and logrus logger
+// Returns server credentials which require and verify client certificate if CA is set or error if certificates
+// can't be loaded
+func NewServerCredentials(cfg TLSConfig, logger *logrus.Logger) (credentials.TransportCredentials, error) {
+	r, err := newCertReloader(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, caPool := r.get()
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   []string{"h2"},
+				Certificates: []tls.Certificate{*cert},
+			}
+			if caPool != nil {
+				config.ClientCAs = caPool
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return config, nil
+		},
+	}), nil
+}
+
+// NewClientCredentials creates TLS credentials for gRPC client, client certificate is reloaded on each handshake
+// when its files are changed, CA is loaded once
+// Receives TLSConfig and logrus logger
+// Returns client credentials or error if certificates can't be loaded
+func NewClientCredentials(cfg TLSConfig, logger *logrus.Logger) (credentials.TransportCredentials, error) {
+	r, err := newCertReloader(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	_, caPool := r.get()
+	return credentials.NewTLS(&tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    caPool,
+		ServerName: cfg.ServerName,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _ := r.get()
+			return cert, nil
+		},
+	}), nil
+}
@@ -0,0 +1,167 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+var tlsEndpoint = "tcp://localhost:4244"
+
+func TestTLS_MutualAuthentication(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ca, caKey := writeCert(t, dir, "ca", nil, nil)
+	writeCert(t, dir, "server", ca, caKey)
+	writeCert(t, dir, "client", ca, caKey)
+	serverCfg := testTLSConfig(dir, "server")
+	clientCfg := testTLSConfig(dir, "client")
+
+	serverCreds, err := NewServerCredentials(serverCfg, serverLogger)
+	assert.Nil(t, err)
+	sr := NewServerRunner(serverCreds, tlsEndpoint, serverLogger)
+	grpc_health_v1.RegisterHealthServer(sr.GRPCServer, health.NewServer())
+	go func() {
+		_ = sr.RunServer()
+	}()
+	defer sr.StopServer()
+
+	check := func(cfg TLSConfig) error {
+		creds, err := NewClientCredentials(cfg, clientLogger)
+		assert.Nil(t, err)
+		client, err := NewClient(creds, tlsEndpoint, clientLogger)
+		assert.Nil(t, err)
+		defer client.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err = grpc_health_v1.NewHealthClient(client.GRPCClient).Check(ctx, &grpc_health_v1.HealthCheckRequest{},
+			grpc.WaitForReady(true))
+		return err
+	}
+
+	assert.Nil(t, check(clientCfg))
+
+	// client certificate isn't signed by trusted CA
+	otherCA, otherKey := writeCert(t, dir, "other-ca", nil, nil)
+	writeCert(t, dir, "other", otherCA, otherKey)
+	assert.NotNil(t, check(testTLSConfig(dir, "other")))
+}
+
+func TestCertReloader_get(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ca, caKey := writeCert(t, dir, "ca", nil, nil)
+	writeCert(t, dir, "server", ca, caKey)
+	cfg := testTLSConfig(dir, "server")
+
+	r, err := newCertReloader(cfg, serverLogger)
+	assert.Nil(t, err)
+	cert, caPool := r.get()
+	assert.NotNil(t, caPool)
+
+	// certificate is rotated
+	writeCert(t, dir, "server", ca, caKey)
+	future := time.Now().Add(time.Minute)
+	assert.Nil(t, os.Chtimes(cfg.CertFile, future, future))
+	newCert, _ := r.get()
+	assert.NotEqual(t, cert.Certificate[0], newCert.Certificate[0])
+
+	// broken certificate isn't used
+	assert.Nil(t, ioutil.WriteFile(cfg.CertFile, []byte("broken"), 0600))
+	future = future.Add(time.Minute)
+	assert.Nil(t, os.Chtimes(cfg.CertFile, future, future))
+	brokenCert, _ := r.get()
+	assert.Equal(t, newCert, brokenCert)
+
+	_, err = newCertReloader(TLSConfig{CertFile: "/not/exist", KeyFile: "/not/exist"}, serverLogger)
+	assert.NotNil(t, err)
+}
+
+func TestTLSConfig_IsEnabled(t *testing.T) {
+	assert.False(t, TLSConfig{}.IsEnabled())
+	assert.False(t, TLSConfig{CAFile: "ca.crt"}.IsEnabled())
+	assert.True(t, TLSConfig{CertFile: "tls.crt", KeyFile: "tls.key"}.IsEnabled())
+}
+
+func testTLSConfig(dir, name string) TLSConfig {
+	return TLSConfig{
+		CertFile: filepath.Join(dir, name+".crt"),
+		KeyFile:  filepath.Join(dir, name+".key"),
+		CAFile:   filepath.Join(dir, "ca.crt"),
+	}
+}
+
+// writeCert generates certificate signed by parent or self-signed CA if parent is nil
+// and writes it to <dir>/<name>.crt and <dir>/<name>.key
+func writeCert(t *testing.T, dir, name string, parent *x509.Certificate,
+	parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	assert.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	assert.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name+".crt"),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name+".key"),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+
+	cert, err := x509.ParseCertificate(der)
+	assert.Nil(t, err)
+	return cert, key
+}
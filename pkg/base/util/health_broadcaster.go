@@ -0,0 +1,101 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	health "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// HealthBroadcaster holds serving status of the service and broadcasts its changes to clients of gRPC health Watch
+type HealthBroadcaster struct {
+	mu          sync.Mutex
+	status      health.HealthCheckResponse_ServingStatus
+	subscribers map[chan health.HealthCheckResponse_ServingStatus]struct{}
+}
+
+// NewHealthBroadcaster is the constructor for HealthBroadcaster struct
+// Returns an instance of HealthBroadcaster with NOT_SERVING status
+func NewHealthBroadcaster() *HealthBroadcaster {
+	return &HealthBroadcaster{
+		status:      health.HealthCheckResponse_NOT_SERVING,
+		subscribers: make(map[chan health.HealthCheckResponse_ServingStatus]struct{}),
+	}
+}
+
+// SetStatus saves serving status and sends it to all Watch clients if it is changed
+func (b *HealthBroadcaster) SetStatus(s health.HealthCheckResponse_ServingStatus) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.status == s {
+		return
+	}
+	b.status = s
+	for ch := range b.subscribers {
+		// slow client receives only the latest status
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- s
+	}
+}
+
+// Status returns current serving status
+func (b *HealthBroadcaster) Status() health.HealthCheckResponse_ServingStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.status
+}
+
+// Watch sends current serving status to the client and then each its change until stream is ended
+// Returns Canceled error when client has closed the stream or error of sending
+func (b *HealthBroadcaster) Watch(srv health.Health_WatchServer) error {
+	ch := b.subscribe()
+	defer b.unsubscribe(ch)
+
+	for {
+		select {
+		case <-srv.Context().Done():
+			return status.Error(codes.Canceled, "stream has ended")
+		case s := <-ch:
+			if err := srv.Send(&health.HealthCheckResponse{Status: s}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (b *HealthBroadcaster) subscribe() chan health.HealthCheckResponse_ServingStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan health.HealthCheckResponse_ServingStatus, 1)
+	ch <- b.status
+	b.subscribers[ch] = struct{}{}
+	return ch
+}
+
+func (b *HealthBroadcaster) unsubscribe(ch chan health.HealthCheckResponse_ServingStatus) {
+	b.mu.Lock()
+	delete(b.subscribers, ch)
+	b.mu.Unlock()
+}
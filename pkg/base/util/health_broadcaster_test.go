@@ -0,0 +1,80 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	health "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// fakeWatchServer passes sent statuses to the channel
+type fakeWatchServer struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan health.HealthCheckResponse_ServingStatus
+}
+
+func (s *fakeWatchServer) Send(resp *health.HealthCheckResponse) error {
+	s.sent <- resp.Status
+	return nil
+}
+
+func (s *fakeWatchServer) Context() context.Context {
+	return s.ctx
+}
+
+func TestHealthBroadcaster_Watch(t *testing.T) {
+	var (
+		b           = NewHealthBroadcaster()
+		ctx, cancel = context.WithCancel(context.Background())
+		srv         = &fakeWatchServer{ctx: ctx, sent: make(chan health.HealthCheckResponse_ServingStatus, 10)}
+		errCh       = make(chan error)
+	)
+
+	go func() { errCh <- b.Watch(srv) }()
+	assert.Equal(t, health.HealthCheckResponse_NOT_SERVING, receive(t, srv.sent))
+
+	b.SetStatus(health.HealthCheckResponse_SERVING)
+	assert.Equal(t, health.HealthCheckResponse_SERVING, receive(t, srv.sent))
+
+	// status isn't sent when it isn't changed
+	b.SetStatus(health.HealthCheckResponse_SERVING)
+	b.SetStatus(health.HealthCheckResponse_NOT_SERVING)
+	assert.Equal(t, health.HealthCheckResponse_NOT_SERVING, receive(t, srv.sent))
+	assert.Equal(t, health.HealthCheckResponse_NOT_SERVING, b.Status())
+
+	cancel()
+	assert.Equal(t, codes.Canceled, status.Code(<-errCh))
+	assert.Empty(t, b.subscribers)
+}
+
+func receive(t *testing.T, ch chan health.HealthCheckResponse_ServingStatus) health.HealthCheckResponse_ServingStatus {
+	select {
+	case s := <-ch:
+		return s
+	case <-time.After(time.Second):
+		t.Fatal("status wasn't sent")
+	}
+	return health.HealthCheckResponse_UNKNOWN
+}
@@ -18,14 +18,17 @@ package util
 
 import (
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/credentials"
 	health "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/dell/csi-baremetal/pkg/base/rpc"
 )
 
 // SetupAndStartHealthCheckServer starts gRPC server to handle Health checking requests
-func SetupAndStartHealthCheckServer(c health.HealthServer, logger *logrus.Logger, endpoint string) error {
-	healthServer := rpc.NewServerRunner(nil, endpoint, logger)
+// Server is insecure if creds are nil
+func SetupAndStartHealthCheckServer(c health.HealthServer, creds credentials.TransportCredentials,
+	logger *logrus.Logger, endpoint string) error {
+	healthServer := rpc.NewServerRunner(creds, endpoint, logger)
 	// register Health checks
 	logger.Info("Registering health check service")
 	health.RegisterHealthServer(healthServer.GRPCServer, c)
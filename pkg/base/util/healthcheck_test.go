@@ -37,7 +37,7 @@ func Test_SetupAndStartHealthCheckServer(t *testing.T) {
 	healthServer := rpc.NewMockHealthServer()
 	endpoint := fmt.Sprintf("tcp://%s:%d", base.DefaultHealthIP, base.DefaultHealthPort)
 	go func() {
-		err := SetupAndStartHealthCheckServer(healthServer, testLogger, endpoint)
+		err := SetupAndStartHealthCheckServer(healthServer, nil, testLogger, endpoint)
 		assert.Nil(t, err)
 	}()
 	time.Sleep(3 * time.Second)
@@ -20,6 +20,8 @@ package util
 import (
 	"errors"
 	"strings"
+
+	"github.com/google/uuid"
 )
 
 const (
@@ -45,6 +47,13 @@ func GetVolumeUUID(volumeID string) (string, error) {
 	return uuid, nil
 }
 
+// GetEphemeralVolumeUUID returns partition GUID for ephemeral volume
+// ID of ephemeral volume is generated by kubelet (csi-<hash>) and isn't UUID, so GUID is derived from it
+// deterministically and could be calculated again at any stage of volume lifecycle
+func GetEphemeralVolumeUUID(volumeID string) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(volumeID)).String()
+}
+
 // HasNameWithPrefix check whether slice has a string
 // with pvcPrefix pvc or not
 func HasNameWithPrefix(names []string) bool {
@@ -41,3 +41,11 @@ func Test_GetEmptyVolumeUUID(t *testing.T) {
 	_, err := GetVolumeUUID(volumeID)
 	assert.Error(t, err, "volume UUID is empty")
 }
+
+func Test_GetEphemeralVolumeUUID(t *testing.T) {
+	volumeID := "csi-b7a3f2a5e2c1d4"
+	first := GetEphemeralVolumeUUID(volumeID)
+	assert.Equal(t, first, GetEphemeralVolumeUUID(volumeID))
+	assert.Assert(t, first != GetEphemeralVolumeUUID(volumeID+"1"))
+	assert.Equal(t, 36, len(first))
+}
@@ -0,0 +1,353 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cli contains logic of csibm command line tool which is used for inspecting of the driver state
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/sirupsen/logrus"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/crcontrollers/csibmnode/common"
+)
+
+// Inspector reads custom resources of the driver and prints them in a human readable form
+type Inspector struct {
+	k8sClient *k8s.KubeClient
+	crHelper  *k8s.CRHelper
+	out       io.Writer
+	log       *logrus.Entry
+}
+
+// NewInspector is the constructor for Inspector struct
+// Receives an instance of base.KubeClient, writer for output and logrus logger
+// Returns an instance of Inspector
+func NewInspector(k8sClient *k8s.KubeClient, out io.Writer, logger *logrus.Logger) *Inspector {
+	return &Inspector{
+		k8sClient: k8sClient,
+		crHelper:  k8s.NewCRHelper(k8sClient, logger),
+		out:       out,
+		log:       logger.WithField("component", "Inspector"),
+	}
+}
+
+// PrintDrives prints drives with their health and usage, if node isn't empty only drives of that node are printed
+func (i *Inspector) PrintDrives(node string) error {
+	nodeNames, err := i.getNodeNames()
+	if err != nil {
+		return err
+	}
+	allDrives, err := i.crHelper.GetDriveCRs()
+	if err != nil {
+		return err
+	}
+	volumes, err := i.crHelper.GetVolumeCRs()
+	if err != nil {
+		return err
+	}
+	lvgs, err := i.crHelper.GetLVGCRs()
+	if err != nil {
+		return err
+	}
+
+	drives := make([]drivecrd.Drive, 0, len(allDrives))
+	for _, d := range allDrives {
+		if nodeNames.match(node, d.Spec.NodeId) {
+			drives = append(drives, d)
+		}
+	}
+
+	// key - drive UUID, value - usage description
+	usage := make(map[string]string)
+	for _, lvg := range lvgs {
+		for _, location := range lvg.Spec.Locations {
+			usage[location] = "LVG " + lvg.Name
+		}
+	}
+	for _, v := range volumes {
+		if _, ok := usage[v.Spec.Location]; !ok {
+			usage[v.Spec.Location] = "Volume " + v.Name
+		}
+	}
+
+	sort.Slice(drives, func(a, b int) bool {
+		return nodeNames.get(drives[a].Spec.NodeId)+drives[a].Spec.Path < nodeNames.get(drives[b].Spec.NodeId)+drives[b].Spec.Path
+	})
+
+	w := tabwriter.NewWriter(i.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "UUID\tNODE\tPATH\tSERIAL NUMBER\tTYPE\tSIZE\tHEALTH\tSTATUS\tOP STATUS\tLED\tUSAGE")
+	for _, d := range drives {
+		u, ok := usage[d.Spec.UUID]
+		if !ok {
+			u = "Free"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			d.Spec.UUID, nodeNames.get(d.Spec.NodeId), d.Spec.Path, d.Spec.SerialNumber, d.Spec.Type,
+			formatSize(d.Spec.Size), d.Spec.Health, d.Spec.Status, d.Spec.OperationalStatus, d.Spec.LEDState, u)
+	}
+	return w.Flush()
+}
+
+// PrintVolumes prints volumes with their physical location and mount state,
+// if node isn't empty only volumes of that node are printed
+func (i *Inspector) PrintVolumes(node string) error {
+	nodeNames, err := i.getNodeNames()
+	if err != nil {
+		return err
+	}
+	allVolumes, err := i.crHelper.GetVolumeCRs()
+	if err != nil {
+		return err
+	}
+	drives, err := i.crHelper.GetDriveCRs()
+	if err != nil {
+		return err
+	}
+	lvgs, err := i.crHelper.GetLVGCRs()
+	if err != nil {
+		return err
+	}
+
+	volumes := make([]volumecrd.Volume, 0, len(allVolumes))
+	for _, v := range allVolumes {
+		if nodeNames.match(node, v.Spec.NodeId) {
+			volumes = append(volumes, v)
+		}
+	}
+	drivesByUUID := make(map[string]drivecrd.Drive, len(drives))
+	for _, d := range drives {
+		drivesByUUID[d.Spec.UUID] = d
+	}
+	// key - LVG name, value - drives UUIDs
+	lvgLocations := make(map[string][]string, len(lvgs))
+	for _, lvg := range lvgs {
+		lvgLocations[lvg.Name] = lvg.Spec.Locations
+	}
+
+	sort.Slice(volumes, func(a, b int) bool {
+		return volumes[a].Name < volumes[b].Name
+	})
+
+	w := tabwriter.NewWriter(i.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNODE\tSTORAGE CLASS\tSIZE\tLOCATION\tDEVICES\tCSI STATUS\tHEALTH\tOP STATUS")
+	for _, v := range volumes {
+		locations := []string{v.Spec.Location}
+		if lvgDrives, ok := lvgLocations[v.Spec.Location]; ok {
+			locations = lvgDrives
+		}
+		devices := ""
+		for _, location := range locations {
+			if d, ok := drivesByUUID[location]; ok {
+				if devices != "" {
+					devices += ","
+				}
+				devices += fmt.Sprintf("%s(%s)", d.Spec.Path, d.Spec.SerialNumber)
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			v.Spec.Id, nodeNames.get(v.Spec.NodeId), v.Spec.StorageClass, formatSize(v.Spec.Size), v.Spec.Location,
+			devices, v.Spec.CSIStatus, v.Spec.Health, v.Spec.OperationalStatus)
+	}
+	return w.Flush()
+}
+
+// PrintCapacity prints free capacity per node and storage class,
+// if node isn't empty only capacity of that node is printed
+func (i *Inspector) PrintCapacity(node string) error {
+	nodeNames, err := i.getNodeNames()
+	if err != nil {
+		return err
+	}
+	acs, err := i.crHelper.GetACCRs()
+	if err != nil {
+		return err
+	}
+
+	type capacityKey struct {
+		node, sc string
+	}
+	var (
+		keys  []capacityKey
+		size  = make(map[capacityKey]int64)
+		count = make(map[capacityKey]int)
+	)
+	for _, ac := range acs {
+		if !nodeNames.match(node, ac.Spec.NodeId) {
+			continue
+		}
+		key := capacityKey{node: nodeNames.get(ac.Spec.NodeId), sc: ac.Spec.StorageClass}
+		if _, ok := size[key]; !ok {
+			keys = append(keys, key)
+		}
+		size[key] += ac.Spec.Size
+		count[key]++
+	}
+	sort.Slice(keys, func(a, b int) bool {
+		if keys[a].node == keys[b].node {
+			return keys[a].sc < keys[b].sc
+		}
+		return keys[a].node < keys[b].node
+	})
+
+	w := tabwriter.NewWriter(i.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE\tSTORAGE CLASS\tAC COUNT\tFREE")
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", key.node, key.sc, count[key], formatSize(size[key]))
+	}
+	return w.Flush()
+}
+
+// CheckPlacement prints nodes on which volume with provided size and storage class could be placed
+// and reasons why other nodes were rejected, capacity isn't reserved or modified
+func (i *Inspector) CheckPlacement(size int64, storageClass, node string) error {
+	nodeNames, err := i.getNodeNames()
+	if err != nil {
+		return err
+	}
+
+	logger := i.log.WithField("method", "CheckPlacement")
+	checker := capacityplanner.NewPlacementChecker(logger, capacityplanner.NewACReader(i.k8sClient, logger, false))
+	placements, err := checker.CheckPlacement(context.Background(), &api.Volume{
+		Id:           "placement-check",
+		Size:         size,
+		StorageClass: util.ConvertStorageClass(storageClass),
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(i.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE\tSUITABLE\tDRIVE\tAC\tREASON")
+	found := false
+	for _, p := range placements {
+		if !nodeNames.match(node, p.NodeID) {
+			continue
+		}
+		found = true
+		if p.Suitable() {
+			fmt.Fprintf(w, "%s\t%t\t%s\t%s\t\n", nodeNames.get(p.NodeID), true, p.AC.Spec.Location, p.AC.Name)
+		} else {
+			fmt.Fprintf(w, "%s\t%t\t\t\t%s\n", nodeNames.get(p.NodeID), false, p.Reason)
+		}
+	}
+	if node != "" && !found {
+		fmt.Fprintf(w, "%s\t%t\t\t\tnode has no available capacity\n", node, false)
+	}
+	return w.Flush()
+}
+
+// Locate requests drive LED locate on the node, LED is turned off if stop is true
+func (i *Inspector) Locate(driveUUID string, stop bool) error {
+	value := apiV1.DriveLocateStart
+	if stop {
+		value = apiV1.DriveLocateStop
+	}
+	if err := i.annotateDrive(driveUUID, apiV1.DriveLocateAnnotationKey, value); err != nil {
+		return err
+	}
+	fmt.Fprintf(i.out, "Locate %s was requested for drive %s\n", value, driveUUID)
+	return nil
+}
+
+// Replace requests drive release before its physical replacement, drive LED locate is started as well
+func (i *Inspector) Replace(driveUUID string) error {
+	if v := i.crHelper.GetVolumeByLocation(driveUUID); v != nil {
+		fmt.Fprintf(i.out, "Warning: drive %s is used by volume %s, data will be lost after replacement\n",
+			driveUUID, v.Name)
+	}
+	if err := i.annotateDrive(driveUUID, apiV1.DriveReplacementAnnotationKey, "true"); err != nil {
+		return err
+	}
+	fmt.Fprintf(i.out, "Replacement was requested for drive %s\n", driveUUID)
+	return nil
+}
+
+// annotateDrive sets annotation on Drive CR, node service handles annotation and removes it
+func (i *Inspector) annotateDrive(driveUUID, key, value string) error {
+	drive := &drivecrd.Drive{}
+	if err := i.k8sClient.ReadCR(context.Background(), driveUUID, drive); err != nil {
+		return err
+	}
+	if drive.Annotations == nil {
+		drive.Annotations = make(map[string]string, 1)
+	}
+	drive.Annotations[key] = value
+	return i.k8sClient.UpdateCR(context.Background(), drive)
+}
+
+// nodeNameMap holds mapping between node ID and k8s node name
+type nodeNameMap map[string]string
+
+// get returns k8s node name by node ID or node ID itself if there is no such node
+func (n nodeNameMap) get(nodeID string) string {
+	if name, ok := n[nodeID]; ok {
+		return name
+	}
+	return nodeID
+}
+
+// match checks whether node ID corresponds to the node with provided name or ID, empty node matches any node ID
+func (n nodeNameMap) match(node, nodeID string) bool {
+	return node == "" || node == nodeID || node == n.get(nodeID)
+}
+
+// getNodeNames builds mapping between node ID (k8s node UID or CSIBMNode UUID) and k8s node name
+func (i *Inspector) getNodeNames() (nodeNameMap, error) {
+	nodes, err := i.k8sClient.GetNodes(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(nodeNameMap, len(nodes))
+	for _, node := range nodes {
+		res[string(node.UID)] = node.Name
+		if id, ok := node.GetAnnotations()[common.NodeIDAnnotationKey]; ok {
+			res[id] = node.Name
+		}
+	}
+	return res, nil
+}
+
+// formatSize converts size in bytes to a human readable string
+func formatSize(size int64) string {
+	units := []struct {
+		unit util.SizeUnit
+		name string
+	}{
+		{util.TBYTE, "Ti"},
+		{util.GBYTE, "Gi"},
+		{util.MBYTE, "Mi"},
+		{util.KBYTE, "Ki"},
+	}
+	for _, u := range units {
+		if size >= int64(u.unit) {
+			return fmt.Sprintf("%.1f%s", float64(size)/float64(u.unit), u.name)
+		}
+	}
+	return fmt.Sprintf("%d", size)
+}
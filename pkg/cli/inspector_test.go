@@ -0,0 +1,155 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/crcontrollers/csibmnode/common"
+)
+
+var (
+	testCtx    = context.Background()
+	testLogger = logrus.New()
+	testNs     = "default"
+
+	nodeName = "node-1"
+	nodeID   = "node-1-uuid"
+
+	drive1 = api.Drive{UUID: "drive-1", NodeId: nodeID, Path: "/dev/sda", SerialNumber: "SN1",
+		Type: apiV1.DriveTypeHDD, Size: int64(util.TBYTE), Health: apiV1.HealthGood}
+	drive2 = api.Drive{UUID: "drive-2", NodeId: "other-node", Path: "/dev/sdb", SerialNumber: "SN2",
+		Type: apiV1.DriveTypeSSD, Size: int64(util.TBYTE), Health: apiV1.HealthGood}
+	volume = api.Volume{Id: "pvc-1", NodeId: nodeID, Location: drive1.UUID, StorageClass: apiV1.StorageClassHDD,
+		Size: int64(util.TBYTE), CSIStatus: apiV1.Published}
+	ac1 = api.AvailableCapacity{Location: drive2.UUID, NodeId: "other-node", StorageClass: apiV1.StorageClassSSD,
+		Size: int64(util.GBYTE)}
+)
+
+func TestInspector_PrintDrives(t *testing.T) {
+	i, out := setup(t)
+
+	assert.Nil(t, i.PrintDrives(""))
+	assert.Contains(t, out.String(), "USAGE")
+	assert.Regexp(t, "drive-1 +node-1 +/dev/sda +SN1 .*Volume pvc-1", out.String())
+	assert.Regexp(t, "drive-2 +other-node .*Free", out.String())
+
+	out.Reset()
+	assert.Nil(t, i.PrintDrives(nodeName))
+	assert.Contains(t, out.String(), "drive-1")
+	assert.NotContains(t, out.String(), "drive-2")
+}
+
+func TestInspector_PrintVolumes(t *testing.T) {
+	i, out := setup(t)
+
+	assert.Nil(t, i.PrintVolumes(nodeID))
+	assert.Regexp(t, "pvc-1 +node-1 +HDD +1.0Ti +drive-1 +/dev/sda\\(SN1\\) +published", out.String())
+}
+
+func TestInspector_PrintCapacity(t *testing.T) {
+	i, out := setup(t)
+
+	assert.Nil(t, i.PrintCapacity(""))
+	assert.Regexp(t, "other-node +SSD +1 +1.0Gi", out.String())
+
+	out.Reset()
+	assert.Nil(t, i.PrintCapacity(nodeName))
+	assert.NotContains(t, out.String(), "other-node")
+}
+
+func TestInspector_CheckPlacement(t *testing.T) {
+	i, out := setup(t)
+
+	assert.Nil(t, i.CheckPlacement(int64(util.MBYTE), apiV1.StorageClassSSD, ""))
+	assert.Regexp(t, "other-node +true +drive-2 +ac-1", out.String())
+
+	out.Reset()
+	assert.Nil(t, i.CheckPlacement(int64(util.TBYTE), apiV1.StorageClassSSD, ""))
+	assert.Regexp(t, "other-node +false +largest available capacity", out.String())
+
+	out.Reset()
+	assert.Nil(t, i.CheckPlacement(int64(util.MBYTE), apiV1.StorageClassSSD, nodeName))
+	assert.Regexp(t, "node-1 +false +node has no available capacity", out.String())
+}
+
+func TestInspector_Locate(t *testing.T) {
+	i, _ := setup(t)
+	drive := &drivecrd.Drive{}
+
+	assert.Nil(t, i.Locate(drive1.UUID, false))
+	assert.Nil(t, i.k8sClient.ReadCR(testCtx, drive1.UUID, drive))
+	assert.Equal(t, apiV1.DriveLocateStart, drive.Annotations[apiV1.DriveLocateAnnotationKey])
+
+	assert.Nil(t, i.Locate(drive1.UUID, true))
+	assert.Nil(t, i.k8sClient.ReadCR(testCtx, drive1.UUID, drive))
+	assert.Equal(t, apiV1.DriveLocateStop, drive.Annotations[apiV1.DriveLocateAnnotationKey])
+
+	assert.NotNil(t, i.Locate("missing-drive", false))
+}
+
+func TestInspector_Replace(t *testing.T) {
+	i, out := setup(t)
+	drive := &drivecrd.Drive{}
+
+	assert.Nil(t, i.Replace(drive1.UUID))
+	assert.Contains(t, out.String(), "Warning")
+	assert.Nil(t, i.k8sClient.ReadCR(testCtx, drive1.UUID, drive))
+	assert.Equal(t, "true", drive.Annotations[apiV1.DriveReplacementAnnotationKey])
+}
+
+func Test_formatSize(t *testing.T) {
+	assert.Equal(t, "512", formatSize(512))
+	assert.Equal(t, "1.5Ki", formatSize(1536))
+	assert.Equal(t, "2.0Gi", formatSize(2*int64(util.GBYTE)))
+}
+
+func setup(t *testing.T) (*Inspector, *bytes.Buffer) {
+	k8sClient, err := k8s.GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+
+	node := &coreV1.Node{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:        nodeName,
+			UID:         types.UID("node-1-uid"),
+			Annotations: map[string]string{common.NodeIDAnnotationKey: nodeID},
+		},
+	}
+	assert.Nil(t, k8sClient.Create(testCtx, node))
+
+	for _, d := range []api.Drive{drive1, drive2} {
+		assert.Nil(t, k8sClient.CreateCR(testCtx, d.UUID, k8sClient.ConstructDriveCR(d.UUID, d)))
+	}
+	assert.Nil(t, k8sClient.CreateCR(testCtx, volume.Id, k8sClient.ConstructVolumeCR(volume.Id, volume)))
+	assert.Nil(t, k8sClient.CreateCR(testCtx, "ac-1", k8sClient.ConstructACCR("ac-1", ac1)))
+
+	out := &bytes.Buffer{}
+	return NewInspector(k8sClient, out, testLogger), out
+}
@@ -0,0 +1,193 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+)
+
+const (
+	// DefaultACBatchInterval is the default interval between writes of accumulated changes of AC sizes
+	DefaultACBatchInterval = time.Second
+	// acUpdateAttempts is amount of attempts to write AC which is modified concurrently
+	acUpdateAttempts = 5
+)
+
+// ACBatcher accumulates changes of AC sizes made by volume operations and periodically writes them to AC CRs,
+// so several volumes which are created or deleted on the same AC during the interval cost one write.
+// Pending changes are applied to ACs which are read through the batcher, so allocations are planned with actual sizes
+type ACBatcher struct {
+	k8sClient *k8s.KubeClient
+	interval  time.Duration
+
+	mu sync.Mutex
+	// key - AC name, value - change of size which isn't written yet
+	pending map[string]int64
+
+	log *logrus.Entry
+}
+
+// NewACBatcher is the constructor for ACBatcher struct
+// Receives an instance of base.KubeClient, logrus logger and interval between writes, DefaultACBatchInterval is used
+// if interval isn't positive
+// Returns an instance of ACBatcher
+func NewACBatcher(k8sClient *k8s.KubeClient, logger *logrus.Logger, interval time.Duration) *ACBatcher {
+	if interval <= 0 {
+		interval = DefaultACBatchInterval
+	}
+	return &ACBatcher{
+		k8sClient: k8sClient,
+		interval:  interval,
+		pending:   make(map[string]int64),
+		log:       logger.WithField("component", "ACBatcher"),
+	}
+}
+
+// Add accumulates change of AC size, opposite changes cancel each other without writes
+func (b *ACBatcher) Add(acName string, delta int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.add(acName, delta)
+}
+
+func (b *ACBatcher) add(acName string, delta int64) {
+	if b.pending[acName] += delta; b.pending[acName] == 0 {
+		delete(b.pending, acName)
+	}
+}
+
+// Pending returns change of AC size which isn't written yet
+func (b *ACBatcher) Pending(acName string) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.pending[acName]
+}
+
+// Run spawns goroutine which periodically writes accumulated changes
+func (b *ACBatcher) Run() {
+	go func() {
+		for {
+			time.Sleep(b.interval)
+			ctx, cancelFn := context.WithTimeout(context.Background(), b.interval*10)
+			if err := b.Flush(ctx); err != nil {
+				b.log.WithField("method", "Run").Errorf("Unable to write AC sizes: %v", err)
+			}
+			cancelFn()
+		}
+	}()
+}
+
+// Flush writes all accumulated changes, changes which weren't written are kept for the next attempt
+// Returns error of the last failed write
+func (b *ACBatcher) Flush(ctx context.Context) error {
+	b.mu.Lock()
+	names := make([]string, 0, len(b.pending))
+	for name := range b.pending {
+		names = append(names, name)
+	}
+	b.mu.Unlock()
+
+	var lastErr error
+	for _, name := range names {
+		if err := b.FlushAC(ctx, name); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// FlushAC writes accumulated change of AC size, it should be called before AC is modified bypassing the batcher
+// Receives golang context and AC name
+// Returns error if AC wasn't written
+func (b *ACBatcher) FlushAC(ctx context.Context, acName string) error {
+	ll := b.log.WithFields(logrus.Fields{
+		"method": "FlushAC",
+		"acName": acName,
+	})
+
+	delta := b.Pending(acName)
+	if delta == 0 {
+		return nil
+	}
+
+	err := updateACSize(ctx, b.k8sClient, acName, delta)
+	switch {
+	case err == nil:
+	case k8sError.IsNotFound(err):
+		ll.Infof("AC was removed, change of size %d is dropped", delta)
+	default:
+		ll.Errorf("Unable to change size of AC by %d: %v", delta, err)
+		return err
+	}
+	// change is removed from pending only after write, so readers never see AC without it
+	b.mu.Lock()
+	b.add(acName, -delta)
+	b.mu.Unlock()
+	return nil
+}
+
+// updateACSize changes size of AC by delta, AC is read again if it is modified concurrently
+// Receives golang context, KubeClient, AC name and delta of size
+// Returns error if AC wasn't updated
+func updateACSize(ctx context.Context, k8sClient *k8s.KubeClient, acName string, delta int64) error {
+	var err error
+	for i := 0; i < acUpdateAttempts; i++ {
+		ac := &accrd.AvailableCapacity{}
+		if err = k8sClient.ReadCRUncached(ctx, acName, ac); err == nil {
+			ac.Spec.Size += delta
+			err = k8sClient.UpdateCR(ctx, ac)
+		}
+		if err == nil || !k8sError.IsConflict(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// Reader wraps CapacityReader, so accumulated changes are applied to read ACs
+func (b *ACBatcher) Reader(reader capacityplanner.CapacityReader) capacityplanner.CapacityReader {
+	return &batchedACReader{reader: reader, batcher: b}
+}
+
+// batchedACReader applies accumulated changes of the batcher to ACs of another reader
+type batchedACReader struct {
+	reader  capacityplanner.CapacityReader
+	batcher *ACBatcher
+}
+
+// ReadCapacity returns ACs with accumulated changes of sizes
+func (r *batchedACReader) ReadCapacity(ctx context.Context) ([]accrd.AvailableCapacity, error) {
+	acs, err := r.reader.ReadCapacity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]accrd.AvailableCapacity, len(acs))
+	for i, ac := range acs {
+		ac.Spec.Size += r.batcher.Pending(ac.Name)
+		result[i] = ac
+	}
+	return result, nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+)
+
+func TestACBatcher(t *testing.T) {
+	ctx := context.Background()
+	k8sClient, err := k8s.GetFakeKubeClient(testNS, testLogger)
+	assert.Nil(t, err)
+	b := NewACBatcher(k8sClient, testLogger, 0)
+
+	ac := k8sClient.ConstructACCR("ac-1", api.AvailableCapacity{Location: "drive-1", NodeId: "node-1",
+		StorageClass: apiV1.StorageClassHDD, Size: 100})
+	assert.Nil(t, k8sClient.CreateCR(ctx, ac.Name, ac))
+
+	// opposite changes cancel each other
+	b.Add(ac.Name, -30)
+	b.Add(ac.Name, 30)
+	assert.Equal(t, int64(0), b.Pending(ac.Name))
+
+	b.Add(ac.Name, -30)
+	b.Add(ac.Name, -20)
+	acs, err := b.Reader(capacityplanner.NewACReader(k8sClient, testLogger.WithField("test", t.Name()),
+		false)).ReadCapacity(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, acs, 1)
+	assert.Equal(t, int64(50), acs[0].Spec.Size)
+
+	stored := &accrd.AvailableCapacity{}
+	assert.Nil(t, k8sClient.ReadCR(ctx, ac.Name, stored))
+	assert.Equal(t, int64(100), stored.Spec.Size)
+
+	assert.Nil(t, b.Flush(ctx))
+	assert.Nil(t, k8sClient.ReadCR(ctx, ac.Name, stored))
+	assert.Equal(t, int64(50), stored.Spec.Size)
+	assert.Equal(t, int64(0), b.Pending(ac.Name))
+
+	// change of removed AC is dropped
+	b.Add("removed", 10)
+	assert.Nil(t, b.Flush(ctx))
+	assert.Equal(t, int64(0), b.Pending("removed"))
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"sort"
+	"sync"
+)
+
+// locationLocks serializes changes of capacity on the same drives while requests on other drives run in parallel.
+// Keys are locations of drives, LVG is protected by keys of all its drives, they are always locked in sorted order,
+// so requests which lock several keys don't deadlock
+type locationLocks struct {
+	sync.Mutex
+	locks map[string]*locationLock
+}
+
+type locationLock struct {
+	sync.Mutex
+	// amount of requests which hold or wait for the lock
+	refs int
+}
+
+// newLocationLocks is the constructor for locationLocks struct
+// Returns an instance of locationLocks
+func newLocationLocks() *locationLocks {
+	return &locationLocks{locks: make(map[string]*locationLock)}
+}
+
+// lock locks keys in sorted order, duplicated keys are locked once
+// Receives keys to lock
+// Returns function which unlocks keys
+func (l *locationLocks) lock(keys ...string) func() {
+	sorted := make([]string, 0, len(keys))
+	seen := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			sorted = append(sorted, key)
+		}
+	}
+	sort.Strings(sorted)
+
+	for _, key := range sorted {
+		l.Lock()
+		lock, ok := l.locks[key]
+		if !ok {
+			lock = &locationLock{}
+			l.locks[key] = lock
+		}
+		lock.refs++
+		l.Unlock()
+
+		lock.Lock()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			for i := len(sorted) - 1; i >= 0; i-- {
+				l.unlock(sorted[i])
+			}
+		})
+	}
+}
+
+// unlock unlocks key and forgets it if nobody waits for it
+func (l *locationLocks) unlock(key string) {
+	l.Lock()
+	defer l.Unlock()
+
+	lock := l.locks[key]
+	lock.refs--
+	if lock.refs == 0 {
+		delete(l.locks, key)
+	}
+	lock.Unlock()
+}
@@ -0,0 +1,94 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocationLocks_DifferentKeys(t *testing.T) {
+	locks := newLocationLocks()
+
+	unlock := locks.lock("drive1")
+	locked := make(chan struct{})
+	go func() {
+		locks.lock("drive2")()
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("request on another drive waits for the lock")
+	}
+	unlock()
+	unlock()
+	assert.Empty(t, locks.locks)
+}
+
+func TestLocationLocks_SameKey(t *testing.T) {
+	locks := newLocationLocks()
+
+	unlock := locks.lock("drive1", "drive2")
+	locked := make(chan struct{})
+	go func() {
+		locks.lock("drive2")()
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("lock of LVG drive isn't held")
+	case <-time.After(50 * time.Millisecond):
+	}
+	unlock()
+	<-locked
+	assert.Empty(t, locks.locks)
+}
+
+func TestLocationLocks_Ordering(t *testing.T) {
+	locks := newLocationLocks()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			locks.lock("drive1", "drive2", "drive1")()
+		}()
+		go func() {
+			defer wg.Done()
+			locks.lock("drive2", "drive1")()
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("requests which lock the same drives in different order are deadlocked")
+	}
+	assert.Empty(t, locks.locks)
+}
@@ -0,0 +1,69 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base"
+	csibmnodeconst "github.com/dell/csi-baremetal/pkg/crcontrollers/csibmnode/common"
+)
+
+// ConstructStaticPV constructs static PV for imported or re-adopted volume, PV is bound to the node of volume
+// and has Retain reclaim policy to keep pre-existing data when PVC is deleted
+// Receives Volume CR and name of k8s StorageClass which is set in PV
+func ConstructStaticPV(volume *volumecrd.Volume, storageClassName string) *coreV1.PersistentVolume {
+	pv := &coreV1.PersistentVolume{
+		TypeMeta:   metaV1.TypeMeta{Kind: "PersistentVolume", APIVersion: "v1"},
+		ObjectMeta: metaV1.ObjectMeta{Name: volume.Name},
+		Spec: coreV1.PersistentVolumeSpec{
+			Capacity: coreV1.ResourceList{
+				coreV1.ResourceStorage: *resource.NewQuantity(volume.Spec.Size, resource.BinarySI),
+			},
+			AccessModes:                   []coreV1.PersistentVolumeAccessMode{coreV1.ReadWriteOnce},
+			PersistentVolumeReclaimPolicy: coreV1.PersistentVolumeReclaimRetain,
+			StorageClassName:              storageClassName,
+			PersistentVolumeSource: coreV1.PersistentVolumeSource{
+				CSI: &coreV1.CSIPersistentVolumeSource{
+					Driver:       base.PluginName,
+					VolumeHandle: volume.Spec.Id,
+					FSType:       volume.Spec.Type,
+				},
+			},
+			NodeAffinity: &coreV1.VolumeNodeAffinity{
+				Required: &coreV1.NodeSelector{
+					NodeSelectorTerms: []coreV1.NodeSelectorTerm{{
+						MatchExpressions: []coreV1.NodeSelectorRequirement{{
+							Key:      csibmnodeconst.NodeIDAnnotationKey,
+							Operator: coreV1.NodeSelectorOpIn,
+							Values:   []string{volume.Spec.NodeId},
+						}},
+					}},
+				},
+			},
+		},
+	}
+	if volume.Spec.Mode == apiV1.ModeRAW {
+		block := coreV1.PersistentVolumeBlock
+		pv.Spec.VolumeMode = &block
+	}
+	return pv
+}
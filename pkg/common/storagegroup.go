@@ -0,0 +1,76 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+)
+
+// storageGroupFilter is CapacityReader which keeps only AC of drives from the storage group of the volume,
+// AC of drives which belong to any storage group are hidden from volumes without storage group
+type storageGroupFilter struct {
+	reader    capacityplanner.CapacityReader
+	k8sClient *k8s.KubeClient
+	group     string
+}
+
+// ReadCapacity returns AC list read by underlying reader without AC of drives from other storage groups,
+// LVG belongs to the storage group of its drives
+func (f *storageGroupFilter) ReadCapacity(ctx context.Context) ([]accrd.AvailableCapacity, error) {
+	acs, err := f.reader.ReadCapacity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	drives := &drivecrd.DriveList{}
+	if err = f.k8sClient.ReadList(ctx, drives); err != nil {
+		return nil, err
+	}
+	// key - drive UUID or LVG name, value - storage group
+	groups := make(map[string]string)
+	for _, d := range drives.Items {
+		if group := d.GetLabels()[apiV1.StorageGroupLabelKey]; group != "" {
+			groups[d.Spec.UUID] = group
+		}
+	}
+	if len(groups) == 0 && f.group == "" {
+		return acs, nil
+	}
+	lvgs := &lvgcrd.LVGList{}
+	if err = f.k8sClient.ReadList(ctx, lvgs); err != nil {
+		return nil, err
+	}
+	for _, lvg := range lvgs.Items {
+		if len(lvg.Spec.Locations) > 0 {
+			groups[lvg.Name] = groups[lvg.Spec.Locations[0]]
+		}
+	}
+
+	filtered := make([]accrd.AvailableCapacity, 0, len(acs))
+	for _, ac := range acs {
+		if groups[ac.Spec.Location] == f.group {
+			filtered = append(filtered, ac)
+		}
+	}
+	return filtered, nil
+}
@@ -0,0 +1,53 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+)
+
+func TestStorageGroupFilter_ReadCapacity(t *testing.T) {
+	svc := setupVOOperationsTest(t)
+	// drive of testAC3 doesn't belong to any group, LVG of testAC4 is placed on the drive from the group
+	for _, uuid := range []string{testDrive2UUID, testDrive4UUID} {
+		drive := svc.k8sClient.ConstructDriveCR(uuid, api.Drive{UUID: uuid, NodeId: testNode2Name})
+		drive.Labels = map[string]string{apiV1.StorageGroupLabelKey: "databases"}
+		assert.Nil(t, svc.k8sClient.CreateCR(testCtx, uuid, drive))
+	}
+	lvg := testLVG.DeepCopy()
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, lvg.Name, lvg))
+
+	capReader := &capacityplanner.CapacityReaderMock{}
+	capReader.On("ReadCapacity", testCtx).Return([]accrd.AvailableCapacity{testAC2, testAC3, testAC4}, nil)
+
+	filter := &storageGroupFilter{reader: capReader, k8sClient: svc.k8sClient, group: "databases"}
+	acs, err := filter.ReadCapacity(testCtx)
+	assert.Nil(t, err)
+	assert.Equal(t, []accrd.AvailableCapacity{testAC2, testAC4}, acs)
+
+	filter = &storageGroupFilter{reader: capReader, k8sClient: svc.k8sClient}
+	acs, err = filter.ReadCapacity(testCtx)
+	assert.Nil(t, err)
+	assert.Equal(t, []accrd.AvailableCapacity{testAC3}, acs)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	csibmnodeconst "github.com/dell/csi-baremetal/pkg/crcontrollers/csibmnode/common"
+)
+
+// GetUpgradingNodes returns IDs of nodes which have NodeUpgradeAnnotationKey annotation,
+// both k8s UID and CSIBMNode UUID from annotation are included as node ID
+func GetUpgradingNodes(ctx context.Context, k8sClient *k8s.KubeClient) (map[string]struct{}, error) {
+	nodes, err := k8sClient.GetNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	upgrading := make(map[string]struct{})
+	for _, node := range nodes {
+		if _, ok := node.GetAnnotations()[apiV1.NodeUpgradeAnnotationKey]; !ok {
+			continue
+		}
+		upgrading[string(node.UID)] = struct{}{}
+		if id, ok := node.GetAnnotations()[csibmnodeconst.NodeIDAnnotationKey]; ok {
+			upgrading[id] = struct{}{}
+		}
+	}
+	return upgrading, nil
+}
+
+// upgradingNodesFilter is CapacityReader which hides AC of nodes where plugin is being updated
+type upgradingNodesFilter struct {
+	reader    capacityplanner.CapacityReader
+	upgrading map[string]struct{}
+}
+
+// ReadCapacity returns AC list read by underlying reader without AC of upgrading nodes
+func (f *upgradingNodesFilter) ReadCapacity(ctx context.Context) ([]accrd.AvailableCapacity, error) {
+	acs, err := f.reader.ReadCapacity(ctx)
+	if err != nil || len(f.upgrading) == 0 {
+		return acs, err
+	}
+	filtered := make([]accrd.AvailableCapacity, 0, len(acs))
+	for _, ac := range acs {
+		if _, ok := f.upgrading[ac.Spec.NodeId]; !ok {
+			filtered = append(filtered, ac)
+		}
+	}
+	return filtered, nil
+}
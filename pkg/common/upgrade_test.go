@@ -0,0 +1,90 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	coreV1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+	csibmnodeconst "github.com/dell/csi-baremetal/pkg/crcontrollers/csibmnode/common"
+)
+
+func TestGetUpgradingNodes(t *testing.T) {
+	svc := setupVOOperationsTest(t)
+	upgradingNode := &coreV1.Node{ObjectMeta: k8smetav1.ObjectMeta{
+		Name: "upgrading",
+		UID:  types.UID("upgrading-uid"),
+		Annotations: map[string]string{
+			apiV1.NodeUpgradeAnnotationKey:     "",
+			csibmnodeconst.NodeIDAnnotationKey: testNode1Name,
+		},
+	}}
+	runningNode := &coreV1.Node{ObjectMeta: k8smetav1.ObjectMeta{
+		Name:        "running",
+		UID:         types.UID(testNode2Name),
+		Annotations: map[string]string{csibmnodeconst.NodeIDAnnotationKey: "running-id"},
+	}}
+	assert.Nil(t, svc.k8sClient.Create(testCtx, upgradingNode))
+	assert.Nil(t, svc.k8sClient.Create(testCtx, runningNode))
+
+	upgrading, err := GetUpgradingNodes(testCtx, svc.k8sClient)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]struct{}{"upgrading-uid": {}, testNode1Name: {}}, upgrading)
+}
+
+func TestUpgradingNodesFilter_ReadCapacity(t *testing.T) {
+	ac1 := testAC2.DeepCopy()
+	ac1.Spec.NodeId = testNode1Name
+	capReader := &capacityplanner.CapacityReaderMock{}
+	capReader.On("ReadCapacity", testCtx).Return([]accrd.AvailableCapacity{*ac1, testAC2}, nil)
+
+	filter := &upgradingNodesFilter{reader: capReader, upgrading: map[string]struct{}{testNode1Name: {}}}
+	acs, err := filter.ReadCapacity(testCtx)
+	assert.Nil(t, err)
+	assert.Equal(t, []accrd.AvailableCapacity{testAC2}, acs)
+}
+
+func TestVolumeOperationsImpl_CreateVolume_FailNodeUpgrading(t *testing.T) {
+	svc := setupVOOperationsTest(t)
+	node := &coreV1.Node{ObjectMeta: k8smetav1.ObjectMeta{
+		Name:        testNode1Name,
+		UID:         types.UID(testNode1Name),
+		Annotations: map[string]string{apiV1.NodeUpgradeAnnotationKey: ""},
+	}}
+	assert.Nil(t, svc.k8sClient.Create(testCtx, node))
+
+	createdVolume, err := svc.CreateVolume(testCtx, api.Volume{
+		Id:           "pvc-aaaa-bbbb",
+		StorageClass: apiV1.StorageClassHDD,
+		NodeId:       testNode1Name,
+		Size:         int64(util.GBYTE),
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Nil(t, createdVolume)
+}
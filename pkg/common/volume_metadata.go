@@ -0,0 +1,44 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+
+	"github.com/dell/csi-baremetal/pkg/base"
+)
+
+// volumeMetadataKey is the key of context which holds VolumeMetadata of created volume
+const volumeMetadataKey base.CtxKey = "VolumeMetadata"
+
+// VolumeMetadata is labels and annotations which are set on Volume CR when it is created,
+// e.g. labels of PVC which are used for per-team capacity reporting
+type VolumeMetadata struct {
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// WithVolumeMetadata returns context which makes CreateVolume set provided metadata on created Volume CR
+func WithVolumeMetadata(ctx context.Context, metadata VolumeMetadata) context.Context {
+	return context.WithValue(ctx, volumeMetadataKey, metadata)
+}
+
+// volumeMetadataFromContext returns VolumeMetadata which was set by WithVolumeMetadata, it is empty if wasn't set
+func volumeMetadataFromContext(ctx context.Context) VolumeMetadata {
+	metadata, _ := ctx.Value(volumeMetadataKey).(VolumeMetadata)
+	return metadata
+}
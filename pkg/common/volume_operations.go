@@ -31,15 +31,20 @@ import (
 	api "github.com/dell/csi-baremetal/api/generated/v1"
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
 	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
 	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
 	"github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
 	fc "github.com/dell/csi-baremetal/pkg/base/featureconfig"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/rpc"
 	"github.com/dell/csi-baremetal/pkg/base/util"
 )
 
+// maxPlacementAttempts is amount of attempts to plan placement of volume when chosen AC is changed concurrently
+const maxPlacementAttempts = 3
+
 // VolumeOperations is the interface that unites common Volume CRs operations. It is designed for inline volume support
 // without code duplication
 type VolumeOperations interface {
@@ -54,17 +59,30 @@ type VolumeOperationsImpl struct {
 	acProvider             AvailableCapacityOperations
 	k8sClient              *k8s.KubeClient
 	capacityManagerBuilder capacityplanner.CapacityManagerBuilder
+	anyPolicy              *capacityplanner.AnyPolicy
+	densityPolicy          *capacityplanner.DensityPolicy
+	// protects capacity of drives from concurrent allocations and releases
+	locks *locationLocks
+	// accumulates changes of AC sizes if it is set, otherwise ACs are written on each change
+	acBatcher *ACBatcher
 
 	featureChecker fc.FeatureChecker
 	log            *logrus.Entry
 }
 
 // NewVolumeOperationsImpl is the constructor for VolumeOperationsImpl struct
-// Receives an instance of base.KubeClient and logrus logger
+// Receives an instance of base.KubeClient, logrus logger, feature config, policy for ANY storage class resolution
+// and policy of volumes density, default policies are used if they are nil
 // Returns an instance of VolumeOperationsImpl
-func NewVolumeOperationsImpl(k8sClient *k8s.KubeClient, logger *logrus.Logger,
-	featureConf fc.FeatureChecker) *VolumeOperationsImpl {
+func NewVolumeOperationsImpl(k8sClient *k8s.KubeClient, logger *logrus.Logger, featureConf fc.FeatureChecker,
+	anyPolicy *capacityplanner.AnyPolicy, densityPolicy *capacityplanner.DensityPolicy) *VolumeOperationsImpl {
+	if anyPolicy == nil {
+		anyPolicy = capacityplanner.NewDefaultAnyPolicy()
+	}
 	return &VolumeOperationsImpl{
+		anyPolicy:              anyPolicy,
+		densityPolicy:          densityPolicy,
+		locks:                  newLocationLocks(),
 		k8sClient:              k8sClient,
 		acProvider:             NewACOperationsImpl(k8sClient, logger),
 		log:                    logger.WithField("component", "VolumeOperationsImpl"),
@@ -73,8 +91,14 @@ func NewVolumeOperationsImpl(k8sClient *k8s.KubeClient, logger *logrus.Logger,
 	}
 }
 
+// SetACBatcher makes volume operations accumulate changes of AC sizes in batcher instead of writing ACs on each change
+func (vo *VolumeOperationsImpl) SetACBatcher(batcher *ACBatcher) {
+	vo.acBatcher = batcher
+}
+
 // CreateVolume searches AC and creates volume CR or returns existed volume CR
-// Receives golang context and api.Volume which is Spec of Volume CR to create
+// Receives golang context, which could hold VolumeMetadata of Volume CR, and api.Volume which is Spec of Volume CR
+// to create
 // Returns api.Volume instance that took the place of chosen by SearchAC method AvailableCapacity CR
 func (vo *VolumeOperationsImpl) CreateVolume(ctx context.Context, v api.Volume) (*api.Volume, error) {
 	ll := vo.log.WithFields(logrus.Fields{
@@ -94,7 +118,7 @@ func (vo *VolumeOperationsImpl) CreateVolume(ctx context.Context, v api.Volume)
 	case err == nil:
 		ll.Infof("Volume exists, current status: %s.", volumeCR.Spec.CSIStatus)
 		if volumeCR.Spec.CSIStatus == apiV1.Failed {
-			return nil, fmt.Errorf("corresponding volume CR %s has failed status", volumeCR.Spec.Id)
+			return nil, status.Errorf(codes.Internal, "corresponding volume CR %s has failed status", volumeCR.Spec.Id)
 		}
 		// check that volume is in created state or time is over (for creating)
 		expiredAt := volumeCR.ObjectMeta.GetCreationTimestamp().Add(base.DefaultTimeoutForVolumeOperations)
@@ -122,30 +146,55 @@ func (vo *VolumeOperationsImpl) CreateVolume(ctx context.Context, v api.Volume)
 			requiredBytes = capacityplanner.AlignSizeByPE(requiredBytes)
 		}
 
-		capReader := capacityplanner.NewACReader(vo.k8sClient, vo.log, true)
-		resReader := capacityplanner.NewACRReader(vo.k8sClient, vo.log, true)
-
-		capacityManager := vo.createCapacityManager(capReader, resReader)
-		plan, err := capacityManager.PlanVolumesPlacing(ctxWithID, []*api.Volume{&v})
-		if err != nil {
-			ll.Errorf("error while planning placing for volume: %s", err.Error())
-			return nil, err
+		var upgrading map[string]struct{}
+		// inline volumes are created by the node itself, so its plugin is already running
+		if !v.Ephemeral {
+			if upgrading, err = GetUpgradingNodes(ctxWithID, vo.k8sClient); err != nil {
+				ll.Errorf("Unable to read nodes: %v", err)
+				return nil, status.Error(codes.Aborted, "unable to check nodes upgrade")
+			}
+			if _, ok := upgrading[v.NodeId]; v.NodeId != "" && ok {
+				ll.Warnf("Plugin on node %s is being updated, provisioning is paused", v.NodeId)
+				return nil, status.Errorf(codes.Unavailable,
+					"provisioning on node %s is paused during plugin upgrade", v.NodeId)
+			}
 		}
-		noResourceMsg := fmt.Sprintf("there is no suitable drive for volume %s", v.Id)
-		if plan == nil {
-			return nil, status.Error(codes.ResourceExhausted, noResourceMsg)
+		// readers cache capacity, so new ones are created when placement is planned again
+		newReaders := func() (capacityplanner.CapacityReader, capacityplanner.ReservationReader) {
+			var capReader capacityplanner.CapacityReader = capacityplanner.NewACReader(vo.k8sClient, vo.log, true)
+			if vo.acBatcher != nil {
+				capReader = vo.acBatcher.Reader(capReader)
+			}
+			if !v.Ephemeral {
+				capReader = &upgradingNodesFilter{reader: capReader, upgrading: upgrading}
+			}
+			capReader = &storageGroupFilter{reader: capReader, k8sClient: vo.k8sClient, group: v.StorageGroup}
+			return capReader, capacityplanner.NewACRReader(vo.k8sClient, vo.log, true)
 		}
-		if v.NodeId == "" {
-			v.NodeId = plan.SelectNode()
+		capReader, resReader := newReaders()
+
+		// reservations are done for the original storage class, so ANY isn't resolved if they are used
+		if v.StorageClass == apiV1.StorageClassAny && !vo.featureChecker.IsEnabled(fc.FeatureACReservation) {
+			v.StorageClass = vo.resolveAnyStorageClass(ctxWithID, capReader, &v)
 		}
-		ll.Infof("Try to create volume on node %s", v.NodeId)
-		ac = plan.GetACForVolume(v.NodeId, &v)
-		if ac == nil {
-			return nil, status.Error(codes.ResourceExhausted, noResourceMsg)
+
+		noResourceMsg := fmt.Sprintf("there is no suitable drive for volume %s", v.Id)
+		var unlock func()
+		ac, unlock, err = vo.planAndLockAC(ctxWithID, capReader, resReader, newReaders, &v)
+		if err != nil {
+			return nil, err
 		}
+		defer unlock()
 		origAC := ac
+		if err = vo.checkDriveIsNotRemoved(ctxWithID, ac); err != nil {
+			return nil, err
+		}
 		if ac.Spec.StorageClass != v.StorageClass && util.IsStorageClassLVG(v.StorageClass) {
 			// AC needs to be converted to LVG AC, LVG doesn't exist yet
+			if ac, err = vo.flushAC(ctxWithID, ac); err != nil {
+				ll.Errorf("Unable to write size of AC %s: %v", ac.Name, err)
+				return nil, status.Error(codes.Aborted, "unable to write available capacity")
+			}
 			if ac = vo.acProvider.RecreateACToLVGSC(ctxWithID, v.StorageClass, *ac); ac == nil {
 				return nil, status.Errorf(codes.Internal,
 					"unable to prepare underlying storage for storage class %s", v.StorageClass)
@@ -157,40 +206,77 @@ func (vo *VolumeOperationsImpl) CreateVolume(ctx context.Context, v api.Volume)
 		// volume should be created with that particular SC
 		sc = ac.Spec.StorageClass
 
-		if util.IsStorageClassLVG(sc) {
+		var (
+			volumeSize      int64
+			partitionLayout string
+		)
+		switch {
+		case util.IsStorageClassLVG(sc):
 			allocatedBytes = requiredBytes
+			volumeSize = allocatedBytes
 			locationType = apiV1.LocationTypeLVM
-		} else {
+		case vo.isSubDriveAllocation(sc, requiredBytes, ac):
+			// volume takes GPT partition of required size, the rest of drive is kept in AC for other volumes
+			volumeSize = capacityplanner.AlignSizeBySubDrivePartition(requiredBytes)
+			allocatedBytes = capacityplanner.SubDriveAllocatedSize(requiredBytes)
+			locationType = apiV1.LocationTypeDrive
+			partitionLayout = apiV1.PartitionLayoutMulti
+		default:
 			allocatedBytes = ac.Spec.Size
+			volumeSize = allocatedBytes
 			locationType = apiV1.LocationTypeDrive
 		}
 
+		if err = vo.checkDensity(ctxWithID, sc, partitionLayout, ac); err != nil {
+			ll.Errorf("Volume can't be placed in AC %s: %v", ac.Name, err)
+			return nil, status.Errorf(codes.ResourceExhausted, "%s: %v", noResourceMsg, err)
+		}
+
 		// create volume CR
 		apiVolume := api.Volume{
 			Id:                v.Id,
 			NodeId:            ac.Spec.NodeId,
-			Size:              allocatedBytes,
+			Size:              volumeSize,
 			Location:          ac.Spec.Location,
 			CSIStatus:         csiStatus,
 			StorageClass:      sc,
+			StorageGroup:      v.StorageGroup,
 			Ephemeral:         v.Ephemeral,
+			Owners:            v.Owners,
 			Health:            apiV1.HealthGood,
 			LocationType:      locationType,
 			OperationalStatus: apiV1.OperationalStatusOperative,
 			Mode:              v.Mode,
 			Type:              v.Type,
+			PartitionLayout:   partitionLayout,
 		}
 		volumeCR = vo.k8sClient.ConstructVolumeCR(v.Id, apiVolume)
+		metadata := volumeMetadataFromContext(ctx)
+		volumeCR.Labels = metadata.Labels
+		volumeCR.Annotations = metadata.Annotations
 
-		if err = vo.k8sClient.CreateCR(ctxWithID, v.Id, volumeCR); err != nil {
-			ll.Errorf("Unable to create CR, error: %v", err)
-			return nil, status.Errorf(codes.Internal, "unable to create volume CR")
+		// decrease AC size before creation of volume CR, so controller which fails over between these steps
+		// leaks capacity instead of allocating it twice
+		if err = vo.changeACSize(ctxWithID, ac, -allocatedBytes); err != nil {
+			ll.Errorf("Unable to set size for AC %s to %d, error: %v", ac.Name, ac.Spec.Size, err)
 		}
 
-		// decrease AC size
-		ac.Spec.Size -= allocatedBytes
-		if err = vo.k8sClient.UpdateCRWithAttempts(ctxWithID, ac, 5); err != nil {
-			ll.Errorf("Unable to set size for AC %s to %d, error: %v", ac.Name, ac.Spec.Size, err)
+		// Volume CR is the idempotency key of the request, another controller replica could create it
+		// concurrently, e.g. previous leader which was processing the same request
+		if err = vo.k8sClient.Create(ctxWithID, volumeCR); err != nil {
+			if err := vo.changeACSize(ctxWithID, ac, allocatedBytes); err != nil {
+				ll.Errorf("Unable to restore size of AC %s to %d, error: %v", ac.Name, ac.Spec.Size, err)
+			}
+			if !k8sError.IsAlreadyExists(err) {
+				ll.Errorf("Unable to create CR, error: %v", err)
+				return nil, status.Errorf(codes.Internal, "unable to create volume CR")
+			}
+			ll.Infof("Volume CR was created concurrently, capacity allocated by the request is returned")
+			if err = vo.k8sClient.ReadCR(ctxWithID, v.Id, volumeCR); err != nil {
+				ll.Errorf("Unable to read volume CR: %v", err)
+				return nil, status.Error(codes.Aborted, "unable to read volume CR")
+			}
+			return &volumeCR.Spec, nil
 		}
 		if vo.featureChecker.IsEnabled(fc.FeatureACReservation) {
 			resHelper := capacityplanner.NewReservationHelper(vo.log, vo.k8sClient, capReader, resReader)
@@ -202,6 +288,185 @@ func (vo *VolumeOperationsImpl) CreateVolume(ctx context.Context, v api.Volume)
 	return &volumeCR.Spec, nil
 }
 
+// planAndLockAC chooses AC for the volume and locks its drives. Plan is made without lock, so AC could be changed by
+// concurrent request in the meantime, placement is planned again in that case
+// Receives golang context, readers of ACs and ACRs, constructor of new readers and volume,
+// NodeId of volume is set to node of chosen AC
+// Returns chosen AC, function which unlocks its drives or error if AC wasn't chosen
+func (vo *VolumeOperationsImpl) planAndLockAC(ctx context.Context, capReader capacityplanner.CapacityReader,
+	resReader capacityplanner.ReservationReader,
+	newReaders func() (capacityplanner.CapacityReader, capacityplanner.ReservationReader),
+	v *api.Volume) (*accrd.AvailableCapacity, func(), error) {
+	ll := vo.log.WithFields(logrus.Fields{
+		"method":   "planAndLockAC",
+		"volumeID": v.Id,
+	})
+	noResourceMsg := fmt.Sprintf("there is no suitable drive for volume %s", v.Id)
+
+	for attempt := 1; ; attempt++ {
+		capacityManager := vo.createCapacityManager(capReader, resReader)
+		plan, err := capacityManager.PlanVolumesPlacing(ctx, []*api.Volume{v})
+		if err != nil {
+			ll.Errorf("error while planning placing for volume: %s", err.Error())
+			return nil, nil, err
+		}
+		if plan == nil {
+			vo.logPlacementRejections(ctx, capReader, v)
+			return nil, nil, status.Error(codes.ResourceExhausted, noResourceMsg)
+		}
+		nodeID := v.NodeId
+		if nodeID == "" {
+			nodeID = plan.SelectNode()
+		}
+		ll.Infof("Try to create volume on node %s", nodeID)
+		ac := plan.GetACForVolume(nodeID, v)
+		if ac == nil {
+			return nil, nil, status.Error(codes.ResourceExhausted, noResourceMsg)
+		}
+
+		unlock, err := vo.lockLocation(ctx, ac.Spec.Location)
+		if err != nil {
+			ll.Errorf("Unable to lock location %s: %v", ac.Spec.Location, err)
+			return nil, nil, status.Error(codes.Aborted, "unable to lock capacity")
+		}
+		actual := &accrd.AvailableCapacity{}
+		err = vo.k8sClient.ReadCRUncached(ctx, ac.Name, actual)
+		if err == nil && vo.acBatcher != nil {
+			actual.Spec.Size += vo.acBatcher.Pending(actual.Name)
+		}
+		if err == nil && actual.Spec.Size == ac.Spec.Size && actual.Spec.StorageClass == ac.Spec.StorageClass &&
+			actual.Spec.Location == ac.Spec.Location {
+			v.NodeId = nodeID
+			return actual, unlock, nil
+		}
+		unlock()
+		if err != nil && !k8sError.IsNotFound(err) {
+			ll.Errorf("Unable to read AC %s: %v", ac.Name, err)
+			return nil, nil, status.Error(codes.Aborted, "unable to read available capacity")
+		}
+		if attempt == maxPlacementAttempts {
+			return nil, nil, status.Errorf(codes.Aborted, "capacity of node %s is changed concurrently", nodeID)
+		}
+		ll.Infof("AC %s was changed by concurrent request, planning again", ac.Name)
+		capReader, resReader = newReaders()
+	}
+}
+
+// changeACSize changes size of AC by delta, change is written immediately or accumulated by batcher if it is set
+// Receives golang context, AC and delta of size
+// Returns error if AC wasn't written
+func (vo *VolumeOperationsImpl) changeACSize(ctx context.Context, ac *accrd.AvailableCapacity, delta int64) error {
+	ac.Spec.Size += delta
+	if vo.acBatcher != nil {
+		vo.acBatcher.Add(ac.Name, delta)
+		return nil
+	}
+	return updateACSize(ctx, vo.k8sClient, ac.Name, delta)
+}
+
+// flushAC writes changes of AC size accumulated by batcher, so AC could be modified directly
+// Receives golang context and AC
+// Returns AC as it is stored or error if AC wasn't written or read
+func (vo *VolumeOperationsImpl) flushAC(ctx context.Context, ac *accrd.AvailableCapacity) (*accrd.AvailableCapacity, error) {
+	if vo.acBatcher == nil || vo.acBatcher.Pending(ac.Name) == 0 {
+		return ac, nil
+	}
+	if err := vo.acBatcher.FlushAC(ctx, ac.Name); err != nil {
+		return ac, err
+	}
+	actual := &accrd.AvailableCapacity{}
+	if err := vo.k8sClient.ReadCRUncached(ctx, ac.Name, actual); err != nil {
+		return ac, err
+	}
+	return actual, nil
+}
+
+// lockLocation locks drives of the location, location of AC is either drive or LVG which could span several drives
+// Receives golang context and location of AC
+// Returns function which unlocks drives or error if LVG wasn't read
+func (vo *VolumeOperationsImpl) lockLocation(ctx context.Context, location string) (func(), error) {
+	lvg := &lvgcrd.LVG{}
+	err := vo.k8sClient.ReadCR(ctx, location, lvg)
+	switch {
+	case err == nil:
+		return vo.locks.lock(lvg.Spec.Locations...), nil
+	case k8sError.IsNotFound(err):
+		return vo.locks.lock(location), nil
+	default:
+		return nil, err
+	}
+}
+
+// isSubDriveAllocation checks whether volume with provided storage class and size should take only part of drive
+// Returns true if SubDriveAllocation feature is enabled, storage class is HDD or SSD
+// and AC has space for partition of required size
+func (vo *VolumeOperationsImpl) isSubDriveAllocation(sc string, requiredBytes int64,
+	ac *accrd.AvailableCapacity) bool {
+	if !vo.featureChecker.IsEnabled(fc.FeatureSubDriveAllocation) {
+		return false
+	}
+	if sc != apiV1.StorageClassHDD && sc != apiV1.StorageClassSSD {
+		return false
+	}
+	return requiredBytes > 0 && capacityplanner.SubDriveAllocatedSize(requiredBytes) <= ac.Spec.Size
+}
+
+// checkDensity checks that location of AC doesn't exceed limits of densityPolicy with one more volume,
+// amount of sub-drive volumes on the drive and amount of logical volumes in LVG are checked
+// Returns error if limit is reached or if volumes can't be read
+func (vo *VolumeOperationsImpl) checkDensity(ctx context.Context, sc, partitionLayout string,
+	ac *accrd.AvailableCapacity) error {
+	isLVG := util.IsStorageClassLVG(sc)
+	if !isLVG && partitionLayout != apiV1.PartitionLayoutMulti {
+		return nil
+	}
+
+	volumes, err := vo.k8sClient.ReadVolumesByField(ctx, k8s.VolumeLocationField, ac.Spec.Location)
+	if err != nil {
+		return fmt.Errorf("unable to read volumes: %v", err)
+	}
+	count := 0
+	for _, v := range volumes {
+		if isLVG || v.Spec.PartitionLayout == apiV1.PartitionLayoutMulti {
+			count++
+		}
+	}
+
+	if isLVG {
+		return vo.densityPolicy.CheckLVG(count)
+	}
+	return vo.densityPolicy.CheckSubDrive(count)
+}
+
+// checkDriveIsNotRemoved checks that drive which is the location of AC isn't being removed,
+// AC of such drive is stale and is removed, volume creation should be retried
+// Returns codes.Unavailable error if drive is removing or offline
+func (vo *VolumeOperationsImpl) checkDriveIsNotRemoved(ctx context.Context, ac *accrd.AvailableCapacity) error {
+	ll := vo.log.WithFields(logrus.Fields{
+		"method": "checkDriveIsNotRemoved",
+		"acName": ac.Name,
+	})
+
+	drive := &drivecrd.Drive{}
+	if err := vo.k8sClient.ReadCR(ctx, ac.Spec.Location, drive); err != nil {
+		// location of LVG AC isn't a drive
+		if !k8sError.IsNotFound(err) {
+			ll.Errorf("Unable to read drive %s: %v", ac.Spec.Location, err)
+		}
+		return nil
+	}
+	if drive.Spec.OperationalStatus != apiV1.DriveOpStatusRemoving && drive.Spec.Status != apiV1.DriveStatusOffline {
+		return nil
+	}
+
+	ll.Warnf("Drive %s is being removed, AC is stale", drive.Spec.SerialNumber)
+	if err := vo.k8sClient.DeleteCR(ctx, ac); err != nil && !k8sError.IsNotFound(err) {
+		ll.Errorf("Unable to delete AC: %v", err)
+	}
+	return status.Errorf(codes.Unavailable, "drive %s on node %s is being removed",
+		drive.Spec.SerialNumber, drive.Spec.NodeId)
+}
+
 func (vo *VolumeOperationsImpl) createCapacityManager(capReader capacityplanner.CapacityReader,
 	resReader capacityplanner.ReservationReader) capacityplanner.CapacityPlaner {
 	if vo.featureChecker.IsEnabled(fc.FeatureACReservation) {
@@ -210,6 +475,49 @@ func (vo *VolumeOperationsImpl) createCapacityManager(capReader capacityplanner.
 	return vo.capacityManagerBuilder.GetCapacityManager(vo.log, capReader)
 }
 
+// resolveAnyStorageClass chooses concrete storage class for volume with ANY storage class using anyPolicy
+// Returns concrete storage class or ANY if policy didn't choose any storage class
+func (vo *VolumeOperationsImpl) resolveAnyStorageClass(ctx context.Context,
+	capReader capacityplanner.CapacityReader, v *api.Volume) string {
+	ll := vo.log.WithFields(logrus.Fields{
+		"method":   "resolveAnyStorageClass",
+		"volumeID": v.Id,
+	})
+
+	capacity, err := capReader.ReadCapacity(ctx)
+	if err != nil {
+		ll.Errorf("Unable to read capacity, storage class %s is kept: %v", v.StorageClass, err)
+		return v.StorageClass
+	}
+	sc := vo.anyPolicy.Resolve(v.Size, capacity, v.NodeId)
+	ll.Infof("Storage class %s was resolved to %s", v.StorageClass, sc)
+	return sc
+}
+
+// logPlacementRejections logs why volume can't be placed on nodes
+func (vo *VolumeOperationsImpl) logPlacementRejections(ctx context.Context,
+	capReader capacityplanner.CapacityReader, v *api.Volume) {
+	ll := vo.log.WithFields(logrus.Fields{
+		"method":   "logPlacementRejections",
+		"volumeID": v.Id,
+	})
+
+	var nodes []string
+	if v.NodeId != "" {
+		nodes = []string{v.NodeId}
+	}
+	placements, err := capacityplanner.NewPlacementChecker(vo.log, capReader).CheckPlacement(ctx, v, nodes)
+	if err != nil {
+		ll.Errorf("Unable to check volume placement: %v", err)
+		return
+	}
+	for _, p := range placements {
+		if !p.Suitable() {
+			ll.Infof("Node %s was rejected: %s", p.NodeID, p.Reason)
+		}
+	}
+}
+
 // DeleteVolume changes volume CR state and updates it,
 // if volume CR doesn't exists return Not found error and that error should be handled by caller.
 // Receives golang context and a volume ID to delete
@@ -232,7 +540,7 @@ func (vo *VolumeOperationsImpl) DeleteVolume(ctx context.Context, volumeID strin
 
 	if !volumeCR.Spec.Ephemeral {
 		switch volumeCR.Spec.CSIStatus {
-		case apiV1.Created:
+		case apiV1.Created, apiV1.Retained:
 		case apiV1.Failed:
 			return status.Error(codes.Internal, "volume has reached failed status")
 		case apiV1.Removed:
@@ -281,6 +589,14 @@ func (vo *VolumeOperationsImpl) UpdateCRsAfterVolumeDeletion(ctx context.Context
 		ll.Errorf("unable to delete volume CR %s: %v", volumeID, err)
 	}
 
+	unlock, err := vo.lockLocation(ctx, volumeCR.Spec.Location)
+	if err != nil {
+		ll.Errorf("Volume was deleted but AC of location %s hadn't updated, unable to lock it: %v",
+			volumeCR.Spec.Location, err)
+		return
+	}
+	defer unlock()
+
 	// find corresponding AC CR
 	acList := accrd.AvailableCapacityList{}
 	if err = vo.k8sClient.ReadList(ctx, &acList); err != nil {
@@ -320,13 +636,32 @@ func (vo *VolumeOperationsImpl) UpdateCRsAfterVolumeDeletion(ctx context.Context
 	// if LVG wasn't deleted increase AC size
 	if !isDeleted {
 		// Increase size of AC using volume size
-		acCR.Spec.Size += volumeCR.Spec.Size
-		if err = vo.k8sClient.UpdateCRWithAttempts(ctx, &acCR, 5); err != nil {
+		released := volumeCR.Spec.Size
+		if volumeCR.Spec.PartitionLayout == apiV1.PartitionLayoutMulti {
+			released = capacityplanner.SubDriveAllocatedSize(volumeCR.Spec.Size)
+		}
+		if err = vo.changeACSize(ctx, &acCR, released); err != nil {
 			ll.Errorf("Unable to update AC %s size: %v", acCR.Name, err)
 		}
 	}
 }
 
+// WaitStatusOrAbort waits for volume status during DefaultStatusWaitTimeout. Operation with volume isn't interrupted
+// when time is over, it is continued by reconcile loop on the node, so CSI call returns quickly and CO retries it later
+// Receives golang context, VolumeOperations, volume ID and expected statuses
+// Returns Aborted status error if volume hasn't reached statuses in time or error of WaitStatus
+func WaitStatusOrAbort(ctx context.Context, vo VolumeOperations, volumeID string, statuses ...string) error {
+	waitCtx, cancelFn := context.WithTimeout(ctx, base.DefaultStatusWaitTimeout)
+	defer cancelFn()
+
+	err := vo.WaitStatus(waitCtx, volumeID, statuses...)
+	if err != nil && ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+		return status.Errorf(codes.Aborted, "operation with volume %s is in progress, expected statuses %v",
+			volumeID, statuses)
+	}
+	return err
+}
+
 // WaitStatus check volume status until it will be reached one of the statuses
 // return error if context is done or volume reaches failed status, return nil if reached status != failed
 func (vo *VolumeOperationsImpl) WaitStatus(ctx context.Context, volumeID string, statuses ...string) error {
@@ -343,26 +678,29 @@ func (vo *VolumeOperationsImpl) WaitStatus(ctx context.Context, volumeID string,
 		err                 error
 	)
 	for {
+		// if volumes are read from informers, volume is checked as soon as it is changed
+		changed := vo.k8sClient.Notify(v)
 		select {
 		case <-ctx.Done():
 			ll.Warnf("Context is done but volume still not reach one of the expected status: %v", statuses)
-			return fmt.Errorf("volume context is done")
+			return fmt.Errorf("volume context is done: %w", ctx.Err())
 		case <-time.After(timeoutBetweenCheck):
-			if err = vo.k8sClient.ReadCR(ctx, volumeID, v); err != nil {
-				ll.Errorf("Unable to read volume CR: %v", err)
-				if k8sError.IsNotFound(err) {
-					ll.Error("Volume CR doesn't exist")
-					return fmt.Errorf("volume isn't found")
-				}
-				continue
+		case <-changed:
+		}
+		if err = vo.k8sClient.ReadCR(ctx, volumeID, v); err != nil {
+			ll.Errorf("Unable to read volume CR: %v", err)
+			if k8sError.IsNotFound(err) {
+				ll.Error("Volume CR doesn't exist")
+				return fmt.Errorf("volume %w", rpc.ErrNotFound)
 			}
-			for _, s := range statuses {
-				if v.Spec.CSIStatus == s {
-					if s == apiV1.Failed {
-						return fmt.Errorf("volume has reached Failed status")
-					}
-					return nil
+			continue
+		}
+		for _, s := range statuses {
+			if v.Spec.CSIStatus == s {
+				if s == apiV1.Failed {
+					return fmt.Errorf("volume has reached Failed status")
 				}
+				return nil
 			}
 		}
 	}
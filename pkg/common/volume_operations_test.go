@@ -37,6 +37,7 @@ import (
 	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
 	"github.com/dell/csi-baremetal/pkg/base/featureconfig"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/rpc"
 	"github.com/dell/csi-baremetal/pkg/base/util"
 	"github.com/dell/csi-baremetal/pkg/mocks"
 )
@@ -102,6 +103,223 @@ func TestVolumeOperationsImpl_CreateVolume_HDDVolumeCreated(t *testing.T) {
 	assert.Equal(t, expectedVolume, createdVolume)
 }
 
+func TestVolumeOperationsImpl_CreateVolume_WithMetadata(t *testing.T) {
+	var (
+		svc        = setupVOOperationsTest(t)
+		volumeID   = "pvc-aaaa-bbbb"
+		ctxWithID  = context.WithValue(testCtx, base.RequestUUID, volumeID)
+		expectedAC = &accrd.AvailableCapacity{
+			ObjectMeta: v1.ObjectMeta{Name: "testAC"},
+			Spec: api.AvailableCapacity{
+				Location:     testDrive1UUID,
+				NodeId:       testNode1Name,
+				StorageClass: apiV1.StorageClassHDD,
+				Size:         int64(util.GBYTE) * 42,
+			},
+		}
+		metadata = VolumeMetadata{
+			Labels:      map[string]string{"team": "storage"},
+			Annotations: map[string]string{"cost-center": "42"},
+		}
+	)
+
+	capMBuilder, capMMock := getCapacityManagerMock()
+	svc.capacityManagerBuilder = capMBuilder
+	capMMock.On("PlanVolumesPlacing", ctxWithID, mock.Anything).
+		Return(buildVolumePlacingPlan(testNode1Name, &api.Volume{Id: volumeID}, expectedAC), nil).Times(1)
+
+	_, err := svc.CreateVolume(WithVolumeMetadata(testCtx, metadata), api.Volume{
+		Id:           volumeID,
+		StorageClass: apiV1.StorageClassHDD,
+		Size:         int64(util.GBYTE),
+	})
+	assert.Nil(t, err)
+
+	volumeCR := &volumecrd.Volume{}
+	assert.Nil(t, svc.k8sClient.ReadCR(testCtx, volumeID, volumeCR))
+	assert.Equal(t, metadata.Labels, volumeCR.Labels)
+	assert.Equal(t, metadata.Annotations, volumeCR.Annotations)
+}
+
+func TestVolumeOperationsImpl_CreateVolume_SubDriveVolumeCreated(t *testing.T) {
+	var (
+		svc           = setupVOOperationsTest(t)
+		volumeID      = "pvc-aaaa-bbbb"
+		ctxWithID     = context.WithValue(testCtx, base.RequestUUID, volumeID)
+		requiredBytes = int64(util.GBYTE) + 1
+		acSize        = int64(util.GBYTE) * 42
+		expectedAC    = &accrd.AvailableCapacity{
+			ObjectMeta: v1.ObjectMeta{Name: "testAC"},
+			Spec: api.AvailableCapacity{
+				Location:     testDrive1UUID,
+				NodeId:       testNode1Name,
+				StorageClass: apiV1.StorageClassHDD,
+				Size:         acSize,
+			},
+		}
+	)
+	featureConf := featureconfig.NewFeatureConfig()
+	featureConf.Update(featureconfig.FeatureSubDriveAllocation, true)
+	svc.featureChecker = featureConf
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, expectedAC.Name, expectedAC))
+
+	capMBuilder, capMMock := getCapacityManagerMock()
+	svc.capacityManagerBuilder = capMBuilder
+	capMMock.On("PlanVolumesPlacing", ctxWithID, mock.Anything).
+		Return(buildVolumePlacingPlan(testNode1Name, &api.Volume{Id: volumeID}, expectedAC), nil).Times(1)
+
+	createdVolume, err := svc.CreateVolume(testCtx, api.Volume{
+		Id:           volumeID,
+		StorageClass: apiV1.StorageClassHDD,
+		Size:         requiredBytes,
+	})
+	assert.Nil(t, err)
+	// size is aligned by partition boundary
+	assert.Equal(t, int64(util.GBYTE)+int64(util.MBYTE), createdVolume.Size)
+	assert.Equal(t, apiV1.PartitionLayoutMulti, createdVolume.PartitionLayout)
+
+	// the rest of drive remains available
+	updatedAC := &accrd.AvailableCapacity{}
+	assert.Nil(t, svc.k8sClient.ReadCR(testCtx, expectedAC.Name, updatedAC))
+	assert.Equal(t, acSize-capacityplanner.SubDriveAllocatedSize(requiredBytes), updatedAC.Spec.Size)
+
+	// AC size is restored after volume deletion
+	svc.UpdateCRsAfterVolumeDeletion(testCtx, volumeID)
+	assert.Nil(t, svc.k8sClient.ReadCR(testCtx, expectedAC.Name, updatedAC))
+	assert.Equal(t, acSize, updatedAC.Spec.Size)
+}
+
+// Volume CR wasn't created, drive already has max amount of sub-drive volumes
+func TestVolumeOperationsImpl_CreateVolume_FailSubDriveDensity(t *testing.T) {
+	var (
+		svc        = setupVOOperationsTest(t)
+		volumeID   = "pvc-aaaa-bbbb"
+		ctxWithID  = context.WithValue(testCtx, base.RequestUUID, volumeID)
+		expectedAC = &accrd.AvailableCapacity{
+			ObjectMeta: v1.ObjectMeta{Name: "testAC"},
+			Spec: api.AvailableCapacity{
+				Location:     testDrive1UUID,
+				NodeId:       testNode1Name,
+				StorageClass: apiV1.StorageClassHDD,
+				Size:         int64(util.GBYTE) * 42,
+			},
+		}
+		volume = &api.Volume{Id: volumeID, StorageClass: apiV1.StorageClassHDD, Size: int64(util.GBYTE)}
+		err    error
+	)
+	featureConf := featureconfig.NewFeatureConfig()
+	featureConf.Update(featureconfig.FeatureSubDriveAllocation, true)
+	svc.featureChecker = featureConf
+	svc.densityPolicy, err = capacityplanner.NewDensityPolicy(1, 0)
+	assert.Nil(t, err)
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, expectedAC.Name, expectedAC))
+	existing := svc.k8sClient.ConstructVolumeCR("pvc-existing", api.Volume{
+		Id:              "pvc-existing",
+		Location:        testDrive1UUID,
+		NodeId:          testNode1Name,
+		PartitionLayout: apiV1.PartitionLayoutMulti,
+	})
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, existing.Name, existing))
+
+	capMBuilder, capMMock := getCapacityManagerMock()
+	svc.capacityManagerBuilder = capMBuilder
+	capMMock.On("PlanVolumesPlacing", ctxWithID, mock.Anything).
+		Return(buildVolumePlacingPlan(testNode1Name, volume, expectedAC), nil).Times(1)
+
+	createdVolume, err := svc.CreateVolume(testCtx, *volume)
+	assert.Nil(t, createdVolume)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// AC isn't changed
+	updatedAC := &accrd.AvailableCapacity{}
+	assert.Nil(t, svc.k8sClient.ReadCR(testCtx, expectedAC.Name, updatedAC))
+	assert.Equal(t, expectedAC.Spec.Size, updatedAC.Spec.Size)
+}
+
+// Volume CR wasn't created, drive is being removed
+// Volume CR was created by another controller replica while request was processed
+func TestVolumeOperationsImpl_CreateVolume_CreatedConcurrently(t *testing.T) {
+	var (
+		svc        = setupVOOperationsTest(t)
+		volumeID   = "pvc-aaaa-bbbb"
+		ctxWithID  = context.WithValue(testCtx, base.RequestUUID, volumeID)
+		expectedAC = &accrd.AvailableCapacity{
+			ObjectMeta: v1.ObjectMeta{Name: "testAC"},
+			Spec: api.AvailableCapacity{
+				Location:     testDrive1UUID,
+				NodeId:       testNode1Name,
+				StorageClass: apiV1.StorageClassHDD,
+				Size:         int64(util.GBYTE) * 42,
+			},
+		}
+		volume = &api.Volume{Id: volumeID, StorageClass: apiV1.StorageClassHDD, Size: int64(util.GBYTE)}
+	)
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, expectedAC.Name, expectedAC))
+	concurrent := svc.k8sClient.ConstructVolumeCR(volumeID, api.Volume{
+		Id:        volumeID,
+		Location:  testDrive2UUID,
+		NodeId:    testNode2Name,
+		CSIStatus: apiV1.Creating,
+	})
+
+	capMBuilder, capMMock := getCapacityManagerMock()
+	svc.capacityManagerBuilder = capMBuilder
+	capMMock.On("PlanVolumesPlacing", ctxWithID, mock.Anything).
+		Run(func(mock.Arguments) {
+			assert.Nil(t, svc.k8sClient.CreateCR(testCtx, volumeID, concurrent))
+		}).
+		Return(buildVolumePlacingPlan(testNode1Name, volume, expectedAC), nil).Times(1)
+
+	createdVolume, err := svc.CreateVolume(testCtx, *volume)
+	assert.Nil(t, err)
+	assert.Equal(t, &concurrent.Spec, createdVolume)
+
+	// capacity allocated by the request is returned to AC
+	updatedAC := &accrd.AvailableCapacity{}
+	assert.Nil(t, svc.k8sClient.ReadCR(testCtx, expectedAC.Name, updatedAC))
+	assert.Equal(t, int64(util.GBYTE)*42, updatedAC.Spec.Size)
+}
+
+func TestVolumeOperationsImpl_CreateVolume_FailDriveRemoving(t *testing.T) {
+	var (
+		svc        = setupVOOperationsTest(t)
+		volumeID   = "pvc-aaaa-bbbb"
+		ctxWithID  = context.WithValue(testCtx, base.RequestUUID, volumeID)
+		expectedAC = &accrd.AvailableCapacity{
+			ObjectMeta: v1.ObjectMeta{Name: "testAC", Namespace: testNS},
+			Spec: api.AvailableCapacity{
+				Location:     testDrive1UUID,
+				NodeId:       testNode1Name,
+				StorageClass: apiV1.StorageClassHDD,
+				Size:         int64(util.GBYTE) * 42,
+			},
+		}
+		volume = &api.Volume{Id: volumeID, StorageClass: apiV1.StorageClassHDD, Size: int64(util.GBYTE)}
+	)
+
+	drive := svc.k8sClient.ConstructDriveCR(testDrive1UUID, api.Drive{
+		UUID:              testDrive1UUID,
+		NodeId:            testNode1Name,
+		Status:            apiV1.DriveStatusOffline,
+		OperationalStatus: apiV1.DriveOpStatusRemoving,
+	})
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, testDrive1UUID, drive))
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, expectedAC.Name, expectedAC))
+
+	capMBuilder, capMMock := getCapacityManagerMock()
+	svc.capacityManagerBuilder = capMBuilder
+	capMMock.On("PlanVolumesPlacing", ctxWithID, mock.Anything).
+		Return(buildVolumePlacingPlan(testNode1Name, volume, expectedAC), nil).Times(1)
+
+	createdVolume, err := svc.CreateVolume(testCtx, *volume)
+	assert.Nil(t, createdVolume)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	// stale AC is removed
+	err = svc.k8sClient.ReadCR(testCtx, expectedAC.Name, &accrd.AvailableCapacity{})
+	assert.True(t, k8sError.IsNotFound(err))
+}
+
 // Volume CR was successfully created, HDDLVG SC
 func TestVolumeOperationsImpl_CreateVolume_HDDLVGVolumeCreated(t *testing.T) {
 	var (
@@ -372,6 +590,7 @@ func TestVolumeOperationsImpl_WaitStatus_Fails(t *testing.T) {
 	// volume CR wasn't found scenario
 	err := svc.WaitStatus(testCtx, "unknown_name", apiV1.Created)
 	assert.NotNil(t, err)
+	assert.Equal(t, codes.NotFound, rpc.StatusCode(err))
 	// ctx is done scenario
 	err = svc.k8sClient.CreateCR(testCtx, testVolume1Name, &testVolume1)
 	assert.Nil(t, err)
@@ -383,6 +602,11 @@ func TestVolumeOperationsImpl_WaitStatus_Fails(t *testing.T) {
 	// volume CR wasn't found
 	err = svc.WaitStatus(ctx, testVolume1Name, apiV1.Created)
 	assert.NotNil(t, err)
+	assert.Equal(t, codes.Canceled, rpc.StatusCode(err))
+
+	// context of CSI call is done, error isn't converted to Aborted
+	err = WaitStatusOrAbort(ctx, svc, testVolume1Name, apiV1.Created)
+	assert.Equal(t, codes.Canceled, rpc.StatusCode(err))
 }
 
 func TestVolumeOperationsImpl_UpdateCRsAfterVolumeDeletion(t *testing.T) {
@@ -465,7 +689,7 @@ func setupVOOperationsTest(t *testing.T) *VolumeOperationsImpl {
 	assert.Nil(t, err)
 	assert.NotNil(t, k8sClient)
 
-	return NewVolumeOperationsImpl(k8sClient, testLogger, featureconfig.NewFeatureConfig())
+	return NewVolumeOperationsImpl(k8sClient, testLogger, featureconfig.NewFeatureConfig(), nil, nil)
 }
 
 func buildVolumePlacingPlan(node string, vol *api.Volume,
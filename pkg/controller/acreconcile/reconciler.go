@@ -0,0 +1,241 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package acreconcile contains reconciler which corrects sizes of AvailableCapacity CRs according to volumes
+package acreconcile
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/common"
+)
+
+// DefaultInterval is the default interval between two passes of reconciliation
+const DefaultInterval = 5 * time.Minute
+
+// Reconciler periodically calculates sizes of ACs from sizes of drives and LVGs and volumes allocated on them
+// and corrects ACs which differ. Volume operations change AC and Volume CRs one after another, so AC is corrected
+// only if the same difference is observed by two passes in a row
+type Reconciler struct {
+	k8sClient *k8s.KubeClient
+	// changes of AC sizes which aren't written yet, could be nil
+	batcher  *common.ACBatcher
+	interval time.Duration
+	// key - AC name, value - difference observed by the previous pass
+	drifts map[string]drift
+
+	log *logrus.Entry
+}
+
+// drift is the difference between size of AC and calculated one
+type drift struct {
+	size     int64
+	expected int64
+}
+
+// NewReconciler is the constructor for Reconciler struct
+// Receives an instance of base.KubeClient, AC batcher of volume operations which could be nil, logrus logger
+// and interval between passes, DefaultInterval is used if interval isn't positive
+// Returns an instance of Reconciler
+func NewReconciler(k8sClient *k8s.KubeClient, batcher *common.ACBatcher, logger *logrus.Logger,
+	interval time.Duration) *Reconciler {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Reconciler{
+		k8sClient: k8sClient,
+		batcher:   batcher,
+		interval:  interval,
+		drifts:    make(map[string]drift),
+		log:       logger.WithField("component", "ACReconciler"),
+	}
+}
+
+// Run spawns goroutine which periodically reconciles ACs
+func (r *Reconciler) Run() {
+	go func() {
+		for {
+			time.Sleep(r.interval)
+			ctx, cancelFn := context.WithTimeout(context.Background(), r.interval)
+			if err := r.Reconcile(ctx); err != nil {
+				r.log.WithField("method", "Run").Errorf("Unable to reconcile ACs: %v", err)
+			}
+			cancelFn()
+		}
+	}()
+}
+
+// Reconcile calculates sizes of ACs and corrects ACs which differ from calculated sizes twice in a row
+// Returns error if CRs weren't read or at least one AC wasn't corrected
+func (r *Reconciler) Reconcile(ctx context.Context) error {
+	ll := r.log.WithField("method", "Reconcile")
+
+	var (
+		acs    = &accrd.AvailableCapacityList{}
+		drives = &drivecrd.DriveList{}
+		lvgs   = &lvgcrd.LVGList{}
+	)
+	for _, list := range []runtime.Object{acs, drives, lvgs} {
+		if err := r.k8sClient.ReadList(ctx, list); err != nil {
+			return err
+		}
+	}
+	// volumes are the largest set, they are read page by page and only their usage is kept
+	usage := newVolumeUsage()
+	volumes := &volumecrd.VolumeList{}
+	err := r.k8sClient.ReadListPaged(ctx, volumes, k8s.DefaultPageSize, func() error {
+		usage.add(volumes.Items)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	expected := calculateSizes(drives.Items, lvgs.Items, usage)
+	drifts := make(map[string]drift)
+	var lastErr error
+	for i := range acs.Items {
+		ac := &acs.Items[i]
+		size, ok := expected[ac.Spec.Location]
+		if !ok || size == ac.Spec.Size {
+			continue
+		}
+		if r.batcher != nil && r.batcher.Pending(ac.Name) != 0 {
+			// AC is being changed by volume operation
+			continue
+		}
+		current := drift{size: ac.Spec.Size, expected: size}
+		if r.drifts[ac.Name] != current {
+			drifts[ac.Name] = current
+			continue
+		}
+		ll.Warnf("Size of AC %s is %d, but %d is calculated from volumes, correcting", ac.Name, ac.Spec.Size, size)
+		ac.Spec.Size = size
+		if err := r.k8sClient.UpdateCR(ctx, ac); err != nil && !k8sError.IsNotFound(err) {
+			ll.Errorf("Unable to correct size of AC %s: %v", ac.Name, err)
+			lastErr = err
+		}
+	}
+	r.drifts = drifts
+	return lastErr
+}
+
+// volumeUsage is space of locations taken by volumes
+type volumeUsage struct {
+	// key - location, value - space taken by volumes
+	used map[string]int64
+	// locations which are taken by volume entirely
+	whole map[string]struct{}
+}
+
+func newVolumeUsage() *volumeUsage {
+	return &volumeUsage{used: make(map[string]int64), whole: make(map[string]struct{})}
+}
+
+// add accounts space taken by provided volumes
+func (u *volumeUsage) add(volumes []volumecrd.Volume) {
+	for _, v := range volumes {
+		switch {
+		case v.Spec.LocationType == apiV1.LocationTypeLVM:
+			u.used[v.Spec.Location] += v.Spec.Size
+		case v.Spec.PartitionLayout == apiV1.PartitionLayoutMulti:
+			u.used[v.Spec.Location] += capacityplanner.SubDriveAllocatedSize(v.Spec.Size)
+		default:
+			u.whole[v.Spec.Location] = struct{}{}
+		}
+	}
+}
+
+// calculateSizes calculates sizes of ACs from sizes of drives and LVGs and space taken by volumes on them.
+// Locations in transitional states and system drives with LVGs on them are skipped,
+// drives which are used by LVG or by volume without partitions aren't expected to have capacity
+// Returns map with key - AC location, value - size
+func calculateSizes(drives []drivecrd.Drive, lvgs []lvgcrd.LVG, usage *volumeUsage) map[string]int64 {
+	var (
+		used  = usage.used
+		whole = usage.whole
+		// key - drive UUID, value - LVG which uses the drive
+		drivesLVG = make(map[string]*lvgcrd.LVG)
+		system    = make(map[string]struct{})
+		sizes     = make(map[string]int64)
+	)
+	for _, d := range drives {
+		if d.Spec.IsSystem {
+			system[d.Spec.UUID] = struct{}{}
+		}
+	}
+	for i := range lvgs {
+		lvg := &lvgs[i]
+		for _, location := range lvg.Spec.Locations {
+			drivesLVG[location] = lvg
+		}
+	}
+
+	for i := range lvgs {
+		lvg := &lvgs[i]
+		if lvg.Spec.Status != apiV1.Created || isSystem(lvg.Spec.Locations, system) {
+			continue
+		}
+		sizes[lvg.Name] = nonNegative(lvg.Spec.Size - used[lvg.Name])
+	}
+	for _, d := range drives {
+		if _, ok := system[d.Spec.UUID]; ok {
+			continue
+		}
+		if d.Spec.Health != apiV1.HealthGood || d.Spec.Status != apiV1.DriveStatusOnline {
+			continue
+		}
+		if _, ok := whole[d.Spec.UUID]; ok {
+			continue
+		}
+		if lvg, ok := drivesLVG[d.Spec.UUID]; ok {
+			if lvg.Spec.Status == apiV1.Created {
+				sizes[d.Spec.UUID] = 0
+			}
+			continue
+		}
+		sizes[d.Spec.UUID] = nonNegative(d.Spec.Size - used[d.Spec.UUID])
+	}
+	return sizes
+}
+
+func isSystem(locations []string, system map[string]struct{}) bool {
+	for _, location := range locations {
+		if _, ok := system[location]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func nonNegative(size int64) int64 {
+	if size < 0 {
+		return 0
+	}
+	return size
+}
@@ -0,0 +1,121 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acreconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/common"
+)
+
+var (
+	testCtx    = context.Background()
+	testLogger = logrus.New()
+	testNs     = "default"
+	testNodeID = "node-uuid"
+)
+
+func TestReconciler_Reconcile(t *testing.T) {
+	k8sClient, err := k8s.GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+	batcher := common.NewACBatcher(k8sClient, testLogger, 0)
+	r := NewReconciler(k8sClient, batcher, testLogger, 0)
+
+	drive := k8sClient.ConstructDriveCR("drive-1", api.Drive{UUID: "drive-1", NodeId: testNodeID,
+		Type: apiV1.DriveTypeHDD, Health: apiV1.HealthGood, Status: apiV1.DriveStatusOnline, Size: 1000})
+	assert.Nil(t, k8sClient.CreateCR(testCtx, drive.Name, drive))
+	volume := k8sClient.ConstructVolumeCR("volume-1", api.Volume{Id: "volume-1", NodeId: testNodeID,
+		Location: "drive-1", Size: 100, LocationType: apiV1.LocationTypeDrive,
+		PartitionLayout: apiV1.PartitionLayoutMulti})
+	assert.Nil(t, k8sClient.CreateCR(testCtx, volume.Name, volume))
+	expected := 1000 - capacityplanner.SubDriveAllocatedSize(100)
+
+	// capacity of volume isn't returned to AC
+	ac := k8sClient.ConstructACCR("ac-1", api.AvailableCapacity{Location: "drive-1", NodeId: testNodeID,
+		StorageClass: apiV1.StorageClassHDD, Size: 500})
+	assert.Nil(t, k8sClient.CreateCR(testCtx, ac.Name, ac))
+
+	// the first pass only observes difference
+	assert.Nil(t, r.Reconcile(testCtx))
+	stored := &accrd.AvailableCapacity{}
+	assert.Nil(t, k8sClient.ReadCR(testCtx, ac.Name, stored))
+	assert.Equal(t, int64(500), stored.Spec.Size)
+
+	// AC isn't corrected while it is being changed
+	batcher.Add(ac.Name, -10)
+	assert.Nil(t, r.Reconcile(testCtx))
+	assert.Nil(t, k8sClient.ReadCR(testCtx, ac.Name, stored))
+	assert.Equal(t, int64(500), stored.Spec.Size)
+	batcher.Add(ac.Name, 10)
+
+	assert.Nil(t, r.Reconcile(testCtx))
+	assert.Nil(t, r.Reconcile(testCtx))
+	assert.Nil(t, k8sClient.ReadCR(testCtx, ac.Name, stored))
+	assert.Equal(t, expected, stored.Spec.Size)
+}
+
+func TestCalculateSizes(t *testing.T) {
+	k8sClient, err := k8s.GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+
+	var drives []drivecrd.Drive
+	for _, d := range []api.Drive{
+		// free drive
+		{UUID: "drive-1", Health: apiV1.HealthGood, Status: apiV1.DriveStatusOnline, Size: 1000},
+		// drive of LVG
+		{UUID: "drive-2", Health: apiV1.HealthGood, Status: apiV1.DriveStatusOnline, Size: 1000},
+		// drive which is taken by volume
+		{UUID: "drive-3", Health: apiV1.HealthGood, Status: apiV1.DriveStatusOnline, Size: 1000},
+		{UUID: "drive-4", Health: apiV1.HealthBad, Status: apiV1.DriveStatusOnline, Size: 1000},
+		{UUID: "system", Health: apiV1.HealthGood, Status: apiV1.DriveStatusOnline, Size: 1000, IsSystem: true},
+	} {
+		drives = append(drives, *k8sClient.ConstructDriveCR(d.UUID, d))
+	}
+	lvgs := []lvgcrd.LVG{
+		*k8sClient.ConstructLVGCR("lvg-1", api.LogicalVolumeGroup{Name: "lvg-1", Locations: []string{"drive-2"},
+			Size: 1000, Status: apiV1.Created}),
+		*k8sClient.ConstructLVGCR("lvg-system", api.LogicalVolumeGroup{Name: "lvg-system",
+			Locations: []string{"system"}, Size: 500, Status: apiV1.Created}),
+	}
+	volumes := []volumecrd.Volume{
+		*k8sClient.ConstructVolumeCR("volume-1", api.Volume{Location: "lvg-1", Size: 300,
+			LocationType: apiV1.LocationTypeLVM}),
+		*k8sClient.ConstructVolumeCR("volume-2", api.Volume{Location: "drive-3", Size: 1000,
+			LocationType: apiV1.LocationTypeDrive}),
+	}
+
+	usage := newVolumeUsage()
+	usage.add(volumes)
+	sizes := calculateSizes(drives, lvgs, usage)
+	assert.Equal(t, map[string]int64{
+		"drive-1": 1000,
+		"drive-2": 0,
+		"lvg-1":   700,
+	}, sizes)
+}
@@ -0,0 +1,174 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package capacityhistory contains recorder which keeps history of capacity usage in CapacityHistory CRs
+package capacityhistory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/capacityhistorycrd"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/controller/rebalance"
+)
+
+const (
+	// DefaultInterval is the default interval between two snapshots of capacity
+	DefaultInterval = time.Hour
+	// DefaultMaxSamples is the default amount of snapshots which are kept per storage class, a week for DefaultInterval
+	DefaultMaxSamples = 168
+)
+
+// Recorder periodically takes snapshots of used and free capacity of each storage class on each node
+// and keeps them in CapacityHistory CR of the node together with high-water mark of used capacity
+type Recorder struct {
+	k8sClient *k8s.KubeClient
+	// interval between two snapshots
+	interval time.Duration
+	// maxSamples is the amount of snapshots which are kept per storage class, the oldest ones are dropped
+	maxSamples int
+
+	log *logrus.Entry
+}
+
+// NewRecorder is the constructor for Recorder struct
+// Receives an instance of base.KubeClient, logrus logger, interval between snapshots and amount of kept snapshots,
+// default values are used if interval or maxSamples aren't positive
+// Returns an instance of Recorder
+func NewRecorder(k8sClient *k8s.KubeClient, logger *logrus.Logger, interval time.Duration,
+	maxSamples int) *Recorder {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if maxSamples <= 0 {
+		maxSamples = DefaultMaxSamples
+	}
+	return &Recorder{
+		k8sClient:  k8sClient,
+		interval:   interval,
+		maxSamples: maxSamples,
+		log:        logger.WithField("component", "CapacityHistoryRecorder"),
+	}
+}
+
+// Run spawns goroutine which periodically records snapshots of capacity
+func (r *Recorder) Run() {
+	go func() {
+		for {
+			ctx, cancelFn := context.WithTimeout(context.Background(), r.interval)
+			if err := r.Record(ctx); err != nil {
+				r.log.WithField("method", "Run").Errorf("Unable to record capacity history: %v", err)
+			}
+			cancelFn()
+			time.Sleep(r.interval)
+		}
+	}()
+}
+
+// Record takes snapshot of utilization of each storage class and appends it to CapacityHistory CRs of the nodes
+// Returns error if utilization can't be calculated or at least one CR wasn't saved
+func (r *Recorder) Record(ctx context.Context) error {
+	ll := r.log.WithField("method", "Record")
+
+	utilization, err := rebalance.GetUtilization(ctx, r.k8sClient)
+	if err != nil {
+		return err
+	}
+
+	// key - node ID
+	byNode := make(map[string][]rebalance.Utilization)
+	for _, u := range utilization {
+		byNode[u.NodeID] = append(byNode[u.NodeID], u)
+	}
+
+	now := metav1.Now()
+	var lastErr error
+	for nodeID, list := range byNode {
+		if err = r.recordNode(ctx, nodeID, list, now); err != nil {
+			ll.Errorf("Unable to record capacity history of node %s: %v", nodeID, err)
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// recordNode appends snapshot of utilization to CapacityHistory CR of the node, CR is created if it doesn't exist
+func (r *Recorder) recordNode(ctx context.Context, nodeID string, utilization []rebalance.Utilization,
+	now metav1.Time) error {
+	history := &capacityhistorycrd.CapacityHistory{}
+	err := r.k8sClient.ReadCR(ctx, nodeID, history)
+	if err != nil && !k8sError.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+	if !exists {
+		history = &capacityhistorycrd.CapacityHistory{
+			TypeMeta: metav1.TypeMeta{Kind: apiV1.CapacityHistoryKind, APIVersion: apiV1.APIV1Version},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      nodeID,
+				Namespace: r.k8sClient.Namespace,
+			},
+			Spec: capacityhistorycrd.CapacityHistorySpec{NodeID: nodeID},
+		}
+	}
+
+	for _, u := range utilization {
+		r.appendSample(&history.Spec, u, now)
+	}
+	sort.Slice(history.Spec.StorageClasses, func(i, j int) bool {
+		return history.Spec.StorageClasses[i].StorageClass < history.Spec.StorageClasses[j].StorageClass
+	})
+
+	if exists {
+		return r.k8sClient.UpdateCR(ctx, history)
+	}
+	return r.k8sClient.CreateCR(ctx, nodeID, history)
+}
+
+// appendSample appends snapshot of utilization to history of its storage class, drops the oldest snapshots
+// which exceed maxSamples and raises high-water mark if used capacity is above it
+func (r *Recorder) appendSample(spec *capacityhistorycrd.CapacityHistorySpec, u rebalance.Utilization,
+	now metav1.Time) {
+	var scHistory *capacityhistorycrd.StorageClassHistory
+	for i := range spec.StorageClasses {
+		if spec.StorageClasses[i].StorageClass == u.StorageClass {
+			scHistory = &spec.StorageClasses[i]
+			break
+		}
+	}
+	if scHistory == nil {
+		spec.StorageClasses = append(spec.StorageClasses,
+			capacityhistorycrd.StorageClassHistory{StorageClass: u.StorageClass})
+		scHistory = &spec.StorageClasses[len(spec.StorageClasses)-1]
+	}
+
+	used := u.Total - u.Free
+	scHistory.Samples = append(scHistory.Samples, capacityhistorycrd.CapacitySample{Time: now, Used: used, Free: u.Free})
+	if len(scHistory.Samples) > r.maxSamples {
+		scHistory.Samples = scHistory.Samples[len(scHistory.Samples)-r.maxSamples:]
+	}
+	if used > scHistory.HighWaterMark {
+		scHistory.HighWaterMark = used
+		scHistory.HighWaterMarkTime = now
+	}
+}
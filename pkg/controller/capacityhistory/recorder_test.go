@@ -0,0 +1,77 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacityhistory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/capacityhistorycrd"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+)
+
+var (
+	testCtx    = context.Background()
+	testLogger = logrus.New()
+	testNs     = "default"
+	testNodeID = "node-uuid"
+)
+
+func TestRecorder_Record(t *testing.T) {
+	k8sClient, err := k8s.GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+	r := NewRecorder(k8sClient, testLogger, 0, 2)
+
+	drive := k8sClient.ConstructDriveCR("drive-1", api.Drive{UUID: "drive-1", NodeId: testNodeID,
+		Type: apiV1.DriveTypeHDD, Health: apiV1.HealthGood, Size: 100})
+	assert.Nil(t, k8sClient.CreateCR(testCtx, drive.Name, drive))
+	ac := k8sClient.ConstructACCR("ac-1", api.AvailableCapacity{Location: "drive-1", NodeId: testNodeID,
+		StorageClass: apiV1.StorageClassHDD, Size: 60})
+	assert.Nil(t, k8sClient.CreateCR(testCtx, ac.Name, ac))
+
+	assert.Nil(t, r.Record(testCtx))
+	history := &capacityhistorycrd.CapacityHistory{}
+	assert.Nil(t, k8sClient.ReadCR(testCtx, testNodeID, history))
+	assert.Len(t, history.Spec.StorageClasses, 1)
+	scHistory := history.Spec.StorageClasses[0]
+	assert.Equal(t, apiV1.StorageClassHDD, scHistory.StorageClass)
+	assert.Equal(t, int64(40), scHistory.HighWaterMark)
+	assert.Len(t, scHistory.Samples, 1)
+	assert.Equal(t, int64(40), scHistory.Samples[0].Used)
+	assert.Equal(t, int64(60), scHistory.Samples[0].Free)
+
+	// capacity is used up and released, high-water mark is kept
+	ac.Spec.Size = 0
+	assert.Nil(t, k8sClient.UpdateCR(testCtx, ac))
+	assert.Nil(t, r.Record(testCtx))
+	ac.Spec.Size = 90
+	assert.Nil(t, k8sClient.UpdateCR(testCtx, ac))
+	assert.Nil(t, r.Record(testCtx))
+
+	assert.Nil(t, k8sClient.ReadCR(testCtx, testNodeID, history))
+	scHistory = history.Spec.StorageClasses[0]
+	assert.Equal(t, int64(100), scHistory.HighWaterMark)
+	// the oldest sample is dropped
+	assert.Len(t, scHistory.Samples, 2)
+	assert.Equal(t, int64(100), scHistory.Samples[0].Used)
+	assert.Equal(t, int64(10), scHistory.Samples[1].Used)
+}
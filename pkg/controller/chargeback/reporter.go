@@ -0,0 +1,212 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package chargeback contains reporter which aggregates capacity provisioned by volumes per namespace and per label
+package chargeback
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	coreV1 "k8s.io/api/core/v1"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+)
+
+const (
+	// DefaultInterval is the default interval between two reports
+	DefaultInterval = 10 * time.Minute
+	// ReportPattern is the path of HTTP endpoint which serves the last report
+	ReportPattern = "/chargeback"
+	// NamespaceKey is the key of groups of volumes by namespace of their PVC
+	NamespaceKey = "namespace"
+	// unknownValue groups volumes without PVC or without label
+	unknownValue = ""
+)
+
+// Group is capacity provisioned by volumes which have the same value of the key
+type Group struct {
+	// Key is NamespaceKey or key of Volume CR label
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	// Volumes is the amount of volumes in the group
+	Volumes int `json:"volumes"`
+	// Provisioned is the size of volumes in bytes
+	Provisioned int64 `json:"provisioned"`
+}
+
+// Report is capacity provisioned by volumes grouped by namespace and by each label key
+type Report struct {
+	Time   time.Time `json:"time"`
+	Groups []Group   `json:"groups"`
+}
+
+// Reporter periodically aggregates size of volumes per namespace of their PVC and per value of Volume CR labels,
+// labels are copied to Volume CRs from PVCs on creation of volumes. The last report is logged and served over HTTP
+type Reporter struct {
+	k8sClient *k8s.KubeClient
+	// keys of Volume CR labels by which volumes are grouped
+	labelKeys []string
+	interval  time.Duration
+
+	mu   sync.Mutex
+	last *Report
+
+	log *logrus.Entry
+}
+
+// NewReporter is the constructor for Reporter struct
+// Receives an instance of base.KubeClient, logrus logger, comma separated keys of labels by which volumes are grouped
+// and interval between reports, DefaultInterval is used if interval isn't positive
+// Returns an instance of Reporter
+func NewReporter(k8sClient *k8s.KubeClient, logger *logrus.Logger, labelKeys string,
+	interval time.Duration) *Reporter {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	r := &Reporter{
+		k8sClient: k8sClient,
+		interval:  interval,
+		log:       logger.WithField("component", "ChargebackReporter"),
+	}
+	for _, key := range strings.Split(labelKeys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			r.labelKeys = append(r.labelKeys, key)
+		}
+	}
+	return r
+}
+
+// Run spawns goroutine which periodically generates reports
+func (r *Reporter) Run() {
+	go func() {
+		for {
+			ctx, cancelFn := context.WithTimeout(context.Background(), r.interval)
+			if _, err := r.Generate(ctx); err != nil {
+				r.log.WithField("method", "Run").Errorf("Unable to generate chargeback report: %v", err)
+			}
+			cancelFn()
+			time.Sleep(r.interval)
+		}
+	}()
+}
+
+// Generate aggregates size of volumes which aren't removed and keeps the report as the last one
+// Returns report or error if volumes or PVs weren't read
+func (r *Reporter) Generate(ctx context.Context) (*Report, error) {
+	ll := r.log.WithField("method", "Generate")
+
+	// PV of volume has the same name as Volume CR and refers to PVC
+	pvs := &coreV1.PersistentVolumeList{}
+	if err := r.k8sClient.List(ctx, pvs); err != nil {
+		return nil, err
+	}
+	// key - volume ID, value - namespace of PVC
+	namespaces := make(map[string]string, len(pvs.Items))
+	for _, pv := range pvs.Items {
+		if pv.Spec.ClaimRef != nil {
+			namespaces[pv.Name] = pv.Spec.ClaimRef.Namespace
+		}
+	}
+
+	// key - group key, value - groups by value
+	groups := make(map[string]map[string]*Group)
+	add := func(key, value string, size int64) {
+		if groups[key] == nil {
+			groups[key] = make(map[string]*Group)
+		}
+		g, ok := groups[key][value]
+		if !ok {
+			g = &Group{Key: key, Value: value}
+			groups[key][value] = g
+		}
+		g.Volumes++
+		g.Provisioned += size
+	}
+
+	volumes := &volumecrd.VolumeList{}
+	err := r.k8sClient.ReadListPaged(ctx, volumes, k8s.DefaultPageSize, func() error {
+		for _, v := range volumes.Items {
+			if v.Spec.CSIStatus == apiV1.Removed {
+				continue
+			}
+			add(NamespaceKey, namespaces[v.Name], v.Spec.Size)
+			for _, key := range r.labelKeys {
+				value, ok := v.Labels[key]
+				if !ok {
+					value = unknownValue
+				}
+				add(key, value, v.Spec.Size)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Time: time.Now(), Groups: make([]Group, 0)}
+	for _, byValue := range groups {
+		for _, g := range byValue {
+			report.Groups = append(report.Groups, *g)
+		}
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		if report.Groups[i].Key != report.Groups[j].Key {
+			return report.Groups[i].Key < report.Groups[j].Key
+		}
+		return report.Groups[i].Value < report.Groups[j].Value
+	})
+
+	r.mu.Lock()
+	r.last = report
+	r.mu.Unlock()
+	ll.Infof("Report with %d groups is generated", len(report.Groups))
+	for _, g := range report.Groups {
+		ll.Debugf("%s=%s: %d volumes, %d bytes provisioned", g.Key, g.Value, g.Volumes, g.Provisioned)
+	}
+	return report, nil
+}
+
+// ServeHTTP writes the last report in JSON, report is generated if there is no report younger than interval
+func (r *Reporter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ll := r.log.WithField("method", "ServeHTTP")
+
+	r.mu.Lock()
+	report := r.last
+	r.mu.Unlock()
+	if report == nil || time.Since(report.Time) > r.interval {
+		var err error
+		if report, err = r.Generate(req.Context()); err != nil {
+			ll.Errorf("Unable to generate report: %v", err)
+			http.Error(w, "unable to generate report", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		ll.Errorf("Unable to write report: %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chargeback
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+)
+
+var (
+	testCtx    = context.Background()
+	testLogger = logrus.New()
+	testNs     = "default"
+)
+
+func createVolume(t *testing.T, k8sClient *k8s.KubeClient, name, claimNs string, size int64,
+	labels map[string]string, csiStatus string) {
+	volume := k8sClient.ConstructVolumeCR(name, api.Volume{Id: name, Size: size, CSIStatus: csiStatus})
+	volume.Labels = labels
+	assert.Nil(t, k8sClient.CreateCR(testCtx, name, volume))
+	if claimNs == "" {
+		return
+	}
+	pv := &coreV1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       coreV1.PersistentVolumeSpec{ClaimRef: &coreV1.ObjectReference{Namespace: claimNs, Name: "pvc"}},
+	}
+	assert.Nil(t, k8sClient.Create(testCtx, pv))
+}
+
+func TestReporter_Generate(t *testing.T) {
+	k8sClient, err := k8s.GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+	createVolume(t, k8sClient, "volume-1", "team-a", 100, map[string]string{"team": "a"}, apiV1.Published)
+	createVolume(t, k8sClient, "volume-2", "team-a", 50, map[string]string{"team": "a"}, apiV1.Created)
+	createVolume(t, k8sClient, "volume-3", "team-b", 10, nil, apiV1.Created)
+	// inline volume doesn't have PVC
+	createVolume(t, k8sClient, "volume-4", "", 1, nil, apiV1.Published)
+	// removed volume doesn't take capacity
+	createVolume(t, k8sClient, "volume-5", "team-b", 1000, nil, apiV1.Removed)
+
+	r := NewReporter(k8sClient, testLogger, "team", 0)
+	report, err := r.Generate(testCtx)
+	assert.Nil(t, err)
+	assert.Equal(t, []Group{
+		{Key: NamespaceKey, Value: "", Volumes: 1, Provisioned: 1},
+		{Key: NamespaceKey, Value: "team-a", Volumes: 2, Provisioned: 150},
+		{Key: NamespaceKey, Value: "team-b", Volumes: 1, Provisioned: 10},
+		{Key: "team", Value: "", Volumes: 2, Provisioned: 11},
+		{Key: "team", Value: "a", Volumes: 2, Provisioned: 150},
+	}, report.Groups)
+}
+
+func TestReporter_ServeHTTP(t *testing.T) {
+	k8sClient, err := k8s.GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+	createVolume(t, k8sClient, "volume-1", "team-a", 100, nil, apiV1.Created)
+	r := NewReporter(k8sClient, testLogger, "", 0)
+
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ReportPattern, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	report := &Report{}
+	assert.Nil(t, json.NewDecoder(recorder.Body).Decode(report))
+	assert.Equal(t, []Group{{Key: NamespaceKey, Value: "team-a", Volumes: 1, Provisioned: 100}}, report.Groups)
+}
@@ -28,20 +28,37 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
+	coreV1 "k8s.io/api/core/v1"
 	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
 	"github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
 	"github.com/dell/csi-baremetal/pkg/base/featureconfig"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/rpc"
 	"github.com/dell/csi-baremetal/pkg/base/util"
 	"github.com/dell/csi-baremetal/pkg/common"
 	"github.com/dell/csi-baremetal/pkg/controller/node"
+	"github.com/dell/csi-baremetal/pkg/controller/nodequeue"
 	csibmnodeconst "github.com/dell/csi-baremetal/pkg/crcontrollers/csibmnode/common"
 )
 
+const (
+	// PVCNameKey is the key in CreateVolumeRequest parameters which holds PVC name,
+	// it's passed by external-provisioner with --extra-create-metadata flag
+	PVCNameKey = "csi.storage.k8s.io/pvc/name"
+	// PVCNamespaceKey is the key in CreateVolumeRequest parameters which holds PVC namespace
+	PVCNamespaceKey = "csi.storage.k8s.io/pvc/namespace"
+	// pvcPrefix is the prefix of volume name which external-provisioner generates from PVC UID
+	pvcPrefix = "pvc-"
+)
+
 // NodeID is the type for node hostname
 type NodeID string
 
@@ -54,27 +71,45 @@ type CSIControllerService struct {
 	log   *logrus.Entry
 
 	svc common.VolumeOperations
+	// restricts and rounds requested size of volumes per storage class
+	sizePolicy *capacityplanner.SizePolicy
+	// defines file systems which could be expanded while volume is published
+	expansionPolicy *ExpansionPolicy
 
 	// to track node health status
 	nodeServicesStateMonitor *node.ServicesStateMonitor
+	// limits amount of concurrent creations and deletions of volumes per node, nil means unlimited
+	nodeQueue *nodequeue.Queue
+	// keys of PVC labels and annotations which are copied to Volume CR
+	pvcMetadataKeys []string
 
 	ready bool
+	// broadcasts serving status to clients of health Watch
+	healthBroadcaster *util.HealthBroadcaster
 
 	csi.IdentityServer
 	grpc_health_v1.HealthServer
 }
 
 // NewControllerService is the constructor for CSIControllerService struct
-// Receives an instance of base.KubeClient and logrus logger
+// Receives an instance of base.KubeClient, logrus logger, feature config, policy for ANY storage class resolution,
+// policy of volume size, policy of volume expansion and policy of volumes density, size isn't restricted
+// if sizePolicy is nil, default file systems are expanded online if expansionPolicy is nil,
+// only partition table restricts density if densityPolicy is nil
 // Returns an instance of CSIControllerService
 func NewControllerService(k8sClient *k8s.KubeClient, logger *logrus.Logger,
-	featureConf featureconfig.FeatureChecker) *CSIControllerService {
+	featureConf featureconfig.FeatureChecker, anyPolicy *capacityplanner.AnyPolicy,
+	sizePolicy *capacityplanner.SizePolicy, expansionPolicy *ExpansionPolicy,
+	densityPolicy *capacityplanner.DensityPolicy) *CSIControllerService {
 	c := &CSIControllerService{
 		k8sclient:                k8sClient,
 		log:                      logger.WithField("component", "CSIControllerService"),
-		svc:                      common.NewVolumeOperationsImpl(k8sClient, logger, featureConf),
+		svc:                      common.NewVolumeOperationsImpl(k8sClient, logger, featureConf, anyPolicy, densityPolicy),
+		sizePolicy:               sizePolicy,
+		expansionPolicy:          expansionPolicy,
 		nodeServicesStateMonitor: node.NewNodeServicesStateMonitor(k8sClient, logger),
-		IdentityServer:           NewIdentityServer(base.PluginName, base.PluginVersion),
+		IdentityServer:           NewIdentityServer(base.PluginName, base.PluginVersion, NewManifest(featureConf)),
+		healthBroadcaster:        util.NewHealthBroadcaster(),
 	}
 
 	// run health monitor
@@ -83,6 +118,49 @@ func NewControllerService(k8sClient *k8s.KubeClient, logger *logrus.Logger,
 	return c
 }
 
+// SetNodeOperationsLimit sets maximal amount of volumes which are created or deleted concurrently on one node,
+// other requests wait in the queue of the node, 0 means unlimited
+func (c *CSIControllerService) SetNodeOperationsLimit(limit int) {
+	if limit > 0 {
+		c.nodeQueue = nodequeue.NewQueue(limit)
+	} else {
+		c.nodeQueue = nil
+	}
+}
+
+// SetPVCMetadataKeys sets comma separated keys of labels and annotations which are copied from PVC to Volume CR
+// on its creation, e.g. app,team,cost-center
+func (c *CSIControllerService) SetPVCMetadataKeys(keys string) {
+	c.pvcMetadataKeys = nil
+	for _, key := range strings.Split(keys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			c.pvcMetadataKeys = append(c.pvcMetadataKeys, key)
+		}
+	}
+}
+
+// SetACBatcher makes volume operations accumulate changes of AC sizes in batcher instead of writing ACs on each change
+func (c *CSIControllerService) SetACBatcher(batcher *common.ACBatcher) {
+	if vo, ok := c.svc.(*common.VolumeOperationsImpl); ok {
+		vo.SetACBatcher(batcher)
+	}
+}
+
+// acquireNodeSlot waits in the queue of the node until operation of provided priority could be started
+// Returns function which must be called when operation is finished or Unavailable error if request context
+// was done before, so CO retries the request later
+func (c *CSIControllerService) acquireNodeSlot(ctx context.Context, nodeID string,
+	op nodequeue.Operation, priority nodequeue.Priority) (func(), error) {
+	if c.nodeQueue == nil || nodeID == "" {
+		return func() {}, nil
+	}
+	release, err := c.nodeQueue.Acquire(ctx, nodeID, op, priority)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "too many volume operations on node %s, retry later", nodeID)
+	}
+	return release, nil
+}
+
 // Probe is the implementation of CSI Spec Probe for IdentityServer.
 // This method checks if CSI driver is ready to serve requests
 // overrides same method from defaultIdentityServer struct
@@ -117,17 +195,19 @@ func (c *CSIControllerService) Check(context.Context, *grpc_health_v1.HealthChec
 	}
 	if len(c.nodeServicesStateMonitor.GetReadyPods()) > 0 {
 		c.ready = true
+		c.healthBroadcaster.SetStatus(grpc_health_v1.HealthCheckResponse_SERVING)
 		return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
 	}
 	c.ready = false
+	c.healthBroadcaster.SetStatus(grpc_health_v1.HealthCheckResponse_NOT_SERVING)
 	ll.Info("Controller svc is not ready yet")
 	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_NOT_SERVING}, nil
 }
 
 // Watch is used by clients to receive updates when the svc status changes.
-// Watch only dummy implemented just to satisfy the interface.
-func (c *CSIControllerService) Watch(*grpc_health_v1.HealthCheckRequest, grpc_health_v1.Health_WatchServer) error {
-	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+// Status is sent to client when it is changed by Check
+func (c *CSIControllerService) Watch(_ *grpc_health_v1.HealthCheckRequest, srv grpc_health_v1.Health_WatchServer) error {
+	return c.healthBroadcaster.Watch(srv)
 }
 
 // CreateVolume is the implementation of CSI Spec CreateVolume. If k8s SC of driver is set to WaitForFirstConsumer then
@@ -170,14 +250,42 @@ func (c *CSIControllerService) CreateVolume(ctx context.Context, req *csi.Create
 		return nil, status.Error(codes.Unimplemented, "Block mode is unimplemented")
 	}
 
+	storageClass := util.ConvertStorageClass(req.Parameters[base.StorageTypeKey])
+	size, err := c.sizePolicy.Apply(storageClass, req.GetCapacityRange().GetRequiredBytes())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if limit := req.GetCapacityRange().GetLimitBytes(); limit > 0 && size > limit {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"rounded size %d of volume exceeds limit %d", size, limit)
+	}
+
+	pvc, err := c.getPVC(ctx, req)
+	if err != nil {
+		ll.Errorf("Unable to read PVC: %v", err)
+		return nil, status.Error(codes.Internal, "unable to read PVC")
+	}
+	// volume of generic ephemeral PVC is owned by the pod for which PVC was created
+	owners := getEphemeralOwners(pvc)
+	ctx = common.WithVolumeMetadata(ctx, c.getVolumeMetadata(pvc))
+
+	release, err := c.acquireNodeSlot(ctx, preferredNode, nodequeue.Create, c.getProvisioningPriority(pvc))
+	if err != nil {
+		ll.Warnf("Request wasn't started: %v", err)
+		return nil, err
+	}
+	defer release()
+
 	c.reqMu.Lock()
 	vol, err = c.svc.CreateVolume(ctx, api.Volume{
 		Id:           req.Name,
-		StorageClass: util.ConvertStorageClass(req.Parameters[base.StorageTypeKey]),
+		StorageClass: storageClass,
+		StorageGroup: req.Parameters[base.StorageGroupKey],
 		NodeId:       preferredNode,
-		Size:         req.GetCapacityRange().GetRequiredBytes(),
+		Size:         size,
 		Mode:         mode,
 		Type:         fsType,
+		Owners:       owners,
 	})
 	c.reqMu.Unlock()
 
@@ -187,8 +295,9 @@ func (c *CSIControllerService) CreateVolume(ctx context.Context, req *csi.Create
 
 	if vol.CSIStatus == apiV1.Creating {
 		ll.Infof("Waiting until volume will reach Created status. Current status - %s", vol.CSIStatus)
-		if err := c.svc.WaitStatus(ctx, vol.Id, apiV1.Failed, apiV1.Created); err != nil {
-			return nil, status.Error(codes.Internal, "Unable to create volume")
+		if err := common.WaitStatusOrAbort(ctx, c.svc, vol.Id, apiV1.Failed, apiV1.Created); err != nil {
+			ll.Errorf("Volume hasn't reached Created status: %v", err)
+			return nil, rpc.ToStatus(err, "Unable to create volume")
 		}
 	}
 
@@ -224,10 +333,21 @@ func (c *CSIControllerService) DeleteVolume(ctx context.Context, req *csi.Delete
 	}
 	ctxWithID := context.WithValue(context.Background(), base.RequestUUID, req.VolumeId)
 
-	c.reqMu.Lock()
-	err := c.svc.DeleteVolume(ctxWithID, req.GetVolumeId())
-	c.reqMu.Unlock()
+	volume := &volumecrd.Volume{}
+	err := c.k8sclient.ReadCR(ctx, req.VolumeId, volume)
+	if err != nil && !k8sError.IsNotFound(err) {
+		ll.Errorf("Unable to read volume CR: %v", err)
+		return nil, status.Error(codes.Internal, "unable to read volume")
+	}
+	release, err := c.acquireNodeSlot(ctx, volume.Spec.NodeId, nodequeue.Delete, nodequeue.Normal)
+	if err != nil {
+		ll.Warnf("Request wasn't started: %v", err)
+		return nil, err
+	}
+	defer release()
 
+	// only Volume CR is updated here, so request isn't serialized with others
+	err = c.svc.DeleteVolume(ctxWithID, req.GetVolumeId())
 	if err != nil {
 		if k8sError.IsNotFound(err) {
 			ll.Infof("Volume doesn't exist")
@@ -236,8 +356,16 @@ func (c *CSIControllerService) DeleteVolume(ctx context.Context, req *csi.Delete
 		ll.Errorf("Unable to delete volume: %v", err)
 		return nil, err
 	}
-	if err = c.svc.WaitStatus(ctx, req.VolumeId, apiV1.Failed, apiV1.Removed); err != nil {
-		return nil, status.Error(codes.Internal, "Unable to delete volume")
+	// node returns volume to Created status if its removal is blocked by deletion protection
+	if err = common.WaitStatusOrAbort(ctx, c.svc, req.VolumeId, apiV1.Failed, apiV1.Removed, apiV1.Created); err != nil {
+		ll.Errorf("Volume hasn't reached Removed status: %v", err)
+		return nil, rpc.ToStatus(err, "Unable to delete volume")
+	}
+	if err = c.k8sclient.ReadCR(ctx, req.VolumeId, volume); err == nil && volume.Spec.CSIStatus == apiV1.Created {
+		ll.Warnf("Removal of volume is blocked by node")
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"removal of volume %s is blocked because its file system isn't empty, set annotation %s on Volume CR "+
+				"to allow it", req.VolumeId, apiV1.VolumeDataRemovalAnnotationKey)
 	}
 
 	c.reqMu.Lock()
@@ -249,8 +377,9 @@ func (c *CSIControllerService) DeleteVolume(ctx context.Context, req *csi.Delete
 	return &csi.DeleteVolumeResponse{}, nil
 }
 
-// ControllerPublishVolume is the implementation of CSI Spec ControllerPublishVolume. This method just checks existence
-// of provided Volume CR and returns success response if the Volume CR exists.
+// ControllerPublishVolume is the implementation of CSI Spec ControllerPublishVolume. Volumes are local for the node,
+// so there is nothing to attach and this method only validates request: node has to exist and Volume CR
+// has to be placed on the provided node.
 // Receives golang context and CSI Spec ControllerPublishVolumeRequest
 // Returns CSI Spec ControllerPublishVolumeResponse or error if something went wrong
 func (c *CSIControllerService) ControllerPublishVolume(ctx context.Context,
@@ -258,6 +387,7 @@ func (c *CSIControllerService) ControllerPublishVolume(ctx context.Context,
 	ll := c.log.WithFields(logrus.Fields{
 		"method":   "ControllerPublishVolume",
 		"volumeID": req.GetVolumeId(),
+		"nodeID":   req.GetNodeId(),
 	})
 
 	if req.NodeId == "" {
@@ -273,6 +403,15 @@ func (c *CSIControllerService) ControllerPublishVolume(ctx context.Context,
 			" must be provided")
 	}
 
+	exists, err := c.nodeExists(ctx, req.NodeId)
+	if err != nil {
+		ll.Errorf("k8s client can't read nodes: %v", err)
+		return nil, status.Error(codes.Unavailable, "Something went wrong with k8s client")
+	}
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "Node %s is not found", req.NodeId)
+	}
+
 	vol := &volumecrd.Volume{}
 	if err := c.k8sclient.ReadCR(ctx, req.VolumeId, vol); err != nil {
 		if k8sError.IsNotFound(err) {
@@ -282,13 +421,20 @@ func (c *CSIControllerService) ControllerPublishVolume(ctx context.Context,
 		return nil, status.Error(codes.Unavailable, "Something went wrong with k8s client")
 	}
 
+	if vol.Spec.NodeId != req.NodeId {
+		ll.Errorf("Volume is located on node %s", vol.Spec.NodeId)
+		return nil, status.Errorf(codes.FailedPrecondition, "Volume %s is located on another node %s",
+			req.VolumeId, vol.Spec.NodeId)
+	}
+
 	ll.Info("Return empty response, ok.")
 
 	return &csi.ControllerPublishVolumeResponse{}, nil
 }
 
 // ControllerUnpublishVolume is the implementation of CSI Spec ControllerUnpublishVolume.
-// This method just returns empty response.
+// There is nothing to detach, so this method returns empty response even if Volume CR or node are already removed,
+// mismatch between volume location and provided node is only logged.
 // Receives golang context and CSI Spec ControllerUnpublishVolumeRequest
 // Returns CSI Spec ControllerUnpublishVolumeResponse or error if Volume ID is not provided in request
 func (c *CSIControllerService) ControllerUnpublishVolume(ctx context.Context,
@@ -296,10 +442,25 @@ func (c *CSIControllerService) ControllerUnpublishVolume(ctx context.Context,
 	ll := c.log.WithFields(logrus.Fields{
 		"method":   "ControllerUnpublishVolume",
 		"volumeID": req.GetVolumeId(),
+		"nodeID":   req.GetNodeId(),
 	})
 
 	if req.VolumeId == "" {
-		return nil, status.Error(codes.InvalidArgument, "ControllerPublishVolume: Volume ID must be provided")
+		return nil, status.Error(codes.InvalidArgument, "ControllerUnpublishVolume: Volume ID must be provided")
+	}
+
+	vol := &volumecrd.Volume{}
+	if err := c.k8sclient.ReadCR(ctx, req.VolumeId, vol); err != nil {
+		if k8sError.IsNotFound(err) {
+			ll.Info("Volume is not found, consider it as unpublished")
+			return &csi.ControllerUnpublishVolumeResponse{}, nil
+		}
+		ll.Errorf("k8s client can't read volume CR: %v", err)
+		return nil, status.Error(codes.Unavailable, "Something went wrong with k8s client")
+	}
+
+	if req.NodeId != "" && vol.Spec.NodeId != req.NodeId {
+		ll.Warnf("Volume is located on node %s, it isn't published on the provided node", vol.Spec.NodeId)
 	}
 
 	ll.Info("Return empty response, ok")
@@ -307,6 +468,21 @@ func (c *CSIControllerService) ControllerUnpublishVolume(ctx context.Context,
 	return &csi.ControllerUnpublishVolumeResponse{}, nil
 }
 
+// nodeExists checks whether node with provided ID is present in cluster, k8s UID and CSIBMNode UUID
+// from annotation are used as node ID
+func (c *CSIControllerService) nodeExists(ctx context.Context, nodeID string) (bool, error) {
+	nodes, err := c.k8sclient.GetNodes(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, n := range nodes {
+		if string(n.UID) == nodeID || n.GetAnnotations()[csibmnodeconst.NodeIDAnnotationKey] == nodeID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // ValidateVolumeCapabilities is not implemented yet
 func (c *CSIControllerService) ValidateVolumeCapabilities(context.Context, *csi.ValidateVolumeCapabilitiesRequest) (*csi.ValidateVolumeCapabilitiesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "not implemented yet")
@@ -345,6 +521,7 @@ func (c *CSIControllerService) ControllerGetCapabilities(context.Context, *csi.C
 	for _, c := range []csi.ControllerServiceCapability_RPC_Type{
 		csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME,
 		csi.ControllerServiceCapability_RPC_PUBLISH_UNPUBLISH_VOLUME,
+		csi.ControllerServiceCapability_RPC_EXPAND_VOLUME,
 	} {
 		caps = append(caps, newCap(c))
 	}
@@ -373,7 +550,178 @@ func (c *CSIControllerService) ListSnapshots(context.Context, *csi.ListSnapshots
 	return nil, status.Error(codes.Unimplemented, "not implemented yet")
 }
 
-// ControllerExpandVolume is not implemented yet
-func (c *CSIControllerService) ControllerExpandVolume(context.Context, *csi.ControllerExpandVolumeRequest) (*csi.ControllerExpandVolumeResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "not implemented yet")
+// ControllerExpandVolume is the implementation of CSI Spec ControllerExpandVolume. Only volumes with LVG storage classes
+// could be expanded. This method takes required space from AC of LVG and sets new size to Volume CR,
+// logical volume and file system are expanded by node in NodeExpandVolume.
+// Volume with file system which can't be grown online must be unpublished before expansion.
+// Receives golang context and CSI Spec ControllerExpandVolumeRequest
+// Returns CSI Spec ControllerExpandVolumeResponse or error if something went wrong
+func (c *CSIControllerService) ControllerExpandVolume(ctx context.Context,
+	req *csi.ControllerExpandVolumeRequest) (*csi.ControllerExpandVolumeResponse, error) {
+	ll := c.log.WithFields(logrus.Fields{
+		"method":   "ControllerExpandVolume",
+		"volumeID": req.GetVolumeId(),
+	})
+	ll.Infof("Processing request: %v", req)
+
+	if req.GetVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume ID must be provided")
+	}
+	if req.GetCapacityRange() == nil {
+		return nil, status.Error(codes.InvalidArgument, "Capacity range must be provided")
+	}
+
+	c.reqMu.Lock()
+	defer c.reqMu.Unlock()
+
+	volumeCR := &volumecrd.Volume{}
+	if err := c.k8sclient.ReadCR(ctx, req.GetVolumeId(), volumeCR); err != nil {
+		if k8sError.IsNotFound(err) {
+			return nil, status.Error(codes.NotFound, "Volume is not found")
+		}
+		ll.Errorf("Unable to read volume CR: %v", err)
+		return nil, status.Error(codes.Internal, "unable to read volume")
+	}
+
+	var (
+		vol      = volumeCR.Spec
+		required = req.GetCapacityRange().GetRequiredBytes()
+	)
+	if required <= vol.Size {
+		ll.Infof("Volume has already size %d", vol.Size)
+		return &csi.ControllerExpandVolumeResponse{CapacityBytes: vol.Size, NodeExpansionRequired: true}, nil
+	}
+	if !util.IsStorageClassLVG(vol.StorageClass) {
+		return nil, status.Errorf(codes.OutOfRange,
+			"volume with storage class %s takes the whole drive and can't be expanded", vol.StorageClass)
+	}
+
+	required, err := c.sizePolicy.Apply(vol.StorageClass, capacityplanner.AlignSizeByPE(required))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if limit := req.GetCapacityRange().GetLimitBytes(); limit > 0 && required > limit {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"rounded size %d of volume exceeds limit %d", required, limit)
+	}
+
+	published := vol.CSIStatus == apiV1.VolumeReady || vol.CSIStatus == apiV1.Published
+	if published && !c.expansionPolicy.IsOnline(vol.Type) {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"volume with %s file system must be unpublished before expansion", vol.Type)
+	}
+
+	// take required space from AC of LVG
+	acList := &accrd.AvailableCapacityList{}
+	if err = c.k8sclient.ReadList(ctx, acList); err != nil {
+		ll.Errorf("Unable to read AC list: %v", err)
+		return nil, status.Error(codes.Internal, "unable to read available capacity")
+	}
+	var ac *accrd.AvailableCapacity
+	for i := range acList.Items {
+		if acList.Items[i].Spec.Location == vol.Location {
+			ac = &acList.Items[i]
+			break
+		}
+	}
+	delta := required - vol.Size
+	if ac == nil || ac.Spec.Size < delta {
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"there is no %d bytes of free space in LVG %s", delta, vol.Location)
+	}
+
+	ac.Spec.Size -= delta
+	if err = c.k8sclient.UpdateCR(ctx, ac); err != nil {
+		ll.Errorf("Unable to update AC %s: %v", ac.Name, err)
+		return nil, status.Error(codes.Internal, "unable to update available capacity")
+	}
+	volumeCR.Spec.Size = required
+	if err = c.k8sclient.UpdateCR(ctx, volumeCR); err != nil {
+		ll.Errorf("Unable to set size %d to volume CR: %v", required, err)
+		ac.Spec.Size += delta
+		if err = c.k8sclient.UpdateCRWithAttempts(ctx, ac, 5); err != nil {
+			ll.Errorf("Unable to return size to AC %s: %v", ac.Name, err)
+		}
+		return nil, status.Error(codes.Internal, "unable to update volume")
+	}
+
+	ll.Infof("Volume size was set to %d, file system will be grown by node", required)
+	return &csi.ControllerExpandVolumeResponse{CapacityBytes: required, NodeExpansionRequired: true}, nil
+}
+
+// getPVC returns PVC of the volume which is found by name from request parameters or by UID from volume name,
+// nil is returned if PVC isn't found
+func (c *CSIControllerService) getPVC(ctx context.Context, req *csi.CreateVolumeRequest) (*coreV1.PersistentVolumeClaim,
+	error) {
+	if name, ok := req.GetParameters()[PVCNameKey]; ok {
+		pvc := &coreV1.PersistentVolumeClaim{}
+		key := k8sCl.ObjectKey{Name: name, Namespace: req.GetParameters()[PVCNamespaceKey]}
+		if err := c.k8sclient.Get(ctx, key, pvc); err != nil {
+			return nil, k8sCl.IgnoreNotFound(err)
+		}
+		return pvc, nil
+	}
+	if strings.HasPrefix(req.GetName(), pvcPrefix) {
+		pvcList := &coreV1.PersistentVolumeClaimList{}
+		if err := c.k8sclient.List(ctx, pvcList); err != nil {
+			return nil, err
+		}
+		uid := strings.TrimPrefix(req.GetName(), pvcPrefix)
+		for i := range pvcList.Items {
+			if string(pvcList.Items[i].UID) == uid {
+				return &pvcList.Items[i], nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// getEphemeralOwners returns name of the pod which owns PVC if PVC was created for generic ephemeral
+// volume, nil is returned for usual or unknown PVC
+func getEphemeralOwners(pvc *coreV1.PersistentVolumeClaim) []string {
+	if pvc == nil {
+		return nil
+	}
+	if ref := metaV1.GetControllerOf(pvc); ref != nil && ref.Kind == "Pod" {
+		return []string{ref.Name}
+	}
+	return nil
+}
+
+// getVolumeMetadata returns labels and annotations of PVC which keys are set by SetPVCMetadataKeys,
+// maps are nil if there is nothing to copy
+func (c *CSIControllerService) getVolumeMetadata(pvc *coreV1.PersistentVolumeClaim) common.VolumeMetadata {
+	metadata := common.VolumeMetadata{}
+	if pvc == nil {
+		return metadata
+	}
+	for _, key := range c.pvcMetadataKeys {
+		if value, ok := pvc.GetLabels()[key]; ok {
+			if metadata.Labels == nil {
+				metadata.Labels = make(map[string]string)
+			}
+			metadata.Labels[key] = value
+		}
+		if value, ok := pvc.GetAnnotations()[key]; ok {
+			if metadata.Annotations == nil {
+				metadata.Annotations = make(map[string]string)
+			}
+			metadata.Annotations[key] = value
+		}
+	}
+	return metadata
+}
+
+// getProvisioningPriority returns priority of volume creation from annotation of PVC,
+// normal priority is returned for unknown PVC or unknown value of annotation
+func (c *CSIControllerService) getProvisioningPriority(pvc *coreV1.PersistentVolumeClaim) nodequeue.Priority {
+	if pvc == nil {
+		return nodequeue.Normal
+	}
+	priority, err := nodequeue.ParsePriority(pvc.GetAnnotations()[apiV1.ProvisioningPriorityAnnotationKey])
+	if err != nil {
+		c.log.WithField("method", "getProvisioningPriority").
+			Warnf("PVC %s/%s has invalid annotation: %v", pvc.Namespace, pvc.Name, err)
+	}
+	return priority
 }
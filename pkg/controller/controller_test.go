@@ -32,15 +32,20 @@ import (
 	"google.golang.org/grpc/status"
 	v1 "k8s.io/api/core/v1"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
 	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
 	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
 	vcrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
 	"github.com/dell/csi-baremetal/pkg/base/featureconfig"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
+	"github.com/dell/csi-baremetal/pkg/common"
+	"github.com/dell/csi-baremetal/pkg/controller/nodequeue"
 	csibmnodeconst "github.com/dell/csi-baremetal/pkg/crcontrollers/csibmnode/common"
 	"github.com/dell/csi-baremetal/pkg/testutils"
 )
@@ -145,6 +150,22 @@ var _ = Describe("CSIControllerService CreateVolume", func() {
 			Expect(err).NotTo(BeNil())
 			Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
 		})
+		It("Requested size violates size policy", func() {
+			var err error
+			controller.sizePolicy, err = capacityplanner.NewSizePolicy("ANY=1Gi:2Gi:1Gi")
+			Expect(err).To(BeNil())
+
+			resp, err := controller.CreateVolume(context.Background(), getCreateVolumeRequest("req1", 1024, ""))
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+			req := getCreateVolumeRequest("req1", 1024*1024*1024+1, "")
+			req.CapacityRange.LimitBytes = 1024 * 1024 * 1024 * 3 / 2
+			resp, err = controller.CreateVolume(context.Background(), req)
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			Expect(err.Error()).To(ContainSubstring("exceeds limit"))
+		})
 		It("Status Failed was set in Volume CR", func() {
 			err := testutils.AddAC(controller.k8sclient, &testAC1, &testAC2)
 			Expect(err).To(BeNil())
@@ -305,6 +326,45 @@ var _ = Describe("CSIControllerService DeleteVolume", func() {
 			Expect(err).To(BeNil())
 			Expect(volumeCrd.Spec.CSIStatus).To(Equal(apiV1.Failed))
 		})
+		It("Node service blocks removal of volume with data", func() {
+			var (
+				volumeID  = "volume-id-3333"
+				volumeCrd = &vcrd.Volume{}
+				err       error
+			)
+			volumeCrd = controller.k8sclient.ConstructVolumeCR(volumeID, api.Volume{Id: volumeID, CSIStatus: apiV1.Created})
+			err = controller.k8sclient.CreateCR(testCtx, volumeID, volumeCrd)
+			Expect(err).To(BeNil())
+
+			go testutils.VolumeReconcileImitation(controller.k8sclient, volumeCrd.Spec.Id, apiV1.Created)
+
+			resp, err := controller.DeleteVolume(context.Background(), &csi.DeleteVolumeRequest{VolumeId: volumeID})
+
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+
+			err = controller.k8sclient.ReadCR(context.Background(), volumeID, volumeCrd)
+			Expect(err).To(BeNil())
+			Expect(volumeCrd.Spec.CSIStatus).To(Equal(apiV1.Created))
+		})
+		It("Operations limit of node is reached", func() {
+			controller.SetNodeOperationsLimit(1)
+			release, err := controller.nodeQueue.Acquire(context.Background(), node, nodequeue.Create, nodequeue.Normal)
+			Expect(err).To(BeNil())
+			defer release()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			resp, err := controller.DeleteVolume(ctx, &csi.DeleteVolumeRequest{VolumeId: uuid})
+
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.Unavailable))
+
+			volumeCrd := &vcrd.Volume{}
+			err = controller.k8sclient.ReadCR(context.Background(), uuid, volumeCrd)
+			Expect(err).To(BeNil())
+			Expect(volumeCrd.Spec.CSIStatus).ToNot(Equal(apiV1.Removing))
+		})
 	})
 
 	Context("Success scenarios", func() {
@@ -446,6 +506,139 @@ var _ = Describe("CSIControllerService DeleteVolume", func() {
 	})
 })
 
+var _ = Describe("CSIControllerService ControllerExpandVolume", func() {
+	var (
+		controller *CSIControllerService
+		volumeCR   vcrd.Volume
+		gib        = int64(1024 * 1024 * 1024)
+	)
+
+	BeforeEach(func() {
+		controller = newSvc()
+		volumeCR = testVolume
+		volumeCR.Spec.StorageClass = apiV1.StorageClassHDDLVG
+		volumeCR.Spec.Location = testDriveLocation4
+		volumeCR.Spec.Size = gib
+		volumeCR.Spec.CSIStatus = apiV1.Published
+		Expect(controller.k8sclient.CreateCR(testCtx, volumeCR.Name, &volumeCR)).To(BeNil())
+		Expect(testutils.AddAC(controller.k8sclient, &testAC3)).To(BeNil())
+	})
+
+	It("Should expand volume with LVG storage class", func() {
+		resp, err := controller.ControllerExpandVolume(testCtx, &csi.ControllerExpandVolumeRequest{
+			VolumeId:      testID,
+			CapacityRange: &csi.CapacityRange{RequiredBytes: 2*gib + 1},
+		})
+		Expect(err).To(BeNil())
+		// size is aligned by PE
+		Expect(resp.CapacityBytes).To(Equal(2*gib + capacityplanner.DefaultPESize))
+		Expect(resp.NodeExpansionRequired).To(BeTrue())
+
+		vol := &vcrd.Volume{}
+		Expect(controller.k8sclient.ReadCR(testCtx, testID, vol)).To(BeNil())
+		Expect(vol.Spec.Size).To(Equal(resp.CapacityBytes))
+		ac := &accrd.AvailableCapacity{}
+		Expect(controller.k8sclient.ReadCR(testCtx, testAC3Name, ac)).To(BeNil())
+		Expect(ac.Spec.Size).To(Equal(testAC3.Spec.Size - (resp.CapacityBytes - gib)))
+	})
+	It("Should fail when there is no free space in LVG", func() {
+		_, err := controller.ControllerExpandVolume(testCtx, &csi.ControllerExpandVolumeRequest{
+			VolumeId:      testID,
+			CapacityRange: &csi.CapacityRange{RequiredBytes: testAC3.Spec.Size * 2},
+		})
+		Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+	})
+	It("Should fail for volume based on drive", func() {
+		volumeCR.Spec.StorageClass = apiV1.StorageClassHDD
+		Expect(controller.k8sclient.UpdateCR(testCtx, &volumeCR)).To(BeNil())
+		_, err := controller.ControllerExpandVolume(testCtx, &csi.ControllerExpandVolumeRequest{
+			VolumeId:      testID,
+			CapacityRange: &csi.CapacityRange{RequiredBytes: 2 * gib},
+		})
+		Expect(status.Code(err)).To(Equal(codes.OutOfRange))
+	})
+	It("Should fail for published volume with file system which can't be grown online", func() {
+		controller.expansionPolicy = NewExpansionPolicy("ext4")
+		_, err := controller.ControllerExpandVolume(testCtx, &csi.ControllerExpandVolumeRequest{
+			VolumeId:      testID,
+			CapacityRange: &csi.CapacityRange{RequiredBytes: 2 * gib},
+		})
+		Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+	})
+	It("Should fail if volume doesn't exist", func() {
+		_, err := controller.ControllerExpandVolume(testCtx, &csi.ControllerExpandVolumeRequest{
+			VolumeId:      "not-found",
+			CapacityRange: &csi.CapacityRange{RequiredBytes: 2 * gib},
+		})
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+	})
+})
+
+var _ = Describe("CSIControllerService ControllerPublishVolume", func() {
+	var (
+		controller *CSIControllerService
+		volumeCR   vcrd.Volume
+		capability = &csi.VolumeCapability{
+			AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+		}
+	)
+
+	BeforeEach(func() {
+		controller = newSvc()
+		volumeCR = testVolume
+		volumeCR.Spec.NodeId = testNode1Name
+		Expect(controller.k8sclient.CreateCR(testCtx, volumeCR.Name, &volumeCR)).To(BeNil())
+		for _, id := range []string{testNode1Name, testNode2Name} {
+			k8sNode := &v1.Node{ObjectMeta: k8smetav1.ObjectMeta{Name: id, UID: types.UID(id)}}
+			Expect(controller.k8sclient.Create(testCtx, k8sNode)).To(BeNil())
+		}
+	})
+
+	It("Should publish volume on its node", func() {
+		resp, err := controller.ControllerPublishVolume(testCtx, &csi.ControllerPublishVolumeRequest{
+			VolumeId: testID, NodeId: testNode1Name, VolumeCapability: capability,
+		})
+		Expect(err).To(BeNil())
+		Expect(resp).ToNot(BeNil())
+	})
+	It("Should fail if volume is located on another node", func() {
+		_, err := controller.ControllerPublishVolume(testCtx, &csi.ControllerPublishVolumeRequest{
+			VolumeId: testID, NodeId: testNode2Name, VolumeCapability: capability,
+		})
+		Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+	})
+	It("Should fail if node doesn't exist", func() {
+		_, err := controller.ControllerPublishVolume(testCtx, &csi.ControllerPublishVolumeRequest{
+			VolumeId: testID, NodeId: "not-found", VolumeCapability: capability,
+		})
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+	})
+	It("Should fail if volume doesn't exist", func() {
+		_, err := controller.ControllerPublishVolume(testCtx, &csi.ControllerPublishVolumeRequest{
+			VolumeId: "not-found", NodeId: testNode1Name, VolumeCapability: capability,
+		})
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+	})
+	It("Should fail if request isn't full", func() {
+		_, err := controller.ControllerPublishVolume(testCtx, &csi.ControllerPublishVolumeRequest{
+			VolumeId: testID, NodeId: testNode1Name,
+		})
+		Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+	})
+	It("Should unpublish volume even if it doesn't exist", func() {
+		_, err := controller.ControllerUnpublishVolume(testCtx, &csi.ControllerUnpublishVolumeRequest{
+			VolumeId: testID, NodeId: testNode2Name,
+		})
+		Expect(err).To(BeNil())
+		_, err = controller.ControllerUnpublishVolume(testCtx, &csi.ControllerUnpublishVolumeRequest{
+			VolumeId: "not-found", NodeId: testNode1Name,
+		})
+		Expect(err).To(BeNil())
+		_, err = controller.ControllerUnpublishVolume(testCtx, &csi.ControllerUnpublishVolumeRequest{})
+		Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+	})
+})
+
 var _ = Describe("CSIControllerService ControllerGetCapabilities", func() {
 	It("Should return right capabilities", func() {
 		var (
@@ -454,6 +647,7 @@ var _ = Describe("CSIControllerService ControllerGetCapabilities", func() {
 			expectedCapabilitiesTypes = []csi.ControllerServiceCapability_RPC_Type{
 				csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME,
 				csi.ControllerServiceCapability_RPC_PUBLISH_UNPUBLISH_VOLUME,
+				csi.ControllerServiceCapability_RPC_EXPAND_VOLUME,
 			}
 		)
 
@@ -461,7 +655,7 @@ var _ = Describe("CSIControllerService ControllerGetCapabilities", func() {
 
 		caps, err = svc.ControllerGetCapabilities(context.Background(), &csi.ControllerGetCapabilitiesRequest{})
 		Expect(err).To(BeNil())
-		Expect(len(caps.Capabilities)).To(Equal(2))
+		Expect(len(caps.Capabilities)).To(Equal(3))
 
 		currentCapabilitiesTypes := make([]csi.ControllerServiceCapability_RPC_Type, len(caps.Capabilities))
 		for i := 0; i < len(caps.Capabilities); i++ {
@@ -545,6 +739,83 @@ var _ = Describe("CSIControllerService health check", func() {
 	})
 })
 
+var _ = Describe("CSIControllerService GetPluginInfo", func() {
+	It("Should report version and enabled features", func() {
+		features := featureconfig.NewFeatureConfig()
+		features.Update(featureconfig.FeatureSubDriveAllocation, true)
+		features.Update(featureconfig.FeatureACReservation, true)
+		features.Update(featureconfig.FeatureNodeIDFromAnnotation, false)
+		identity := NewIdentityServer(base.PluginName, base.PluginVersion, NewManifest(features))
+
+		resp, err := identity.GetPluginInfo(testCtx, &csi.GetPluginInfoRequest{})
+		Expect(err).To(BeNil())
+		Expect(resp.Name).To(Equal(base.PluginName))
+		Expect(resp.VendorVersion).To(Equal(base.PluginVersion))
+		Expect(resp.Manifest[ManifestGitCommitKey]).To(Equal(base.PluginGitCommit))
+		Expect(resp.Manifest[ManifestFeaturesKey]).To(Equal(
+			featureconfig.FeatureACReservation + "," + featureconfig.FeatureSubDriveAllocation))
+	})
+})
+
+var _ = Describe("CSIControllerService getPVC", func() {
+	var (
+		controller *CSIControllerService
+		isOwner    = true
+		pvc        = &v1.PersistentVolumeClaim{
+			ObjectMeta: k8smetav1.ObjectMeta{
+				Name:      "pod-1-data",
+				Namespace: testNs,
+				UID:       "1234",
+				OwnerReferences: []k8smetav1.OwnerReference{
+					{Kind: "Pod", Name: "pod-1", Controller: &isOwner},
+				},
+				Annotations: map[string]string{apiV1.ProvisioningPriorityAnnotationKey: apiV1.ProvisioningPriorityCritical},
+			},
+		}
+	)
+
+	BeforeEach(func() {
+		controller = newSvc()
+		Expect(controller.k8sclient.Create(testCtx, pvc.DeepCopy())).To(BeNil())
+	})
+
+	It("Should find PVC by UID and its owner and priority", func() {
+		found, err := controller.getPVC(testCtx, getCreateVolumeRequest("pvc-1234", 1024, ""))
+		Expect(err).To(BeNil())
+		Expect(getEphemeralOwners(found)).To(Equal([]string{"pod-1"}))
+		Expect(controller.getProvisioningPriority(found)).To(Equal(nodequeue.Critical))
+	})
+	It("Should find PVC by name from parameters", func() {
+		req := getCreateVolumeRequest("volume-1", 1024, "")
+		req.Parameters = map[string]string{PVCNameKey: pvc.Name, PVCNamespaceKey: pvc.Namespace}
+		found, err := controller.getPVC(testCtx, req)
+		Expect(err).To(BeNil())
+		Expect(getEphemeralOwners(found)).To(Equal([]string{"pod-1"}))
+	})
+	It("Should return nil for unknown PVC", func() {
+		found, err := controller.getPVC(testCtx, getCreateVolumeRequest("pvc-5678", 1024, ""))
+		Expect(err).To(BeNil())
+		Expect(found).To(BeNil())
+		Expect(getEphemeralOwners(found)).To(BeNil())
+		Expect(controller.getProvisioningPriority(found)).To(Equal(nodequeue.Normal))
+	})
+	It("Should copy selected labels and annotations", func() {
+		controller.SetPVCMetadataKeys("team, cost-center,")
+		withMetadata := pvc.DeepCopy()
+		withMetadata.Labels = map[string]string{"team": "storage", "tier": "gold"}
+		withMetadata.Annotations = map[string]string{"cost-center": "42"}
+		metadata := controller.getVolumeMetadata(withMetadata)
+		Expect(metadata.Labels).To(Equal(map[string]string{"team": "storage"}))
+		Expect(metadata.Annotations).To(Equal(map[string]string{"cost-center": "42"}))
+		Expect(controller.getVolumeMetadata(nil)).To(Equal(common.VolumeMetadata{}))
+	})
+	It("Should use normal priority for invalid annotation", func() {
+		invalid := pvc.DeepCopy()
+		invalid.Annotations[apiV1.ProvisioningPriorityAnnotationKey] = "urgent"
+		Expect(controller.getProvisioningPriority(invalid)).To(Equal(nodequeue.Normal))
+	})
+})
+
 // create and instance of CSIControllerService with scheme for working with CRD
 // create and instance of CSIControllerService with scheme for working with CRD
 func newSvc() *CSIControllerService {
@@ -552,7 +823,7 @@ func newSvc() *CSIControllerService {
 	if err != nil {
 		panic(err)
 	}
-	nSvc := NewControllerService(kubeclient, testLogger, featureconfig.NewFeatureConfig())
+	nSvc := NewControllerService(kubeclient, testLogger, featureconfig.NewFeatureConfig(), nil, nil, nil, nil)
 	return nSvc
 }
 
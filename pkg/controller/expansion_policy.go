@@ -0,0 +1,52 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+)
+
+// DefaultOnlineExpansionFS is the list of file systems which could be grown while they are mounted
+const DefaultOnlineExpansionFS = "xfs,ext4,ext3"
+
+// ExpansionPolicy defines file systems of volumes which could be expanded online, while volume is published,
+// volumes with other file systems are expanded offline only
+type ExpansionPolicy struct {
+	onlineFS map[string]bool
+}
+
+// NewExpansionPolicy is the constructor for ExpansionPolicy
+// Receives comma separated list of file systems which could be expanded online, e.g. "xfs,ext4"
+// Returns an instance of ExpansionPolicy
+func NewExpansionPolicy(onlineFS string) *ExpansionPolicy {
+	p := &ExpansionPolicy{onlineFS: make(map[string]bool)}
+	for _, fsType := range strings.Split(onlineFS, ",") {
+		if fsType = strings.ToLower(strings.TrimSpace(fsType)); fsType != "" {
+			p.onlineFS[fsType] = true
+		}
+	}
+	return p
+}
+
+// IsOnline returns true if volume with provided file system could be expanded while it is published,
+// nil policy allows online expansion for the default file systems
+func (p *ExpansionPolicy) IsOnline(fsType string) bool {
+	if p == nil {
+		return NewExpansionPolicy(DefaultOnlineExpansionFS).IsOnline(fsType)
+	}
+	return p.onlineFS[strings.ToLower(fsType)]
+}
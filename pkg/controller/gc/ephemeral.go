@@ -0,0 +1,121 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"context"
+	"time"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/sirupsen/logrus"
+	coreV1 "k8s.io/api/core/v1"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+)
+
+// volumeDeleter is the part of CSI ControllerServer which is used for removal of volumes
+type volumeDeleter interface {
+	DeleteVolume(ctx context.Context, req *csi.DeleteVolumeRequest) (*csi.DeleteVolumeResponse, error)
+}
+
+// EphemeralCollector removes volumes of generic ephemeral PVCs which were garbage collected after owning pod
+// removal, but weren't deleted by external-provisioner because PV reclaim policy isn't Delete or PV is absent
+type EphemeralCollector struct {
+	k8sClient *k8s.KubeClient
+	deleter   volumeDeleter
+
+	log *logrus.Entry
+}
+
+// NewEphemeralCollector is the constructor for EphemeralCollector struct
+// Receives an instance of base.KubeClient, CSI ControllerServer which removes volumes and logrus logger
+// Returns an instance of EphemeralCollector
+func NewEphemeralCollector(k8sClient *k8s.KubeClient, deleter volumeDeleter, logger *logrus.Logger) *EphemeralCollector {
+	return &EphemeralCollector{
+		k8sClient: k8sClient,
+		deleter:   deleter,
+		log:       logger.WithField("component", "EphemeralCollector"),
+	}
+}
+
+// Run spawns goroutine which periodically collects volumes of generic ephemeral PVCs
+func (e *EphemeralCollector) Run() {
+	go func() {
+		for {
+			e.Collect(time.Now())
+			time.Sleep(CollectInterval)
+		}
+	}()
+}
+
+// Collect deletes volumes of generic ephemeral PVCs which PV is released and isn't deleted by external-provisioner
+// or PV doesn't exist longer than CollectInterval
+// Receives current time which is compared with creation time of volumes without PV
+func (e *EphemeralCollector) Collect(now time.Time) {
+	ll := e.log.WithField("method", "Collect")
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), CollectInterval)
+	defer cancelFn()
+
+	volumes := &volumecrd.VolumeList{}
+	if err := e.k8sClient.ReadList(ctx, volumes); err != nil {
+		ll.Errorf("Unable to read volumes: %v", err)
+		return
+	}
+
+	for _, volume := range volumes.Items {
+		// volume of generic ephemeral PVC has owner pod and isn't an inline one
+		if len(volume.Spec.Owners) == 0 || volume.Spec.Ephemeral || volume.Spec.CSIStatus != apiV1.Created {
+			continue
+		}
+
+		pv := &coreV1.PersistentVolume{}
+		err := e.k8sClient.Get(ctx, k8sCl.ObjectKey{Name: volume.Name}, pv)
+		switch {
+		case k8sError.IsNotFound(err):
+			// PV could be not created yet
+			if now.Sub(volume.CreationTimestamp.Time) < CollectInterval {
+				continue
+			}
+			ll.Infof("PV of ephemeral volume %s owned by %v doesn't exist", volume.Name, volume.Spec.Owners)
+		case err != nil:
+			ll.Errorf("Unable to read PV %s: %v", volume.Name, err)
+			continue
+		case pv.Status.Phase != coreV1.VolumeReleased ||
+			pv.Spec.PersistentVolumeReclaimPolicy == coreV1.PersistentVolumeReclaimDelete:
+			// PV is in use or will be deleted by external-provisioner
+			continue
+		default:
+			ll.Infof("PV of ephemeral volume %s owned by %v is released", volume.Name, volume.Spec.Owners)
+		}
+
+		if _, err = e.deleter.DeleteVolume(ctx, &csi.DeleteVolumeRequest{VolumeId: volume.Spec.Id}); err != nil {
+			ll.Errorf("Unable to delete volume %s: %v", volume.Name, err)
+			continue
+		}
+		if pv.Name != "" {
+			if err = e.k8sClient.Delete(ctx, pv); err != nil && !k8sError.IsNotFound(err) {
+				ll.Errorf("Unable to delete PV %s: %v", pv.Name, err)
+			}
+		}
+		ll.Infof("Ephemeral volume %s was deleted", volume.Name)
+	}
+}
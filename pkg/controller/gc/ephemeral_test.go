@@ -0,0 +1,90 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+)
+
+// fakeDeleter stores IDs of deleted volumes
+type fakeDeleter struct {
+	deleted []string
+}
+
+func (f *fakeDeleter) DeleteVolume(_ context.Context, req *csi.DeleteVolumeRequest) (*csi.DeleteVolumeResponse, error) {
+	f.deleted = append(f.deleted, req.GetVolumeId())
+	return &csi.DeleteVolumeResponse{}, nil
+}
+
+func TestEphemeralCollector_Collect(t *testing.T) {
+	k8sClient, err := k8s.GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+	deleter := &fakeDeleter{}
+	e := NewEphemeralCollector(k8sClient, deleter, testLogger)
+	now := time.Now()
+
+	createVolume := func(name string, owners []string) {
+		volume := k8sClient.ConstructVolumeCR(name,
+			api.Volume{Id: name, Owners: owners, CSIStatus: apiV1.Created})
+		volume.CreationTimestamp = metaV1.NewTime(now)
+		assert.Nil(t, k8sClient.CreateCR(testCtx, name, volume))
+	}
+	createPV := func(name string, policy coreV1.PersistentVolumeReclaimPolicy) {
+		pv := &coreV1.PersistentVolume{
+			ObjectMeta: metaV1.ObjectMeta{Name: name},
+			Spec:       coreV1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: policy},
+			Status:     coreV1.PersistentVolumeStatus{Phase: coreV1.VolumeReleased},
+		}
+		assert.Nil(t, k8sClient.Create(testCtx, pv))
+	}
+
+	// released PV with Retain policy, volume and PV are deleted
+	createVolume("pvc-retain", []string{"pod-1"})
+	createPV("pvc-retain", coreV1.PersistentVolumeReclaimRetain)
+	// released PV with Delete policy is handled by external-provisioner
+	createVolume("pvc-delete", []string{"pod-2"})
+	createPV("pvc-delete", coreV1.PersistentVolumeReclaimDelete)
+	// volume without PV
+	createVolume("pvc-no-pv", []string{"pod-3"})
+	// usual volume without owner
+	createVolume("pvc-usual", nil)
+
+	e.Collect(now)
+	assert.Equal(t, []string{"pvc-retain"}, deleter.deleted)
+	err = k8sClient.Get(testCtx, k8sCl.ObjectKey{Name: "pvc-retain"}, &coreV1.PersistentVolume{})
+	assert.True(t, k8sError.IsNotFound(err))
+
+	// PV wasn't created in time
+	deleter.deleted = nil
+	e.Collect(now.Add(2 * CollectInterval))
+	assert.Contains(t, deleter.deleted, "pvc-no-pv")
+	assert.NotContains(t, deleter.deleted, "pvc-delete")
+	assert.NotContains(t, deleter.deleted, "pvc-usual")
+}
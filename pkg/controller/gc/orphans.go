@@ -0,0 +1,231 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gc contains garbage collectors of custom resources that refer to nodes which were removed from cluster
+// and of volumes of generic ephemeral PVCs
+package gc
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/api/v1/capacityhistorycrd"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/inventorycrd"
+	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/crcontrollers/csibmnode/common"
+)
+
+// CollectInterval is the interval between two runs of OrphanCollector
+const CollectInterval = 60 * time.Second
+
+// OrphanCollector removes Volume, Drive, LVG, AC, CapacityHistory and Inventory CRs that refer to nodes
+// which don't exist in cluster longer than timeout. Physical cleanup is impossible for such CRs so their finalizers are dropped.
+type OrphanCollector struct {
+	k8sClient *k8s.KubeClient
+	// timeout after which CRs of missing node are removed
+	timeout time.Duration
+	// key - node ID, value - time when node was found missing at first
+	missingSince map[string]time.Time
+
+	log *logrus.Entry
+}
+
+// NewOrphanCollector is the constructor for OrphanCollector struct
+// Receives an instance of base.KubeClient, logrus logger and timeout after which CRs of missing node are removed
+// Returns an instance of OrphanCollector
+func NewOrphanCollector(k8sClient *k8s.KubeClient, logger *logrus.Logger, timeout time.Duration) *OrphanCollector {
+	return &OrphanCollector{
+		k8sClient:    k8sClient,
+		timeout:      timeout,
+		missingSince: make(map[string]time.Time),
+		log:          logger.WithField("component", "OrphanCollector"),
+	}
+}
+
+// Run spawns goroutine which periodically collects orphan CRs
+func (o *OrphanCollector) Run() {
+	go func() {
+		for {
+			o.Collect(time.Now())
+			time.Sleep(CollectInterval)
+		}
+	}()
+}
+
+// Collect removes CRs of nodes that are missing longer than timeout
+// Receives current time which is used for tracking of missing nodes
+func (o *OrphanCollector) Collect(now time.Time) {
+	ll := o.log.WithField("method", "Collect")
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), CollectInterval)
+	defer cancelFn()
+
+	existingNodes, err := o.getNodeIDs(ctx)
+	if err != nil {
+		ll.Errorf("Unable to read nodes: %v", err)
+		return
+	}
+
+	objects, err := o.readObjects(ctx)
+	if err != nil {
+		ll.Errorf("Unable to read custom resources: %v", err)
+		return
+	}
+
+	// key - node ID, value - CRs that refer to that node
+	orphans := make(map[string][]runtime.Object)
+	for _, obj := range objects {
+		nodeID := getNodeID(obj)
+		if nodeID == "" {
+			continue
+		}
+		if _, ok := existingNodes[nodeID]; !ok {
+			orphans[nodeID] = append(orphans[nodeID], obj)
+		}
+	}
+
+	// forget nodes that are back or don't have CRs anymore
+	for nodeID := range o.missingSince {
+		if _, ok := orphans[nodeID]; !ok {
+			delete(o.missingSince, nodeID)
+		}
+	}
+
+	for nodeID, objs := range orphans {
+		since, ok := o.missingSince[nodeID]
+		if !ok {
+			ll.Warnf("Node %s doesn't exist, its %d custom resources will be removed after %s",
+				nodeID, len(objs), o.timeout)
+			o.missingSince[nodeID] = now
+			continue
+		}
+		if now.Sub(since) < o.timeout {
+			continue
+		}
+		ll.Infof("Node %s is missing since %s, removing its custom resources", nodeID, since)
+		for _, obj := range objs {
+			o.remove(ctx, obj)
+		}
+	}
+}
+
+// getNodeIDs returns set of IDs of the nodes in cluster, both k8s UID and CSIBMNode UUID from annotation are used
+func (o *OrphanCollector) getNodeIDs(ctx context.Context) (map[string]struct{}, error) {
+	nodes, err := o.k8sClient.GetNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]struct{}, len(nodes)*2)
+	for _, node := range nodes {
+		ids[string(node.UID)] = struct{}{}
+		if id, ok := node.GetAnnotations()[common.NodeIDAnnotationKey]; ok {
+			ids[id] = struct{}{}
+		}
+	}
+	return ids, nil
+}
+
+// readObjects reads Volume, LVG, AC, Drive, CapacityHistory and Inventory CRs
+func (o *OrphanCollector) readObjects(ctx context.Context) ([]runtime.Object, error) {
+	var (
+		volumes     = &volumecrd.VolumeList{}
+		lvgs        = &lvgcrd.LVGList{}
+		acs         = &accrd.AvailableCapacityList{}
+		drives      = &drivecrd.DriveList{}
+		histories   = &capacityhistorycrd.CapacityHistoryList{}
+		inventories = &inventorycrd.InventoryList{}
+		res         []runtime.Object
+	)
+
+	// order matters, dependent CRs go first
+	for _, list := range []runtime.Object{volumes, lvgs, acs, drives, histories, inventories} {
+		if err := o.k8sClient.ReadList(ctx, list); err != nil {
+			return nil, err
+		}
+	}
+	for i := range volumes.Items {
+		res = append(res, &volumes.Items[i])
+	}
+	for i := range lvgs.Items {
+		res = append(res, &lvgs.Items[i])
+	}
+	for i := range acs.Items {
+		res = append(res, &acs.Items[i])
+	}
+	for i := range drives.Items {
+		res = append(res, &drives.Items[i])
+	}
+	for i := range histories.Items {
+		res = append(res, &histories.Items[i])
+	}
+	for i := range inventories.Items {
+		res = append(res, &inventories.Items[i])
+	}
+	return res, nil
+}
+
+// remove drops finalizers of provided CR and deletes it
+func (o *OrphanCollector) remove(ctx context.Context, obj runtime.Object) {
+	ll := o.log.WithField("method", "remove")
+
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		ll.Errorf("Unable to get object metadata: %v", err)
+		return
+	}
+
+	if len(accessor.GetFinalizers()) != 0 {
+		accessor.SetFinalizers(nil)
+		if err = o.k8sClient.UpdateCR(ctx, obj); err != nil {
+			ll.Errorf("Unable to remove finalizers from %s: %v", accessor.GetName(), err)
+			return
+		}
+	}
+	if err = o.k8sClient.DeleteCR(ctx, obj); err != nil && !k8sError.IsNotFound(err) {
+		ll.Errorf("Unable to delete %s: %v", accessor.GetName(), err)
+		return
+	}
+	ll.Infof("Orphan custom resource %s was removed", accessor.GetName())
+}
+
+// getNodeID returns ID of the node which provided CR refers to
+func getNodeID(obj runtime.Object) string {
+	switch o := obj.(type) {
+	case *volumecrd.Volume:
+		return o.Spec.NodeId
+	case *lvgcrd.LVG:
+		return o.Spec.Node
+	case *accrd.AvailableCapacity:
+		return o.Spec.NodeId
+	case *drivecrd.Drive:
+		return o.Spec.NodeId
+	case *capacityhistorycrd.CapacityHistory:
+		return o.Spec.NodeID
+	case *inventorycrd.Inventory:
+		return o.Spec.NodeID
+	}
+	return ""
+}
@@ -0,0 +1,127 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/capacityhistorycrd"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/inventorycrd"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/crcontrollers/csibmnode/common"
+)
+
+var (
+	testCtx    = context.Background()
+	testLogger = logrus.New()
+	testNs     = "default"
+
+	existingNodeID = "existing-node-uid"
+	annotatedID    = "annotated-node-uuid"
+	missingNodeID  = "missing-node-uid"
+)
+
+func TestOrphanCollector_Collect(t *testing.T) {
+	o := setup(t, time.Minute)
+
+	liveVolume := o.k8sClient.ConstructVolumeCR("live-volume", api.Volume{Id: "live-volume", NodeId: annotatedID})
+	orphanVolume := o.k8sClient.ConstructVolumeCR("orphan-volume",
+		api.Volume{Id: "orphan-volume", NodeId: missingNodeID, CSIStatus: apiV1.Created})
+	orphanVolume.Finalizers = []string{"dell.emc.csi/volume-cleanup"}
+	orphanDrive := o.k8sClient.ConstructDriveCR("orphan-drive", api.Drive{UUID: "orphan-drive", NodeId: missingNodeID})
+	orphanDrive.Finalizers = []string{"dell.emc.csi/drive-cleanup"}
+	assert.Nil(t, o.k8sClient.CreateCR(testCtx, liveVolume.Name, liveVolume))
+	assert.Nil(t, o.k8sClient.CreateCR(testCtx, orphanVolume.Name, orphanVolume))
+	assert.Nil(t, o.k8sClient.CreateCR(testCtx, orphanDrive.Name, orphanDrive))
+	orphanHistory := &capacityhistorycrd.CapacityHistory{
+		TypeMeta:   metaV1.TypeMeta{Kind: apiV1.CapacityHistoryKind, APIVersion: apiV1.APIV1Version},
+		ObjectMeta: metaV1.ObjectMeta{Name: missingNodeID, Namespace: testNs},
+		Spec:       capacityhistorycrd.CapacityHistorySpec{NodeID: missingNodeID},
+	}
+	assert.Nil(t, o.k8sClient.CreateCR(testCtx, orphanHistory.Name, orphanHistory))
+	orphanInventory := &inventorycrd.Inventory{
+		TypeMeta:   metaV1.TypeMeta{Kind: apiV1.InventoryKind, APIVersion: apiV1.APIV1Version},
+		ObjectMeta: metaV1.ObjectMeta{Name: missingNodeID, Namespace: testNs},
+		Spec:       inventorycrd.InventorySpec{NodeID: missingNodeID},
+	}
+	assert.Nil(t, o.k8sClient.CreateCR(testCtx, orphanInventory.Name, orphanInventory))
+
+	now := time.Now()
+	// first run, missing node is remembered
+	o.Collect(now)
+	assert.Contains(t, o.missingSince, missingNodeID)
+	assert.Nil(t, o.k8sClient.ReadCR(testCtx, orphanVolume.Name, &volumecrd.Volume{}))
+
+	// timeout isn't reached
+	o.Collect(now.Add(30 * time.Second))
+	assert.Nil(t, o.k8sClient.ReadCR(testCtx, orphanVolume.Name, &volumecrd.Volume{}))
+
+	// timeout is reached, orphans are removed
+	o.Collect(now.Add(2 * time.Minute))
+	err := o.k8sClient.ReadCR(testCtx, orphanVolume.Name, &volumecrd.Volume{})
+	assert.True(t, k8sError.IsNotFound(err))
+	err = o.k8sClient.ReadCR(testCtx, orphanDrive.Name, &drivecrd.Drive{})
+	assert.True(t, k8sError.IsNotFound(err))
+	err = o.k8sClient.ReadCR(testCtx, orphanHistory.Name, &capacityhistorycrd.CapacityHistory{})
+	assert.True(t, k8sError.IsNotFound(err))
+	err = o.k8sClient.ReadCR(testCtx, orphanInventory.Name, &inventorycrd.Inventory{})
+	assert.True(t, k8sError.IsNotFound(err))
+	assert.Nil(t, o.k8sClient.ReadCR(testCtx, liveVolume.Name, &volumecrd.Volume{}))
+
+	// node doesn't have CRs anymore and is forgotten
+	o.Collect(now.Add(3 * time.Minute))
+	assert.NotContains(t, o.missingSince, missingNodeID)
+}
+
+func TestOrphanCollector_getNodeIDs(t *testing.T) {
+	o := setup(t, time.Minute)
+
+	ids, err := o.getNodeIDs(testCtx)
+	assert.Nil(t, err)
+	assert.Len(t, ids, 2)
+	assert.Contains(t, ids, existingNodeID)
+	assert.Contains(t, ids, annotatedID)
+}
+
+func setup(t *testing.T, timeout time.Duration) *OrphanCollector {
+	k8sClient, err := k8s.GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+
+	node := &coreV1.Node{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:        "node-1",
+			UID:         types.UID(existingNodeID),
+			Annotations: map[string]string{common.NodeIDAnnotationKey: annotatedID},
+		},
+	}
+	assert.Nil(t, k8sClient.Create(testCtx, node))
+
+	return NewOrphanCollector(k8sClient, testLogger, timeout)
+}
@@ -18,19 +18,51 @@ package controller
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/sirupsen/logrus"
+
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/featureconfig"
+)
+
+const (
+	// ManifestGitCommitKey is the key of manifest of GetPluginInfo with git commit of the driver
+	ManifestGitCommitKey = "gitCommit"
+	// ManifestFeaturesKey is the key of manifest of GetPluginInfo with comma separated enabled features
+	ManifestFeaturesKey = "features"
+	// ManifestDriveManagerKey is the key of manifest of GetPluginInfo with type of hardware manager, node only
+	ManifestDriveManagerKey = "driveManager"
 )
 
+// NewManifest creates manifest of GetPluginInfo with git commit of the driver and its enabled features
+// Receives FeatureChecker with features of the component
+// Returns map which could be extended with component specific values before passing to NewIdentityServer
+func NewManifest(features featureconfig.FeatureChecker) map[string]string {
+	enabled := make([]string, 0)
+	for _, name := range features.List() {
+		if features.IsEnabled(name) {
+			enabled = append(enabled, name)
+		}
+	}
+	sort.Strings(enabled)
+	return map[string]string{
+		ManifestGitCommitKey: base.PluginGitCommit,
+		ManifestFeaturesKey:  strings.Join(enabled, ","),
+	}
+}
+
 // NewIdentityServer is the creator for defaultIdentityServer struct
-// Receives name of the driver, driver version and readiness state of the driver
+// Receives name of the driver, driver version and manifest with additional information about the driver
 // Returns csi.IdentityServer because defaultIdentityServer struct implements it
-func NewIdentityServer(name string, version string) csi.IdentityServer {
+func NewIdentityServer(name string, version string, manifest map[string]string) csi.IdentityServer {
 	return &defaultIdentityServer{
 		name:      name,
 		version:   version,
+		manifest:  manifest,
 		readiness: true,
 	}
 }
@@ -39,22 +71,24 @@ func NewIdentityServer(name string, version string) csi.IdentityServer {
 type defaultIdentityServer struct {
 	name      string
 	version   string
+	manifest  map[string]string
 	readiness bool
 }
 
 // GetPluginInfo is the implementation of CSI Spec GetPluginInfo.
-// This method returns information about CSI driver: its name and version.
+// This method returns information about CSI driver: its name, version and manifest.
 // Receives golang context and CSI Spec GetPluginInfoRequest
 // Returns CSI Spec GetPluginInfoResponse and nil error
 func (s *defaultIdentityServer) GetPluginInfo(context.Context, *csi.GetPluginInfoRequest) (*csi.GetPluginInfoResponse, error) {
 	return &csi.GetPluginInfoResponse{
 		Name:          s.name,
 		VendorVersion: s.version,
+		Manifest:      s.manifest,
 	}, nil
 }
 
 // GetPluginCapabilities is the implementation of CSI Spec GetPluginCapabilities. This method returns information about
-// capabilities of  CSI driver. CONTROLLER_SERVICE and VOLUME_ACCESSIBILITY_CONSTRAINTS for now.
+// capabilities of  CSI driver. CONTROLLER_SERVICE, VOLUME_ACCESSIBILITY_CONSTRAINTS and ONLINE volume expansion for now.
 // Receives golang context and CSI Spec GetPluginCapabilitiesRequest
 // Returns CSI Spec GetPluginCapabilitiesResponse and nil error
 func (s *defaultIdentityServer) GetPluginCapabilities(context.Context, *csi.GetPluginCapabilitiesRequest) (*csi.GetPluginCapabilitiesResponse, error) {
@@ -74,6 +108,13 @@ func (s *defaultIdentityServer) GetPluginCapabilities(context.Context, *csi.GetP
 					},
 				},
 			},
+			{
+				Type: &csi.PluginCapability_VolumeExpansion_{
+					VolumeExpansion: &csi.PluginCapability_VolumeExpansion{
+						Type: csi.PluginCapability_VolumeExpansion_ONLINE,
+					},
+				},
+			},
 		},
 	}
 	logrus.WithFields(logrus.Fields{
@@ -0,0 +1,114 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package leader contains lease based leader election which allows to run several replicas of controller,
+// only the leader runs background components while the others are in standby
+package leader
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+const (
+	// LeaseName is the name of Lease in the namespace of controller which is held by the leader
+	LeaseName = "baremetal-csi-controller"
+	// DefaultLeaseDuration is the time during which standby replicas wait before taking over the lease
+	DefaultLeaseDuration = 15 * time.Second
+	// DefaultRenewDeadline is the time during which the leader tries to renew the lease before giving up leadership
+	DefaultRenewDeadline = 10 * time.Second
+	// DefaultRetryPeriod is the interval between two attempts to acquire or renew the lease
+	DefaultRetryPeriod = 2 * time.Second
+)
+
+// Elector elects the leader among controller replicas through Lease object
+type Elector struct {
+	clientset kubernetes.Interface
+	namespace string
+	// identity of the replica which is written into the lease, e.g. pod name
+	identity string
+	// 1 if the replica holds the lease
+	leader int32
+
+	log *logrus.Entry
+}
+
+// NewElector is the constructor for Elector struct
+// Receives kubernetes clientset, namespace of Lease, identity of the replica and logrus logger
+// Returns an instance of Elector
+func NewElector(clientset kubernetes.Interface, namespace, identity string, logger *logrus.Logger) *Elector {
+	return &Elector{
+		clientset: clientset,
+		namespace: namespace,
+		identity:  identity,
+		log:       logger.WithField("component", "LeaderElector"),
+	}
+}
+
+// Run blocks until the replica becomes the leader, then calls onStartedLeading and keeps renewing the lease.
+// onStoppedLeading is called when leadership is lost or ctx is done, the lease is released in the last case
+// Returns error if lock can't be created, otherwise returns when leadership is lost or ctx is done
+func (e *Elector) Run(ctx context.Context, onStartedLeading func(ctx context.Context), onStoppedLeading func()) error {
+	ll := e.log.WithField("method", "Run")
+
+	lock, err := resourcelock.New(resourcelock.LeasesResourceLock, e.namespace, LeaseName,
+		e.clientset.CoreV1(), e.clientset.CoordinationV1(), resourcelock.ResourceLockConfig{Identity: e.identity})
+	if err != nil {
+		return err
+	}
+
+	ll.Infof("Waiting for lease %s/%s as %s", e.namespace, LeaseName, e.identity)
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   DefaultLeaseDuration,
+		RenewDeadline:   DefaultRenewDeadline,
+		RetryPeriod:     DefaultRetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            LeaseName,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				ll.Info("Became the leader")
+				atomic.StoreInt32(&e.leader, 1)
+				onStartedLeading(ctx)
+			},
+			// it is called even if the replica wasn't the leader
+			OnStoppedLeading: func() {
+				if e.IsLeader() {
+					ll.Warn("Leadership is lost")
+				}
+				atomic.StoreInt32(&e.leader, 0)
+				onStoppedLeading()
+			},
+			OnNewLeader: func(identity string) {
+				if identity != e.identity {
+					ll.Infof("Replica %s is the leader", identity)
+				}
+			},
+		},
+	})
+	return nil
+}
+
+// IsLeader returns true if the replica holds the lease
+func (e *Elector) IsLeader() bool {
+	return atomic.LoadInt32(&e.leader) == 1
+}
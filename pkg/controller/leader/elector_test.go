@@ -0,0 +1,86 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package leader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var (
+	testLogger = logrus.New()
+	testNs     = "default"
+)
+
+func TestElector_Run(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	e := NewElector(clientset, testNs, "controller-1", testLogger)
+	assert.False(t, e.IsLeader())
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	started, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		assert.Nil(t, e.Run(ctx, func(context.Context) { close(started) }, func() { close(stopped) }))
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(DefaultLeaseDuration):
+		t.Fatal("replica didn't become the leader")
+	}
+	assert.True(t, e.IsLeader())
+	lease, err := clientset.CoordinationV1().Leases(testNs).Get(LeaseName, metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "controller-1", *lease.Spec.HolderIdentity)
+
+	// lease is released on shutdown
+	cancelFn()
+	select {
+	case <-stopped:
+	case <-time.After(DefaultLeaseDuration):
+		t.Fatal("leadership wasn't released")
+	}
+	assert.False(t, e.IsLeader())
+}
+
+func TestElector_RunStandby(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	leader := NewElector(clientset, testNs, "controller-1", testLogger)
+	standby := NewElector(clientset, testNs, "controller-2", testLogger)
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	defer cancelFn()
+	started := make(chan struct{})
+	go func() {
+		_ = leader.Run(ctx, func(context.Context) { close(started) }, func() {})
+	}()
+	<-started
+
+	standbyCtx, standbyCancelFn := context.WithTimeout(ctx, 2*DefaultRetryPeriod)
+	defer standbyCancelFn()
+	assert.Nil(t, standby.Run(standbyCtx, func(context.Context) {
+		t.Error("standby replica became the leader")
+	}, func() {}))
+	assert.True(t, leader.IsLeader())
+	assert.False(t, standby.IsLeader())
+}
@@ -317,7 +317,7 @@ func (n *ServicesStateMonitor) updateCRs() {
 			if err != nil {
 				log.Tracef("Error occurred during drives status update: %s", err)
 			}
-			// todo create issue to return volume back to OPERATIVE state when node is up
+			// node service returns volumes back to OPERATIVE state when it finds their storage after node is up
 			err = n.crHelper.UpdateVolumesOpStatusOnNode(id, apiV1.OperationalStatusMissing)
 			if err != nil {
 				log.Tracef("Error occurred during volumes status update: %s", err)
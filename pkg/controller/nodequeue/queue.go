@@ -0,0 +1,185 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package nodequeue contains queue which limits amount of concurrent volume operations per node
+package nodequeue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+)
+
+// Operation is the kind of volume operation, waiting operations of different kinds get free slots in turn
+type Operation int
+
+const (
+	// Create is the creation of volume
+	Create Operation = iota
+	// Delete is the removal of volume
+	Delete
+	// operationsCount is the amount of operation kinds
+	operationsCount
+)
+
+// Priority is the priority of waiting operation, free slot is given to operations of lower priority
+// only if there are no waiting operations of higher priority
+type Priority int
+
+const (
+	// Background is the priority of bulk operations which could wait for others
+	Background Priority = iota
+	// Normal is the default priority
+	Normal
+	// Critical is the priority of operations which must not wait behind others, e.g. volumes of etcd
+	Critical
+	// prioritiesCount is the amount of priorities
+	prioritiesCount
+)
+
+// ParsePriority converts value of apiV1.ProvisioningPriorityAnnotationKey annotation to Priority,
+// empty value is Normal
+// Returns Priority or error if value is unknown
+func ParsePriority(value string) (Priority, error) {
+	switch value {
+	case apiV1.ProvisioningPriorityCritical:
+		return Critical, nil
+	case apiV1.ProvisioningPriorityNormal, "":
+		return Normal, nil
+	case apiV1.ProvisioningPriorityBackground:
+		return Background, nil
+	}
+	return Normal, fmt.Errorf("unknown provisioning priority %s", value)
+}
+
+// Queue limits amount of concurrent operations per node, operations which don't fit into the limit wait
+// for a free slot in order of priority and in FIFO order within their kind, kinds of the same priority
+// are served round-robin, so storm of deletions doesn't starve creations on the same node and vice versa
+type Queue struct {
+	sync.Mutex
+	limit int
+	nodes map[string]*nodeQueue
+}
+
+// nodeQueue is the state of operations on one node
+type nodeQueue struct {
+	running int
+	waiting [prioritiesCount][operationsCount][]chan struct{}
+	// next is the kind of operation of each priority which gets the next free slot if it has waiting operations
+	next [prioritiesCount]Operation
+}
+
+// NewQueue is the constructor for Queue struct
+// Receives maximal amount of concurrent operations per node, it must be positive
+// Returns an instance of Queue
+func NewQueue(limit int) *Queue {
+	return &Queue{limit: limit, nodes: make(map[string]*nodeQueue)}
+}
+
+// Acquire waits until operation on the node could be started
+// Receives golang context, ID of the node, kind and priority of operation
+// Returns function which must be called when operation is finished or error if context was done before
+func (q *Queue) Acquire(ctx context.Context, nodeID string, op Operation, priority Priority) (func(), error) {
+	q.Lock()
+	node, ok := q.nodes[nodeID]
+	if !ok {
+		node = &nodeQueue{}
+		q.nodes[nodeID] = node
+	}
+	if node.running < q.limit && node.waitingCount() == 0 {
+		node.running++
+		q.Unlock()
+		return q.releaseFunc(nodeID), nil
+	}
+	ready := make(chan struct{})
+	waiting := &node.waiting[priority][op]
+	*waiting = append(*waiting, ready)
+	q.Unlock()
+
+	select {
+	case <-ready:
+		return q.releaseFunc(nodeID), nil
+	case <-ctx.Done():
+		q.Lock()
+		defer q.Unlock()
+		for i, ch := range *waiting {
+			if ch == ready {
+				*waiting = append((*waiting)[:i], (*waiting)[i+1:]...)
+				return nil, ctx.Err()
+			}
+		}
+		// slot was passed to the operation concurrently with cancellation
+		q.release(nodeID)
+		return nil, ctx.Err()
+	}
+}
+
+// Running returns amount of running operations on the node
+func (q *Queue) Running(nodeID string) int {
+	q.Lock()
+	defer q.Unlock()
+	if node, ok := q.nodes[nodeID]; ok {
+		return node.running
+	}
+	return 0
+}
+
+// releaseFunc returns function which releases slot of the node once
+func (q *Queue) releaseFunc(nodeID string) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			q.Lock()
+			defer q.Unlock()
+			q.release(nodeID)
+		})
+	}
+}
+
+// release passes slot of finished operation to the next waiting one or frees it, lock must be held
+func (q *Queue) release(nodeID string) {
+	node := q.nodes[nodeID]
+	for priority := prioritiesCount - 1; priority >= 0; priority-- {
+		for i := Operation(0); i < operationsCount; i++ {
+			op := (node.next[priority] + i) % operationsCount
+			waiting := node.waiting[priority][op]
+			if len(waiting) == 0 {
+				continue
+			}
+			close(waiting[0])
+			node.waiting[priority][op] = waiting[1:]
+			node.next[priority] = (op + 1) % operationsCount
+			return
+		}
+	}
+	node.running--
+	if node.running == 0 {
+		delete(q.nodes, nodeID)
+	}
+}
+
+// waitingCount returns amount of waiting operations of all kinds
+func (n *nodeQueue) waitingCount() int {
+	count := 0
+	for _, ops := range n.waiting {
+		for _, waiting := range ops {
+			count += len(waiting)
+		}
+	}
+	return count
+}
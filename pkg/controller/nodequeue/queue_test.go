@@ -0,0 +1,156 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodequeue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+)
+
+const testNode = "node1"
+
+func TestQueue_Limit(t *testing.T) {
+	q := NewQueue(2)
+
+	release1, err := q.Acquire(context.Background(), testNode, Delete, Normal)
+	assert.Nil(t, err)
+	release2, err := q.Acquire(context.Background(), testNode, Delete, Normal)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, q.Running(testNode))
+
+	// another node isn't affected
+	releaseOther, err := q.Acquire(context.Background(), "node2", Delete, Normal)
+	assert.Nil(t, err)
+	releaseOther()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = q.Acquire(ctx, testNode, Delete, Normal)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	acquired := make(chan struct{})
+	go func() {
+		release, err := q.Acquire(context.Background(), testNode, Delete, Normal)
+		assert.Nil(t, err)
+		close(acquired)
+		release()
+	}()
+	release1()
+	// release is idempotent
+	release1()
+	<-acquired
+	release2()
+	waitFor(t, func() bool { return q.Running(testNode) == 0 })
+}
+
+func TestQueue_Fairness(t *testing.T) {
+	q := NewQueue(1)
+	release, err := q.Acquire(context.Background(), testNode, Delete, Normal)
+	assert.Nil(t, err)
+
+	var (
+		order  = make(chan Operation, 3)
+		queued = make(map[Operation]int)
+	)
+	enqueue := func(op Operation) {
+		queued[op]++
+		expected := queued[op]
+		go func() {
+			release, err := q.Acquire(context.Background(), testNode, op, Normal)
+			assert.Nil(t, err)
+			order <- op
+			release()
+		}()
+		// wait until operation is queued
+		waitFor(t, func() bool {
+			q.Lock()
+			defer q.Unlock()
+			return len(q.nodes[testNode].waiting[Normal][op]) == expected
+		})
+	}
+	enqueue(Delete)
+	enqueue(Delete)
+	enqueue(Create)
+	release()
+
+	// creation which was queued after deletions doesn't wait for all of them
+	assert.Equal(t, Create, <-order)
+	assert.Equal(t, Delete, <-order)
+	assert.Equal(t, Delete, <-order)
+}
+
+func TestQueue_Priority(t *testing.T) {
+	q := NewQueue(1)
+	release, err := q.Acquire(context.Background(), testNode, Create, Normal)
+	assert.Nil(t, err)
+
+	order := make(chan Priority, 3)
+	for _, priority := range []Priority{Background, Normal, Critical} {
+		priority := priority
+		go func() {
+			release, err := q.Acquire(context.Background(), testNode, Create, priority)
+			assert.Nil(t, err)
+			order <- priority
+			release()
+		}()
+		// wait until operation is queued
+		waitFor(t, func() bool {
+			q.Lock()
+			defer q.Unlock()
+			return len(q.nodes[testNode].waiting[priority][Create]) == 1
+		})
+	}
+	release()
+
+	// operations which were queued later, but have higher priority, are started first
+	assert.Equal(t, Critical, <-order)
+	assert.Equal(t, Normal, <-order)
+	assert.Equal(t, Background, <-order)
+}
+
+func TestParsePriority(t *testing.T) {
+	for value, expected := range map[string]Priority{
+		"":                                   Normal,
+		apiV1.ProvisioningPriorityNormal:     Normal,
+		apiV1.ProvisioningPriorityCritical:   Critical,
+		apiV1.ProvisioningPriorityBackground: Background,
+	} {
+		priority, err := ParsePriority(value)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, priority)
+	}
+
+	priority, err := ParsePriority("urgent")
+	assert.NotNil(t, err)
+	assert.Equal(t, Normal, priority)
+}
+
+// waitFor waits up to a second until condition is true
+func waitFor(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition isn't reached")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
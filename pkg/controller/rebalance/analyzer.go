@@ -0,0 +1,228 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rebalance contains analyzer of capacity distribution which produces rebalancing recommendations
+package rebalance
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/crcontrollers/csibmnode/common"
+	"github.com/dell/csi-baremetal/pkg/eventing"
+)
+
+// AnalyzeInterval is the interval between two runs of Analyzer
+const AnalyzeInterval = 10 * time.Minute
+
+// eventRecorder interface for sending events
+type eventRecorder interface {
+	Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{})
+}
+
+// Utilization describes usage of the storage class on the node
+type Utilization struct {
+	NodeID       string
+	StorageClass string
+	// Total is the size of all drives of the storage class on the node
+	Total int64
+	// Free is the size of available capacity of the storage class on the node
+	Free int64
+}
+
+// Percent returns used capacity in percents
+func (u Utilization) Percent() int {
+	if u.Total == 0 {
+		return 0
+	}
+	return int((u.Total - u.Free) * 100 / u.Total)
+}
+
+// Recommendation suggests to move volumes of the storage class from overloaded node to underloaded one
+type Recommendation struct {
+	StorageClass string
+	From         Utilization
+	To           Utilization
+}
+
+// String returns human readable description of the recommendation
+func (r Recommendation) String() string {
+	return fmt.Sprintf("node %s %s %d%% full, node %s %d%%, consider placing new volumes on node %s",
+		r.From.NodeID, r.StorageClass, r.From.Percent(), r.To.NodeID, r.To.Percent(), r.To.NodeID)
+}
+
+// Analyzer periodically analyzes distribution of Drives and AvailableCapacities across nodes
+// and sends events with rebalancing recommendations on k8s Node objects
+type Analyzer struct {
+	k8sClient *k8s.KubeClient
+	recorder  eventRecorder
+	// highWatermark is the percent of used capacity above which node is overloaded
+	highWatermark int
+	// lowWatermark is the percent of used capacity below which node is underloaded
+	lowWatermark int
+
+	log *logrus.Entry
+}
+
+// NewAnalyzer is the constructor for Analyzer struct
+// Receives an instance of base.KubeClient, event recorder, logrus logger and watermarks in percents
+// Returns an instance of Analyzer
+func NewAnalyzer(k8sClient *k8s.KubeClient, recorder eventRecorder, logger *logrus.Logger,
+	highWatermark, lowWatermark int) *Analyzer {
+	return &Analyzer{
+		k8sClient:     k8sClient,
+		recorder:      recorder,
+		highWatermark: highWatermark,
+		lowWatermark:  lowWatermark,
+		log:           logger.WithField("component", "RebalanceAnalyzer"),
+	}
+}
+
+// Run spawns goroutine which periodically analyzes capacity and sends recommendations
+func (a *Analyzer) Run() {
+	go func() {
+		for {
+			a.analyzeAndReport()
+			time.Sleep(AnalyzeInterval)
+		}
+	}()
+}
+
+// analyzeAndReport analyzes capacity and sends event for each recommendation on the overloaded node
+func (a *Analyzer) analyzeAndReport() {
+	ll := a.log.WithField("method", "analyzeAndReport")
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), AnalyzeInterval)
+	defer cancelFn()
+
+	recommendations, err := a.Analyze(ctx)
+	if err != nil {
+		ll.Errorf("Unable to analyze capacity: %v", err)
+		return
+	}
+	if len(recommendations) == 0 {
+		return
+	}
+
+	nodes, err := a.k8sClient.GetNodes(ctx)
+	if err != nil {
+		ll.Errorf("Unable to read nodes: %v", err)
+		return
+	}
+	for _, r := range recommendations {
+		ll.Info(r.String())
+		if node := findNode(nodes, r.From.NodeID); node != nil {
+			a.recorder.Eventf(node, eventing.WarningType, eventing.CapacityRebalanceRecommended, "%s", r.String())
+		}
+	}
+}
+
+// Analyze calculates utilization of each storage class on each node and returns recommendation for each
+// storage class which has both overloaded and underloaded nodes, most and least loaded nodes are used
+func (a *Analyzer) Analyze(ctx context.Context) ([]Recommendation, error) {
+	utilization, err := GetUtilization(ctx, a.k8sClient)
+	if err != nil {
+		return nil, err
+	}
+
+	// key - storage class, value - utilization on the nodes
+	bySC := make(map[string][]Utilization)
+	for _, u := range utilization {
+		bySC[u.StorageClass] = append(bySC[u.StorageClass], u)
+	}
+
+	var res []Recommendation
+	for sc, list := range bySC {
+		if len(list) < 2 {
+			continue
+		}
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].Percent() > list[j].Percent()
+		})
+		most, least := list[0], list[len(list)-1]
+		if most.Percent() >= a.highWatermark && least.Percent() <= a.lowWatermark {
+			res = append(res, Recommendation{StorageClass: sc, From: most, To: least})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].StorageClass < res[j].StorageClass
+	})
+	return res, nil
+}
+
+// GetUtilization calculates total and free size of each storage class on each node,
+// total size is based on non-system drives and free size is based on ACs, LVG ACs are counted in underlying class
+func GetUtilization(ctx context.Context, k8sClient *k8s.KubeClient) ([]Utilization, error) {
+	drives := &drivecrd.DriveList{}
+	if err := k8sClient.ReadList(ctx, drives); err != nil {
+		return nil, err
+	}
+	acs := &accrd.AvailableCapacityList{}
+	if err := k8sClient.ReadList(ctx, acs); err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		node, sc string
+	}
+	utilization := make(map[key]*Utilization)
+	for _, d := range drives.Items {
+		if d.Spec.IsSystem || d.Spec.Health != apiV1.HealthGood {
+			continue
+		}
+		k := key{node: d.Spec.NodeId, sc: util.ConvertDriveTypeToStorageClass(d.Spec.Type)}
+		if _, ok := utilization[k]; !ok {
+			utilization[k] = &Utilization{NodeID: k.node, StorageClass: k.sc}
+		}
+		utilization[k].Total += d.Spec.Size
+	}
+	for _, ac := range acs.Items {
+		sc := ac.Spec.StorageClass
+		if subSC := util.GetSubStorageClass(sc); subSC != "" {
+			sc = subSC
+		}
+		if u, ok := utilization[key{node: ac.Spec.NodeId, sc: sc}]; ok {
+			u.Free += ac.Spec.Size
+		}
+	}
+
+	res := make([]Utilization, 0, len(utilization))
+	for _, u := range utilization {
+		res = append(res, *u)
+	}
+	return res, nil
+}
+
+// findNode returns k8s node which UID or CSIBMNode UUID annotation is equal to node ID
+func findNode(nodes []coreV1.Node, nodeID string) *coreV1.Node {
+	for i := range nodes {
+		if string(nodes[i].UID) == nodeID || nodes[i].GetAnnotations()[common.NodeIDAnnotationKey] == nodeID {
+			return &nodes[i]
+		}
+	}
+	return nil
+}
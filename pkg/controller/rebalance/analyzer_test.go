@@ -0,0 +1,115 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rebalance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/crcontrollers/csibmnode/common"
+	"github.com/dell/csi-baremetal/pkg/eventing"
+	"github.com/dell/csi-baremetal/pkg/mocks"
+)
+
+var (
+	testCtx    = context.Background()
+	testLogger = logrus.New()
+	testNs     = "default"
+
+	fullNodeID  = "full-node-uuid"
+	emptyNodeID = "empty-node-uid"
+)
+
+func TestAnalyzer_Analyze(t *testing.T) {
+	a, _ := setup(t)
+
+	createDrive(t, a, "drive-1", fullNodeID, apiV1.DriveTypeSSD, apiV1.HealthGood, 100)
+	createDrive(t, a, "drive-2", emptyNodeID, apiV1.DriveTypeSSD, apiV1.HealthGood, 100)
+	// bad drive isn't counted
+	createDrive(t, a, "drive-3", fullNodeID, apiV1.DriveTypeSSD, apiV1.HealthBad, 1000)
+	createAC(t, a, "ac-1", fullNodeID, apiV1.StorageClassSSD, 5)
+	createAC(t, a, "ac-2", emptyNodeID, apiV1.StorageClassSSDLVG, 90)
+
+	recommendations, err := a.Analyze(testCtx)
+	assert.Nil(t, err)
+	assert.Len(t, recommendations, 1)
+	r := recommendations[0]
+	assert.Equal(t, apiV1.StorageClassSSD, r.StorageClass)
+	assert.Equal(t, fullNodeID, r.From.NodeID)
+	assert.Equal(t, 95, r.From.Percent())
+	assert.Equal(t, emptyNodeID, r.To.NodeID)
+	assert.Equal(t, 10, r.To.Percent())
+
+	// nodes are balanced
+	a.highWatermark = 96
+	recommendations, err = a.Analyze(testCtx)
+	assert.Nil(t, err)
+	assert.Empty(t, recommendations)
+}
+
+func TestAnalyzer_analyzeAndReport(t *testing.T) {
+	a, recorder := setup(t)
+
+	createDrive(t, a, "drive-1", fullNodeID, apiV1.DriveTypeHDD, apiV1.HealthGood, 100)
+	createDrive(t, a, "drive-2", emptyNodeID, apiV1.DriveTypeHDD, apiV1.HealthGood, 100)
+	createAC(t, a, "ac-2", emptyNodeID, apiV1.StorageClassHDD, 100)
+
+	a.analyzeAndReport()
+	assert.Len(t, recorder.Calls, 1)
+	assert.Equal(t, eventing.CapacityRebalanceRecommended, recorder.Calls[0].Reason)
+	assert.Equal(t, "full-node", recorder.Calls[0].Object.(*coreV1.Node).Name)
+}
+
+func setup(t *testing.T) (*Analyzer, *mocks.NoOpRecorder) {
+	k8sClient, err := k8s.GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+
+	nodes := []*coreV1.Node{
+		{ObjectMeta: metaV1.ObjectMeta{
+			Name:        "full-node",
+			Annotations: map[string]string{common.NodeIDAnnotationKey: fullNodeID},
+		}},
+		{ObjectMeta: metaV1.ObjectMeta{Name: "empty-node", UID: types.UID(emptyNodeID)}},
+	}
+	for _, node := range nodes {
+		assert.Nil(t, k8sClient.Create(testCtx, node))
+	}
+
+	recorder := new(mocks.NoOpRecorder)
+	return NewAnalyzer(k8sClient, recorder, testLogger, 90, 30), recorder
+}
+
+func createDrive(t *testing.T, a *Analyzer, name, nodeID, driveType, health string, size int64) {
+	drive := a.k8sClient.ConstructDriveCR(name,
+		api.Drive{UUID: name, NodeId: nodeID, Type: driveType, Health: health, Size: size})
+	assert.Nil(t, a.k8sClient.CreateCR(testCtx, name, drive))
+}
+
+func createAC(t *testing.T, a *Analyzer, name, nodeID, sc string, size int64) {
+	ac := a.k8sClient.ConstructACCR(name,
+		api.AvailableCapacity{Location: name, NodeId: nodeID, StorageClass: sc, Size: size})
+	assert.Nil(t, a.k8sClient.CreateCR(testCtx, name, ac))
+}
@@ -0,0 +1,226 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package retention contains keeper of volumes which PV has Retain reclaim policy
+package retention
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/sirupsen/logrus"
+	coreV1 "k8s.io/api/core/v1"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/common"
+)
+
+// CheckInterval is the interval between two checks of volumes
+const CheckInterval = time.Minute
+
+// volumeDeleter is the part of CSI ControllerServer which is used for removal of released volumes
+type volumeDeleter interface {
+	DeleteVolume(ctx context.Context, req *csi.DeleteVolumeRequest) (*csi.DeleteVolumeResponse, error)
+}
+
+// Keeper moves volumes which PV was released and has Retain reclaim policy to Retained status, so partition or
+// logical volume with data is kept until admin decides what to do with it. Retained volume is bound to another
+// PVC through VolumeRebindAnnotationKey annotation or removed through VolumeReleaseAnnotationKey annotation
+type Keeper struct {
+	k8sClient *k8s.KubeClient
+	deleter   volumeDeleter
+
+	log *logrus.Entry
+}
+
+// NewKeeper is the constructor for Keeper struct
+// Receives an instance of base.KubeClient, CSI ControllerServer which removes volumes and logrus logger
+// Returns an instance of Keeper
+func NewKeeper(k8sClient *k8s.KubeClient, deleter volumeDeleter, logger *logrus.Logger) *Keeper {
+	return &Keeper{
+		k8sClient: k8sClient,
+		deleter:   deleter,
+		log:       logger.WithField("component", "RetentionKeeper"),
+	}
+}
+
+// Run spawns goroutine which periodically checks volumes
+func (k *Keeper) Run() {
+	go func() {
+		for {
+			ctx, cancelFn := context.WithTimeout(context.Background(), CheckInterval)
+			if err := k.Reconcile(ctx); err != nil {
+				k.log.WithField("method", "Run").Errorf("Unable to handle retained volumes: %v", err)
+			}
+			cancelFn()
+			time.Sleep(CheckInterval)
+		}
+	}()
+}
+
+// Reconcile retains volumes which PV was released and handles annotations of retained volumes
+// Returns error if Volume CRs can't be read or at least one of them wasn't handled
+func (k *Keeper) Reconcile(ctx context.Context) error {
+	ll := k.log.WithField("method", "Reconcile")
+
+	volumes := &volumecrd.VolumeList{}
+	if err := k.k8sClient.ReadList(ctx, volumes); err != nil {
+		return err
+	}
+
+	var lastErr error
+	for i := range volumes.Items {
+		volume := &volumes.Items[i]
+		var err error
+		switch volume.Spec.CSIStatus {
+		case apiV1.Created:
+			// volumes of generic ephemeral PVCs are handled by EphemeralCollector
+			if !volume.Spec.Ephemeral && len(volume.Spec.Owners) == 0 {
+				err = k.retainIfReleased(ctx, volume)
+			}
+		case apiV1.Retained:
+			if _, ok := volume.Annotations[apiV1.VolumeReleaseAnnotationKey]; ok {
+				err = k.release(ctx, volume)
+			} else if claim := volume.Annotations[apiV1.VolumeRebindAnnotationKey]; claim != "" {
+				err = k.rebind(ctx, volume, claim)
+			}
+		}
+		if err != nil {
+			ll.Errorf("Unable to handle volume %s: %v", volume.Name, err)
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// retainIfReleased sets Retained status if PV of the volume was released and has Retain reclaim policy,
+// name of PVC which used the volume is kept in VolumeRetainedClaimAnnotationKey annotation
+func (k *Keeper) retainIfReleased(ctx context.Context, volume *volumecrd.Volume) error {
+	pv := &coreV1.PersistentVolume{}
+	if err := k.k8sClient.Get(ctx, k8sCl.ObjectKey{Name: volume.Name}, pv); err != nil {
+		// PV could be not created yet
+		if k8sError.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if pv.Status.Phase != coreV1.VolumeReleased ||
+		pv.Spec.PersistentVolumeReclaimPolicy != coreV1.PersistentVolumeReclaimRetain {
+		return nil
+	}
+
+	if volume.Annotations == nil {
+		volume.Annotations = make(map[string]string)
+	}
+	if claim := pv.Spec.ClaimRef; claim != nil {
+		volume.Annotations[apiV1.VolumeRetainedClaimAnnotationKey] = claim.Namespace + "/" + claim.Name
+	}
+	volume.Spec.CSIStatus = apiV1.Retained
+	if err := k.k8sClient.UpdateCR(ctx, volume); err != nil {
+		return err
+	}
+	k.log.WithField("method", "retainIfReleased").Infof("Volume %s of released PVC %s is retained",
+		volume.Name, volume.Annotations[apiV1.VolumeRetainedClaimAnnotationKey])
+	return nil
+}
+
+// rebind pre-binds PV of retained volume to PVC, PV controller binds them when PVC with requested size and
+// storage class exists. PV which was deleted by admin is recreated with storage class of PVC
+func (k *Keeper) rebind(ctx context.Context, volume *volumecrd.Volume, claim string) error {
+	ll := k.log.WithFields(logrus.Fields{
+		"method":   "rebind",
+		"volumeID": volume.Name,
+	})
+
+	parts := strings.Split(claim, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("value %s of annotation %s must be in <namespace>/<name> format",
+			claim, apiV1.VolumeRebindAnnotationKey)
+	}
+	claimRef := &coreV1.ObjectReference{
+		Kind:       "PersistentVolumeClaim",
+		APIVersion: "v1",
+		Namespace:  parts[0],
+		Name:       parts[1],
+	}
+
+	pv := &coreV1.PersistentVolume{}
+	err := k.k8sClient.Get(ctx, k8sCl.ObjectKey{Name: volume.Name}, pv)
+	switch {
+	case err == nil:
+		pv.Spec.ClaimRef = claimRef
+		if err = k.k8sClient.Update(ctx, pv); err != nil {
+			return fmt.Errorf("unable to update PV: %v", err)
+		}
+	case k8sError.IsNotFound(err):
+		pvc := &coreV1.PersistentVolumeClaim{}
+		if err = k.k8sClient.Get(ctx, k8sCl.ObjectKey{Namespace: parts[0], Name: parts[1]}, pvc); err != nil {
+			if k8sError.IsNotFound(err) {
+				// storage class of PV is taken from PVC
+				ll.Infof("PVC %s doesn't exist yet, PV can't be created", claim)
+				return nil
+			}
+			return err
+		}
+		storageClassName := ""
+		if pvc.Spec.StorageClassName != nil {
+			storageClassName = *pvc.Spec.StorageClassName
+		}
+		pv = common.ConstructStaticPV(volume, storageClassName)
+		pv.Spec.ClaimRef = claimRef
+		if err = k.k8sClient.Create(ctx, pv); err != nil {
+			return fmt.Errorf("unable to create PV: %v", err)
+		}
+	default:
+		return err
+	}
+
+	delete(volume.Annotations, apiV1.VolumeRebindAnnotationKey)
+	delete(volume.Annotations, apiV1.VolumeRetainedClaimAnnotationKey)
+	volume.Spec.CSIStatus = apiV1.Created
+	if err = k.k8sClient.UpdateCR(ctx, volume); err != nil {
+		return err
+	}
+	ll.Infof("PV %s is bound to PVC %s", pv.Name, claim)
+	return nil
+}
+
+// release removes retained volume through CSI DeleteVolume and deletes its PV
+func (k *Keeper) release(ctx context.Context, volume *volumecrd.Volume) error {
+	if _, err := k.deleter.DeleteVolume(ctx, &csi.DeleteVolumeRequest{VolumeId: volume.Spec.Id}); err != nil {
+		return fmt.Errorf("unable to delete volume: %v", err)
+	}
+
+	pv := &coreV1.PersistentVolume{}
+	err := k.k8sClient.Get(ctx, k8sCl.ObjectKey{Name: volume.Name}, pv)
+	switch {
+	case err == nil:
+		if err = k.k8sClient.Delete(ctx, pv); err != nil && !k8sError.IsNotFound(err) {
+			return fmt.Errorf("unable to delete PV: %v", err)
+		}
+	case !k8sError.IsNotFound(err):
+		return err
+	}
+	k.log.WithField("method", "release").Infof("Retained volume %s was deleted", volume.Name)
+	return nil
+}
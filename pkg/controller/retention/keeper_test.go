@@ -0,0 +1,178 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"context"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+)
+
+var (
+	testCtx    = context.Background()
+	testLogger = logrus.New()
+	testNs     = "default"
+	testSC     = "csi-baremetal-sc-hdd"
+)
+
+// fakeDeleter stores IDs of deleted volumes
+type fakeDeleter struct {
+	deleted []string
+}
+
+func (f *fakeDeleter) DeleteVolume(_ context.Context, req *csi.DeleteVolumeRequest) (*csi.DeleteVolumeResponse, error) {
+	f.deleted = append(f.deleted, req.GetVolumeId())
+	return &csi.DeleteVolumeResponse{}, nil
+}
+
+func prepareKeeper(t *testing.T) (*Keeper, *k8s.KubeClient, *fakeDeleter) {
+	k8sClient, err := k8s.GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+	deleter := &fakeDeleter{}
+	return NewKeeper(k8sClient, deleter, testLogger), k8sClient, deleter
+}
+
+func createVolume(t *testing.T, k8sClient *k8s.KubeClient, name, status string, annotations map[string]string) {
+	volume := k8sClient.ConstructVolumeCR(name, api.Volume{Id: name, NodeId: "node-1", Size: 1024,
+		Mode: apiV1.ModeFS, Type: "xfs", CSIStatus: status})
+	volume.Annotations = annotations
+	assert.Nil(t, k8sClient.CreateCR(testCtx, name, volume))
+}
+
+func createPV(t *testing.T, k8sClient *k8s.KubeClient, name string, policy coreV1.PersistentVolumeReclaimPolicy) {
+	pv := &coreV1.PersistentVolume{
+		ObjectMeta: metaV1.ObjectMeta{Name: name},
+		Spec: coreV1.PersistentVolumeSpec{
+			PersistentVolumeReclaimPolicy: policy,
+			ClaimRef:                      &coreV1.ObjectReference{Namespace: testNs, Name: "old-claim", UID: "uid"},
+		},
+		Status: coreV1.PersistentVolumeStatus{Phase: coreV1.VolumeReleased},
+	}
+	assert.Nil(t, k8sClient.Create(testCtx, pv))
+}
+
+func readVolume(t *testing.T, k8sClient *k8s.KubeClient, name string) *volumecrd.Volume {
+	volume := &volumecrd.Volume{}
+	assert.Nil(t, k8sClient.ReadCR(testCtx, name, volume))
+	return volume
+}
+
+func TestKeeper_Retain(t *testing.T) {
+	k, k8sClient, deleter := prepareKeeper(t)
+
+	createVolume(t, k8sClient, "pvc-retain", apiV1.Created, nil)
+	createPV(t, k8sClient, "pvc-retain", coreV1.PersistentVolumeReclaimRetain)
+	// released PV with Delete policy is handled by external-provisioner
+	createVolume(t, k8sClient, "pvc-delete", apiV1.Created, nil)
+	createPV(t, k8sClient, "pvc-delete", coreV1.PersistentVolumeReclaimDelete)
+	// volume without PV
+	createVolume(t, k8sClient, "pvc-no-pv", apiV1.Created, nil)
+
+	assert.Nil(t, k.Reconcile(testCtx))
+	assert.Empty(t, deleter.deleted)
+
+	volume := readVolume(t, k8sClient, "pvc-retain")
+	assert.Equal(t, apiV1.Retained, volume.Spec.CSIStatus)
+	assert.Equal(t, testNs+"/old-claim", volume.Annotations[apiV1.VolumeRetainedClaimAnnotationKey])
+	assert.Equal(t, apiV1.Created, readVolume(t, k8sClient, "pvc-delete").Spec.CSIStatus)
+	assert.Equal(t, apiV1.Created, readVolume(t, k8sClient, "pvc-no-pv").Spec.CSIStatus)
+}
+
+func TestKeeper_Rebind(t *testing.T) {
+	t.Run("PV exists", func(t *testing.T) {
+		k, k8sClient, _ := prepareKeeper(t)
+		createVolume(t, k8sClient, "pvc-1", apiV1.Retained, map[string]string{
+			apiV1.VolumeRetainedClaimAnnotationKey: testNs + "/old-claim",
+			apiV1.VolumeRebindAnnotationKey:        testNs + "/new-claim",
+		})
+		createPV(t, k8sClient, "pvc-1", coreV1.PersistentVolumeReclaimRetain)
+
+		assert.Nil(t, k.Reconcile(testCtx))
+
+		pv := &coreV1.PersistentVolume{}
+		assert.Nil(t, k8sClient.Get(testCtx, k8sCl.ObjectKey{Name: "pvc-1"}, pv))
+		assert.Equal(t, "new-claim", pv.Spec.ClaimRef.Name)
+		assert.Empty(t, pv.Spec.ClaimRef.UID)
+		volume := readVolume(t, k8sClient, "pvc-1")
+		assert.Equal(t, apiV1.Created, volume.Spec.CSIStatus)
+		assert.Empty(t, volume.Annotations[apiV1.VolumeRebindAnnotationKey])
+		assert.Empty(t, volume.Annotations[apiV1.VolumeRetainedClaimAnnotationKey])
+	})
+
+	t.Run("PV is recreated", func(t *testing.T) {
+		k, k8sClient, _ := prepareKeeper(t)
+		createVolume(t, k8sClient, "pvc-2", apiV1.Retained, map[string]string{
+			apiV1.VolumeRebindAnnotationKey: testNs + "/new-claim",
+		})
+
+		// PVC doesn't exist yet
+		assert.Nil(t, k.Reconcile(testCtx))
+		assert.Equal(t, apiV1.Retained, readVolume(t, k8sClient, "pvc-2").Spec.CSIStatus)
+
+		pvc := &coreV1.PersistentVolumeClaim{
+			ObjectMeta: metaV1.ObjectMeta{Name: "new-claim", Namespace: testNs},
+			Spec:       coreV1.PersistentVolumeClaimSpec{StorageClassName: &testSC},
+		}
+		assert.Nil(t, k8sClient.Create(testCtx, pvc))
+		assert.Nil(t, k.Reconcile(testCtx))
+
+		pv := &coreV1.PersistentVolume{}
+		assert.Nil(t, k8sClient.Get(testCtx, k8sCl.ObjectKey{Name: "pvc-2"}, pv))
+		assert.Equal(t, testSC, pv.Spec.StorageClassName)
+		assert.Equal(t, "pvc-2", pv.Spec.CSI.VolumeHandle)
+		assert.Equal(t, "new-claim", pv.Spec.ClaimRef.Name)
+		assert.Equal(t, apiV1.Created, readVolume(t, k8sClient, "pvc-2").Spec.CSIStatus)
+	})
+
+	t.Run("Wrong claim", func(t *testing.T) {
+		k, k8sClient, _ := prepareKeeper(t)
+		createVolume(t, k8sClient, "pvc-3", apiV1.Retained, map[string]string{
+			apiV1.VolumeRebindAnnotationKey: "new-claim",
+		})
+
+		assert.NotNil(t, k.Reconcile(testCtx))
+		assert.Equal(t, apiV1.Retained, readVolume(t, k8sClient, "pvc-3").Spec.CSIStatus)
+	})
+}
+
+func TestKeeper_Release(t *testing.T) {
+	k, k8sClient, deleter := prepareKeeper(t)
+	createVolume(t, k8sClient, "pvc-1", apiV1.Retained, map[string]string{
+		apiV1.VolumeReleaseAnnotationKey: "",
+	})
+	createPV(t, k8sClient, "pvc-1", coreV1.PersistentVolumeReclaimRetain)
+	// retained volume without annotations is kept
+	createVolume(t, k8sClient, "pvc-2", apiV1.Retained, nil)
+
+	assert.Nil(t, k.Reconcile(testCtx))
+	assert.Equal(t, []string{"pvc-1"}, deleter.deleted)
+
+	err := k8sClient.Get(testCtx, k8sCl.ObjectKey{Name: "pvc-1"}, &coreV1.PersistentVolume{})
+	assert.True(t, k8sError.IsNotFound(err))
+}
@@ -0,0 +1,178 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package storagegroup contains labeler which assigns drives to StorageGroups
+package storagegroup
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	sgcrd "github.com/dell/csi-baremetal/api/v1/storagegroupcrd"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+)
+
+// DefaultInterval is the default interval between two passes of labeling
+const DefaultInterval = time.Minute
+
+// Labeler periodically matches drives against selectors of StorageGroups and keeps the name of the matched group
+// in apiV1.StorageGroupLabelKey label of Drive CR, volume operations place volumes only on drives of their group
+type Labeler struct {
+	k8sClient *k8s.KubeClient
+	interval  time.Duration
+
+	log *logrus.Entry
+}
+
+// NewLabeler is the constructor for Labeler struct
+// Receives an instance of base.KubeClient, logrus logger and interval between passes,
+// DefaultInterval is used if interval isn't positive
+// Returns an instance of Labeler
+func NewLabeler(k8sClient *k8s.KubeClient, logger *logrus.Logger, interval time.Duration) *Labeler {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Labeler{
+		k8sClient: k8sClient,
+		interval:  interval,
+		log:       logger.WithField("component", "StorageGroupLabeler"),
+	}
+}
+
+// Run spawns goroutine which periodically labels drives
+func (l *Labeler) Run() {
+	go func() {
+		for {
+			ctx, cancelFn := context.WithTimeout(context.Background(), l.interval)
+			if err := l.Label(ctx); err != nil {
+				l.log.WithField("method", "Run").Errorf("Unable to label drives: %v", err)
+			}
+			cancelFn()
+			time.Sleep(l.interval)
+		}
+	}()
+}
+
+// Label sets or removes storage group label of drives according to StorageGroups and updates amount of drives
+// in their status. Drive which is matched by several groups is assigned to the first one in alphabetical order
+// Returns error if CRs weren't read or at least one CR wasn't updated
+func (l *Labeler) Label(ctx context.Context) error {
+	ll := l.log.WithField("method", "Label")
+
+	groups := &sgcrd.StorageGroupList{}
+	if err := l.k8sClient.ReadList(ctx, groups); err != nil {
+		return err
+	}
+	drives := &drivecrd.DriveList{}
+	if err := l.k8sClient.ReadList(ctx, drives); err != nil {
+		return err
+	}
+	sort.Slice(groups.Items, func(i, j int) bool {
+		return groups.Items[i].Name < groups.Items[j].Name
+	})
+
+	var (
+		counts  = make(map[string]int, len(groups.Items))
+		lastErr error
+	)
+	for i := range drives.Items {
+		drive := &drives.Items[i]
+		group := ""
+		for _, sg := range groups.Items {
+			if !matches(&sg.Spec.DriveSelector, drive) {
+				continue
+			}
+			if group != "" {
+				ll.Warnf("Drive %s is selected by storage groups %s and %s, it is assigned to %s",
+					drive.Name, group, sg.Name, group)
+				break
+			}
+			group = sg.Name
+		}
+		counts[group]++
+
+		current := drive.GetLabels()[apiV1.StorageGroupLabelKey]
+		if current == group {
+			continue
+		}
+		ll.Infof("Moving drive %s from storage group %q to %q", drive.Name, current, group)
+		if group == "" {
+			delete(drive.Labels, apiV1.StorageGroupLabelKey)
+		} else {
+			if drive.Labels == nil {
+				drive.Labels = make(map[string]string)
+			}
+			drive.Labels[apiV1.StorageGroupLabelKey] = group
+		}
+		if err := l.k8sClient.UpdateCR(ctx, drive); err != nil && !k8sError.IsNotFound(err) {
+			ll.Errorf("Unable to update drive %s: %v", drive.Name, err)
+			lastErr = err
+		}
+	}
+
+	for i := range groups.Items {
+		sg := &groups.Items[i]
+		if sg.Status.Drives == counts[sg.Name] {
+			continue
+		}
+		sg.Status.Drives = counts[sg.Name]
+		if err := l.k8sClient.UpdateCR(ctx, sg); err != nil && !k8sError.IsNotFound(err) {
+			ll.Errorf("Unable to update status of storage group %s: %v", sg.Name, err)
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// matches returns true if drive matches all non-empty criteria of selector, empty selector doesn't match drives
+func matches(selector *sgcrd.DriveSelector, drive *drivecrd.Drive) bool {
+	if len(selector.SerialNumbers) == 0 && len(selector.Slots) == 0 && len(selector.Types) == 0 &&
+		len(selector.NodeIDs) == 0 && len(selector.MatchLabels) == 0 {
+		return false
+	}
+	if len(selector.SerialNumbers) > 0 && !util.ContainsString(selector.SerialNumbers, drive.Spec.SerialNumber) {
+		return false
+	}
+	if len(selector.Slots) > 0 && !util.ContainsString(selector.Slots, drive.Spec.Slot) {
+		return false
+	}
+	if len(selector.Types) > 0 && !containsFold(selector.Types, drive.Spec.Type) {
+		return false
+	}
+	if len(selector.NodeIDs) > 0 && !util.ContainsString(selector.NodeIDs, drive.Spec.NodeId) {
+		return false
+	}
+	return labels.SelectorFromSet(selector.MatchLabels).Matches(labels.Set(drive.GetLabels()))
+}
+
+// containsFold returns true if slice contains s regardless of case
+func containsFold(slice []string, s string) bool {
+	for _, item := range slice {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storagegroup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	sgcrd "github.com/dell/csi-baremetal/api/v1/storagegroupcrd"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+)
+
+var (
+	testCtx    = context.Background()
+	testLogger = logrus.New()
+	testNs     = "default"
+	testNodeID = "node-uuid"
+)
+
+func TestLabeler_Label(t *testing.T) {
+	k8sClient, err := k8s.GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+	l := NewLabeler(k8sClient, testLogger, 0)
+
+	ssd := k8sClient.ConstructDriveCR("drive-ssd", api.Drive{UUID: "drive-ssd", NodeId: testNodeID,
+		Type: apiV1.DriveTypeSSD, SerialNumber: "sn-ssd", Slot: "1"})
+	hdd := k8sClient.ConstructDriveCR("drive-hdd", api.Drive{UUID: "drive-hdd", NodeId: testNodeID,
+		Type: apiV1.DriveTypeHDD, SerialNumber: "sn-hdd", Slot: "2"})
+	// drive which was in the removed group
+	free := k8sClient.ConstructDriveCR("drive-free", api.Drive{UUID: "drive-free", NodeId: testNodeID,
+		Type: apiV1.DriveTypeHDD, SerialNumber: "sn-free", Slot: "3"})
+	free.Labels = map[string]string{apiV1.StorageGroupLabelKey: "removed"}
+	for _, d := range []*drivecrd.Drive{ssd, hdd, free} {
+		assert.Nil(t, k8sClient.CreateCR(testCtx, d.Name, d))
+	}
+
+	databases := &sgcrd.StorageGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "databases"},
+		Spec: sgcrd.StorageGroupSpec{DriveSelector: sgcrd.DriveSelector{
+			Types: []string{"ssd"}, NodeIDs: []string{testNodeID}}},
+	}
+	// selects the same SSD drive, but loses to databases in alphabetical order
+	logging := &sgcrd.StorageGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "logging"},
+		Spec: sgcrd.StorageGroupSpec{DriveSelector: sgcrd.DriveSelector{
+			SerialNumbers: []string{"sn-ssd", "sn-hdd"}}},
+	}
+	empty := &sgcrd.StorageGroup{ObjectMeta: metav1.ObjectMeta{Name: "empty"}}
+	for _, sg := range []*sgcrd.StorageGroup{databases, logging, empty} {
+		assert.Nil(t, k8sClient.CreateCR(testCtx, sg.Name, sg))
+	}
+
+	assert.Nil(t, l.Label(testCtx))
+
+	expected := map[string]string{"drive-ssd": "databases", "drive-hdd": "logging", "drive-free": ""}
+	for name, group := range expected {
+		drive := &drivecrd.Drive{}
+		assert.Nil(t, k8sClient.ReadCR(testCtx, name, drive))
+		assert.Equal(t, group, drive.Labels[apiV1.StorageGroupLabelKey], name)
+	}
+	for name, count := range map[string]int{"databases": 1, "logging": 1, "empty": 0} {
+		sg := &sgcrd.StorageGroup{}
+		assert.Nil(t, k8sClient.ReadCR(testCtx, name, sg))
+		assert.Equal(t, count, sg.Status.Drives, name)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	drive := &drivecrd.Drive{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"tier": "fast"}},
+		Spec:       api.Drive{SerialNumber: "sn", Slot: "1", Type: apiV1.DriveTypeNVMe, NodeId: testNodeID},
+	}
+	assert.False(t, matches(&sgcrd.DriveSelector{}, drive))
+	assert.True(t, matches(&sgcrd.DriveSelector{Slots: []string{"0", "1"}}, drive))
+	assert.True(t, matches(&sgcrd.DriveSelector{MatchLabels: map[string]string{"tier": "fast"}}, drive))
+	assert.False(t, matches(&sgcrd.DriveSelector{
+		Slots: []string{"1"}, MatchLabels: map[string]string{"tier": "slow"}}, drive))
+	assert.False(t, matches(&sgcrd.DriveSelector{Types: []string{apiV1.DriveTypeHDD}}, drive))
+}
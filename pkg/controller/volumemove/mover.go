@@ -0,0 +1,425 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package volumemove contains mover which orchestrates copying of data of local volume to the new volume
+// on another node through VolumeMove CRs
+package volumemove
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	coreV1 "k8s.io/api/core/v1"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/api/v1/volumemovecrd"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/controller/rebalance"
+	csibmnodeconst "github.com/dell/csi-baremetal/pkg/crcontrollers/csibmnode/common"
+)
+
+// DefaultInterval is the default interval between two reconciliations of VolumeMove CRs
+const DefaultInterval = 10 * time.Second
+
+// boundByControllerAnnotation is set by k8s on PV which was bound by PV controller, it isn't copied to the new PV
+const boundByControllerAnnotation = "pv.kubernetes.io/bound-by-controller"
+
+// volumeProvisioner is the part of CSI ControllerServer which is used for creation and removal of target volumes
+type volumeProvisioner interface {
+	CreateVolume(ctx context.Context, req *csi.CreateVolumeRequest) (*csi.CreateVolumeResponse, error)
+	DeleteVolume(ctx context.Context, req *csi.DeleteVolumeRequest) (*csi.DeleteVolumeResponse, error)
+}
+
+// Mover drives VolumeMove CRs through their phases on the controller side. It provisions the target volume
+// on the target node, nodes copy the data and then Mover binds PVC of the source volume to the new PV of
+// the target volume. Source node must be alive and source volume must not be staged for the whole move,
+// therefore pods which use PVC must be stopped, e.g. StatefulSet is scaled down
+type Mover struct {
+	k8sClient   *k8s.KubeClient
+	provisioner volumeProvisioner
+	// interval between two reconciliations
+	interval time.Duration
+
+	log *logrus.Entry
+}
+
+// NewMover is the constructor for Mover struct
+// Receives an instance of base.KubeClient, CSI ControllerServer which creates and removes volumes, logrus logger
+// and interval between reconciliations, default value is used if interval isn't positive
+// Returns an instance of Mover
+func NewMover(k8sClient *k8s.KubeClient, provisioner volumeProvisioner, logger *logrus.Logger,
+	interval time.Duration) *Mover {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Mover{
+		k8sClient:   k8sClient,
+		provisioner: provisioner,
+		interval:    interval,
+		log:         logger.WithField("component", "VolumeMover"),
+	}
+}
+
+// Run spawns goroutine which periodically reconciles VolumeMove CRs
+func (m *Mover) Run() {
+	go func() {
+		for {
+			ctx, cancelFn := context.WithTimeout(context.Background(), base.DefaultTimeoutForVolumeOperations)
+			if err := m.Reconcile(ctx); err != nil {
+				m.log.WithField("method", "Run").Errorf("Unable to reconcile volume moves: %v", err)
+			}
+			cancelFn()
+			time.Sleep(m.interval)
+		}
+	}()
+}
+
+// Reconcile moves each VolumeMove CR to the next phase if it is handled by controller
+// Returns error if VolumeMove CRs can't be read or at least one of them wasn't handled
+func (m *Mover) Reconcile(ctx context.Context) error {
+	ll := m.log.WithField("method", "Reconcile")
+
+	moves := &volumemovecrd.VolumeMoveList{}
+	if err := m.k8sClient.ReadList(ctx, moves); err != nil {
+		return err
+	}
+
+	var lastErr error
+	for i := range moves.Items {
+		move := &moves.Items[i]
+		if err := m.handleMove(ctx, move); err != nil {
+			ll.Errorf("Unable to handle volume move %s in phase %s: %v", move.Name, move.Spec.Phase, err)
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// handleMove performs the step of the move which corresponds to its phase, phases RECEIVING and COPYING
+// are handled by nodes
+func (m *Mover) handleMove(ctx context.Context, move *volumemovecrd.VolumeMove) error {
+	switch move.Spec.Phase {
+	case apiV1.Empty:
+		return m.startMove(ctx, move)
+	case apiV1.VolumeMoveProvisioning:
+		return m.provisionTarget(ctx, move)
+	case apiV1.VolumeMoveRebinding:
+		return m.rebind(ctx, move)
+	case apiV1.VolumeMoveFailed:
+		return m.cleanupTarget(ctx, move)
+	}
+	return nil
+}
+
+// startMove validates source volume and chooses ID of target volume
+func (m *Mover) startMove(ctx context.Context, move *volumemovecrd.VolumeMove) error {
+	source := &volumecrd.Volume{}
+	err := m.k8sClient.ReadCR(ctx, move.Spec.SourceVolumeID, source)
+	switch {
+	case k8sError.IsNotFound(err):
+		return m.fail(ctx, move, fmt.Errorf("source volume %s doesn't exist", move.Spec.SourceVolumeID))
+	case err != nil:
+		return err
+	}
+	if move.Spec.TargetNodeID == "" {
+		if move.Spec.TargetNodeID, err = m.chooseTargetNode(ctx, source); err != nil {
+			return m.fail(ctx, move, err)
+		}
+	}
+	if err = checkSourceVolume(source, move.Spec.TargetNodeID); err != nil {
+		return m.fail(ctx, move, err)
+	}
+
+	// ID is saved before creation of the volume, so the same volume is created after restart of controller
+	move.Spec.TargetVolumeID = "pvc-" + uuid.New().String()
+	move.Spec.Phase = apiV1.VolumeMoveProvisioning
+	m.log.WithField("method", "startMove").Infof("Volume %s is moved to node %s as volume %s",
+		source.Spec.Id, move.Spec.TargetNodeID, move.Spec.TargetVolumeID)
+	return m.k8sClient.UpdateCR(ctx, move)
+}
+
+// chooseTargetNode returns ID of the node other than the node of the source volume which has the most free
+// capacity of the storage class of the source volume, LVG storage classes are compared by underlying class
+func (m *Mover) chooseTargetNode(ctx context.Context, source *volumecrd.Volume) (string, error) {
+	utilization, err := rebalance.GetUtilization(ctx, m.k8sClient)
+	if err != nil {
+		return "", err
+	}
+
+	sc := source.Spec.StorageClass
+	if subSC := util.GetSubStorageClass(sc); subSC != "" {
+		sc = subSC
+	}
+	var (
+		target string
+		free   int64
+	)
+	for _, u := range utilization {
+		if u.StorageClass != sc || u.NodeID == source.Spec.NodeId || u.Free < source.Spec.Size {
+			continue
+		}
+		if target == "" || u.Free > free {
+			target, free = u.NodeID, u.Free
+		}
+	}
+	if target == "" {
+		return "", fmt.Errorf("there is no node with %d bytes of free %s capacity for volume %s",
+			source.Spec.Size, sc, source.Spec.Id)
+	}
+	return target, nil
+}
+
+// checkSourceVolume checks whether data of the volume could be copied to the target node
+func checkSourceVolume(source *volumecrd.Volume, targetNodeID string) error {
+	switch {
+	case source.Spec.NodeId == targetNodeID:
+		return fmt.Errorf("volume %s is already located on node %s", source.Spec.Id, targetNodeID)
+	case source.Spec.Ephemeral:
+		return fmt.Errorf("volume %s is an inline ephemeral volume", source.Spec.Id)
+	case source.Spec.Mode != apiV1.ModeFS:
+		return fmt.Errorf("volume %s has mode %s, only %s volumes are supported",
+			source.Spec.Id, source.Spec.Mode, apiV1.ModeFS)
+	case source.Spec.CSIStatus != apiV1.Created:
+		return fmt.Errorf("volume %s is in status %s, pods which use it must be stopped",
+			source.Spec.Id, source.Spec.CSIStatus)
+	}
+	return nil
+}
+
+// provisionTarget creates target volume of the same storage class and file system on the target node,
+// target volume could be larger than the source one because of size policy or drive size
+func (m *Mover) provisionTarget(ctx context.Context, move *volumemovecrd.VolumeMove) error {
+	source := &volumecrd.Volume{}
+	if err := m.k8sClient.ReadCR(ctx, move.Spec.SourceVolumeID, source); err != nil {
+		if k8sError.IsNotFound(err) {
+			return m.fail(ctx, move, fmt.Errorf("source volume %s doesn't exist", move.Spec.SourceVolumeID))
+		}
+		return err
+	}
+
+	resp, err := m.provisioner.CreateVolume(ctx, &csi.CreateVolumeRequest{
+		Name:          move.Spec.TargetVolumeID,
+		CapacityRange: &csi.CapacityRange{RequiredBytes: source.Spec.Size},
+		VolumeCapabilities: []*csi.VolumeCapability{{
+			AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: source.Spec.Type}},
+		}},
+		Parameters: map[string]string{base.StorageTypeKey: source.Spec.StorageClass},
+		AccessibilityRequirements: &csi.TopologyRequirement{
+			Preferred: []*csi.Topology{
+				{Segments: map[string]string{csibmnodeconst.NodeIDAnnotationKey: move.Spec.TargetNodeID}},
+			},
+		},
+	})
+	if err != nil {
+		return m.fail(ctx, move, fmt.Errorf("unable to create target volume: %v", err))
+	}
+
+	volume := resp.GetVolume()
+	topology := volume.GetAccessibleTopology()
+	if len(topology) == 0 || topology[0].GetSegments()[csibmnodeconst.NodeIDAnnotationKey] != move.Spec.TargetNodeID {
+		return m.fail(ctx, move, fmt.Errorf("target volume wasn't created on node %s", move.Spec.TargetNodeID))
+	}
+	if volume.GetCapacityBytes() < source.Spec.Size {
+		return m.fail(ctx, move, fmt.Errorf("target volume of %d bytes is smaller than source volume of %d bytes",
+			volume.GetCapacityBytes(), source.Spec.Size))
+	}
+
+	move.Spec.Phase = apiV1.VolumeMoveReceiving
+	return m.k8sClient.UpdateCR(ctx, move)
+}
+
+// rebind creates PV of target volume pre-bound to PVC of source volume, deletes PVC and recreates it with
+// the same name, so StatefulSet pod uses the target volume on start. PV of source volume is released and handled
+// according to its reclaim policy
+func (m *Mover) rebind(ctx context.Context, move *volumemovecrd.VolumeMove) error {
+	ll := m.log.WithFields(logrus.Fields{
+		"method": "rebind",
+		"move":   move.Name,
+	})
+
+	sourcePV := &coreV1.PersistentVolume{}
+	err := m.k8sClient.Get(ctx, k8sCl.ObjectKey{Name: move.Spec.SourceVolumeID}, sourcePV)
+	switch {
+	case k8sError.IsNotFound(err):
+		return m.fail(ctx, move, fmt.Errorf("PV %s doesn't exist", move.Spec.SourceVolumeID))
+	case err != nil:
+		return err
+	case sourcePV.Spec.ClaimRef == nil || sourcePV.Spec.CSI == nil:
+		return m.fail(ctx, move, fmt.Errorf("PV %s isn't bound to PVC", sourcePV.Name))
+	}
+	claim := sourcePV.Spec.ClaimRef
+
+	targetPV := &coreV1.PersistentVolume{}
+	err = m.k8sClient.Get(ctx, k8sCl.ObjectKey{Name: move.Spec.TargetVolumeID}, targetPV)
+	switch {
+	case k8sError.IsNotFound(err):
+		targetPV = constructTargetPV(sourcePV, move)
+		if err = m.k8sClient.Create(ctx, targetPV); err != nil {
+			return fmt.Errorf("unable to create PV %s: %v", targetPV.Name, err)
+		}
+		ll.Infof("PV %s was created", targetPV.Name)
+	case err != nil:
+		return err
+	}
+
+	pvc := &coreV1.PersistentVolumeClaim{}
+	err = m.k8sClient.Get(ctx, k8sCl.ObjectKey{Namespace: claim.Namespace, Name: claim.Name}, pvc)
+	switch {
+	case k8sError.IsNotFound(err):
+		pvc = constructTargetPVC(targetPV)
+		if err = m.k8sClient.Create(ctx, pvc); err != nil {
+			return fmt.Errorf("unable to create PVC %s/%s: %v", pvc.Namespace, pvc.Name, err)
+		}
+		ll.Infof("PVC %s/%s was recreated", pvc.Namespace, pvc.Name)
+	case err != nil:
+		return err
+	case pvc.UID == claim.UID:
+		// PVC is deleted when pods which use it are removed
+		if pvc.DeletionTimestamp == nil {
+			if err = m.k8sClient.Delete(ctx, pvc); err != nil {
+				return fmt.Errorf("unable to delete PVC %s/%s: %v", pvc.Namespace, pvc.Name, err)
+			}
+			ll.Infof("PVC %s/%s was deleted", pvc.Namespace, pvc.Name)
+		}
+		return nil
+	default:
+		// PVC was already recreated, e.g. by StatefulSet controller, it is bound to pre-bound PV
+		ll.Infof("PVC %s/%s was recreated by other controller", pvc.Namespace, pvc.Name)
+	}
+
+	move.Spec.Phase = apiV1.VolumeMoveCompleted
+	return m.k8sClient.UpdateCR(ctx, move)
+}
+
+// constructTargetPV copies PV of source volume with volume handle and node affinity of target volume,
+// the copy is pre-bound to PVC by name, so PV controller binds it to the recreated PVC
+func constructTargetPV(sourcePV *coreV1.PersistentVolume, move *volumemovecrd.VolumeMove) *coreV1.PersistentVolume {
+	pv := &coreV1.PersistentVolume{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:        move.Spec.TargetVolumeID,
+			Labels:      sourcePV.Labels,
+			Annotations: make(map[string]string),
+		},
+		Spec: *sourcePV.Spec.DeepCopy(),
+	}
+	for key, value := range sourcePV.Annotations {
+		if key != boundByControllerAnnotation {
+			pv.Annotations[key] = value
+		}
+	}
+
+	pv.Spec.CSI.VolumeHandle = move.Spec.TargetVolumeID
+	pv.Spec.ClaimRef = &coreV1.ObjectReference{
+		Kind:       sourcePV.Spec.ClaimRef.Kind,
+		APIVersion: sourcePV.Spec.ClaimRef.APIVersion,
+		Namespace:  sourcePV.Spec.ClaimRef.Namespace,
+		Name:       sourcePV.Spec.ClaimRef.Name,
+	}
+	if pv.Spec.NodeAffinity != nil && pv.Spec.NodeAffinity.Required != nil {
+		for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+			for _, expr := range term.MatchExpressions {
+				if expr.Key != csibmnodeconst.NodeIDAnnotationKey {
+					continue
+				}
+				for i := range expr.Values {
+					expr.Values[i] = move.Spec.TargetNodeID
+				}
+			}
+		}
+	}
+	return pv
+}
+
+// constructTargetPVC constructs PVC which is bound to the PV of target volume, name of PVC is taken from
+// claim reference of PV
+func constructTargetPVC(targetPV *coreV1.PersistentVolume) *coreV1.PersistentVolumeClaim {
+	storageClassName := targetPV.Spec.StorageClassName
+	return &coreV1.PersistentVolumeClaim{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      targetPV.Spec.ClaimRef.Name,
+			Namespace: targetPV.Spec.ClaimRef.Namespace,
+		},
+		Spec: coreV1.PersistentVolumeClaimSpec{
+			AccessModes: targetPV.Spec.AccessModes,
+			Resources: coreV1.ResourceRequirements{
+				Requests: coreV1.ResourceList{coreV1.ResourceStorage: targetPV.Spec.Capacity[coreV1.ResourceStorage]},
+			},
+			StorageClassName: &storageClassName,
+			VolumeMode:       targetPV.Spec.VolumeMode,
+			VolumeName:       targetPV.Name,
+		},
+	}
+}
+
+// cleanupTarget deletes target volume of failed move unless PV was already created for it,
+// ID of target volume is cleared when volume is deleted
+func (m *Mover) cleanupTarget(ctx context.Context, move *volumemovecrd.VolumeMove) error {
+	if move.Spec.TargetVolumeID == "" {
+		return nil
+	}
+
+	err := m.k8sClient.Get(ctx, k8sCl.ObjectKey{Name: move.Spec.TargetVolumeID}, &coreV1.PersistentVolume{})
+	switch {
+	case err == nil:
+		// target volume is handled through its PV
+		return nil
+	case !k8sError.IsNotFound(err):
+		return err
+	}
+
+	ll := m.log.WithFields(logrus.Fields{
+		"method": "cleanupTarget",
+		"move":   move.Name,
+	})
+	target := &volumecrd.Volume{}
+	err = m.k8sClient.ReadCR(ctx, move.Spec.TargetVolumeID, target)
+	switch {
+	case err != nil && !k8sError.IsNotFound(err):
+		return err
+	case err == nil && target.Spec.CSIStatus == apiV1.Failed:
+		// failed volume can't be deleted through CSI, it is left for investigation
+		ll.Warnf("Target volume %s has failed status and isn't deleted", move.Spec.TargetVolumeID)
+	default:
+		if _, err = m.provisioner.DeleteVolume(ctx,
+			&csi.DeleteVolumeRequest{VolumeId: move.Spec.TargetVolumeID}); err != nil {
+			return fmt.Errorf("unable to delete target volume %s: %v", move.Spec.TargetVolumeID, err)
+		}
+		ll.Infof("Target volume %s was deleted", move.Spec.TargetVolumeID)
+	}
+	move.Spec.TargetVolumeID = ""
+	return m.k8sClient.UpdateCR(ctx, move)
+}
+
+// fail sets FAILED phase with the reason of failure
+func (m *Mover) fail(ctx context.Context, move *volumemovecrd.VolumeMove, reason error) error {
+	m.log.WithFields(logrus.Fields{
+		"method": "fail",
+		"move":   move.Name,
+	}).Errorf("Volume move failed in phase %s: %v", move.Spec.Phase, reason)
+	move.Spec.Phase = apiV1.VolumeMoveFailed
+	move.Spec.Message = reason.Error()
+	return m.k8sClient.UpdateCR(ctx, move)
+}
@@ -0,0 +1,250 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumemove
+
+import (
+	"context"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/volumemovecrd"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	csibmnodeconst "github.com/dell/csi-baremetal/pkg/crcontrollers/csibmnode/common"
+)
+
+var (
+	testCtx        = context.Background()
+	testLogger     = logrus.New()
+	testNs         = "default"
+	testPVCNs      = "apps"
+	testSourceID   = "pvc-source"
+	testSourceNode = "node-1"
+	testTargetNode = "node-2"
+)
+
+// fakeProvisioner stores requests and creates volumes on the requested node
+type fakeProvisioner struct {
+	created []*csi.CreateVolumeRequest
+	deleted []string
+}
+
+func (f *fakeProvisioner) CreateVolume(_ context.Context, req *csi.CreateVolumeRequest) (*csi.CreateVolumeResponse, error) {
+	f.created = append(f.created, req)
+	return &csi.CreateVolumeResponse{Volume: &csi.Volume{
+		VolumeId:           req.GetName(),
+		CapacityBytes:      req.GetCapacityRange().GetRequiredBytes(),
+		AccessibleTopology: req.GetAccessibilityRequirements().GetPreferred(),
+	}}, nil
+}
+
+func (f *fakeProvisioner) DeleteVolume(_ context.Context, req *csi.DeleteVolumeRequest) (*csi.DeleteVolumeResponse, error) {
+	f.deleted = append(f.deleted, req.GetVolumeId())
+	return &csi.DeleteVolumeResponse{}, nil
+}
+
+func prepareMover(t *testing.T) (*Mover, *k8s.KubeClient, *fakeProvisioner) {
+	k8sClient, err := k8s.GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+	provisioner := &fakeProvisioner{}
+	return NewMover(k8sClient, provisioner, testLogger, 0), k8sClient, provisioner
+}
+
+func createSourceVolume(t *testing.T, k8sClient *k8s.KubeClient, csiStatus string) {
+	volume := k8sClient.ConstructVolumeCR(testSourceID, api.Volume{Id: testSourceID, NodeId: testSourceNode,
+		Size: 1024, StorageClass: apiV1.StorageClassHDD, Mode: apiV1.ModeFS, Type: "xfs", CSIStatus: csiStatus})
+	assert.Nil(t, k8sClient.CreateCR(testCtx, testSourceID, volume))
+}
+
+func createMove(t *testing.T, k8sClient *k8s.KubeClient, spec volumemovecrd.VolumeMoveSpec) {
+	move := &volumemovecrd.VolumeMove{
+		TypeMeta:   metaV1.TypeMeta{Kind: apiV1.VolumeMoveKind, APIVersion: apiV1.APIV1Version},
+		ObjectMeta: metaV1.ObjectMeta{Name: "move", Namespace: testNs},
+		Spec:       spec,
+	}
+	assert.Nil(t, k8sClient.CreateCR(testCtx, move.Name, move))
+}
+
+func readMove(t *testing.T, k8sClient *k8s.KubeClient) *volumemovecrd.VolumeMove {
+	move := &volumemovecrd.VolumeMove{}
+	assert.Nil(t, k8sClient.ReadCR(testCtx, "move", move))
+	return move
+}
+
+func TestMover_Reconcile(t *testing.T) {
+	m, k8sClient, provisioner := prepareMover(t)
+	createSourceVolume(t, k8sClient, apiV1.Created)
+	createMove(t, k8sClient, volumemovecrd.VolumeMoveSpec{SourceVolumeID: testSourceID, TargetNodeID: testTargetNode})
+
+	// target volume ID is chosen
+	assert.Nil(t, m.Reconcile(testCtx))
+	move := readMove(t, k8sClient)
+	assert.Equal(t, apiV1.VolumeMoveProvisioning, move.Spec.Phase)
+	assert.NotEmpty(t, move.Spec.TargetVolumeID)
+	targetID := move.Spec.TargetVolumeID
+
+	// target volume is created on target node
+	assert.Nil(t, m.Reconcile(testCtx))
+	assert.Equal(t, apiV1.VolumeMoveReceiving, readMove(t, k8sClient).Spec.Phase)
+	assert.Len(t, provisioner.created, 1)
+	req := provisioner.created[0]
+	assert.Equal(t, targetID, req.GetName())
+	assert.Equal(t, int64(1024), req.GetCapacityRange().GetRequiredBytes())
+	assert.Equal(t, apiV1.StorageClassHDD, req.GetParameters()[base.StorageTypeKey])
+	assert.Equal(t, "xfs", req.GetVolumeCapabilities()[0].GetMount().GetFsType())
+
+	// receiving and copying are handled by nodes
+	assert.Nil(t, m.Reconcile(testCtx))
+	assert.Equal(t, apiV1.VolumeMoveReceiving, readMove(t, k8sClient).Spec.Phase)
+
+	move = readMove(t, k8sClient)
+	move.Spec.Phase = apiV1.VolumeMoveRebinding
+	assert.Nil(t, k8sClient.UpdateCR(testCtx, move))
+	storageClassName := "csi-baremetal-sc-hdd"
+	sourcePV := &coreV1.PersistentVolume{
+		ObjectMeta: metaV1.ObjectMeta{Name: testSourceID,
+			Annotations: map[string]string{boundByControllerAnnotation: "yes"}},
+		Spec: coreV1.PersistentVolumeSpec{
+			Capacity:    coreV1.ResourceList{coreV1.ResourceStorage: *resource.NewQuantity(1024, resource.BinarySI)},
+			AccessModes: []coreV1.PersistentVolumeAccessMode{coreV1.ReadWriteOnce},
+			PersistentVolumeSource: coreV1.PersistentVolumeSource{
+				CSI: &coreV1.CSIPersistentVolumeSource{VolumeHandle: testSourceID}},
+			ClaimRef:         &coreV1.ObjectReference{Namespace: testPVCNs, Name: "data-app-0", UID: "uid-1"},
+			StorageClassName: storageClassName,
+			NodeAffinity: &coreV1.VolumeNodeAffinity{Required: &coreV1.NodeSelector{
+				NodeSelectorTerms: []coreV1.NodeSelectorTerm{{MatchExpressions: []coreV1.NodeSelectorRequirement{{
+					Key:      csibmnodeconst.NodeIDAnnotationKey,
+					Operator: coreV1.NodeSelectorOpIn,
+					Values:   []string{testSourceNode},
+				}}}}}},
+		},
+	}
+	assert.Nil(t, k8sClient.Create(testCtx, sourcePV))
+	pvc := &coreV1.PersistentVolumeClaim{
+		ObjectMeta: metaV1.ObjectMeta{Name: "data-app-0", Namespace: testPVCNs, UID: "uid-1"},
+		Spec:       coreV1.PersistentVolumeClaimSpec{VolumeName: testSourceID},
+	}
+	assert.Nil(t, k8sClient.Create(testCtx, pvc))
+
+	// target PV is created and old PVC is deleted
+	assert.Nil(t, m.Reconcile(testCtx))
+	assert.Equal(t, apiV1.VolumeMoveRebinding, readMove(t, k8sClient).Spec.Phase)
+	targetPV := &coreV1.PersistentVolume{}
+	assert.Nil(t, k8sClient.Get(testCtx, k8sCl.ObjectKey{Name: targetID}, targetPV))
+	assert.Equal(t, targetID, targetPV.Spec.CSI.VolumeHandle)
+	assert.Equal(t, "data-app-0", targetPV.Spec.ClaimRef.Name)
+	assert.Empty(t, targetPV.Spec.ClaimRef.UID)
+	assert.Equal(t, []string{testTargetNode},
+		targetPV.Spec.NodeAffinity.Required.NodeSelectorTerms[0].MatchExpressions[0].Values)
+	assert.NotContains(t, targetPV.Annotations, boundByControllerAnnotation)
+	err := k8sClient.Get(testCtx, k8sCl.ObjectKey{Namespace: testPVCNs, Name: "data-app-0"}, pvc)
+	assert.True(t, k8sError.IsNotFound(err))
+
+	// PVC is recreated with the target PV
+	assert.Nil(t, m.Reconcile(testCtx))
+	assert.Equal(t, apiV1.VolumeMoveCompleted, readMove(t, k8sClient).Spec.Phase)
+	pvc = &coreV1.PersistentVolumeClaim{}
+	assert.Nil(t, k8sClient.Get(testCtx, k8sCl.ObjectKey{Namespace: testPVCNs, Name: "data-app-0"}, pvc))
+	assert.Equal(t, targetID, pvc.Spec.VolumeName)
+	assert.Equal(t, storageClassName, *pvc.Spec.StorageClassName)
+	assert.Equal(t, int64(1024), pvc.Spec.Resources.Requests.Storage().Value())
+}
+
+func TestMover_ReconcileFailed(t *testing.T) {
+	// source volume doesn't exist
+	m, k8sClient, _ := prepareMover(t)
+	createMove(t, k8sClient, volumemovecrd.VolumeMoveSpec{SourceVolumeID: testSourceID, TargetNodeID: testTargetNode})
+	assert.Nil(t, m.Reconcile(testCtx))
+	assert.Equal(t, apiV1.VolumeMoveFailed, readMove(t, k8sClient).Spec.Phase)
+
+	// source volume is in use
+	m, k8sClient, _ = prepareMover(t)
+	createSourceVolume(t, k8sClient, apiV1.Published)
+	createMove(t, k8sClient, volumemovecrd.VolumeMoveSpec{SourceVolumeID: testSourceID, TargetNodeID: testTargetNode})
+	assert.Nil(t, m.Reconcile(testCtx))
+	move := readMove(t, k8sClient)
+	assert.Equal(t, apiV1.VolumeMoveFailed, move.Spec.Phase)
+	assert.Contains(t, move.Spec.Message, apiV1.Published)
+
+	// source volume is already on target node
+	m, k8sClient, _ = prepareMover(t)
+	createSourceVolume(t, k8sClient, apiV1.Created)
+	createMove(t, k8sClient, volumemovecrd.VolumeMoveSpec{SourceVolumeID: testSourceID, TargetNodeID: testSourceNode})
+	assert.Nil(t, m.Reconcile(testCtx))
+	assert.Equal(t, apiV1.VolumeMoveFailed, readMove(t, k8sClient).Spec.Phase)
+
+	// target volume of failed move is deleted
+	m, k8sClient, provisioner := prepareMover(t)
+	createMove(t, k8sClient, volumemovecrd.VolumeMoveSpec{SourceVolumeID: testSourceID, TargetNodeID: testTargetNode,
+		TargetVolumeID: "pvc-target", Phase: apiV1.VolumeMoveFailed})
+	assert.Nil(t, m.Reconcile(testCtx))
+	assert.Equal(t, []string{"pvc-target"}, provisioner.deleted)
+	assert.Empty(t, readMove(t, k8sClient).Spec.TargetVolumeID)
+	assert.Nil(t, m.Reconcile(testCtx))
+	assert.Len(t, provisioner.deleted, 1)
+}
+
+func TestMover_ChooseTargetNode(t *testing.T) {
+	m, k8sClient, _ := prepareMover(t)
+	createSourceVolume(t, k8sClient, apiV1.Created)
+	for _, node := range []struct {
+		id, sc string
+		acSize int64
+	}{
+		{id: testSourceNode, sc: apiV1.StorageClassHDD, acSize: 8192},
+		{id: "node-2", sc: apiV1.StorageClassHDDLVG, acSize: 2048},
+		{id: "node-3", sc: apiV1.StorageClassHDD, acSize: 4096},
+		{id: "node-4", sc: apiV1.StorageClassSSD, acSize: 16384},
+	} {
+		name := "drive-" + node.id
+		drive := k8sClient.ConstructDriveCR(name, api.Drive{UUID: name, NodeId: node.id,
+			Type: apiV1.DriveTypeHDD, Health: apiV1.HealthGood, Size: 16384})
+		if node.sc == apiV1.StorageClassSSD {
+			drive.Spec.Type = apiV1.DriveTypeSSD
+		}
+		assert.Nil(t, k8sClient.CreateCR(testCtx, name, drive))
+		ac := k8sClient.ConstructACCR(name, api.AvailableCapacity{Location: name, NodeId: node.id,
+			StorageClass: node.sc, Size: node.acSize})
+		assert.Nil(t, k8sClient.CreateCR(testCtx, name, ac))
+	}
+
+	// node with the most free HDD capacity except the source node is chosen
+	createMove(t, k8sClient, volumemovecrd.VolumeMoveSpec{SourceVolumeID: testSourceID})
+	assert.Nil(t, m.Reconcile(testCtx))
+	move := readMove(t, k8sClient)
+	assert.Equal(t, apiV1.VolumeMoveProvisioning, move.Spec.Phase)
+	assert.Equal(t, "node-3", move.Spec.TargetNodeID)
+
+	// there is no node with enough free capacity
+	m, k8sClient, _ = prepareMover(t)
+	createSourceVolume(t, k8sClient, apiV1.Created)
+	createMove(t, k8sClient, volumemovecrd.VolumeMoveSpec{SourceVolumeID: testSourceID})
+	assert.Nil(t, m.Reconcile(testCtx))
+	move = readMove(t, k8sClient)
+	assert.Equal(t, apiV1.VolumeMoveFailed, move.Spec.Phase)
+	assert.Empty(t, move.Spec.TargetNodeID)
+}
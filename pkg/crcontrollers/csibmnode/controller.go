@@ -35,6 +35,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
 	nodecrd "github.com/dell/csi-baremetal/api/v1/csibmnodecrd"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
 	"github.com/dell/csi-baremetal/pkg/crcontrollers/csibmnode/common"
@@ -239,6 +240,19 @@ func (bmc *Controller) reconcileForK8sNode(k8sNode *coreV1.Node) (ctrl.Result, e
 		bmNodes = bmNodeCRs.Items
 	}
 
+	// hardware fingerprint survives OS reinstallation, when k8s node could get new name and addresses
+	for i := range bmNodes {
+		if matchFingerprint(&bmNodes[i], k8sNode) {
+			bmNode = &bmNodes[i]
+			ll.Infof("CSIBMNode %s matches k8s node by fingerprint %s", bmNode.Name, bmNode.Spec.SystemUUID)
+			if res, err := bmc.syncCSIBMNode(bmNode, k8sNode); err != nil {
+				return res, err
+			}
+			bmc.cache.put(k8sNode.Name, bmNode.Name)
+			return bmc.updateAnnotation(k8sNode, bmNode.Spec.UUID)
+		}
+	}
+
 	matchedCRs := make([]string, 0)
 	for i := range bmNodes {
 		matchedAddresses := bmc.matchedAddressesCount(&bmNodes[i], k8sNode)
@@ -265,13 +279,21 @@ func (bmc *Controller) reconcileForK8sNode(k8sNode *coreV1.Node) (ctrl.Result, e
 		id := uuid.New().String()
 		bmNodeName := namePrefix + id
 		bmNode = bmc.k8sClient.ConstructCSIBMNodeCR(bmNodeName, api.CSIBMNode{
-			UUID:      id,
-			Addresses: bmc.constructAddresses(k8sNode),
+			UUID:       id,
+			Addresses:  bmc.constructAddresses(k8sNode),
+			SystemUUID: k8sNode.Status.NodeInfo.SystemUUID,
 		})
 		if err := bmc.k8sClient.CreateCR(context.Background(), bmNodeName, bmNode); err != nil {
 			ll.Errorf("Unable to create CSIBMNode CR: %v", err)
 			return ctrl.Result{Requeue: true}, err
 		}
+	} else if bmNode.Spec.SystemUUID == "" && k8sNode.Status.NodeInfo.SystemUUID != "" {
+		// CSIBMNode was created before fingerprint was introduced
+		bmNode.Spec.SystemUUID = k8sNode.Status.NodeInfo.SystemUUID
+		if err := bmc.k8sClient.UpdateCR(context.Background(), bmNode); err != nil {
+			ll.Errorf("Unable to set fingerprint of CSIBMNode CR: %v", err)
+			return ctrl.Result{Requeue: true}, err
+		}
 	}
 
 	bmc.cache.put(k8sNode.Name, bmNode.Name)
@@ -314,6 +336,20 @@ func (bmc *Controller) reconcileForCSIBMNode(bmNode *nodecrd.CSIBMNode) (ctrl.Re
 		k8sNodes = k8sNodeCRs.Items
 	}
 
+	for i := range k8sNodes {
+		if matchFingerprint(bmNode, &k8sNodes[i]) {
+			k8sNode = &k8sNodes[i]
+			if res, err := bmc.syncCSIBMNode(bmNode, k8sNode); err != nil {
+				return res, err
+			}
+			bmc.cache.put(k8sNode.Name, bmNode.Name)
+			if res, err := bmc.updateAnnotation(k8sNode, bmNode.Spec.UUID); err != nil {
+				return res, err
+			}
+			return bmc.updateReadyCondition(bmNode, true, "MatchedByFingerprint", fmt.Sprintf("k8s node %s", k8sNode.Name))
+		}
+	}
+
 	matchedNodes := make([]string, 0)
 	for i := range k8sNodes {
 		matchedAddresses := bmc.matchedAddressesCount(bmNode, &k8sNodes[i])
@@ -325,16 +361,56 @@ func (bmc *Controller) reconcileForCSIBMNode(bmNode *nodecrd.CSIBMNode) (ctrl.Re
 		if matchedAddresses > 0 {
 			ll.Errorf("There is k8s node %s that partially match CSIBMNode CR %s. CSIBMNode.Spec: %v, k8s node addresses: %v",
 				k8sNodes[i].Name, bmNode.Name, bmNode.Spec, k8sNodes[i].Status.Addresses)
-			return ctrl.Result{}, nil
+			return bmc.updateReadyCondition(bmNode, false, "PartiallyMatched",
+				fmt.Sprintf("k8s node %s partially matches addresses", k8sNodes[i].Name))
 		}
 	}
 
 	if len(matchedNodes) == 1 {
 		bmc.cache.put(k8sNode.Name, bmNode.Name)
-		return bmc.updateAnnotation(k8sNode, bmNode.Spec.UUID)
+		if res, err := bmc.updateAnnotation(k8sNode, bmNode.Spec.UUID); err != nil {
+			return res, err
+		}
+		return bmc.updateReadyCondition(bmNode, true, "Matched", fmt.Sprintf("k8s node %s", k8sNode.Name))
 	}
 
 	ll.Warnf("Unable to detect k8s node that corresponds to CSIBMNode %v, matched nodes: %v", bmNode, matchedNodes)
+	return bmc.updateReadyCondition(bmNode, false, "NotMatched",
+		fmt.Sprintf("matched k8s nodes: %v", matchedNodes))
+}
+
+// syncCSIBMNode sets addresses of k8s node which was matched by fingerprint in CSIBMNode CR,
+// CSIBMNode is updated only if addresses were changed, e.g. after OS reinstallation
+func (bmc *Controller) syncCSIBMNode(bmNode *nodecrd.CSIBMNode, k8sNode *coreV1.Node) (ctrl.Result, error) {
+	addresses := bmc.constructAddresses(k8sNode)
+	if reflect.DeepEqual(bmNode.Spec.Addresses, addresses) {
+		return ctrl.Result{}, nil
+	}
+
+	bmc.log.WithField("method", "syncCSIBMNode").
+		Infof("Updating addresses of CSIBMNode %s: %v -> %v", bmNode.Name, bmNode.Spec.Addresses, addresses)
+	bmNode.Spec.Addresses = addresses
+	if err := bmc.k8sClient.UpdateCR(context.Background(), bmNode); err != nil {
+		bmc.log.WithField("method", "syncCSIBMNode").Errorf("Unable to update CSIBMNode %s: %v", bmNode.Name, err)
+		return ctrl.Result{Requeue: true}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// updateReadyCondition sets observed generation and Ready condition of CSIBMNode CR and updates its status if needed
+func (bmc *Controller) updateReadyCondition(bmNode *nodecrd.CSIBMNode, ready bool, reason, message string) (ctrl.Result, error) {
+	modified := bmNode.Status.SetObservedGeneration(bmNode.Generation)
+	modified = bmNode.Status.SetCondition(apiV1.ConditionReady, apiV1.ConditionStatusFromBool(ready), reason, message) ||
+		modified
+	if !modified {
+		return ctrl.Result{}, nil
+	}
+
+	if err := bmc.k8sClient.UpdateCRStatus(context.Background(), bmNode); err != nil {
+		bmc.log.WithField("method", "updateReadyCondition").
+			Errorf("Unable to update status of CSIBMNode %s: %v", bmNode.Name, err)
+		return ctrl.Result{Requeue: true}, err
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -377,6 +453,11 @@ func (bmc *Controller) matchedAddressesCount(bmNodeCR *nodecrd.CSIBMNode, k8sNod
 	return matchedCount
 }
 
+// matchFingerprint checks whether hardware fingerprint of CSIBMNode CR is the same as system UUID of k8s node
+func matchFingerprint(bmNode *nodecrd.CSIBMNode, k8sNode *coreV1.Node) bool {
+	return bmNode.Spec.SystemUUID != "" && bmNode.Spec.SystemUUID == k8sNode.Status.NodeInfo.SystemUUID
+}
+
 // constructAddresses converts k8sNode.Status.Addresses into the the map[string]string, key - address type, value - address
 func (bmc *Controller) constructAddresses(k8sNode *coreV1.Node) map[string]string {
 	res := make(map[string]string, len(k8sNode.Status.Addresses))
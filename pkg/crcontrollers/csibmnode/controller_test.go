@@ -20,9 +20,10 @@ import (
 )
 
 var (
-	testNS     = "default"
-	testCtx    = context.Background()
-	testLogger = logrus.New()
+	testNS         = "default"
+	testCtx        = context.Background()
+	testLogger     = logrus.New()
+	testSystemUUID = "4c4c4544-0035-4b10-8046-b8c04f4e4432"
 
 	testCSIBMNode1 = nodecrd.CSIBMNode{
 		TypeMeta: metaV1.TypeMeta{
@@ -284,6 +285,49 @@ func Test_reconcileForK8sNode(t *testing.T) {
 		_, ok := nodeObj.GetAnnotations()[nodeIDAnnotationKey]
 		assert.False(t, ok)
 	})
+
+	t.Run("Reinstalled k8s node is matched by fingerprint", func(t *testing.T) {
+		var (
+			c       = setup(t)
+			k8sNode = testNode2.DeepCopy()
+			bmNode  = testCSIBMNode1.DeepCopy()
+		)
+
+		// node-1 was reinstalled and joined the cluster as node-2
+		bmNode.Spec.SystemUUID = testSystemUUID
+		k8sNode.Status.NodeInfo.SystemUUID = testSystemUUID
+		createObjects(t, c.k8sClient, k8sNode, bmNode)
+
+		res, err := c.reconcileForK8sNode(k8sNode)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+
+		nodeObj := new(coreV1.Node)
+		assert.Nil(t, c.k8sClient.ReadCR(testCtx, k8sNode.Name, nodeObj))
+		assert.Equal(t, bmNode.Spec.UUID, nodeObj.GetAnnotations()[nodeIDAnnotationKey])
+
+		bmNodeObj := new(nodecrd.CSIBMNode)
+		assert.Nil(t, c.k8sClient.ReadCR(testCtx, bmNode.Name, bmNodeObj))
+		assert.Equal(t, testCSIBMNode2.Spec.Addresses, bmNodeObj.Spec.Addresses)
+	})
+
+	t.Run("Fingerprint is set for matched CSIBMNode", func(t *testing.T) {
+		var (
+			c       = setup(t)
+			k8sNode = testNode1.DeepCopy()
+			bmNode  = testCSIBMNode1.DeepCopy()
+		)
+
+		k8sNode.Status.NodeInfo.SystemUUID = testSystemUUID
+		createObjects(t, c.k8sClient, k8sNode, bmNode)
+
+		_, err := c.reconcileForK8sNode(k8sNode)
+		assert.Nil(t, err)
+
+		bmNodeObj := new(nodecrd.CSIBMNode)
+		assert.Nil(t, c.k8sClient.ReadCR(testCtx, bmNode.Name, bmNodeObj))
+		assert.Equal(t, testSystemUUID, bmNodeObj.Spec.SystemUUID)
+	})
 }
 
 func Test_reconcileForCSIBMNode(t *testing.T) {
@@ -335,6 +379,12 @@ func Test_reconcileForCSIBMNode(t *testing.T) {
 		assert.Nil(t, c.k8sClient.ReadCR(testCtx, k8sNode.Name, nodeObj))
 		_, ok := nodeObj.GetAnnotations()[nodeIDAnnotationKey]
 		assert.False(t, ok)
+
+		// read CSIBMNode status
+		bmNodeObj := new(nodecrd.CSIBMNode)
+		assert.Nil(t, c.k8sClient.ReadCR(testCtx, bmNode.Name, bmNodeObj))
+		assert.False(t, bmNodeObj.Status.IsConditionTrue(crdV1.ConditionReady))
+		assert.Equal(t, "PartiallyMatched", bmNodeObj.Status.GetCondition(crdV1.ConditionReady).Reason)
 	})
 
 	t.Run("More then one k8s node match CSIBMNode CR", func(t *testing.T) {
@@ -361,6 +411,31 @@ func Test_reconcileForCSIBMNode(t *testing.T) {
 		_, ok = nodeObj.GetAnnotations()[nodeIDAnnotationKey]
 		assert.False(t, ok)
 	})
+
+	t.Run("K8s node is matched by fingerprint", func(t *testing.T) {
+		var (
+			c       = setup(t)
+			k8sNode = testNode2.DeepCopy()
+			bmNode  = testCSIBMNode1.DeepCopy()
+		)
+
+		bmNode.Spec.SystemUUID = testSystemUUID
+		k8sNode.Status.NodeInfo.SystemUUID = testSystemUUID
+		createObjects(t, c.k8sClient, k8sNode, bmNode)
+
+		res, err := c.reconcileForCSIBMNode(bmNode)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+
+		nodeObj := new(coreV1.Node)
+		assert.Nil(t, c.k8sClient.ReadCR(testCtx, k8sNode.Name, nodeObj))
+		assert.Equal(t, bmNode.Spec.UUID, nodeObj.GetAnnotations()[nodeIDAnnotationKey])
+
+		bmNodeObj := new(nodecrd.CSIBMNode)
+		assert.Nil(t, c.k8sClient.ReadCR(testCtx, bmNode.Name, bmNodeObj))
+		assert.True(t, bmNodeObj.Status.IsConditionTrue(crdV1.ConditionReady))
+		assert.Equal(t, "MatchedByFingerprint", bmNodeObj.Status.GetCondition(crdV1.ConditionReady).Reason)
+	})
 }
 
 func Test_checkAnnotation(t *testing.T) {
@@ -0,0 +1,209 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package deployment contains controller which deploys and upgrades the driver according to CSIBMDeployment CR
+package deployment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/sirupsen/logrus"
+	appsV1 "k8s.io/api/apps/v1"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/deploymentcrd"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/crcontrollers/storageclass"
+)
+
+const (
+	// specHashAnnotation holds hash of the spec which was applied by Controller,
+	// object is updated only when expected spec is changed, so defaults which are set by k8s don't trigger updates
+	specHashAnnotation = "baremetal-csi.dellemc.com/spec-hash"
+	// storageClassPrefix is the name prefix of StorageClasses which are created for CSIBMDeployment
+	storageClassPrefix = "baremetal-csi-sc"
+)
+
+// Controller deploys node DaemonSet, controller StatefulSet and StorageClasses of the driver described
+// by CSIBMDeployment CR and upgrades them when CR is changed
+type Controller struct {
+	k8sClient *k8s.KubeClient
+
+	log *logrus.Entry
+}
+
+// NewController is the constructor for Controller struct
+// Receives an instance of base.KubeClient which namespace is used for workloads of the driver and logrus logger
+// Returns an instance of Controller
+func NewController(k8sClient *k8s.KubeClient, logger *logrus.Logger) *Controller {
+	return &Controller{
+		k8sClient: k8sClient,
+		log:       logger.WithField("component", "DeploymentController"),
+	}
+}
+
+// SetupWithManager registers Controller to ControllerManager
+func (c *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&deploymentcrd.CSIBMDeployment{}).
+		Owns(&appsV1.DaemonSet{}).
+		Owns(&appsV1.StatefulSet{}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: 1,
+		}).
+		Complete(c)
+}
+
+// Reconcile creates or updates workloads and StorageClasses of the driver according to CSIBMDeployment CR
+// Returns reconcile result as ctrl.Result or error if something went wrong
+func (c *Controller) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ll := c.log.WithFields(logrus.Fields{
+		"method": "Reconcile",
+		"name":   req.Name,
+	})
+
+	var (
+		ctx = context.WithValue(context.Background(), base.RequestUUID, req.Name)
+		d   = &deploymentcrd.CSIBMDeployment{}
+	)
+
+	if err := c.k8sClient.ReadCR(ctx, req.Name, d); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !d.DeletionTimestamp.IsZero() {
+		// workloads and StorageClasses are removed by garbage collector through owner references
+		return ctrl.Result{}, nil
+	}
+	if d.Spec.Version == "" {
+		ll.Errorf("Version of the driver isn't set")
+		return ctrl.Result{}, c.updateStatus(ctx, d, false, "InvalidSpec", "version of the driver isn't set")
+	}
+
+	ds := buildNodeDaemonSet(d, c.k8sClient.Namespace)
+	if err := c.apply(ctx, d, ds, &appsV1.DaemonSet{}, func(current runtime.Object) {
+		current.(*appsV1.DaemonSet).Spec = ds.Spec
+	}); err != nil {
+		ll.Errorf("Unable to apply DaemonSet %s: %v", ds.Name, err)
+		return ctrl.Result{Requeue: true}, c.updateStatus(ctx, d, false, "NodeFailed", err.Error())
+	}
+
+	sts := buildControllerStatefulSet(d, c.k8sClient.Namespace)
+	if err := c.apply(ctx, d, sts, &appsV1.StatefulSet{}, func(current runtime.Object) {
+		current.(*appsV1.StatefulSet).Spec = sts.Spec
+	}); err != nil {
+		ll.Errorf("Unable to apply StatefulSet %s: %v", sts.Name, err)
+		return ctrl.Result{Requeue: true}, c.updateStatus(ctx, d, false, "ControllerFailed", err.Error())
+	}
+
+	if len(d.Spec.StorageClasses) > 0 {
+		classes := storageclass.BuildStorageClasses(storageclass.Config{
+			NamePrefix:     storageClassPrefix,
+			FsType:         base.DefaultFsType,
+			StorageClasses: d.Spec.StorageClasses,
+		})
+		for name, sc := range classes {
+			sc.OwnerReferences = append(sc.OwnerReferences, ownerReference(d))
+			// StorageClass is created only once, parameters of existing one are immutable
+			if err := c.k8sClient.CreateCR(ctx, name, sc); err != nil {
+				ll.Errorf("Unable to create StorageClass %s: %v", name, err)
+				return ctrl.Result{Requeue: true}, c.updateStatus(ctx, d, false, "StorageClassFailed", err.Error())
+			}
+		}
+	}
+
+	ll.Infof("Driver of version %s is deployed", d.Spec.Version)
+	return ctrl.Result{}, c.updateStatus(ctx, d, true, "Deployed",
+		fmt.Sprintf("driver of version %s is deployed", d.Spec.Version))
+}
+
+// apply creates expected object if it doesn't exist or updates it if spec of CSIBMDeployment was changed
+// Receives golang context, owner CSIBMDeployment, expected object, empty object of the same type for reading
+// and function which copies expected spec into current object
+// Returns error if something went wrong
+func (c *Controller) apply(ctx context.Context, d *deploymentcrd.CSIBMDeployment, expected, current runtime.Object,
+	setSpec func(current runtime.Object)) error {
+	expectedMeta, err := meta.Accessor(expected)
+	if err != nil {
+		return err
+	}
+	hash, err := specHash(expected)
+	if err != nil {
+		return err
+	}
+	expectedMeta.SetOwnerReferences([]metaV1.OwnerReference{ownerReference(d)})
+	expectedMeta.SetAnnotations(map[string]string{specHashAnnotation: hash})
+
+	err = c.k8sClient.ReadCR(ctx, expectedMeta.GetName(), current)
+	switch {
+	case k8sError.IsNotFound(err):
+		return c.k8sClient.CreateCR(ctx, expectedMeta.GetName(), expected)
+	case err != nil:
+		return err
+	}
+
+	currentMeta, err := meta.Accessor(current)
+	if err != nil {
+		return err
+	}
+	if currentMeta.GetAnnotations()[specHashAnnotation] == hash {
+		return nil
+	}
+
+	c.log.WithField("method", "apply").Infof("Updating %s, spec was changed", expectedMeta.GetName())
+	setSpec(current)
+	annotations := currentMeta.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[specHashAnnotation] = hash
+	currentMeta.SetAnnotations(annotations)
+	currentMeta.SetOwnerReferences(expectedMeta.GetOwnerReferences())
+	return c.k8sClient.UpdateCR(ctx, current)
+}
+
+// updateStatus sets Ready condition and observed generation of CSIBMDeployment
+func (c *Controller) updateStatus(ctx context.Context, d *deploymentcrd.CSIBMDeployment,
+	ready bool, reason, message string) error {
+	modified := d.Status.SetObservedGeneration(d.Generation)
+	modified = d.Status.SetCondition(apiV1.ConditionReady, apiV1.ConditionStatusFromBool(ready), reason, message) ||
+		modified
+	if !modified {
+		return nil
+	}
+	return c.k8sClient.UpdateCRStatus(ctx, d)
+}
+
+// specHash returns FNV hash of JSON representation of object
+func specHash(obj runtime.Object) (string, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	h := fnv.New32a()
+	_, _ = h.Write(data)
+	return fmt.Sprintf("%x", h.Sum32()), nil
+}
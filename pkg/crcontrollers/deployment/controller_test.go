@@ -0,0 +1,131 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	appsV1 "k8s.io/api/apps/v1"
+	storageV1 "k8s.io/api/storage/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/deploymentcrd"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+)
+
+var (
+	testCtx    = context.Background()
+	testLogger = logrus.New()
+	testNs     = "default"
+	testName   = "csi-baremetal"
+	testReq    = ctrl.Request{NamespacedName: types.NamespacedName{Namespace: testNs, Name: testName}}
+)
+
+func TestController_Reconcile(t *testing.T) {
+	c := setup(t)
+	d := testDeployment()
+	assert.Nil(t, c.k8sClient.CreateCR(testCtx, testName, d))
+
+	res, err := c.Reconcile(testReq)
+	assert.Nil(t, err)
+	assert.Equal(t, ctrl.Result{}, res)
+
+	ds := &appsV1.DaemonSet{}
+	assert.Nil(t, c.k8sClient.ReadCR(testCtx, NodeName, ds))
+	assert.Equal(t, "registry/baremetal-csi-plugin-node:1.0.0", ds.Spec.Template.Spec.Containers[1].Image)
+	assert.NotEmpty(t, ds.Annotations[specHashAnnotation])
+	assert.Equal(t, testName, ds.OwnerReferences[0].Name)
+
+	sts := &appsV1.StatefulSet{}
+	assert.Nil(t, c.k8sClient.ReadCR(testCtx, ControllerName, sts))
+
+	sc := &storageV1.StorageClass{}
+	assert.Nil(t, c.k8sClient.ReadCR(testCtx, storageClassPrefix+"-hddlvg", sc))
+	assert.NotNil(t, c.k8sClient.ReadCR(testCtx, storageClassPrefix+"-ssd", sc))
+
+	assert.Nil(t, c.k8sClient.ReadCR(testCtx, testName, d))
+	assert.True(t, d.Status.IsConditionTrue(apiV1.ConditionReady))
+}
+
+func TestController_ReconcileUpgrade(t *testing.T) {
+	c := setup(t)
+	d := testDeployment()
+	assert.Nil(t, c.k8sClient.CreateCR(testCtx, testName, d))
+	_, err := c.Reconcile(testReq)
+	assert.Nil(t, err)
+
+	ds := &appsV1.DaemonSet{}
+	assert.Nil(t, c.k8sClient.ReadCR(testCtx, NodeName, ds))
+	oldHash := ds.Annotations[specHashAnnotation]
+
+	assert.Nil(t, c.k8sClient.ReadCR(testCtx, testName, d))
+	d.Spec.Version = "1.1.0"
+	assert.Nil(t, c.k8sClient.UpdateCR(testCtx, d))
+	_, err = c.Reconcile(testReq)
+	assert.Nil(t, err)
+
+	assert.Nil(t, c.k8sClient.ReadCR(testCtx, NodeName, ds))
+	assert.NotEqual(t, oldHash, ds.Annotations[specHashAnnotation])
+	assert.Equal(t, "registry/baremetal-csi-plugin-node:1.1.0", ds.Spec.Template.Spec.Containers[1].Image)
+}
+
+func TestController_ReconcileInvalidSpec(t *testing.T) {
+	c := setup(t)
+	d := testDeployment()
+	d.Spec.Version = ""
+	assert.Nil(t, c.k8sClient.CreateCR(testCtx, testName, d))
+
+	_, err := c.Reconcile(testReq)
+	assert.Nil(t, err)
+
+	assert.NotNil(t, c.k8sClient.ReadCR(testCtx, NodeName, &appsV1.DaemonSet{}))
+	assert.Nil(t, c.k8sClient.ReadCR(testCtx, testName, d))
+	assert.False(t, d.Status.IsConditionTrue(apiV1.ConditionReady))
+	assert.Equal(t, "InvalidSpec", d.Status.GetCondition(apiV1.ConditionReady).Reason)
+}
+
+func TestController_ReconcileNotFound(t *testing.T) {
+	c := setup(t)
+
+	res, err := c.Reconcile(testReq)
+	assert.Nil(t, err)
+	assert.Equal(t, ctrl.Result{}, res)
+}
+
+func setup(t *testing.T) *Controller {
+	k8sClient, err := k8s.GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+	return NewController(k8sClient, testLogger)
+}
+
+func testDeployment() *deploymentcrd.CSIBMDeployment {
+	return &deploymentcrd.CSIBMDeployment{
+		TypeMeta:   metaV1.TypeMeta{Kind: apiV1.CSIBMDeploymentKind, APIVersion: apiV1.APIV1Version},
+		ObjectMeta: metaV1.ObjectMeta{Name: testName},
+		Spec: deploymentcrd.CSIBMDeploymentSpec{
+			Registry:       "registry",
+			Version:        "1.0.0",
+			StorageClasses: []string{apiV1.StorageClassHDDLVG},
+		},
+	}
+}
@@ -0,0 +1,337 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"fmt"
+
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/deploymentcrd"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/drivemgr"
+)
+
+const (
+	// NodeName is the name of DaemonSet with node service of the driver
+	NodeName = "baremetal-csi-node"
+	// ControllerName is the name of StatefulSet with controller service of the driver
+	ControllerName = "baremetal-csi-controller"
+
+	nodeServiceAccount       = "csi-node-sa"
+	controllerServiceAccount = "csi-controller-sa"
+
+	provisionerTag   = "v1.2.2"
+	resizerTag       = "v0.5.0"
+	registrarTag     = "v1.0.1-gke.0"
+	livenessProbeTag = "v2.1.0"
+
+	appLabelKey      = "app"
+	csiSocketDir     = "/csi"
+	csiSocket        = "unix:///csi/csi.sock"
+	pluginDir        = "/var/lib/kubelet/plugins/baremetal-csi"
+	registrationDir  = "/var/lib/kubelet/plugins_registry/"
+	healthProbeCmd   = "/health_probe"
+	livenessPortName = "liveness-port"
+	livenessPort     = 9808
+	socketVolumeName = "csi-socket-dir"
+	registrationName = "registration-dir"
+	mountpointName   = "mountpoint-dir"
+)
+
+// image returns full name of image from registry of the deployment
+func image(d *deploymentcrd.CSIBMDeployment, name, tag string) string {
+	if d.Spec.Registry == "" {
+		return fmt.Sprintf("%s:%s", name, tag)
+	}
+	return fmt.Sprintf("%s/%s:%s", d.Spec.Registry, name, tag)
+}
+
+// pullPolicy returns pull policy of the deployment, Always by default
+func pullPolicy(d *deploymentcrd.CSIBMDeployment) coreV1.PullPolicy {
+	if d.Spec.PullPolicy == "" {
+		return coreV1.PullAlways
+	}
+	return coreV1.PullPolicy(d.Spec.PullPolicy)
+}
+
+// logLevel returns log level of the deployment, info by default
+func logLevel(d *deploymentcrd.CSIBMDeployment) string {
+	if d.Spec.LogLevel == "" {
+		return base.InfoLevel
+	}
+	return d.Spec.LogLevel
+}
+
+// fieldEnv returns environment variable which value is taken from field of the pod
+func fieldEnv(name, fieldPath string) coreV1.EnvVar {
+	return coreV1.EnvVar{
+		Name:      name,
+		ValueFrom: &coreV1.EnvVarSource{FieldRef: &coreV1.ObjectFieldSelector{APIVersion: "v1", FieldPath: fieldPath}},
+	}
+}
+
+// hostPathVolume returns volume which is backed by path on the host
+func hostPathVolume(name, path string, pathType coreV1.HostPathType) coreV1.Volume {
+	return coreV1.Volume{
+		Name:         name,
+		VolumeSource: coreV1.VolumeSource{HostPath: &coreV1.HostPathVolumeSource{Path: path, Type: &pathType}},
+	}
+}
+
+// livenessProbeContainer returns sidecar which checks driver health through CSI socket
+func livenessProbeContainer(d *deploymentcrd.CSIBMDeployment, socketVolume string) coreV1.Container {
+	return coreV1.Container{
+		Name:            "liveness-probe",
+		Image:           image(d, "livenessprobe", livenessProbeTag),
+		ImagePullPolicy: pullPolicy(d),
+		Args:            []string{"--csi-address=/csi/csi.sock"},
+		VolumeMounts:    []coreV1.VolumeMount{{Name: socketVolume, MountPath: csiSocketDir}},
+	}
+}
+
+// livenessProbe returns probe of driver container which is served by liveness-probe sidecar
+func livenessProbe() *coreV1.Probe {
+	return &coreV1.Probe{
+		Handler: coreV1.Handler{HTTPGet: &coreV1.HTTPGetAction{
+			Path: "/healthz",
+			Port: intstr.FromString(livenessPortName),
+		}},
+		InitialDelaySeconds: 300,
+		TimeoutSeconds:      3,
+		PeriodSeconds:       10,
+		FailureThreshold:    5,
+	}
+}
+
+// buildNodeDaemonSet constructs DaemonSet with node service of the driver
+func buildNodeDaemonSet(d *deploymentcrd.CSIBMDeployment, namespace string) *appsV1.DaemonSet {
+	var (
+		labels     = map[string]string{appLabelKey: NodeName}
+		privileged = true
+		grace      = int64(10)
+		mountProp  = coreV1.MountPropagationBidirectional
+		backend    = drivemgr.BackendBaseMgr
+		args       = []string{
+			"--csiendpoint=$(CSI_ENDPOINT)",
+			"--nodename=$(KUBE_NODE_NAME)",
+			"--namespace=$(NAMESPACE)",
+			"--loglevel=" + logLevel(d),
+		}
+	)
+	if d.Spec.HWManagerEndpoint != "" {
+		backend = drivemgr.BackendGRPC
+		args = append(args, "--drivemgrendpoint="+d.Spec.HWManagerEndpoint)
+	}
+	args = append(args, "--drivemgrbackend="+backend)
+
+	registrar := coreV1.Container{
+		Name:            "csi-node-driver-registrar",
+		Image:           image(d, "csi-node-driver-registrar", registrarTag),
+		ImagePullPolicy: pullPolicy(d),
+		Args: []string{
+			"--v=5",
+			"--csi-address=$(ADDRESS)",
+			"--kubelet-registration-path=$(DRIVER_REG_SOCK_PATH)",
+		},
+		Lifecycle: &coreV1.Lifecycle{PreStop: &coreV1.Handler{Exec: &coreV1.ExecAction{
+			Command: []string{"/bin/sh", "-c",
+				"rm -rf /registration/baremetal-csi /registration/baremetal-csi-reg.sock"},
+		}}},
+		Env: []coreV1.EnvVar{
+			{Name: "ADDRESS", Value: csiSocketDir + "/csi.sock"},
+			{Name: "DRIVER_REG_SOCK_PATH", Value: pluginDir + "/csi.sock"},
+			fieldEnv("KUBE_NODE_NAME", "spec.nodeName"),
+		},
+		VolumeMounts: []coreV1.VolumeMount{
+			{Name: socketVolumeName, MountPath: csiSocketDir},
+			{Name: registrationName, MountPath: "/registration"},
+		},
+	}
+
+	node := coreV1.Container{
+		Name:            "node",
+		Image:           image(d, "baremetal-csi-plugin-node", d.Spec.Version),
+		ImagePullPolicy: pullPolicy(d),
+		Args:            args,
+		Ports: []coreV1.ContainerPort{
+			{Name: livenessPortName, ContainerPort: livenessPort, Protocol: coreV1.ProtocolTCP},
+		},
+		LivenessProbe: livenessProbe(),
+		ReadinessProbe: &coreV1.Probe{
+			Handler: coreV1.Handler{Exec: &coreV1.ExecAction{
+				Command: []string{healthProbeCmd, fmt.Sprintf("-addr=:%d", base.DefaultHealthPort)},
+			}},
+			InitialDelaySeconds: 3,
+			PeriodSeconds:       3,
+			SuccessThreshold:    3,
+			FailureThreshold:    100,
+		},
+		Env: []coreV1.EnvVar{
+			{Name: "CSI_ENDPOINT", Value: csiSocket},
+			{Name: "LOG_FORMAT", Value: base.LogFormatText},
+			fieldEnv("KUBE_NODE_NAME", "spec.nodeName"),
+			fieldEnv("MY_POD_IP", "status.podIP"),
+			fieldEnv("NAMESPACE", "metadata.namespace"),
+		},
+		SecurityContext: &coreV1.SecurityContext{Privileged: &privileged},
+		VolumeMounts: []coreV1.VolumeMount{
+			{Name: "logs", MountPath: "/var/log"},
+			{Name: "host-dev", MountPath: "/dev"},
+			{Name: "host-sys", MountPath: "/sys"},
+			{Name: "host-run-udev", MountPath: "/run/udev"},
+			{Name: "host-run-lvm", MountPath: "/run/lvm"},
+			{Name: "host-run-lock", MountPath: "/run/lock"},
+			{Name: socketVolumeName, MountPath: csiSocketDir},
+			{Name: mountpointName, MountPath: base.KubeletRootPath, MountPropagation: &mountProp},
+		},
+	}
+
+	return &appsV1.DaemonSet{
+		TypeMeta:   metaV1.TypeMeta{Kind: "DaemonSet", APIVersion: "apps/v1"},
+		ObjectMeta: metaV1.ObjectMeta{Name: NodeName, Namespace: namespace, Labels: labels},
+		Spec: appsV1.DaemonSetSpec{
+			Selector: &metaV1.LabelSelector{MatchLabels: labels},
+			Template: coreV1.PodTemplateSpec{
+				ObjectMeta: metaV1.ObjectMeta{Labels: labels},
+				Spec: coreV1.PodSpec{
+					NodeSelector:                  d.Spec.NodeSelector,
+					HostIPC:                       true,
+					ServiceAccountName:            nodeServiceAccount,
+					TerminationGracePeriodSeconds: &grace,
+					Containers:                    []coreV1.Container{registrar, node, livenessProbeContainer(d, socketVolumeName)},
+					Volumes: []coreV1.Volume{
+						{Name: "logs", VolumeSource: coreV1.VolumeSource{EmptyDir: &coreV1.EmptyDirVolumeSource{}}},
+						hostPathVolume("host-dev", "/dev", coreV1.HostPathDirectory),
+						hostPathVolume("host-sys", "/sys", coreV1.HostPathDirectory),
+						hostPathVolume("host-run-udev", "/run/udev", coreV1.HostPathDirectory),
+						hostPathVolume("host-run-lvm", "/run/lvm", coreV1.HostPathDirectory),
+						hostPathVolume("host-run-lock", "/run/lock", coreV1.HostPathDirectory),
+						hostPathVolume(socketVolumeName, pluginDir, coreV1.HostPathDirectoryOrCreate),
+						hostPathVolume(registrationName, registrationDir, coreV1.HostPathDirectoryOrCreate),
+						hostPathVolume(mountpointName, base.KubeletRootPath, coreV1.HostPathDirectory),
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildControllerStatefulSet constructs StatefulSet with controller service of the driver and its sidecars
+func buildControllerStatefulSet(d *deploymentcrd.CSIBMDeployment, namespace string) *appsV1.StatefulSet {
+	var (
+		labels       = map[string]string{appLabelKey: ControllerName}
+		replicas     = int32(1)
+		grace        = int64(10)
+		socketVolume = "socket-dir"
+		socketMount  = []coreV1.VolumeMount{{Name: socketVolume, MountPath: csiSocketDir}}
+		addressEnv   = []coreV1.EnvVar{{Name: "ADDRESS", Value: csiSocketDir + "/csi.sock"}}
+	)
+
+	provisioner := coreV1.Container{
+		Name:            "csi-provisioner",
+		Image:           image(d, "csi-provisioner", provisionerTag),
+		ImagePullPolicy: pullPolicy(d),
+		Args:            []string{"--csi-address=$(ADDRESS)", "--v=5", "--feature-gates=Topology=true"},
+		Env:             addressEnv,
+		VolumeMounts:    socketMount,
+	}
+	resizer := coreV1.Container{
+		Name:            "csi-resizer",
+		Image:           image(d, "csi-resizer", resizerTag),
+		ImagePullPolicy: pullPolicy(d),
+		Args:            []string{"--v=5", "--csi-address=$(ADDRESS)"},
+		Env:             addressEnv,
+		VolumeMounts:    socketMount,
+	}
+	controller := coreV1.Container{
+		Name:            "controller",
+		Image:           image(d, "baremetal-csi-plugin-controller", d.Spec.Version),
+		ImagePullPolicy: pullPolicy(d),
+		Args: []string{
+			"--endpoint=$(CSI_ENDPOINT)",
+			"--namespace=$(NAMESPACE)",
+			"--loglevel=" + logLevel(d),
+			fmt.Sprintf("--healthport=%d", base.DefaultHealthPort),
+		},
+		Env: []coreV1.EnvVar{
+			{Name: "CSI_ENDPOINT", Value: csiSocket},
+			{Name: "LOG_FORMAT", Value: base.LogFormatText},
+			fieldEnv("POD_IP", "status.podIP"),
+			fieldEnv("KUBE_NODE_NAME", "spec.nodeName"),
+			fieldEnv("NAMESPACE", "metadata.namespace"),
+		},
+		Ports: []coreV1.ContainerPort{
+			{Name: livenessPortName, ContainerPort: livenessPort, Protocol: coreV1.ProtocolTCP},
+		},
+		LivenessProbe: livenessProbe(),
+		ReadinessProbe: &coreV1.Probe{
+			Handler: coreV1.Handler{Exec: &coreV1.ExecAction{
+				Command: []string{healthProbeCmd, fmt.Sprintf("-addr=:%d", base.DefaultHealthPort)},
+			}},
+			InitialDelaySeconds: 3,
+			PeriodSeconds:       10,
+			SuccessThreshold:    1,
+			FailureThreshold:    15,
+		},
+		VolumeMounts: []coreV1.VolumeMount{
+			{Name: socketVolume, MountPath: csiSocketDir},
+			{Name: "logs", MountPath: "/var/log"},
+		},
+	}
+
+	return &appsV1.StatefulSet{
+		TypeMeta:   metaV1.TypeMeta{Kind: "StatefulSet", APIVersion: "apps/v1"},
+		ObjectMeta: metaV1.ObjectMeta{Name: ControllerName, Namespace: namespace, Labels: labels},
+		Spec: appsV1.StatefulSetSpec{
+			Replicas:    &replicas,
+			ServiceName: ControllerName,
+			Selector:    &metaV1.LabelSelector{MatchLabels: labels},
+			Template: coreV1.PodTemplateSpec{
+				ObjectMeta: metaV1.ObjectMeta{Labels: labels},
+				Spec: coreV1.PodSpec{
+					ServiceAccountName:            controllerServiceAccount,
+					TerminationGracePeriodSeconds: &grace,
+					Containers: []coreV1.Container{
+						provisioner, resizer, controller, livenessProbeContainer(d, socketVolume),
+					},
+					Volumes: []coreV1.Volume{
+						{Name: "logs", VolumeSource: coreV1.VolumeSource{EmptyDir: &coreV1.EmptyDirVolumeSource{}}},
+						{Name: socketVolume, VolumeSource: coreV1.VolumeSource{EmptyDir: &coreV1.EmptyDirVolumeSource{}}},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ownerReference returns reference to CSIBMDeployment which is set on all objects created for it,
+// so they are removed by garbage collector together with CSIBMDeployment
+func ownerReference(d *deploymentcrd.CSIBMDeployment) metaV1.OwnerReference {
+	isController := true
+	return metaV1.OwnerReference{
+		APIVersion: apiV1.APIV1Version,
+		Kind:       apiV1.CSIBMDeploymentKind,
+		Name:       d.Name,
+		UID:        d.UID,
+		Controller: &isController,
+	}
+}
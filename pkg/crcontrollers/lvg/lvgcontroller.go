@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -32,17 +33,22 @@ import (
 	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
 	"github.com/dell/csi-baremetal/api/v1/drivecrd"
 	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
-	vccrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/command"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsblk"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lvm"
 	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/eventing"
 )
 
 const lvgFinalizer = "dell.emc.csi/lvg-cleanup"
 
+// eventRecorder interface for sending events
+type eventRecorder interface {
+	Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{})
+}
+
 // Controller is the LVG custom resource Controller for serving VG operations on Node side in Reconcile loop
 type Controller struct {
 	k8sClient *k8s.KubeClient
@@ -51,18 +57,21 @@ type Controller struct {
 	lvmOps  lvm.WrapLVM
 	e       command.CmdExecutor
 
+	recorder eventRecorder
+
 	node string
 	log  *logrus.Entry
 }
 
 // NewController is the constructor for Controller struct
-// Receives an instance of base.KubeClient, ID of a node where it works and logrus logger
+// Receives an instance of base.KubeClient, ID of a node where it works, event recorder and logrus logger
 // Returns an instance of Controller
-func NewController(k8sClient *k8s.KubeClient, nodeID string, log *logrus.Logger) *Controller {
+func NewController(k8sClient *k8s.KubeClient, nodeID string, recorder eventRecorder, log *logrus.Logger) *Controller {
 	e := &command.Executor{}
 	e.SetLogger(log)
 	return &Controller{
 		k8sClient: k8sClient,
+		recorder:  recorder,
 		node:      nodeID,
 		log:       log.WithField("component", "Controller"),
 		e:         e,
@@ -89,6 +98,13 @@ func (c *Controller) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 
 	ll.Infof("Reconciling LVG: %v", lvg)
 
+	if setConditions(lvg) {
+		if err := c.k8sClient.UpdateCRStatus(context.Background(), lvg); err != nil {
+			ll.Errorf("Unable to update LVG status: %v", err)
+			return ctrl.Result{Requeue: true}, err
+		}
+	}
+
 	switch {
 	case !lvg.ObjectMeta.DeletionTimestamp.IsZero():
 		ll.Info("Delete LVG")
@@ -108,6 +124,23 @@ func (c *Controller) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	}
 }
 
+// setConditions sets observed generation and conditions of LVG CR based on LVG.Spec.Status
+// Returns true if status was modified
+func setConditions(lvg *lvgcrd.LVG) bool {
+	var (
+		modified = lvg.Status.SetObservedGeneration(lvg.Generation)
+		created  = apiV1.ConditionStatusFromBool(lvg.Spec.Status == apiV1.Created)
+		reason   = "Unknown"
+	)
+	if lvg.Spec.Status != "" {
+		reason = strings.ToUpper(lvg.Spec.Status[:1]) + lvg.Spec.Status[1:]
+	}
+
+	modified = lvg.Status.SetCondition(apiV1.ConditionProvisioned, created, reason, "") || modified
+	modified = lvg.Status.SetCondition(apiV1.ConditionReady, created, reason, "") || modified
+	return modified
+}
+
 // appendFinalizer appends finalizer to the LVG CR (update CR)
 func (c *Controller) appendFinalizer(lvg *lvgcrd.LVG) (ctrl.Result, error) {
 	if len(lvg.Spec.VolumeRefs) == 0 || util.HasNameWithPrefix(lvg.Spec.VolumeRefs) {
@@ -156,6 +189,14 @@ func (c *Controller) handlerLVGCreation(lvg *lvgcrd.LVG) (ctrl.Result, error) {
 		return ctrl.Result{Requeue: true}, err
 	}
 
+	if newStatus == apiV1.Failed {
+		c.recorder.Eventf(lvg, eventing.ErrorType, eventing.LVGCreationFailed,
+			"Unable to create LVG on node %s: %v", lvg.Spec.Node, err)
+	} else {
+		c.recorder.Eventf(lvg, eventing.InfoType, eventing.LVGCreated,
+			"LVG was created on node %s, locations: %v", lvg.Spec.Node, locations)
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -167,17 +208,15 @@ func (c *Controller) handleLVGRemoving(lvg *lvgcrd.LVG) (ctrl.Result, error) {
 		return ctrl.Result{}, nil
 	}
 
-	volumes := &vccrd.VolumeList{}
-
-	err := c.k8sClient.ReadList(context.Background(), volumes)
+	volumes, err := c.k8sClient.ReadVolumesByField(context.Background(), k8s.VolumeLocationField, lvg.Name)
 	if err != nil {
 		ll.Errorf("Unable to read volume list: %v", err)
 		return ctrl.Result{Requeue: true}, err
 	}
 	// If Kubernetes has volumes with location of LVG, which is needed to be deleted,
 	// we prevent removing, because this LVG is still used.
-	for _, item := range volumes.Items {
-		if item.Spec.Location == lvg.Name && item.DeletionTimestamp.IsZero() {
+	for _, item := range volumes {
+		if item.DeletionTimestamp.IsZero() {
 			ll.Debugf("There are volume %v with LVG location, stop LVG deletion", item)
 			return ctrl.Result{}, nil
 		}
@@ -190,11 +229,17 @@ func (c *Controller) handleLVGRemoving(lvg *lvgcrd.LVG) (ctrl.Result, error) {
 		// cleanup LVM artifacts
 		if err := c.removeLVGArtifacts(lvg.Name); err != nil {
 			ll.Errorf("Unable to cleanup LVM artifacts: %v", err)
+			c.recorder.Eventf(lvg, eventing.ErrorType, eventing.LVGRemovalFailed,
+				"Unable to remove LVG on node %s: %v", lvg.Spec.Node, err)
 			return ctrl.Result{}, err
 		}
 	}
 
-	return c.removeFinalizer(lvg)
+	res, err := c.removeFinalizer(lvg)
+	if err == nil {
+		c.recorder.Eventf(lvg, eventing.InfoType, eventing.LVGRemoved, "LVG was removed on node %s", lvg.Spec.Node)
+	}
+	return res, err
 }
 
 // SetupWithManager registers Controller to ControllerManager
@@ -237,7 +282,7 @@ func (c *Controller) createSystemLVG(lvg *lvgcrd.LVG) (locations []string, err e
 	})
 	ll.Info("Processing ...")
 
-	var deviceFiles = make([]string, 0) // device files of each drive in LVG
+	var drives = make([]*drivecrd.Drive, 0, len(lvg.Spec.Locations))
 	for _, driveUUID := range lvg.Spec.Locations {
 		drive := &drivecrd.Drive{}
 		if err := c.k8sClient.ReadCR(context.Background(), driveUUID, drive); err != nil {
@@ -245,6 +290,16 @@ func (c *Controller) createSystemLVG(lvg *lvgcrd.LVG) (locations []string, err e
 			ll.Errorf("Unable to read drive %s, error: %v", driveUUID, err)
 			continue
 		}
+		drives = append(drives, drive)
+	}
+	if err = checkFailureDomains(lvg.Spec.FailureDomain, drives); err != nil {
+		// keep locations, they are needed for releasing of capacity when LVG CR is removed
+		return lvg.Spec.Locations, err
+	}
+
+	var deviceFiles = make([]string, 0) // device files of each drive in LVG
+	for _, drive := range drives {
+		driveUUID := drive.Name
 		// get serial number
 		sn := drive.Spec.SerialNumber
 		// get device path
@@ -273,6 +328,36 @@ func (c *Controller) createSystemLVG(lvg *lvgcrd.LVG) (locations []string, err e
 	return locations, nil
 }
 
+// checkFailureDomains returns error if at least two drives share the same failure domain or failure domain
+// of the drive is unknown. Any placement is accepted if failure domain is empty
+func checkFailureDomains(domain string, drives []*drivecrd.Drive) error {
+	if domain == "" {
+		return nil
+	}
+
+	// key - failure domain, value - drive name
+	used := make(map[string]string, len(drives))
+	for _, drive := range drives {
+		var key string
+		switch domain {
+		case apiV1.FailureDomainEnclosure:
+			key = drive.Spec.Enclosure
+		case apiV1.FailureDomainController:
+			key = drive.Spec.Controller
+		default:
+			return fmt.Errorf("unknown failure domain %s", domain)
+		}
+		if key == "" {
+			return fmt.Errorf("%s of drive %s is unknown", domain, drive.Name)
+		}
+		if other, ok := used[key]; ok {
+			return fmt.Errorf("drives %s and %s share %s %s", other, drive.Name, domain, key)
+		}
+		used[key] = drive.Name
+	}
+	return nil
+}
+
 // removeLVGArtifacts removes LVG and PVs that doesn't correspond to particular LVG
 // when LVG is removed all PVs that were in that LVG becomes orphans
 func (c *Controller) removeLVGArtifacts(lvgName string) error {
@@ -40,6 +40,7 @@ import (
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsblk"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lvm"
 	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/eventing"
 	"github.com/dell/csi-baremetal/pkg/mocks"
 	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
 )
@@ -157,7 +158,7 @@ var (
 )
 
 func Test_NewLVGController(t *testing.T) {
-	c := NewController(nil, "node", testLogger)
+	c := NewController(nil, "node", new(mocks.NoOpRecorder), testLogger)
 	assert.NotNil(t, c)
 }
 
@@ -195,6 +196,9 @@ func TestReconcile_SuccessCreatingLVG(t *testing.T) {
 	assert.Equal(t, res, ctrl.Result{})
 	err = c.k8sClient.ReadCR(tCtx, req.Name, lvg)
 	assert.Equal(t, apiV1.Created, lvg.Spec.Status)
+	recorder := c.recorder.(*mocks.NoOpRecorder)
+	assert.Len(t, recorder.Calls, 1)
+	assert.Equal(t, eventing.LVGCreated, recorder.Calls[0].Reason)
 
 	// reconciled second time
 	res, err = c.Reconcile(req)
@@ -337,6 +341,50 @@ func TestReconcile_FailedVGCreate(t *testing.T) {
 	assert.Equal(t, apiV1.Failed, lvgCR.Spec.Status)
 }
 
+func TestReconcile_FailedFailureDomain(t *testing.T) {
+	var (
+		fLVG = lvgCR1
+		e    = &mocks.GoMockExecutor{}
+	)
+
+	fLVG.Finalizers = []string{lvgFinalizer}
+	fLVG.Spec.FailureDomain = apiV1.FailureDomainEnclosure
+	c := setup(t, node1ID, fLVG)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: fLVG.Name}}
+
+	// PVs must not be created
+	c.lvmOps = lvm.NewLVM(e, testLogger)
+
+	res, err := c.Reconcile(req)
+	assert.Nil(t, err)
+	assert.Equal(t, res, ctrl.Result{})
+
+	lvgCR := &lvgcrd.LVG{}
+	assert.Nil(t, c.k8sClient.ReadCR(tCtx, lvgCR1.Name, lvgCR))
+	assert.Equal(t, apiV1.Failed, lvgCR.Spec.Status)
+	assert.Equal(t, lvgCR1.Spec.Locations, lvgCR.Spec.Locations)
+	e.AssertNotCalled(t, "RunCmd", mock.Anything)
+}
+
+func Test_checkFailureDomains(t *testing.T) {
+	var (
+		d1 = drive1CR.DeepCopy()
+		d2 = drive2CR.DeepCopy()
+	)
+	d1.Spec.Enclosure, d1.Spec.Controller = "enc-1", "host0"
+	d2.Spec.Enclosure, d2.Spec.Controller = "enc-2", "host0"
+	drives := []*drivecrd.Drive{d1, d2}
+
+	assert.Nil(t, checkFailureDomains("", drives))
+	assert.Nil(t, checkFailureDomains(apiV1.FailureDomainEnclosure, drives))
+	assert.NotNil(t, checkFailureDomains(apiV1.FailureDomainController, drives))
+	assert.NotNil(t, checkFailureDomains("rack", drives))
+
+	d2.Spec.Enclosure = ""
+	assert.NotNil(t, checkFailureDomains(apiV1.FailureDomainEnclosure, drives))
+}
+
 func Test_removeLVGArtifacts_Success(t *testing.T) {
 	var (
 		c   = setup(t, node1ID)
@@ -414,7 +462,7 @@ func setup(t *testing.T, node string, lvgs ...lvgcrd.LVG) *Controller {
 		assert.Nil(t, k8sClient.CreateCR(tCtx, lvg.Name, &lvg))
 	}
 
-	return NewController(k8sClient, node, testLogger)
+	return NewController(k8sClient, node, new(mocks.NoOpRecorder), testLogger)
 }
 
 func TestController_appendFinalizer(t *testing.T) {
@@ -0,0 +1,369 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package storageclass contains controller which bootstraps canonical k8s StorageClasses of the driver
+package storageclass
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	coreV1 "k8s.io/api/core/v1"
+	storageV1 "k8s.io/api/storage/v1"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+)
+
+const (
+	// defaultClassAnnotation marks StorageClass as a default one for the cluster
+	defaultClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+	// managedByLabel is set on each StorageClass which is handled by Controller
+	managedByLabel = "app.kubernetes.io/managed-by"
+	// managedByValue is the value of managedByLabel
+	managedByValue = "csibm-operator"
+	// fsTypeKey is the key in StorageClass parameters which holds FS type
+	fsTypeKey = "fsType"
+	// capacityAnnotation shows whether any node of the cluster has capacity for StorageClass,
+	// is set only when StorageClasses are auto provisioned
+	capacityAnnotation = "baremetal-csi.dellemc.com/capacity-available"
+)
+
+// Config holds parameters from which canonical StorageClasses are built
+type Config struct {
+	// NamePrefix is the name of StorageClass with ANY storage type, other classes are named as <NamePrefix>-<sc>
+	NamePrefix string
+	// FsType is the file system that is used for volumes
+	FsType string
+	// SetDefault defines whether StorageClass with ANY storage type should be marked as a default one
+	SetDefault bool
+	// StorageClasses is the list of CSI storage classes for which StorageClasses are built, all if it is empty
+	StorageClasses []string
+	// AutoProvision defines whether StorageClass is created only when any node has AvailableCapacity for it,
+	// existing StorageClasses aren't removed when capacity disappears but are marked with capacityAnnotation
+	AutoProvision bool
+}
+
+// Controller creates and keeps in sync canonical StorageClass objects of the driver
+type Controller struct {
+	k8sClient *k8s.KubeClient
+	// key - StorageClass name, value - expected StorageClass
+	classes map[string]*storageV1.StorageClass
+	// whether StorageClasses are created according to discovered capacity
+	autoProvision bool
+
+	log *logrus.Entry
+}
+
+// NewController is the constructor for Controller struct
+// Receives an instance of base.KubeClient, Config which describes StorageClasses and logrus logger
+// Returns an instance of Controller
+func NewController(k8sClient *k8s.KubeClient, conf Config, logger *logrus.Logger) *Controller {
+	if conf.FsType == "" {
+		conf.FsType = base.DefaultFsType
+	}
+	return &Controller{
+		k8sClient:     k8sClient,
+		classes:       BuildStorageClasses(conf),
+		autoProvision: conf.AutoProvision,
+		log:           logger.WithField("component", "StorageClassController"),
+	}
+}
+
+// BuildStorageClasses constructs expected StorageClasses for each CSI storage class from Config
+// Returns map where key is StorageClass name and value is StorageClass
+func BuildStorageClasses(conf Config) map[string]*storageV1.StorageClass {
+	var (
+		reclaimPolicy  = coreV1.PersistentVolumeReclaimDelete
+		bindingMode    = storageV1.VolumeBindingWaitForFirstConsumer
+		storageClasses = []string{
+			apiV1.StorageClassAny,
+			apiV1.StorageClassHDD,
+			apiV1.StorageClassSSD,
+			apiV1.StorageClassNVMe,
+			apiV1.StorageClassHDDLVG,
+			apiV1.StorageClassSSDLVG,
+			apiV1.StorageClassNVMeLVG,
+			apiV1.StorageClassSystemLVG,
+		}
+		res = make(map[string]*storageV1.StorageClass, len(storageClasses))
+	)
+	if len(conf.StorageClasses) > 0 {
+		storageClasses = make([]string, 0, len(conf.StorageClasses))
+		for _, sc := range conf.StorageClasses {
+			storageClasses = append(storageClasses, util.ConvertStorageClass(sc))
+		}
+	}
+
+	for _, sc := range storageClasses {
+		name := conf.NamePrefix
+		if sc != apiV1.StorageClassAny {
+			name += "-" + strings.ToLower(sc)
+		}
+		obj := &storageV1.StorageClass{
+			TypeMeta: metaV1.TypeMeta{Kind: "StorageClass", APIVersion: "storage.k8s.io/v1"},
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{managedByLabel: managedByValue},
+			},
+			Provisioner: base.PluginName,
+			Parameters: map[string]string{
+				base.StorageTypeKey: sc,
+				fsTypeKey:           conf.FsType,
+			},
+			ReclaimPolicy:     &reclaimPolicy,
+			VolumeBindingMode: &bindingMode,
+		}
+		if sc == apiV1.StorageClassAny && conf.SetDefault {
+			obj.Annotations = map[string]string{defaultClassAnnotation: "true"}
+		}
+		res[name] = obj
+	}
+
+	return res
+}
+
+// SetupWithManager registers Controller to ControllerManager and schedules initial creation of StorageClasses
+func (c *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.Add(manager.RunnableFunc(func(<-chan struct{}) error {
+		c.Bootstrap()
+		return nil
+	})); err != nil {
+		return err
+	}
+
+	builder := ctrl.NewControllerManagedBy(mgr).For(&storageV1.StorageClass{})
+	if c.autoProvision {
+		// any change of AvailableCapacity could make StorageClass available or unavailable
+		builder = builder.Watches(&source.Kind{Type: &accrd.AvailableCapacity{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(func(handler.MapObject) []reconcile.Request {
+				return c.allRequests()
+			}),
+		})
+	}
+
+	return builder.
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: 1,
+		}).
+		WithEventFilter(predicate.Funcs{
+			CreateFunc: func(e event.CreateEvent) bool {
+				return c.isManaged(e.Object)
+			},
+			DeleteFunc: func(e event.DeleteEvent) bool {
+				return c.isManaged(e.Object)
+			},
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				return c.isManaged(e.ObjectNew)
+			},
+			GenericFunc: func(e event.GenericEvent) bool {
+				return c.isManaged(e.Object)
+			},
+		}).
+		Complete(c)
+}
+
+// isManaged checks whether provided object is a StorageClass which is handled by Controller
+// or AvailableCapacity if StorageClasses are auto provisioned
+func (c *Controller) isManaged(obj runtime.Object) bool {
+	switch o := obj.(type) {
+	case *storageV1.StorageClass:
+		_, ok := c.classes[o.Name]
+		return ok
+	case *accrd.AvailableCapacity:
+		return c.autoProvision
+	}
+	return false
+}
+
+// allRequests returns reconcile requests for all canonical StorageClasses
+func (c *Controller) allRequests() []reconcile.Request {
+	requests := make([]reconcile.Request, 0, len(c.classes))
+	for name := range c.classes {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: name}})
+	}
+	return requests
+}
+
+// Bootstrap creates or fixes all canonical StorageClasses, is called once on start
+func (c *Controller) Bootstrap() {
+	for _, req := range c.allRequests() {
+		if _, err := c.Reconcile(req); err != nil {
+			c.log.WithField("method", "Bootstrap").Errorf("Unable to bootstrap StorageClass %s: %v", req.Name, err)
+		}
+	}
+}
+
+// Reconcile creates StorageClass if it doesn't exist or recreates it if immutable fields were changed
+// Returns reconcile result as ctrl.Result or error if something went wrong
+func (c *Controller) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ll := c.log.WithFields(logrus.Fields{
+		"method": "Reconcile",
+		"name":   req.Name,
+	})
+
+	expected, ok := c.classes[req.Name]
+	if !ok {
+		return ctrl.Result{}, nil
+	}
+
+	var (
+		ctx       = context.WithValue(context.Background(), base.RequestUUID, req.Name)
+		sc        = &storageV1.StorageClass{}
+		available = true
+	)
+
+	if c.autoProvision {
+		classes, err := c.availableClasses(ctx)
+		if err != nil {
+			ll.Errorf("Unable to read AvailableCapacities: %v", err)
+			return ctrl.Result{Requeue: true}, err
+		}
+		available = classes[expected.Parameters[base.StorageTypeKey]]
+		expected = withCapacityAnnotation(expected, available)
+	}
+
+	err := c.k8sClient.ReadCR(ctx, req.Name, sc)
+	switch {
+	case k8sError.IsNotFound(err) && !available:
+		ll.Debugf("There is no capacity for StorageClass, skip creation")
+		return ctrl.Result{}, nil
+	case k8sError.IsNotFound(err):
+		ll.Infof("StorageClass doesn't exist, creating it")
+		return c.create(ctx, expected)
+	case err != nil:
+		ll.Errorf("Unable to read StorageClass: %v", err)
+		return ctrl.Result{Requeue: true}, err
+	case !sc.DeletionTimestamp.IsZero():
+		// will be created again on delete event
+		return ctrl.Result{}, nil
+	}
+
+	if !immutableFieldsEqual(sc, expected) {
+		// StorageClass parameters are immutable, the only way to fix them is to recreate object
+		ll.Warnf("StorageClass parameters drifted (provisioner: %s, parameters: %v), recreating it",
+			sc.Provisioner, sc.Parameters)
+		if err = c.k8sClient.DeleteCR(ctx, sc); err != nil && !k8sError.IsNotFound(err) {
+			ll.Errorf("Unable to delete StorageClass: %v", err)
+			return ctrl.Result{Requeue: true}, err
+		}
+		return c.create(ctx, expected)
+	}
+
+	if needUpdate := mergeMetadata(sc, expected); needUpdate {
+		ll.Infof("Updating StorageClass labels and annotations")
+		if err = c.k8sClient.UpdateCR(ctx, sc); err != nil {
+			ll.Errorf("Unable to update StorageClass: %v", err)
+			return ctrl.Result{Requeue: true}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// create creates copy of provided StorageClass
+func (c *Controller) create(ctx context.Context, expected *storageV1.StorageClass) (ctrl.Result, error) {
+	sc := expected.DeepCopy()
+	if err := c.k8sClient.CreateCR(ctx, sc.Name, sc); err != nil {
+		return ctrl.Result{Requeue: true}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// availableClasses returns CSI storage classes for which any node has non-zero AvailableCapacity,
+// LVG storage classes are available when there is capacity of underlying drives
+// Returns map where key is CSI storage class or error if AvailableCapacities can't be read
+func (c *Controller) availableClasses(ctx context.Context) (map[string]bool, error) {
+	acList := &accrd.AvailableCapacityList{}
+	if err := c.k8sClient.ReadList(ctx, acList); err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]bool)
+	for _, ac := range acList.Items {
+		if ac.Spec.Size > 0 {
+			res[ac.Spec.StorageClass] = true
+			res[apiV1.StorageClassAny] = true
+		}
+	}
+	for _, sc := range []string{apiV1.StorageClassHDDLVG, apiV1.StorageClassSSDLVG, apiV1.StorageClassNVMeLVG} {
+		if res[util.GetSubStorageClass(sc)] {
+			res[sc] = true
+		}
+	}
+	return res, nil
+}
+
+// withCapacityAnnotation returns copy of StorageClass with capacityAnnotation
+func withCapacityAnnotation(sc *storageV1.StorageClass, available bool) *storageV1.StorageClass {
+	res := sc.DeepCopy()
+	if res.Annotations == nil {
+		res.Annotations = make(map[string]string, 1)
+	}
+	res.Annotations[capacityAnnotation] = strconv.FormatBool(available)
+	return res
+}
+
+// immutableFieldsEqual compares fields of StorageClass that can't be updated
+func immutableFieldsEqual(current, expected *storageV1.StorageClass) bool {
+	return current.Provisioner == expected.Provisioner &&
+		reflect.DeepEqual(current.Parameters, expected.Parameters) &&
+		reflect.DeepEqual(current.ReclaimPolicy, expected.ReclaimPolicy) &&
+		reflect.DeepEqual(current.VolumeBindingMode, expected.VolumeBindingMode)
+}
+
+// mergeMetadata adds expected labels and annotations to current StorageClass
+// Returns true if current StorageClass was modified
+func mergeMetadata(current, expected *storageV1.StorageClass) bool {
+	modified := false
+	for key, value := range expected.Labels {
+		if current.Labels[key] != value {
+			if current.Labels == nil {
+				current.Labels = make(map[string]string, len(expected.Labels))
+			}
+			current.Labels[key] = value
+			modified = true
+		}
+	}
+	for key, value := range expected.Annotations {
+		if current.Annotations[key] != value {
+			if current.Annotations == nil {
+				current.Annotations = make(map[string]string, len(expected.Annotations))
+			}
+			current.Annotations[key] = value
+			modified = true
+		}
+	}
+	return modified
+}
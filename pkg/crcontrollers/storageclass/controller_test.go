@@ -0,0 +1,202 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storageclass
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	storageV1 "k8s.io/api/storage/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+)
+
+var (
+	testCtx    = context.Background()
+	testLogger = logrus.New()
+	testPrefix = "baremetal-csi-sc"
+	testConf   = Config{NamePrefix: testPrefix, FsType: "ext4", SetDefault: true}
+)
+
+func TestNewController(t *testing.T) {
+	c := setup(t, Config{NamePrefix: testPrefix})
+
+	assert.Len(t, c.classes, 8)
+	anySC, ok := c.classes[testPrefix]
+	assert.True(t, ok)
+	assert.Equal(t, apiV1.StorageClassAny, anySC.Parameters[base.StorageTypeKey])
+	assert.Equal(t, base.DefaultFsType, anySC.Parameters[fsTypeKey])
+	assert.Empty(t, anySC.Annotations)
+
+	hddLVG, ok := c.classes[testPrefix+"-hddlvg"]
+	assert.True(t, ok)
+	assert.Equal(t, apiV1.StorageClassHDDLVG, hddLVG.Parameters[base.StorageTypeKey])
+	assert.Equal(t, storageV1.VolumeBindingWaitForFirstConsumer, *hddLVG.VolumeBindingMode)
+}
+
+func TestBuildStorageClasses(t *testing.T) {
+	classes := BuildStorageClasses(Config{NamePrefix: testPrefix, StorageClasses: []string{"hdd", apiV1.StorageClassSSDLVG}})
+
+	assert.Len(t, classes, 2)
+	assert.Contains(t, classes, testPrefix+"-hdd")
+	assert.Contains(t, classes, testPrefix+"-ssdlvg")
+}
+
+func TestReconcile(t *testing.T) {
+	t.Run("StorageClass is created", func(t *testing.T) {
+		c := setup(t, testConf)
+		name := testPrefix + "-ssd"
+
+		res, err := c.Reconcile(request(name))
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+
+		sc := &storageV1.StorageClass{}
+		assert.Nil(t, c.k8sClient.ReadCR(testCtx, name, sc))
+		assert.Equal(t, apiV1.StorageClassSSD, sc.Parameters[base.StorageTypeKey])
+		assert.Equal(t, "ext4", sc.Parameters[fsTypeKey])
+		assert.Equal(t, base.PluginName, sc.Provisioner)
+	})
+
+	t.Run("Unmanaged StorageClass is skipped", func(t *testing.T) {
+		c := setup(t, testConf)
+
+		res, err := c.Reconcile(request("some-sc"))
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+
+		sc := &storageV1.StorageClass{}
+		assert.NotNil(t, c.k8sClient.ReadCR(testCtx, "some-sc", sc))
+	})
+
+	t.Run("Drifted StorageClass is recreated", func(t *testing.T) {
+		c := setup(t, testConf)
+		name := testPrefix + "-hdd"
+
+		drifted := c.classes[name].DeepCopy()
+		drifted.Parameters[base.StorageTypeKey] = "HDDD"
+		assert.Nil(t, c.k8sClient.Create(testCtx, drifted))
+
+		_, err := c.Reconcile(request(name))
+		assert.Nil(t, err)
+
+		sc := &storageV1.StorageClass{}
+		assert.Nil(t, c.k8sClient.ReadCR(testCtx, name, sc))
+		assert.Equal(t, apiV1.StorageClassHDD, sc.Parameters[base.StorageTypeKey])
+	})
+
+	t.Run("Missed annotation is restored", func(t *testing.T) {
+		c := setup(t, testConf)
+
+		withoutAnnotation := c.classes[testPrefix].DeepCopy()
+		withoutAnnotation.Annotations = nil
+		assert.Nil(t, c.k8sClient.Create(testCtx, withoutAnnotation))
+
+		_, err := c.Reconcile(request(testPrefix))
+		assert.Nil(t, err)
+
+		sc := &storageV1.StorageClass{}
+		assert.Nil(t, c.k8sClient.ReadCR(testCtx, testPrefix, sc))
+		assert.Equal(t, "true", sc.Annotations[defaultClassAnnotation])
+	})
+}
+
+func TestReconcileAutoProvision(t *testing.T) {
+	conf := testConf
+	conf.AutoProvision = true
+
+	t.Run("StorageClass without capacity isn't created", func(t *testing.T) {
+		c := setup(t, conf)
+		createAC(t, c, "ac-1", apiV1.StorageClassHDD, 0)
+		name := testPrefix + "-hdd"
+
+		_, err := c.Reconcile(request(name))
+		assert.Nil(t, err)
+		assert.NotNil(t, c.k8sClient.ReadCR(testCtx, name, &storageV1.StorageClass{}))
+	})
+
+	t.Run("StorageClasses with capacity are created", func(t *testing.T) {
+		c := setup(t, conf)
+		createAC(t, c, "ac-1", apiV1.StorageClassSSD, 1024)
+
+		for _, name := range []string{testPrefix, testPrefix + "-ssd", testPrefix + "-ssdlvg"} {
+			_, err := c.Reconcile(request(name))
+			assert.Nil(t, err)
+
+			sc := &storageV1.StorageClass{}
+			assert.Nil(t, c.k8sClient.ReadCR(testCtx, name, sc))
+			assert.Equal(t, "true", sc.Annotations[capacityAnnotation])
+		}
+		_, err := c.Reconcile(request(testPrefix + "-nvme"))
+		assert.Nil(t, err)
+		assert.NotNil(t, c.k8sClient.ReadCR(testCtx, testPrefix+"-nvme", &storageV1.StorageClass{}))
+	})
+
+	t.Run("StorageClass is marked when capacity disappears", func(t *testing.T) {
+		c := setup(t, conf)
+		name := testPrefix + "-hdd"
+		createAC(t, c, "ac-1", apiV1.StorageClassHDD, 1024)
+		_, err := c.Reconcile(request(name))
+		assert.Nil(t, err)
+
+		assert.Nil(t, c.k8sClient.DeleteCR(testCtx, &accrd.AvailableCapacity{
+			ObjectMeta: metaV1.ObjectMeta{Name: "ac-1"}}))
+		_, err = c.Reconcile(request(name))
+		assert.Nil(t, err)
+
+		sc := &storageV1.StorageClass{}
+		assert.Nil(t, c.k8sClient.ReadCR(testCtx, name, sc))
+		assert.Equal(t, "false", sc.Annotations[capacityAnnotation])
+	})
+}
+
+func TestBootstrap(t *testing.T) {
+	c := setup(t, testConf)
+
+	c.Bootstrap()
+
+	scList := &storageV1.StorageClassList{}
+	assert.Nil(t, c.k8sClient.ReadList(testCtx, scList))
+	assert.Len(t, scList.Items, len(c.classes))
+}
+
+func setup(t *testing.T, conf Config) *Controller {
+	// StorageClass is a cluster scoped resource
+	k8sClient, err := k8s.GetFakeKubeClient("", testLogger)
+	assert.Nil(t, err)
+
+	return NewController(k8sClient, conf, testLogger)
+}
+
+func createAC(t *testing.T, c *Controller, name, sc string, size int64) {
+	ac := c.k8sClient.ConstructACCR(name, api.AvailableCapacity{
+		Location: name, NodeId: "node-1", StorageClass: sc, Size: size})
+	assert.Nil(t, c.k8sClient.CreateCR(testCtx, name, ac))
+}
+
+func request(name string) ctrl.Request {
+	return ctrl.Request{NamespacedName: types.NamespacedName{Name: name}}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package crdconversion converts Volume, Drive and LVG custom resources between v1 and v2 versions through
+// conversion webhook and migrates stored objects to the storage version
+package crdconversion
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	v2 "github.com/dell/csi-baremetal/api/v2"
+)
+
+// movedFields contains fields which are kept in spec in v1 and in status in v2,
+// key - kind, value - map from the name of the field in v1 spec to the name of the field in v2 status
+var movedFields = map[string]map[string]string{
+	apiV1.VolumeKind: {
+		"CSIStatus":         "csiStatus",
+		"Health":            "health",
+		"OperationalStatus": "operationalStatus",
+		"StagingTargetPath": "stagingTargetPath",
+		"TargetPath":        "targetPath",
+	},
+	apiV1.DriveKind: {
+		"Health":            "health",
+		"Status":            "state",
+		"OperationalStatus": "operationalStatus",
+		"IOErrors":          "ioErrors",
+		"MediaErrors":       "mediaErrors",
+		"HealthScore":       "healthScore",
+	},
+	apiV1.LVGKind: {
+		"Status": "state",
+	},
+}
+
+// Convert converts custom resource to the desired apiVersion in place, fields which aren't changed between
+// versions are kept as is, so conversion is lossless in both directions
+// Returns error if kind or versions aren't supported
+func Convert(obj *unstructured.Unstructured, desiredAPIVersion string) error {
+	currentAPIVersion := obj.GetAPIVersion()
+	if currentAPIVersion == desiredAPIVersion {
+		return nil
+	}
+	fields, ok := movedFields[obj.GetKind()]
+	if !ok {
+		return fmt.Errorf("kind %s doesn't have several versions", obj.GetKind())
+	}
+
+	var err error
+	switch {
+	case currentAPIVersion == apiV1.APIV1Version && desiredAPIVersion == v2.APIV2Version:
+		for specField, statusField := range fields {
+			if err = moveField(obj, []string{"spec", specField}, []string{"status", statusField}); err != nil {
+				return err
+			}
+		}
+	case currentAPIVersion == v2.APIV2Version && desiredAPIVersion == apiV1.APIV1Version:
+		for specField, statusField := range fields {
+			if err = moveField(obj, []string{"status", statusField}, []string{"spec", specField}); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("conversion of %s from %s to %s isn't supported",
+			obj.GetKind(), currentAPIVersion, desiredAPIVersion)
+	}
+	obj.SetAPIVersion(desiredAPIVersion)
+	return nil
+}
+
+// moveField moves value of the field to another path, nothing is done if the field isn't set
+func moveField(obj *unstructured.Unstructured, from, to []string) error {
+	value, found, err := unstructured.NestedFieldNoCopy(obj.Object, from...)
+	if err != nil || !found {
+		return err
+	}
+	unstructured.RemoveNestedField(obj.Object, from...)
+	return unstructured.SetNestedField(obj.Object, value, to...)
+}
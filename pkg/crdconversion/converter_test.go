@@ -0,0 +1,109 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdconversion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	v2 "github.com/dell/csi-baremetal/api/v2"
+)
+
+func newVolumeV1() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiV1.APIV1Version,
+		"kind":       apiV1.VolumeKind,
+		"metadata":   map[string]interface{}{"name": "pvc-1"},
+		"spec": map[string]interface{}{
+			"Id":        "pvc-1",
+			"Size":      int64(1024),
+			"CSIStatus": apiV1.Created,
+			"Health":    apiV1.HealthGood,
+		},
+		"status": map[string]interface{}{"retries": int64(1)},
+	}}
+}
+
+func TestConvert(t *testing.T) {
+	obj := newVolumeV1()
+	assert.Nil(t, Convert(obj, v2.APIV2Version))
+	assert.Equal(t, v2.APIV2Version, obj.GetAPIVersion())
+	assert.Equal(t, map[string]interface{}{"Id": "pvc-1", "Size": int64(1024)}, obj.Object["spec"])
+	assert.Equal(t, map[string]interface{}{
+		"retries":   int64(1),
+		"csiStatus": apiV1.Created,
+		"health":    apiV1.HealthGood,
+	}, obj.Object["status"])
+
+	// conversion is lossless
+	assert.Nil(t, Convert(obj, apiV1.APIV1Version))
+	assert.Equal(t, newVolumeV1(), obj)
+
+	// the same version
+	assert.Nil(t, Convert(obj, apiV1.APIV1Version))
+	assert.Equal(t, newVolumeV1(), obj)
+}
+
+func TestConvert_Drive(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": v2.APIV2Version,
+		"kind":       apiV1.DriveKind,
+		"spec":       map[string]interface{}{"UUID": "drive-1"},
+		"status": map[string]interface{}{
+			"state":    apiV1.DriveStatusOnline,
+			"ioErrors": int64(3),
+		},
+	}}
+	assert.Nil(t, Convert(obj, apiV1.APIV1Version))
+	assert.Equal(t, map[string]interface{}{
+		"UUID":     "drive-1",
+		"Status":   apiV1.DriveStatusOnline,
+		"IOErrors": int64(3),
+	}, obj.Object["spec"])
+	assert.Equal(t, map[string]interface{}{}, obj.Object["status"])
+}
+
+func TestConvert_Fail(t *testing.T) {
+	// kind without versions
+	obj := newVolumeV1()
+	obj.SetKind(apiV1.AvailableCapacityKind)
+	assert.NotNil(t, Convert(obj, v2.APIV2Version))
+
+	// unknown version
+	obj = newVolumeV1()
+	assert.NotNil(t, Convert(obj, apiV1.CSICRsGroupVersion+"/v3"))
+	assert.Equal(t, apiV1.APIV1Version, obj.GetAPIVersion())
+
+	// malformed object
+	obj = newVolumeV1()
+	obj.Object["spec"] = "spec"
+	assert.NotNil(t, Convert(obj, v2.APIV2Version))
+}
+
+func TestGetStorageVersion(t *testing.T) {
+	crd := newCRD()
+	assert.Equal(t, "", getStorageVersion(crd))
+
+	assert.Nil(t, unstructured.SetNestedSlice(crd.Object, []interface{}{
+		map[string]interface{}{"name": "v1", "served": true, "storage": true},
+		map[string]interface{}{"name": "v2", "served": true, "storage": false},
+	}, "spec", "versions"))
+	assert.Equal(t, "v1", getStorageVersion(crd))
+}
@@ -0,0 +1,190 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdconversion
+
+import (
+	"context"
+	"encoding/base64"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+)
+
+// MigrationRetryInterval is the interval between two attempts of storage version migration
+const MigrationRetryInterval = time.Minute
+
+// versionedCRDs are CRDs which have several versions, key - name of CRD, value - kind of custom resource
+var versionedCRDs = map[string]string{
+	"volumes." + apiV1.CSICRsGroupVersion: apiV1.VolumeKind,
+	"drives." + apiV1.CSICRsGroupVersion:  apiV1.DriveKind,
+	"lvgs." + apiV1.CSICRsGroupVersion:    apiV1.LVGKind,
+}
+
+// newCRD returns empty unstructured CustomResourceDefinition, typed one isn't used to avoid dependency
+// on apiextensions-apiserver
+func newCRD() *unstructured.Unstructured {
+	crd := &unstructured.Unstructured{}
+	crd.SetAPIVersion("apiextensions.k8s.io/v1beta1")
+	crd.SetKind("CustomResourceDefinition")
+	return crd
+}
+
+// EnableWebhook switches conversion of versioned CRDs to the webhook which is served by the service,
+// unknown fields must be pruned for webhook conversion
+// Receives golang context, an instance of base.KubeClient, namespace and name of the service and
+// CA bundle which is used by kube-apiserver for verification of the webhook certificate
+// Returns error if at least one CRD wasn't updated
+func EnableWebhook(ctx context.Context, k8sClient *k8s.KubeClient, namespace, service string, caBundle []byte) error {
+	for name := range versionedCRDs {
+		crd := newCRD()
+		if err := k8sClient.ReadCR(ctx, name, crd); err != nil {
+			return err
+		}
+		conversion := map[string]interface{}{
+			"strategy": "Webhook",
+			"webhookClientConfig": map[string]interface{}{
+				"service": map[string]interface{}{
+					"namespace": namespace,
+					"name":      service,
+					"path":      ConvertPattern,
+				},
+				"caBundle": base64.StdEncoding.EncodeToString(caBundle),
+			},
+			"conversionReviewVersions": []interface{}{"v1beta1"},
+		}
+		if err := unstructured.SetNestedMap(crd.Object, conversion, "spec", "conversion"); err != nil {
+			return err
+		}
+		if err := unstructured.SetNestedField(crd.Object, false, "spec", "preserveUnknownFields"); err != nil {
+			return err
+		}
+		if err := k8sClient.UpdateCR(ctx, crd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Migrator rewrites objects of versioned custom resources, so kube-apiserver stores them in the storage version,
+// and then leaves only the storage version in storedVersions of CRD. Afterwards previous versions could be
+// removed from CRD in the next release of the driver
+type Migrator struct {
+	k8sClient *k8s.KubeClient
+
+	log *logrus.Entry
+}
+
+// NewMigrator is the constructor for Migrator struct
+// Receives an instance of base.KubeClient and logrus logger
+// Returns an instance of Migrator
+func NewMigrator(k8sClient *k8s.KubeClient, logger *logrus.Logger) *Migrator {
+	return &Migrator{
+		k8sClient: k8sClient,
+		log:       logger.WithField("component", "StorageVersionMigrator"),
+	}
+}
+
+// Run spawns goroutine which migrates custom resources to the storage version and retries it until success
+func (m *Migrator) Run() {
+	go func() {
+		for {
+			ctx, cancelFn := context.WithTimeout(context.Background(), base.DefaultTimeoutForVolumeOperations)
+			err := m.Migrate(ctx)
+			cancelFn()
+			if err == nil {
+				return
+			}
+			m.log.WithField("method", "Run").Errorf("Unable to migrate custom resources: %v", err)
+			time.Sleep(MigrationRetryInterval)
+		}
+	}()
+}
+
+// Migrate migrates objects of each versioned CRD which has versions other than the storage one in storedVersions
+// Returns error if at least one CRD wasn't migrated
+func (m *Migrator) Migrate(ctx context.Context) error {
+	for name, kind := range versionedCRDs {
+		if err := m.migrateCRD(ctx, name, kind); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateCRD rewrites all objects of CRD without changes and updates storedVersions of CRD
+func (m *Migrator) migrateCRD(ctx context.Context, name, kind string) error {
+	ll := m.log.WithFields(logrus.Fields{
+		"method": "migrateCRD",
+		"crd":    name,
+	})
+
+	crd := newCRD()
+	if err := m.k8sClient.ReadCR(ctx, name, crd); err != nil {
+		return err
+	}
+	storageVersion := getStorageVersion(crd)
+	storedVersions, _, _ := unstructured.NestedStringSlice(crd.Object, "status", "storedVersions")
+	if storageVersion == "" || (len(storedVersions) == 1 && storedVersions[0] == storageVersion) {
+		return nil
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetAPIVersion(apiV1.CSICRsGroupVersion + "/" + storageVersion)
+	list.SetKind(kind + "List")
+	if err := m.k8sClient.ReadList(ctx, list); err != nil {
+		return err
+	}
+	ll.Infof("Migrating %d objects from versions %v to %s", len(list.Items), storedVersions, storageVersion)
+	for i := range list.Items {
+		// update without changes makes kube-apiserver to write the object in the storage version
+		if err := m.k8sClient.Update(ctx, &list.Items[i]); err != nil && !k8sError.IsNotFound(err) {
+			return err
+		}
+	}
+
+	if err := unstructured.SetNestedStringSlice(crd.Object, []string{storageVersion},
+		"status", "storedVersions"); err != nil {
+		return err
+	}
+	if err := m.k8sClient.Status().Update(ctx, crd); err != nil {
+		return err
+	}
+	ll.Infof("Objects are stored in version %s", storageVersion)
+	return nil
+}
+
+// getStorageVersion returns name of the version of CRD which is marked as the storage one
+func getStorageVersion(crd *unstructured.Unstructured) string {
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, v := range versions {
+		version, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if storage, _ := version["storage"].(bool); storage {
+			name, _ := version["name"].(string)
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,99 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdconversion
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ConvertPattern is the path on which conversion webhook is served
+const ConvertPattern = "/convert"
+
+// ConversionReview is apiextensions.k8s.io/v1beta1 ConversionReview which is sent to conversion webhook
+type ConversionReview struct {
+	metav1.TypeMeta `json:",inline"`
+	Request         *ConversionRequest  `json:"request,omitempty"`
+	Response        *ConversionResponse `json:"response,omitempty"`
+}
+
+// ConversionRequest contains objects which should be converted to DesiredAPIVersion
+type ConversionRequest struct {
+	UID               types.UID                    `json:"uid"`
+	DesiredAPIVersion string                       `json:"desiredAPIVersion"`
+	Objects           []*unstructured.Unstructured `json:"objects"`
+}
+
+// ConversionResponse contains converted objects in the same order as in request or the reason of failure
+type ConversionResponse struct {
+	UID              types.UID                    `json:"uid"`
+	ConvertedObjects []*unstructured.Unstructured `json:"convertedObjects"`
+	Result           metav1.Status                `json:"result"`
+}
+
+// Webhook serves conversion requests of kube-apiserver
+type Webhook struct {
+	logger *logrus.Entry
+}
+
+// NewWebhook is the constructor for Webhook struct
+// Receives logrus logger
+// Returns an instance of Webhook
+func NewWebhook(logger *logrus.Logger) *Webhook {
+	return &Webhook{logger: logger.WithField("component", "ConversionWebhook")}
+}
+
+// ConvertHandler extracts ConversionReview from req and writes ConversionReview with response to the w
+func (wh *Webhook) ConvertHandler(w http.ResponseWriter, req *http.Request) {
+	ll := wh.logger.WithField("method", "ConvertHandler")
+
+	review := &ConversionReview{}
+	if err := json.NewDecoder(req.Body).Decode(review); err != nil || review.Request == nil {
+		ll.Errorf("Unable to decode request body: %v", err)
+		http.Error(w, "unable to decode ConversionReview", http.StatusBadRequest)
+		return
+	}
+
+	request := review.Request
+	ll.Debugf("Converting %d objects to %s", len(request.Objects), request.DesiredAPIVersion)
+	response := &ConversionResponse{
+		UID:              request.UID,
+		ConvertedObjects: make([]*unstructured.Unstructured, 0, len(request.Objects)),
+		Result:           metav1.Status{Status: metav1.StatusSuccess},
+	}
+	for _, obj := range request.Objects {
+		if err := Convert(obj, request.DesiredAPIVersion); err != nil {
+			ll.Errorf("Unable to convert %s %s: %v", obj.GetKind(), obj.GetName(), err)
+			response.ConvertedObjects = nil
+			response.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+			break
+		}
+		response.ConvertedObjects = append(response.ConvertedObjects, obj)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	review.Request = nil
+	review.Response = response
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		ll.Errorf("Unable to write response: %v", err)
+	}
+}
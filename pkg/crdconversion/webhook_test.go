@@ -0,0 +1,78 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdconversion
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	v2 "github.com/dell/csi-baremetal/api/v2"
+)
+
+func sendReview(t *testing.T, body []byte) (*httptest.ResponseRecorder, *ConversionReview) {
+	recorder := httptest.NewRecorder()
+	NewWebhook(logrus.New()).ConvertHandler(recorder, httptest.NewRequest(http.MethodPost, ConvertPattern,
+		bytes.NewReader(body)))
+	review := &ConversionReview{}
+	if recorder.Code == http.StatusOK {
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), review))
+	}
+	return recorder, review
+}
+
+func TestWebhook_ConvertHandler(t *testing.T) {
+	request := &ConversionReview{Request: &ConversionRequest{
+		UID:               "uid",
+		DesiredAPIVersion: v2.APIV2Version,
+		Objects:           []*unstructured.Unstructured{newVolumeV1()},
+	}}
+	body, err := json.Marshal(request)
+	assert.Nil(t, err)
+
+	recorder, review := sendReview(t, body)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Nil(t, review.Request)
+	assert.Equal(t, "uid", string(review.Response.UID))
+	assert.Equal(t, metav1.StatusSuccess, review.Response.Result.Status)
+	assert.Len(t, review.Response.ConvertedObjects, 1)
+	converted := review.Response.ConvertedObjects[0]
+	assert.Equal(t, v2.APIV2Version, converted.GetAPIVersion())
+	csiStatus, _, _ := unstructured.NestedString(converted.Object, "status", "csiStatus")
+	assert.Equal(t, apiV1.Created, csiStatus)
+
+	// unsupported version
+	request.Request.DesiredAPIVersion = apiV1.CSICRsGroupVersion + "/v3"
+	body, err = json.Marshal(request)
+	assert.Nil(t, err)
+	recorder, review = sendReview(t, body)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, metav1.StatusFailure, review.Response.Result.Status)
+	assert.Empty(t, review.Response.ConvertedObjects)
+
+	// malformed request
+	recorder, _ = sendReview(t, []byte("{"))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
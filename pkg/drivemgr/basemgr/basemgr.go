@@ -17,7 +17,10 @@ limitations under the License.
 package basemgr
 
 import (
+	"fmt"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
@@ -28,9 +31,12 @@ import (
 	"github.com/dell/csi-baremetal/pkg/base/command"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsscsi"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/nvmecli"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/sgses"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/smartctl"
 )
 
+var nvmeControllerRegexp = regexp.MustCompile(`nvme\d+`)
+
 // BaseManager is a drive manager based on Linux system utils
 type BaseManager struct {
 	exec     command.CmdExecutor
@@ -38,6 +44,7 @@ type BaseManager struct {
 	lsscsi   lsscsi.WrapLsscsi
 	smartctl smartctl.WrapSmartctl
 	nvme     nvmecli.WrapNvmecli
+	sgses    sgses.WrapSgSes
 }
 
 // GetDrivesList gets api.Drive slice using Linux system utils
@@ -58,9 +65,55 @@ func (mgr BaseManager) GetDrivesList() ([]*api.Drive, error) {
 	return devices, nil
 }
 
-// Locate implements Locate method of DriveManager interface
+// Locate implements Locate method of DriveManager interface, LED of enclosure slot which holds the drive is used
 func (mgr *BaseManager) Locate(serialNumber string, action int32) (int32, error) {
-	return -1, status.Error(codes.Unimplemented, "method Locate not implemented in BaseManager")
+	slot, err := mgr.findSlot(serialNumber)
+	if err != nil {
+		return -1, err
+	}
+
+	switch action {
+	case apiV1.LocateStart, apiV1.LocateStop:
+		if err = mgr.sgses.SetLocate(slot, action == apiV1.LocateStart); err != nil {
+			return -1, fmt.Errorf("unable to set LED of slot %s in enclosure %s: %v", slot.Name, slot.Enclosure, err)
+		}
+	case apiV1.LocateStatus:
+	default:
+		return -1, status.Errorf(codes.InvalidArgument, "unsupported action %d", action)
+	}
+
+	on, err := mgr.sgses.GetLocate(slot)
+	if err != nil {
+		return -1, fmt.Errorf("unable to get LED of slot %s in enclosure %s: %v", slot.Name, slot.Enclosure, err)
+	}
+	if on {
+		return apiV1.LocateStatusOn, nil
+	}
+	return apiV1.LocateStatusOff, nil
+}
+
+// findSlot searches enclosure slot which holds SCSI drive with provided serial number
+func (mgr *BaseManager) findSlot(serialNumber string) (*sgses.Slot, error) {
+	drives, err := mgr.GetSCSIDevices()
+	if err != nil {
+		return nil, err
+	}
+	slots, err := mgr.sgses.GetSlots()
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range drives {
+		if d.SerialNumber != serialNumber {
+			continue
+		}
+		for _, slot := range slots {
+			if slot.DevicePath == d.Path {
+				return slot, nil
+			}
+		}
+		return nil, status.Errorf(codes.NotFound, "drive with serial number %s isn't placed in enclosure", serialNumber)
+	}
+	return nil, status.Errorf(codes.NotFound, "drive with serial number %s isn't found", serialNumber)
 }
 
 // New is a constructor BaseManager
@@ -71,6 +124,7 @@ func New(exec command.CmdExecutor, logger *logrus.Logger) *BaseManager {
 		lsscsi:   lsscsi.NewLSSCSI(exec, logger),
 		smartctl: smartctl.NewSMARTCTL(exec),
 		nvme:     nvmecli.NewNVMECLI(exec, logger),
+		sgses:    sgses.NewSGSES(exec, logger),
 	}
 }
 
@@ -85,11 +139,12 @@ func (mgr *BaseManager) GetSCSIDevices() ([]*api.Drive, error) {
 	}
 	for _, device := range scsiDevices {
 		allDevices = append(allDevices, &api.Drive{
-			Path:     device.Path,
-			Firmware: device.Firmware,
-			VID:      device.Vendor,
-			PID:      device.Model,
-			Size:     device.Size,
+			Path:       device.Path,
+			Firmware:   device.Firmware,
+			Controller: scsiHost(device.ID),
+			VID:        device.Vendor,
+			PID:        device.Model,
+			Size:       device.Size,
 		})
 	}
 	devices := make([]*api.Drive, 0)
@@ -100,6 +155,10 @@ func (mgr *BaseManager) GetSCSIDevices() ([]*api.Drive, error) {
 			ll.Errorf("Failed to get SMART information for Device %v, Error: %v", allDevices[i], err)
 		} else {
 			allDevices[i].SerialNumber = smartInfo.SerialNumber
+			allDevices[i].WWN = smartInfo.GetWWN()
+			allDevices[i].LinkSpeed = smartInfo.GetLinkSpeed()
+			allDevices[i].Interface = smartInfo.GetInterface()
+			allDevices[i].MediaErrors = smartInfo.GetMediaErrors()
 			if allDevices[i].SerialNumber != "" && allDevices[i].VID != "" && allDevices[i].PID != "" {
 				if smartInfo.Rotation > 0 {
 					allDevices[i].Type = apiV1.DriveTypeHDD
@@ -117,9 +176,33 @@ func (mgr *BaseManager) GetSCSIDevices() ([]*api.Drive, error) {
 			}
 		}
 	}
+	mgr.fillEnclosureSlots(devices)
 	return devices, nil
 }
 
+// fillEnclosureSlots sets enclosure, slot and bay of drives which are placed in SCSI enclosures.
+// Enclosure is the logical ID of the enclosure, slot is the number which is used for LED manipulation
+// and bay is the name of the slot from enclosure descriptor
+func (mgr *BaseManager) fillEnclosureSlots(devices []*api.Drive) {
+	slots, err := mgr.sgses.GetSlots()
+	if err != nil {
+		// enclosures are optional, drives might be connected directly to controller
+		mgr.log.WithField("method", "fillEnclosureSlots").Debugf("Unable to get enclosure slots: %v", err)
+		return
+	}
+	byPath := make(map[string]*sgses.Slot, len(slots))
+	for _, slot := range slots {
+		byPath[slot.DevicePath] = slot
+	}
+	for _, d := range devices {
+		if slot, ok := byPath[d.Path]; ok {
+			d.Enclosure = slot.Enclosure
+			d.Slot = slot.Number
+			d.Bay = slot.Name
+		}
+	}
+}
+
 // GetNVMDevices get []*api.Drive using nvme_cli system util
 func (mgr *BaseManager) GetNVMDevices() ([]*api.Drive, error) {
 	ll := mgr.log.WithField("method", "GetNVMDevices")
@@ -133,6 +216,7 @@ func (mgr *BaseManager) GetNVMDevices() ([]*api.Drive, error) {
 		if device.Vendor != 0 && device.ModelNumber != "" && device.SerialNumber != "" {
 			devices = append(devices, &api.Drive{
 				Health:       device.Health,
+				MediaErrors:  device.MediaErrors,
 				PID:          device.ModelNumber,
 				VID:          strconv.Itoa(device.Vendor),
 				SerialNumber: device.SerialNumber,
@@ -140,6 +224,8 @@ func (mgr *BaseManager) GetNVMDevices() ([]*api.Drive, error) {
 				Size:         device.PhysicalSize,
 				Firmware:     device.Firmware,
 				Path:         device.DevicePath,
+				Interface:    "NVMe",
+				Controller:   nvmeController(device.DevicePath),
 			})
 		} else {
 			ll.Errorf("Device has empty VID, PID or SN field: %v", device)
@@ -147,3 +233,17 @@ func (mgr *BaseManager) GetNVMDevices() ([]*api.Drive, error) {
 	}
 	return devices, nil
 }
+
+// scsiHost returns SCSI host (HBA) from lsscsi ID in [H:C:T:L] format, for example host0
+func scsiHost(id string) string {
+	hctl := strings.Split(strings.Trim(id, "[]"), ":")
+	if len(hctl) != 4 {
+		return ""
+	}
+	return "host" + hctl[0]
+}
+
+// nvmeController returns NVMe controller of namespace device, for example nvme0 for /dev/nvme0n1
+func nvmeController(path string) string {
+	return nvmeControllerRegexp.FindString(path)
+}
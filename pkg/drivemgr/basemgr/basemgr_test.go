@@ -27,6 +27,7 @@ import (
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsscsi"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/nvmecli"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/sgses"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/smartctl"
 	"github.com/dell/csi-baremetal/pkg/mocks"
 	"github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
@@ -60,6 +61,7 @@ func TestLoopBackManager_GetNVMDevicesSuccess(t *testing.T) {
 	assert.Equal(t, 1, len(devices))
 	assert.Equal(t, "testPath", devices[0].Path)
 	assert.Equal(t, "testFirmware", devices[0].Firmware)
+	assert.Equal(t, "NVMe", devices[0].Interface)
 	assert.Equal(t, "testModel", devices[0].PID)
 	assert.Equal(t, "testSN", devices[0].SerialNumber)
 	assert.Equal(t, int64(1000), devices[0].Size)
@@ -115,14 +117,23 @@ func TestLoopBackManager_GetSCSIDevices(t *testing.T) {
 		manager      = New(mockexec, logger)
 		mockLsscsi   = &linuxutils.MockWrapLsscsi{}
 		mockSmartctl = &linuxutils.MockWrapSmartctl{}
+		mockSgSes    = &linuxutils.MockWrapSgSes{}
 	)
 
+	mockSgSes.On("GetSlots").Return([]*sgses.Slot{
+		{Enclosure: "0x500056b3a4f1e4ff", Number: "3", Name: "Slot 03", DevicePath: "testPath"},
+	}, nil)
+	manager.sgses = mockSgSes
+
 	smart := &smartctl.DeviceSMARTInfo{
 		SerialNumber: "testSN",
 		SmartStatus:  make(map[string]bool),
 		Rotation:     0,
 	}
 	smart.SmartStatus["passed"] = true
+	smart.Device.Protocol = "ATA"
+	smart.LogicalUnitID = "0x5000c500a1b2c3d4"
+	smart.InterfaceSpeed.Current.String = "6.0 Gb/s"
 	scsiDevice := make([]*lsscsi.SCSIDevice, 0)
 	scsiDevice = append(scsiDevice, &lsscsi.SCSIDevice{
 		ID:       "[0:0:0:1]",
@@ -153,6 +164,13 @@ func TestLoopBackManager_GetSCSIDevices(t *testing.T) {
 	assert.Equal(t, int64(1000), devices[0].Size)
 	assert.Equal(t, apiV1.HealthGood, devices[0].Health)
 	assert.Equal(t, apiV1.DriveTypeSSD, devices[0].Type)
+	assert.Equal(t, "0x5000c500a1b2c3d4", devices[0].WWN)
+	assert.Equal(t, "6.0 Gb/s", devices[0].LinkSpeed)
+	assert.Equal(t, "SATA", devices[0].Interface)
+	assert.Equal(t, "0x500056b3a4f1e4ff", devices[0].Enclosure)
+	assert.Equal(t, "3", devices[0].Slot)
+	assert.Equal(t, "Slot 03", devices[0].Bay)
+	assert.Equal(t, "host0", devices[0].Controller)
 
 	smart.SmartStatus["passed"] = false
 	smart.Rotation = 7200
@@ -306,3 +324,55 @@ func TestLoopBackManager_GetDrivesListSuccess(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func TestBaseManager_Locate(t *testing.T) {
+	var (
+		mockexec     = &mocks.GoMockExecutor{}
+		manager      = New(mockexec, logger)
+		mockLsscsi   = &linuxutils.MockWrapLsscsi{}
+		mockSmartctl = &linuxutils.MockWrapSmartctl{}
+		mockSgSes    = &linuxutils.MockWrapSgSes{}
+		slot         = &sgses.Slot{EnclosureDevice: "/dev/sg5", Number: "3", DevicePath: "/dev/sdb"}
+	)
+	mockLsscsi.On("GetSCSIDevices").Return([]*lsscsi.SCSIDevice{
+		{Path: "/dev/sdb", Vendor: "testVendor", Model: "testModel"},
+		{Path: "/dev/sdc", Vendor: "testVendor", Model: "testModel"},
+	}, nil)
+	mockSmartctl.On("GetDriveInfoByPath", "/dev/sdb").
+		Return(&smartctl.DeviceSMARTInfo{SerialNumber: "sn-sdb"}, nil)
+	mockSmartctl.On("GetDriveInfoByPath", "/dev/sdc").
+		Return(&smartctl.DeviceSMARTInfo{SerialNumber: "sn-sdc"}, nil)
+	mockSgSes.On("GetSlots").Return([]*sgses.Slot{slot}, nil)
+	mockSgSes.On("SetLocate", slot, true).Return(nil)
+	mockSgSes.On("GetLocate", slot).Return(true, nil)
+	manager.lsscsi = mockLsscsi
+	manager.smartctl = mockSmartctl
+	manager.sgses = mockSgSes
+
+	status, err := manager.Locate("sn-sdb", apiV1.LocateStart)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocateStatusOn, status)
+	mockSgSes.AssertCalled(t, "SetLocate", slot, true)
+
+	status, err = manager.Locate("sn-sdb", apiV1.LocateStatus)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocateStatusOn, status)
+
+	_, err = manager.Locate("sn-sdb", 10)
+	assert.NotNil(t, err)
+
+	// drive isn't in enclosure
+	_, err = manager.Locate("sn-sdc", apiV1.LocateStart)
+	assert.NotNil(t, err)
+
+	// drive doesn't exist
+	_, err = manager.Locate("sn-unknown", apiV1.LocateStart)
+	assert.NotNil(t, err)
+}
+
+func Test_scsiHostAndNvmeController(t *testing.T) {
+	assert.Equal(t, "host2", scsiHost("[2:0:1:0]"))
+	assert.Equal(t, "", scsiHost("unknown"))
+	assert.Equal(t, "nvme1", nvmeController("/dev/nvme1n1"))
+	assert.Equal(t, "", nvmeController("testPath"))
+}
@@ -0,0 +1,253 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drivemgr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	"github.com/dell/csi-baremetal/pkg/base/rpc"
+)
+
+const (
+	// HealthCheckInterval is the interval between health checks of DriveManager endpoints
+	HealthCheckInterval = 10 * time.Second
+	// MinBackoff is the time during which failed endpoint isn't used after the first failure
+	MinBackoff = time.Second
+	// MaxBackoff is the limit of exponentially growing time during which failed endpoint isn't used
+	MaxBackoff = time.Minute
+)
+
+// connection is the part of grpc.ClientConn which is used for health checks of endpoint
+type connection interface {
+	GetState() connectivity.State
+	ResetConnectBackoff()
+}
+
+// endpoint holds client and failures state of single DriveManager endpoint
+type endpoint struct {
+	address  string
+	client   api.DriveServiceClient
+	conn     connection
+	failures int
+	retryAt  time.Time
+}
+
+// FailoverClient is the implementation of api.DriveServiceClient which sends requests to the active DriveManager
+// endpoint and switches to standby one when the active endpoint is unavailable.
+// Failed endpoint isn't used during exponentially growing backoff time unless all other endpoints are failed too.
+type FailoverClient struct {
+	endpoints []*endpoint
+	active    int
+	mu        sync.Mutex
+	// now is used for mocking of current time in tests
+	now func() time.Time
+
+	log *logrus.Entry
+}
+
+// NewFailoverClient is the constructor for FailoverClient struct
+// Receives comma separated list of DriveManager endpoints, the first one is active and others are standby,
+// credentials for connection (insecure if nil) and logrus logger
+// Returns an instance of FailoverClient or error if gRPC client for some endpoint can't be created
+func NewFailoverClient(endpoints string, creds credentials.TransportCredentials,
+	logger *logrus.Logger) (*FailoverClient, error) {
+	f := newFailoverClient(logger)
+	for _, address := range strings.Split(endpoints, ",") {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+		client, err := rpc.NewClient(creds, address, logger)
+		if err != nil {
+			return nil, fmt.Errorf("fail to create grpc client for endpoint %s: %v", address, err)
+		}
+		f.addEndpoint(address, api.NewDriveServiceClient(client.GRPCClient), client.GRPCClient)
+	}
+	if len(f.endpoints) == 0 {
+		return nil, fmt.Errorf("DriveManager endpoints aren't provided")
+	}
+	return f, nil
+}
+
+func newFailoverClient(logger *logrus.Logger) *FailoverClient {
+	return &FailoverClient{
+		now: time.Now,
+		log: logger.WithField("component", "FailoverClient"),
+	}
+}
+
+func (f *FailoverClient) addEndpoint(address string, client api.DriveServiceClient, conn connection) {
+	f.endpoints = append(f.endpoints, &endpoint{address: address, client: client, conn: conn})
+}
+
+// GetDrivesList sends GetDrivesList request to the active DriveManager endpoint with failover to standby ones
+func (f *FailoverClient) GetDrivesList(ctx context.Context, in *api.DrivesRequest,
+	opts ...grpc.CallOption) (*api.DrivesResponse, error) {
+	var resp *api.DrivesResponse
+	err := f.call(func(client api.DriveServiceClient) (err error) {
+		resp, err = client.GetDrivesList(ctx, in, opts...)
+		return err
+	})
+	return resp, err
+}
+
+// Locate sends Locate request to the active DriveManager endpoint with failover to standby ones
+func (f *FailoverClient) Locate(ctx context.Context, in *api.DriveLocateRequest,
+	opts ...grpc.CallOption) (*api.DriveLocateResponse, error) {
+	var resp *api.DriveLocateResponse
+	err := f.call(func(client api.DriveServiceClient) (err error) {
+		resp, err = client.Locate(ctx, in, opts...)
+		return err
+	})
+	return resp, err
+}
+
+// Run spawns goroutine which periodically checks connection state of DriveManager endpoints
+func (f *FailoverClient) Run() {
+	go func() {
+		for {
+			time.Sleep(HealthCheckInterval)
+			f.CheckHealth()
+		}
+	}()
+}
+
+// CheckHealth marks endpoints which connection is broken as failed, switches from failed active endpoint
+// to healthy standby one and triggers reconnection of failed endpoints which backoff time is passed
+func (f *FailoverClient) CheckHealth() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	for i, e := range f.endpoints {
+		if e.conn == nil {
+			continue
+		}
+		state := e.conn.GetState()
+		switch state {
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			if !now.Before(e.retryAt) {
+				e.conn.ResetConnectBackoff()
+				f.markFailed(i, fmt.Errorf("connection state is %s", state))
+			}
+		case connectivity.Ready:
+			f.markHealthy(i, false)
+		}
+	}
+
+	if f.isFailed(f.active, now) {
+		for i := range f.endpoints {
+			if !f.isFailed(i, now) {
+				f.switchTo(i)
+				break
+			}
+		}
+	}
+}
+
+// call invokes fn for the active endpoint, then for standby endpoints which aren't failed and at last
+// for failed endpoints until fn doesn't return Unavailable error
+func (f *FailoverClient) call(fn func(client api.DriveServiceClient) error) error {
+	var err error
+	for _, i := range f.candidates() {
+		if err = fn(f.endpoints[i].client); status.Code(err) != codes.Unavailable {
+			f.mu.Lock()
+			f.markHealthy(i, true)
+			f.mu.Unlock()
+			return err
+		}
+		f.mu.Lock()
+		f.markFailed(i, err)
+		f.mu.Unlock()
+	}
+	return err
+}
+
+// candidates returns indexes of endpoints in order in which they should be tried
+func (f *FailoverClient) candidates() []int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var (
+		now     = f.now()
+		healthy = make([]int, 0, len(f.endpoints))
+		failed  = make([]int, 0, len(f.endpoints))
+	)
+	for n := range f.endpoints {
+		i := (f.active + n) % len(f.endpoints)
+		if f.isFailed(i, now) {
+			failed = append(failed, i)
+		} else {
+			healthy = append(healthy, i)
+		}
+	}
+	return append(healthy, failed...)
+}
+
+func (f *FailoverClient) isFailed(i int, now time.Time) bool {
+	return now.Before(f.endpoints[i].retryAt)
+}
+
+// markFailed increases failures counter of endpoint and doubles its backoff time
+func (f *FailoverClient) markFailed(i int, err error) {
+	e := f.endpoints[i]
+	e.failures++
+	backoff := MinBackoff
+	for n := 1; n < e.failures && backoff < MaxBackoff; n++ {
+		backoff *= 2
+	}
+	if backoff > MaxBackoff {
+		backoff = MaxBackoff
+	}
+	e.retryAt = f.now().Add(backoff)
+	f.log.WithField("method", "markFailed").
+		Warnf("DriveManager endpoint %s is unavailable, it isn't used during %s: %v", e.address, backoff, err)
+}
+
+// markHealthy resets failures of endpoint and makes it active one if activate is true
+func (f *FailoverClient) markHealthy(i int, activate bool) {
+	e := f.endpoints[i]
+	if e.failures > 0 {
+		f.log.WithField("method", "markHealthy").Infof("DriveManager endpoint %s is available", e.address)
+	}
+	e.failures = 0
+	e.retryAt = time.Time{}
+	if activate {
+		f.switchTo(i)
+	}
+}
+
+func (f *FailoverClient) switchTo(i int) {
+	if f.active == i {
+		return
+	}
+	f.log.WithField("method", "switchTo").Infof("Switch from DriveManager endpoint %s to %s",
+		f.endpoints[f.active].address, f.endpoints[i].address)
+	f.active = i
+}
@@ -0,0 +1,185 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drivemgr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+)
+
+var (
+	testCtx    = context.Background()
+	testLogger = logrus.New()
+)
+
+// fakeClient counts requests and returns Unavailable error if it is down
+type fakeClient struct {
+	name  string
+	down  bool
+	calls int
+}
+
+func (c *fakeClient) GetDrivesList(_ context.Context, _ *api.DrivesRequest,
+	_ ...grpc.CallOption) (*api.DrivesResponse, error) {
+	c.calls++
+	if c.down {
+		return nil, status.Error(codes.Unavailable, "connection refused")
+	}
+	return &api.DrivesResponse{Disks: []*api.Drive{{SerialNumber: c.name}}}, nil
+}
+
+func (c *fakeClient) Locate(_ context.Context, _ *api.DriveLocateRequest,
+	_ ...grpc.CallOption) (*api.DriveLocateResponse, error) {
+	c.calls++
+	if c.down {
+		return nil, status.Error(codes.Unavailable, "connection refused")
+	}
+	return &api.DriveLocateResponse{}, nil
+}
+
+// fakeConn returns configured connectivity state
+type fakeConn struct {
+	state  connectivity.State
+	resets int
+}
+
+func (c *fakeConn) GetState() connectivity.State {
+	return c.state
+}
+
+func (c *fakeConn) ResetConnectBackoff() {
+	c.resets++
+}
+
+func TestFailoverClient_GetDrivesList(t *testing.T) {
+	var (
+		f       = newFailoverClient(testLogger)
+		now     = time.Now()
+		active  = &fakeClient{name: "active"}
+		standby = &fakeClient{name: "standby"}
+	)
+	f.now = func() time.Time { return now }
+	f.addEndpoint("active", active, nil)
+	f.addEndpoint("standby", standby, nil)
+
+	resp, err := f.GetDrivesList(testCtx, &api.DrivesRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, "active", resp.Disks[0].SerialNumber)
+
+	// active endpoint is restarted
+	active.down = true
+	resp, err = f.GetDrivesList(testCtx, &api.DrivesRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, "standby", resp.Disks[0].SerialNumber)
+	assert.Equal(t, 1, f.active)
+
+	// standby endpoint stays active when previous one is back
+	active.down = false
+	active.calls = 0
+	resp, err = f.GetDrivesList(testCtx, &api.DrivesRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, "standby", resp.Disks[0].SerialNumber)
+	assert.Equal(t, 0, active.calls)
+
+	// both endpoints are down, failed endpoint is tried at last
+	active.down = true
+	standby.down = true
+	_, err = f.Locate(testCtx, &api.DriveLocateRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	// failed endpoint is used in spite of backoff when all endpoints are failed
+	standby.down = false
+	_, err = f.Locate(testCtx, &api.DriveLocateRequest{})
+	assert.Nil(t, err)
+}
+
+func TestFailoverClient_markFailed(t *testing.T) {
+	var (
+		f   = newFailoverClient(testLogger)
+		now = time.Now()
+	)
+	f.now = func() time.Time { return now }
+	f.addEndpoint("active", &fakeClient{}, nil)
+
+	expected := []time.Duration{MinBackoff, 2 * MinBackoff, 4 * MinBackoff}
+	for _, backoff := range expected {
+		f.markFailed(0, nil)
+		assert.Equal(t, now.Add(backoff), f.endpoints[0].retryAt)
+	}
+	for i := 0; i < 10; i++ {
+		f.markFailed(0, nil)
+	}
+	assert.Equal(t, now.Add(MaxBackoff), f.endpoints[0].retryAt)
+
+	f.markHealthy(0, true)
+	assert.Equal(t, 0, f.endpoints[0].failures)
+	assert.False(t, f.isFailed(0, now))
+}
+
+func TestFailoverClient_CheckHealth(t *testing.T) {
+	var (
+		f           = newFailoverClient(testLogger)
+		now         = time.Now()
+		activeConn  = &fakeConn{state: connectivity.Ready}
+		standbyConn = &fakeConn{state: connectivity.Ready}
+	)
+	f.now = func() time.Time { return now }
+	f.addEndpoint("active", &fakeClient{}, activeConn)
+	f.addEndpoint("standby", &fakeClient{}, standbyConn)
+
+	f.CheckHealth()
+	assert.Equal(t, 0, f.active)
+
+	// connection of active endpoint is broken
+	activeConn.state = connectivity.TransientFailure
+	f.CheckHealth()
+	assert.Equal(t, 1, f.active)
+	assert.Equal(t, 1, activeConn.resets)
+
+	// reconnection isn't triggered until backoff time is passed
+	f.CheckHealth()
+	assert.Equal(t, 1, activeConn.resets)
+	now = now.Add(MaxBackoff)
+	f.CheckHealth()
+	assert.Equal(t, 2, activeConn.resets)
+
+	// endpoint is reconnected
+	activeConn.state = connectivity.Ready
+	f.CheckHealth()
+	assert.False(t, f.isFailed(0, now))
+	assert.Equal(t, 1, f.active)
+}
+
+func TestNewFailoverClient(t *testing.T) {
+	f, err := NewFailoverClient("tcp://localhost:8888, tcp://localhost:8889", nil, testLogger)
+	assert.Nil(t, err)
+	assert.Len(t, f.endpoints, 2)
+	assert.Equal(t, "tcp://localhost:8889", f.endpoints[1].address)
+
+	_, err = NewFailoverClient("", nil, testLogger)
+	assert.NotNil(t, err)
+}
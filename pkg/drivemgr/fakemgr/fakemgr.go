@@ -0,0 +1,130 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fakemgr contains in-memory DriveManager for test purposes which doesn't require any hardware
+package fakemgr
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+)
+
+const (
+	defaultNumberOfDrives = 3
+	// 500Gi
+	defaultSize = 1024 * 1024 * 1024 * 500
+)
+
+/*
+FakeManager is created for testing purposes only!
+It keeps drives in memory and allows to run CSI sanity tests and control plane of the driver without real disks.
+Volumes can't be created on its drives because paths of drives don't exist on the host.
+*/
+type FakeManager struct {
+	log    *logrus.Entry
+	drives []*api.Drive
+	// LED state of drives by serial number
+	leds map[string]int32
+	sync.Mutex
+}
+
+// New is the constructor for FakeManager
+// Receives logrus logger and drives which are returned by GetDrivesList, default drives are used if it is empty
+// Returns an instance of FakeManager
+func New(logger *logrus.Logger, drives []*api.Drive) *FakeManager {
+	if len(drives) == 0 {
+		drives = DefaultDrives()
+	}
+	return &FakeManager{
+		log:    logger.WithField("component", "FakeManager"),
+		drives: drives,
+		leds:   make(map[string]int32),
+	}
+}
+
+// DefaultDrives returns online HDD drives with good health
+func DefaultDrives() []*api.Drive {
+	drives := make([]*api.Drive, 0, defaultNumberOfDrives)
+	for i := 0; i < defaultNumberOfDrives; i++ {
+		drives = append(drives, &api.Drive{
+			SerialNumber: fmt.Sprintf("FAKE%d", i),
+			VID:          "Test",
+			PID:          "Fake",
+			Size:         defaultSize,
+			Health:       apiV1.HealthGood,
+			Status:       apiV1.DriveStatusOnline,
+			Type:         apiV1.DriveTypeHDD,
+			Path:         fmt.Sprintf("/dev/fake%d", i),
+		})
+	}
+	return drives
+}
+
+// SetDrives replaces drives of FakeManager, it allows to imitate hotplug, removal or failure of drives
+func (mgr *FakeManager) SetDrives(drives []*api.Drive) {
+	mgr.Lock()
+	defer mgr.Unlock()
+	mgr.drives = drives
+}
+
+// GetDrivesList returns copies of drives of FakeManager
+func (mgr *FakeManager) GetDrivesList() ([]*api.Drive, error) {
+	mgr.Lock()
+	defer mgr.Unlock()
+
+	drives := make([]*api.Drive, 0, len(mgr.drives))
+	for _, d := range mgr.drives {
+		drives = append(drives, proto.Clone(d).(*api.Drive))
+	}
+	mgr.log.WithField("method", "GetDrivesList").Debugf("Return %d drives", len(drives))
+	return drives, nil
+}
+
+// Locate turns on, turns off or returns state of LED of the drive with provided serial number
+func (mgr *FakeManager) Locate(serialNumber string, action int32) (int32, error) {
+	mgr.Lock()
+	defer mgr.Unlock()
+
+	found := false
+	for _, d := range mgr.drives {
+		if d.SerialNumber == serialNumber {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return -1, status.Errorf(codes.NotFound, "drive with serial number %s isn't found", serialNumber)
+	}
+
+	switch action {
+	case apiV1.LocateStart:
+		mgr.leds[serialNumber] = apiV1.LocateStatusOn
+	case apiV1.LocateStop:
+		mgr.leds[serialNumber] = apiV1.LocateStatusOff
+	case apiV1.LocateStatus:
+	default:
+		return -1, status.Errorf(codes.InvalidArgument, "unsupported action %d", action)
+	}
+	return mgr.leds[serialNumber], nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakemgr
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+)
+
+var testLogger = logrus.New()
+
+func TestFakeManager_GetDrivesList(t *testing.T) {
+	mgr := New(testLogger, nil)
+
+	drives, err := mgr.GetDrivesList()
+	assert.Nil(t, err)
+	assert.Len(t, drives, defaultNumberOfDrives)
+
+	// returned drives are copies
+	drives[0].Status = apiV1.DriveStatusOffline
+	drives, err = mgr.GetDrivesList()
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.DriveStatusOnline, drives[0].Status)
+
+	mgr.SetDrives([]*api.Drive{{SerialNumber: "hdd1"}})
+	drives, err = mgr.GetDrivesList()
+	assert.Nil(t, err)
+	assert.Len(t, drives, 1)
+}
+
+func TestFakeManager_Locate(t *testing.T) {
+	mgr := New(testLogger, []*api.Drive{{SerialNumber: "hdd1"}})
+
+	ledState, err := mgr.Locate("hdd1", apiV1.LocateStart)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocateStatusOn, ledState)
+
+	ledState, err = mgr.Locate("hdd1", apiV1.LocateStatus)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocateStatusOn, ledState)
+
+	ledState, err = mgr.Locate("hdd1", apiV1.LocateStop)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocateStatusOff, ledState)
+
+	_, err = mgr.Locate("hdd2", apiV1.LocateStart)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = mgr.Locate("hdd1", 10)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
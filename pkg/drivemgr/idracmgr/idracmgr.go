@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -98,6 +99,11 @@ type IDRACDrive struct {
 	Manufacturer  string            `json:"Manufacturer"`
 	Protocol      string            `json:"Protocol"`
 	Model         string            `json:"Model"`
+	Revision      string            `json:"Revision"`
+	// NegotiatedSpeedGbs is the speed of the link between drive and controller
+	NegotiatedSpeedGbs float64 `json:"NegotiatedSpeedGbs"`
+	// Identifiers contain durable names of the drive such as NAA WWN
+	Identifiers []map[string]string `json:"Identifiers"`
 }
 
 // GetDrivesList returns slice of *api.Drive created from iDRAC drives
@@ -218,10 +224,26 @@ func (mgr *IDRACManager) getDrive(driveURL string) *api.Drive {
 		Type:         diskType,
 		Size:         drive.CapacityBytes,
 		Status:       apiV1.DriveStatusOnline,
+		Firmware:     drive.Revision,
+		WWN:          drive.getWWN(),
+		Interface:    drive.Protocol,
+	}
+	if drive.NegotiatedSpeedGbs > 0 {
+		apiDrive.LinkSpeed = fmt.Sprintf("%.1f Gb/s", drive.NegotiatedSpeedGbs)
 	}
 	return apiDrive
 }
 
+// getWWN returns durable name of the drive in NAA format or empty string if iDRAC doesn't report it
+func (d *IDRACDrive) getWWN() string {
+	for _, id := range d.Identifiers {
+		if id["DurableNameFormat"] == "NAA" && id["DurableName"] != "" {
+			return "0x" + strings.ToLower(id["DurableName"])
+		}
+	}
+	return ""
+}
+
 // doRequest performs HTTP GET request on provided url
 // Receives url to request
 // Returns *http.Response or error if something went wrong
@@ -51,6 +51,15 @@ func Test_convertDriveHealth(t *testing.T) {
 	assert.Equal(t, api.HealthBad, health)
 }
 
+func TestIDRACDrive_getWWN(t *testing.T) {
+	drive := &IDRACDrive{Identifiers: []map[string]string{
+		{"DurableNameFormat": "EUI", "DurableName": "0100000000000000"},
+		{"DurableNameFormat": "NAA", "DurableName": "5000C500A1B2C3D4"},
+	}}
+	assert.Equal(t, "0x5000c500a1b2c3d4", drive.getWWN())
+	assert.Equal(t, "", (&IDRACDrive{}).getWWN())
+}
+
 func TestNewIDRACManager(t *testing.T) {
 	idracManager := NewIDRACManager(logger, time.Second, "user", "password", "10.10.10.10")
 	assert.Equal(t, "user", idracManager.user)
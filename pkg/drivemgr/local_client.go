@@ -0,0 +1,63 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drivemgr
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+)
+
+const (
+	// BackendGRPC is the type of DriveManager backend which is run as a separate service and is called through gRPC
+	BackendGRPC = "grpc"
+	// BackendBaseMgr is the type of DriveManager backend which is based on lsscsi, smartctl and nvme system utils
+	// and is called in-process
+	BackendBaseMgr = "basemgr"
+	// BackendLoopback is the type of DriveManager backend which provides loopback devices backed by sparse files
+	// instead of physical drives and is called in-process, it is used for development on kind or minikube
+	BackendLoopback = "loopback"
+)
+
+// LocalClient is the implementation of api.DriveServiceClient which calls DriveManager in-process,
+// it is used when separate DriveManager service isn't deployed
+type LocalClient struct {
+	server *DriveServiceServerImpl
+}
+
+// NewLocalClient is the constructor for LocalClient struct
+// Receives logrus logger and implementation of DriveManager as parameters
+// Returns an instance of LocalClient
+func NewLocalClient(logger *logrus.Logger, manager DriveManager) *LocalClient {
+	server := NewDriveServer(logger, manager)
+	return &LocalClient{server: &server}
+}
+
+// GetDrivesList invokes DriveManager's GetDrivesList in-process
+func (c *LocalClient) GetDrivesList(ctx context.Context, in *api.DrivesRequest,
+	_ ...grpc.CallOption) (*api.DrivesResponse, error) {
+	return c.server.GetDrivesList(ctx, in)
+}
+
+// Locate invokes DriveManager's Locate in-process
+func (c *LocalClient) Locate(ctx context.Context, in *api.DriveLocateRequest,
+	_ ...grpc.CallOption) (*api.DriveLocateResponse, error) {
+	return c.server.Locate(ctx, in)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drivemgr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+)
+
+// fakeManager returns configured drives
+type fakeManager struct {
+	drives []*api.Drive
+}
+
+func (m *fakeManager) GetDrivesList() ([]*api.Drive, error) {
+	return m.drives, nil
+}
+
+func (m *fakeManager) Locate(serialNumber string, action int32) (int32, error) {
+	return -1, errors.New("not supported")
+}
+
+func TestLocalClient(t *testing.T) {
+	c := NewLocalClient(testLogger, &fakeManager{drives: []*api.Drive{{SerialNumber: "hdd1"}}})
+
+	resp, err := c.GetDrivesList(testCtx, &api.DrivesRequest{NodeId: "node"})
+	assert.Nil(t, err)
+	assert.Len(t, resp.Disks, 1)
+	assert.Equal(t, "node", resp.Disks[0].NodeId)
+	assert.Equal(t, apiV1.DriveStatusOnline, resp.Disks[0].Status)
+
+	_, err = c.Locate(testCtx, &api.DriveLocateRequest{DriveSerialNumber: "hdd1"})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
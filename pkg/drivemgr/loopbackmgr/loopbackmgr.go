@@ -51,7 +51,7 @@ const (
 	defaultFileName   = "loopback"
 	rootPath          = "/"
 	imagesFolder      = "/host/home"
-	createFileCmdTmpl = "dd if=/dev/zero of=%s bs=1M count=%d"
+	createFileCmdTmpl = "truncate --size=%[2]dM %[1]s" // sparse file, space is allocated on write
 	deleteFileCmdTmpl = "rm -rf %s"
 	// requires root privileges
 	losetupCmd                      = "losetup"
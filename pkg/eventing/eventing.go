@@ -32,6 +32,22 @@ const (
 	VolumeGoodHealth    = "VolumeGoodHealth"
 	VolumeSuspectHealth = "VolumeSuspectHealth"
 
+	VolumeCreated        = "VolumeCreated"
+	VolumeCreationFailed = "VolumeCreationFailed"
+	VolumeRemoved        = "VolumeRemoved"
+	VolumeRemovalFailed  = "VolumeRemovalFailed"
+	VolumeRemovalBlocked = "VolumeRemovalBlocked"
+	VolumeStaged         = "VolumeStaged"
+	VolumePublished      = "VolumePublished"
+	VolumeMountFailed    = "VolumeMountFailed"
+	VolumeUnmountFailed  = "VolumeUnmountFailed"
+	VolumeMountLost      = "VolumeMountLost"
+	VolumeRemounted      = "VolumeRemounted"
+	VolumeReadOnly       = "VolumeReadOnly"
+	VolumeRecovered      = "VolumeRecovered"
+	VolumeImported       = "VolumeImported"
+	VolumeImportFailed   = "VolumeImportFailed"
+
 	DriveDiscovered    = "DriveDiscovered"
 	DriveHealthSuspect = "DriveHealthSuspect"
 	DriveHealthFailure = "DriveHealthFailure"
@@ -39,4 +55,17 @@ const (
 	DriveHealthUnknown = "DriveHealthUnknown"
 	DriveStatusOnline  = "DriveStatusOnline"
 	DriveStatusOffline = "DriveStatusOffline"
+	DriveIOErrors      = "DriveIOErrors"
+	DriveEvacuation    = "DriveEvacuation"
+
+	DriveBenchmarkFinished = "DriveBenchmarkFinished"
+	DriveBenchmarkFailed   = "DriveBenchmarkFailed"
+
+	LVGCreated           = "LVGCreated"
+	LVGCreationFailed    = "LVGCreationFailed"
+	LVGRemoved           = "LVGRemoved"
+	LVGRemovalFailed     = "LVGRemovalFailed"
+	LVGCapacityExhausted = "LVGCapacityExhausted"
+
+	CapacityRebalanceRecommended = "CapacityRebalanceRecommended"
 )
@@ -66,6 +66,7 @@ var DiskCommands = map[string]CmdOut{
 		Err:    errors.New("unable to get partition table"),
 	},
 	"partprobe":                      EmptyOutSuccess,
+	"udevadm settle --timeout=10":    EmptyOutSuccess,
 	"parted -s /dev/sda mklabel gpt": EmptyOutSuccess,
 	"parted -s /dev/sdd mklabel gpt": {
 		Stdout: "",
@@ -77,6 +78,12 @@ var DiskCommands = map[string]CmdOut{
 	"parted -s /dev/sdb rm 1":                               EmptyOutFail,
 	"parted -s /dev/sde mkpart --align optimal CSI 0% 100%": EmptyOutSuccess,
 	"parted -s /dev/sdf mkpart --align optimal CSI 0% 100%": EmptyOutFail,
+	"sgdisk /dev/sde --new=0:0:+1024K --change-name=0:CSI --partition-guid=0:64be631b-62a5-11e9-a756-00505680d67f": {
+		Stdout: "The operation has completed successfully.",
+		Stderr: "",
+		Err:    nil,
+	},
+	"sgdisk /dev/sdf --new=0:0:+1024K --change-name=0:CSI --partition-guid=0:64be631b-62a5-11e9-a756-00505680d67f": EmptyOutFail,
 	"sgdisk /dev/sda --partition-guid=1:64be631b-62a5-11e9-a756-00505680d67f": {
 		Stdout: "The operation has completed successfully.",
 		Stderr: "",
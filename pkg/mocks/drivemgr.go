@@ -25,6 +25,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
 )
 
 // MockDriveMgrClient is the implementation of DriveManager interface to imitate success state
@@ -72,7 +73,13 @@ func (m *MockDriveMgrClient) GetDrivesList(ctx context.Context, in *api.DrivesRe
 	}, nil
 }
 
-// Locate is a stub for Locate DriveManager's method
+// Locate imitates Locate DriveManager's method, LED is turned on or off according to the requested action
 func (m *MockDriveMgrClient) Locate(ctx context.Context, in *api.DriveLocateRequest, opts ...grpc.CallOption) (*api.DriveLocateResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method Locate not implemented in MockDriveMgrClient")
+	switch in.GetAction() {
+	case apiV1.LocateStart:
+		return &api.DriveLocateResponse{Status: apiV1.LocateStatusOn}, nil
+	case apiV1.LocateStop:
+		return &api.DriveLocateResponse{Status: apiV1.LocateStatusOff}, nil
+	}
+	return nil, status.Error(codes.Unimplemented, "action is not supported by MockDriveMgrClient")
 }
@@ -0,0 +1,40 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linuxutils
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fio"
+)
+
+// MockWrapFio is a mock implementation of WrapFio interface from fio package
+type MockWrapFio struct {
+	mock.Mock
+}
+
+// Benchmark is a mock implementations
+func (m *MockWrapFio) Benchmark(device string, runtime time.Duration) (*fio.BenchmarkResult, error) {
+	args := m.Mock.Called(device, runtime)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fio.BenchmarkResult), args.Error(1)
+}
@@ -55,6 +55,13 @@ func (m *MockWrapFS) CreateFS(fsType fs.FileSystem, device string) error {
 	return args.Error(0)
 }
 
+// GrowFS is a mock implementations
+func (m *MockWrapFS) GrowFS(fsType fs.FileSystem, device, mountPoint string) error {
+	args := m.Mock.Called(fsType, device, mountPoint)
+
+	return args.Error(0)
+}
+
 // WipeFS is a mock implementations
 func (m *MockWrapFS) WipeFS(device string) error {
 	args := m.Mock.Called(device)
@@ -83,6 +90,27 @@ func (m *MockWrapFS) FindMountPoint(target string) (string, error) {
 	return args.String(0), args.Error(1)
 }
 
+// FindMountTarget is a mock implementations
+func (m *MockWrapFS) FindMountTarget(device string) (string, error) {
+	args := m.Mock.Called(device)
+
+	return args.String(0), args.Error(1)
+}
+
+// IsReadOnlyMount is a mock implementations
+func (m *MockWrapFS) IsReadOnlyMount(path string) (bool, error) {
+	args := m.Mock.Called(path)
+
+	return args.Bool(0), args.Error(1)
+}
+
+// Trim is a mock implementations
+func (m *MockWrapFS) Trim(mountPoint string) error {
+	args := m.Mock.Called(mountPoint)
+
+	return args.Error(0)
+}
+
 // Mount is a mock implementations
 func (m *MockWrapFS) Mount(src, dst string, opts ...string) error {
 	args := m.Mock.Called(src, dst, opts)
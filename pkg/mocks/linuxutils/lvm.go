@@ -67,6 +67,13 @@ func (m *MockWrapLVM) LVRemove(fullLVName string) error {
 	return args.Error(0)
 }
 
+// LVExpand is a mock implementations
+func (m *MockWrapLVM) LVExpand(fullLVName, size string) error {
+	args := m.Mock.Called(fullLVName, size)
+
+	return args.Error(0)
+}
+
 // IsVGContainsLVs is a mock implementations
 func (m *MockWrapLVM) IsVGContainsLVs(vgName string) bool {
 	args := m.Mock.Called(vgName)
@@ -53,6 +53,13 @@ func (m *MockWrapPartition) CreatePartition(device, label string) (err error) {
 	return args.Error(0)
 }
 
+// CreatePartitionWithSize is a mock implementations
+func (m *MockWrapPartition) CreatePartitionWithSize(device, label, partUUID string, size int64) (err error) {
+	args := m.Mock.Called(device, label, partUUID, size)
+
+	return args.Error(0)
+}
+
 // DeletePartition is a mock implementations
 func (m *MockWrapPartition) DeletePartition(device, partNum string) (err error) {
 	args := m.Mock.Called(device, partNum)
@@ -0,0 +1,49 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linuxutils
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/sgses"
+)
+
+// MockWrapSgSes is a mock implementation of WrapSgSes interface from sgses package
+type MockWrapSgSes struct {
+	mock.Mock
+}
+
+// GetSlots is a mock implementations
+func (m *MockWrapSgSes) GetSlots() ([]*sgses.Slot, error) {
+	args := m.Mock.Called()
+
+	return args.Get(0).([]*sgses.Slot), args.Error(1)
+}
+
+// SetLocate is a mock implementations
+func (m *MockWrapSgSes) SetLocate(slot *sgses.Slot, on bool) error {
+	args := m.Mock.Called(slot, on)
+
+	return args.Error(0)
+}
+
+// GetLocate is a mock implementations
+func (m *MockWrapSgSes) GetLocate(slot *sgses.Slot) (bool, error) {
+	args := m.Mock.Called(slot)
+
+	return args.Bool(0), args.Error(1)
+}
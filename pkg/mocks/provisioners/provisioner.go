@@ -34,6 +34,7 @@ func GetMockProvisionerSuccess(everytimePath string) *MockProvisioner {
 	mp.On("PrepareVolume", mock.Anything).Return(nil)
 	mp.On("ReleaseVolume", mock.Anything).Return(nil)
 	mp.On("GetVolumePath", mock.Anything).Return(everytimePath, nil)
+	mp.On("ExpandVolume", mock.Anything).Return(nil)
 
 	return &mp
 }
@@ -58,3 +59,10 @@ func (m *MockProvisioner) GetVolumePath(volume api.Volume) (string, error) {
 
 	return args.String(0), args.Error(1)
 }
+
+// ExpandVolume is the mock implementation of ExpandVolume method from Provisioner interface
+func (m *MockProvisioner) ExpandVolume(volume api.Volume) error {
+	args := m.Mock.Called(volume)
+
+	return args.Error(0)
+}
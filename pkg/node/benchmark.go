@@ -0,0 +1,187 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fio"
+	"github.com/dell/csi-baremetal/pkg/eventing"
+)
+
+// BenchmarkReport is stored as a value of DriveBenchmarkResultAnnotationKey annotation of Drive CR
+type BenchmarkReport struct {
+	// Time when benchmark was finished or rejected in RFC3339 format
+	Time string `json:"time"`
+	// Result of benchmark, nil if benchmark failed or was rejected
+	Result *fio.BenchmarkResult `json:"result,omitempty"`
+	// Error contains the reason why benchmark failed or was rejected
+	Error string `json:"error,omitempty"`
+}
+
+// handleBenchmarkRequest starts I/O benchmark of the drive in background. Benchmark overwrites data on the drive,
+// therefore only healthy online drives without volumes and LVG are benchmarked, AC of the drive is removed for
+// the time of benchmark. Request which can't be satisfied is rejected with the result annotation
+// Receives golang context and Drive CR with DriveBenchmarkAnnotationKey annotation
+// Returns error if Drive CR or AC CR wasn't updated
+func (m *VolumeManager) handleBenchmarkRequest(ctx context.Context, drive *drivecrd.Drive) error {
+	ll := m.log.WithFields(logrus.Fields{
+		"method": "handleBenchmarkRequest",
+		"drive":  drive.Name,
+	})
+
+	if m.isBenchmarkRunning(drive.Spec.UUID) {
+		return nil
+	}
+
+	runtime, err := parseBenchmarkRuntime(drive.Annotations[apiV1.DriveBenchmarkAnnotationKey])
+	if err == nil {
+		err = m.checkDriveUnallocated(drive)
+	}
+	if err != nil {
+		ll.Warnf("Benchmark request is rejected: %v", err)
+		m.sendEventForDrive(drive, eventing.WarningType, eventing.DriveBenchmarkFailed,
+			"Benchmark request is rejected: %v.", err)
+		return m.setBenchmarkReport(ctx, drive, &BenchmarkReport{Error: err.Error()})
+	}
+
+	if ac := m.crHelper.GetACByLocation(drive.Spec.UUID); ac != nil {
+		if err = m.k8sClient.DeleteCR(ctx, ac); err != nil && !k8sError.IsNotFound(err) {
+			return fmt.Errorf("unable to delete AC %s: %v", ac.Name, err)
+		}
+	}
+	m.setBenchmarkRunning(drive.Spec.UUID, true)
+
+	ll.Infof("Starting benchmark of %s for %s", drive.Spec.Path, runtime)
+	go m.runBenchmark(drive.Name, drive.Spec.UUID, drive.Spec.Path, runtime)
+	return nil
+}
+
+// runBenchmark runs fio against the device and stores its results on Drive CR
+func (m *VolumeManager) runBenchmark(driveName, driveUUID, path string, runtime time.Duration) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method": "runBenchmark",
+		"drive":  driveName,
+	})
+	// AC will be recreated by the next discover
+	defer m.setBenchmarkRunning(driveUUID, false)
+
+	report := &BenchmarkReport{}
+	result, err := m.fio.Benchmark(path, runtime)
+	if err != nil {
+		report.Error = err.Error()
+	} else {
+		report.Result = result
+	}
+
+	ctx := context.Background()
+	drive := &drivecrd.Drive{}
+	if err := m.k8sClient.ReadCR(ctx, driveName, drive); err != nil {
+		ll.Errorf("Unable to read Drive CR: %v", err)
+		return
+	}
+	if err := m.setBenchmarkReport(ctx, drive, report); err != nil {
+		ll.Errorf("Unable to store benchmark result: %v", err)
+		return
+	}
+
+	if report.Error != "" {
+		m.sendEventForDrive(drive, eventing.ErrorType, eventing.DriveBenchmarkFailed,
+			"Benchmark failed: %s.", report.Error)
+		return
+	}
+	m.sendEventForDrive(drive, eventing.InfoType, eventing.DriveBenchmarkFinished,
+		"Benchmark finished, read IOPS: %.0f, write IOPS: %.0f.", result.Read.IOPS, result.Write.IOPS)
+}
+
+// setBenchmarkReport removes benchmark request from Drive CR and stores the report instead
+func (m *VolumeManager) setBenchmarkReport(ctx context.Context, drive *drivecrd.Drive, report *BenchmarkReport) error {
+	report.Time = time.Now().UTC().Format(time.RFC3339)
+	value, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	if drive.Annotations == nil {
+		drive.Annotations = make(map[string]string)
+	}
+	delete(drive.Annotations, apiV1.DriveBenchmarkAnnotationKey)
+	drive.Annotations[apiV1.DriveBenchmarkResultAnnotationKey] = string(value)
+	return m.k8sClient.UpdateCR(ctx, drive)
+}
+
+// checkDriveUnallocated returns error if the drive can't be exclusively used by benchmark
+func (m *VolumeManager) checkDriveUnallocated(drive *drivecrd.Drive) error {
+	switch {
+	case drive.Spec.IsSystem:
+		return fmt.Errorf("drive is system")
+	case drive.Spec.Health != apiV1.HealthGood || drive.Spec.Status != apiV1.DriveStatusOnline:
+		return fmt.Errorf("drive health is %s and status is %s", drive.Spec.Health, drive.Spec.Status)
+	case !drive.DeletionTimestamp.IsZero():
+		return fmt.Errorf("drive is being removed")
+	case drive.Spec.Path == "":
+		return fmt.Errorf("drive path is unknown")
+	case m.crHelper.GetVolumeByLocation(drive.Spec.UUID) != nil:
+		return fmt.Errorf("drive has volumes")
+	case m.isDriveInLVG(drive.Spec):
+		return fmt.Errorf("drive is a part of LVG")
+	}
+	return nil
+}
+
+func (m *VolumeManager) isBenchmarkRunning(driveUUID string) bool {
+	m.benchmarksMu.Lock()
+	defer m.benchmarksMu.Unlock()
+	_, ok := m.benchmarks[driveUUID]
+	return ok
+}
+
+func (m *VolumeManager) setBenchmarkRunning(driveUUID string, running bool) {
+	m.benchmarksMu.Lock()
+	defer m.benchmarksMu.Unlock()
+	if running {
+		m.benchmarks[driveUUID] = struct{}{}
+	} else {
+		delete(m.benchmarks, driveUUID)
+	}
+}
+
+// parseBenchmarkRuntime converts value of DriveBenchmarkAnnotationKey annotation to duration,
+// empty value means default duration
+func parseBenchmarkRuntime(value string) (time.Duration, error) {
+	if value == "" {
+		return fio.DefaultRuntime, nil
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds <= 0 {
+		return 0, fmt.Errorf("invalid benchmark duration %q, positive amount of seconds is expected", value)
+	}
+	runtime := time.Duration(seconds) * time.Second
+	if runtime > fio.MaxRuntime {
+		return 0, fmt.Errorf("benchmark duration %s exceeds maximum %s", runtime, fio.MaxRuntime)
+	}
+	return runtime, nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fio"
+	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
+)
+
+func TestVolumeManager_handleBenchmarkRequest(t *testing.T) {
+	var (
+		vm      = prepareSuccessVolumeManagerWithDrives([]*api.Drive{&drive1}, t)
+		fioMock = &mocklu.MockWrapFio{}
+		drive   = &drivecrd.Drive{}
+		release = make(chan time.Time)
+		result  = &fio.BenchmarkResult{Read: fio.OperationResult{IOPS: 100}, Runtime: 30}
+	)
+	vm.fio = fioMock
+	fioMock.On("Benchmark", drive1.Path, 30*time.Second).Return(result, nil).WaitUntil(release)
+
+	ac := acCR.DeepCopy()
+	ac.Spec.Location = drive1.UUID
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, ac.Name, ac))
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive1.UUID, drive))
+	drive.Annotations = map[string]string{apiV1.DriveBenchmarkAnnotationKey: "30"}
+	assert.Nil(t, vm.k8sClient.UpdateCR(testCtx, drive))
+
+	// benchmark is started, AC is removed and isn't recreated while benchmark is running
+	assert.Nil(t, vm.handleDrivesActions(testCtx))
+	assert.True(t, vm.isBenchmarkRunning(drive1.UUID))
+	assert.True(t, k8sError.IsNotFound(vm.k8sClient.ReadCR(testCtx, ac.Name, &accrd.AvailableCapacity{})))
+	assert.Nil(t, vm.discoverAvailableCapacity(testCtx))
+	assert.Nil(t, vm.crHelper.GetACByLocation(drive1.UUID))
+
+	// request isn't handled twice
+	assert.Nil(t, vm.handleDrivesActions(testCtx))
+
+	close(release)
+	for i := 0; i < 100 && vm.isBenchmarkRunning(drive1.UUID); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.False(t, vm.isBenchmarkRunning(drive1.UUID))
+	fioMock.AssertNumberOfCalls(t, "Benchmark", 1)
+
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive1.UUID, drive))
+	assert.NotContains(t, drive.Annotations, apiV1.DriveBenchmarkAnnotationKey)
+	report := &BenchmarkReport{}
+	assert.Nil(t, json.Unmarshal([]byte(drive.Annotations[apiV1.DriveBenchmarkResultAnnotationKey]), report))
+	assert.Equal(t, result, report.Result)
+	assert.Empty(t, report.Error)
+}
+
+func TestVolumeManager_handleBenchmarkRequestRejected(t *testing.T) {
+	var (
+		vm    = prepareSuccessVolumeManagerWithDrives([]*api.Drive{&drive1}, t)
+		drive = &drivecrd.Drive{}
+	)
+	vm.fio = &mocklu.MockWrapFio{}
+
+	// drive has volume
+	vol := testVolumeCR1.DeepCopy()
+	vol.Spec.Location = drive1.UUID
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, vol.Name, vol))
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive1.UUID, drive))
+	drive.Annotations = map[string]string{apiV1.DriveBenchmarkAnnotationKey: ""}
+	assert.Nil(t, vm.k8sClient.UpdateCR(testCtx, drive))
+
+	assert.Nil(t, vm.handleDrivesActions(testCtx))
+	assert.False(t, vm.isBenchmarkRunning(drive1.UUID))
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive1.UUID, drive))
+	assert.NotContains(t, drive.Annotations, apiV1.DriveBenchmarkAnnotationKey)
+	report := &BenchmarkReport{}
+	assert.Nil(t, json.Unmarshal([]byte(drive.Annotations[apiV1.DriveBenchmarkResultAnnotationKey]), report))
+	assert.Nil(t, report.Result)
+	assert.Equal(t, "drive has volumes", report.Error)
+}
+
+func Test_parseBenchmarkRuntime(t *testing.T) {
+	runtime, err := parseBenchmarkRuntime("")
+	assert.Nil(t, err)
+	assert.Equal(t, fio.DefaultRuntime, runtime)
+
+	runtime, err = parseBenchmarkRuntime("120")
+	assert.Nil(t, err)
+	assert.Equal(t, 2*time.Minute, runtime)
+
+	for _, value := range []string{"-1", "0", "1m", "100000"} {
+		_, err = parseBenchmarkRuntime(value)
+		assert.NotNil(t, err, value)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	health "google.golang.org/grpc/health/grpc_health_v1"
+	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+)
+
+const (
+	// HealthDiscoveryTimeout is the time since the last successful discovery after which node isn't healthy
+	HealthDiscoveryTimeout = 5 * time.Minute
+	// HealthAPITimeout is the timeout of kubernetes API reachability check
+	HealthAPITimeout = 5 * time.Second
+)
+
+// healthState holds state of VolumeManager dependencies which is used for health checks
+type healthState struct {
+	mu sync.RWMutex
+	// lastDiscovery is the time of the last successful discovery
+	lastDiscovery time.Time
+	// driveMgrErr is the error of the last request to DriveManager
+	driveMgrErr error
+	// discovering is true while re-discovery of drives is in progress
+	discovering bool
+	// operations holds start time of volume operations which are in progress
+	operations map[string]time.Time
+}
+
+func (h *healthState) setDriveMgrErr(err error) {
+	h.mu.Lock()
+	h.driveMgrErr = err
+	h.mu.Unlock()
+}
+
+func (h *healthState) discovered(now time.Time) {
+	h.mu.Lock()
+	h.lastDiscovery = now
+	h.mu.Unlock()
+}
+
+func (h *healthState) setDiscovering(discovering bool) {
+	h.mu.Lock()
+	h.discovering = discovering
+	h.mu.Unlock()
+}
+
+// startOperation saves start time of operation with volume
+// Returns function which should be called when operation is finished
+func (h *healthState) startOperation(volumeID string) func() {
+	h.mu.Lock()
+	if h.operations == nil {
+		h.operations = make(map[string]time.Time)
+	}
+	h.operations[volumeID] = time.Now()
+	h.mu.Unlock()
+
+	return func() {
+		h.mu.Lock()
+		delete(h.operations, volumeID)
+		h.mu.Unlock()
+	}
+}
+
+// check returns error if re-discovery is in progress, DriveManager is unreachable, discovery wasn't completed
+// during HealthDiscoveryTimeout or some volume operation is in progress longer than VolumeOperationsTimeout
+func (h *healthState) check(now time.Time) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.discovering {
+		return errors.New("re-discovery of drives is in progress")
+	}
+	if h.driveMgrErr != nil {
+		return fmt.Errorf("DriveManager is unreachable: %v", h.driveMgrErr)
+	}
+	if now.Sub(h.lastDiscovery) > HealthDiscoveryTimeout {
+		return fmt.Errorf("discovery isn't completed since %s", h.lastDiscovery.Format(time.RFC3339))
+	}
+	for volumeID, started := range h.operations {
+		if now.Sub(started) > VolumeOperationsTimeout {
+			return fmt.Errorf("operation with volume %s is stuck since %s", volumeID, started.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// checkHealth verifies that VolumeManager is initialized and its dependencies are healthy
+// Returns error which describes the first failed dependency
+func (m *VolumeManager) checkHealth(ctx context.Context) error {
+	if !m.initialized {
+		return errors.New("initial discovery isn't completed")
+	}
+	if err := m.health.check(time.Now()); err != nil {
+		return err
+	}
+
+	ctx, cancelFn := context.WithTimeout(ctx, HealthAPITimeout)
+	defer cancelFn()
+	if err := m.k8sClient.List(ctx, &drivecrd.DriveList{}, &k8sCl.ListOptions{Limit: 1}); err != nil {
+		return fmt.Errorf("kubernetes API is unreachable: %v", err)
+	}
+	return nil
+}
+
+// updateHealthStatus checks health of VolumeManager and broadcasts its serving status to clients of Watch
+// Returns error of health check
+func (m *VolumeManager) updateHealthStatus(ctx context.Context) error {
+	err := m.checkHealth(ctx)
+	if err != nil {
+		m.healthBroadcaster.SetStatus(health.HealthCheckResponse_NOT_SERVING)
+	} else {
+		m.healthBroadcaster.SetStatus(health.HealthCheckResponse_SERVING)
+	}
+	return err
+}
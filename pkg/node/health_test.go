@@ -0,0 +1,74 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	health "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealthState_check(t *testing.T) {
+	var (
+		h   = &healthState{}
+		now = time.Now()
+	)
+
+	// discovery wasn't completed
+	assert.NotNil(t, h.check(now))
+
+	h.discovered(now)
+	assert.Nil(t, h.check(now))
+	assert.NotNil(t, h.check(now.Add(HealthDiscoveryTimeout+time.Second)))
+
+	h.setDriveMgrErr(errors.New("connection refused"))
+	assert.Contains(t, h.check(now).Error(), "DriveManager")
+	h.setDriveMgrErr(nil)
+
+	// operation is stuck
+	finish := h.startOperation("pvc-1")
+	assert.Nil(t, h.check(now))
+	assert.Contains(t, h.check(now.Add(VolumeOperationsTimeout+time.Second)).Error(), "pvc-1")
+	finish()
+	assert.Empty(t, h.operations)
+}
+
+func TestVolumeManager_checkHealth(t *testing.T) {
+	vm := prepareSuccessVolumeManager(t)
+	assert.NotNil(t, vm.checkHealth(testCtx))
+
+	vm.initialized = true
+	vm.health.discovered(time.Now())
+	assert.Nil(t, vm.checkHealth(testCtx))
+}
+
+func TestVolumeManager_updateHealthStatus(t *testing.T) {
+	vm := prepareSuccessVolumeManager(t)
+	vm.initialized = true
+	vm.health.discovered(time.Now())
+
+	assert.Nil(t, vm.updateHealthStatus(testCtx))
+	assert.Equal(t, health.HealthCheckResponse_SERVING, vm.healthBroadcaster.Status())
+
+	// re-discovery is in progress
+	vm.health.setDiscovering(true)
+	assert.NotNil(t, vm.updateHealthStatus(testCtx))
+	assert.Equal(t, health.HealthCheckResponse_NOT_SERVING, vm.healthBroadcaster.Status())
+}
@@ -0,0 +1,138 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/api/v1/volumemovecrd"
+	"github.com/dell/csi-baremetal/pkg/base/healthscore"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/eventing"
+)
+
+// evacuationPrefix is the prefix of names of VolumeMove CRs which move volumes off SUSPECT drives
+const evacuationPrefix = "evacuate-"
+
+// SetHealthPolicy sets policy which calculates health score of drives, scoring is disabled if policy is nil.
+// If evacuate is true volumes of the drive which became SUSPECT are moved to other nodes
+func (m *VolumeManager) SetHealthPolicy(policy *healthscore.Policy, evacuate bool) {
+	m.healthPolicy = policy
+	m.evacuateSuspect = evacuate
+}
+
+// applyHealthPolicy sets health score of the drive reported by drive manager, health of the drive is downgraded
+// if the score reaches the threshold of the policy. I/O errors and latency are taken from Drive CR, which is nil
+// for the new drive
+func (m *VolumeManager) applyHealthPolicy(drive *api.Drive, driveCR *drivecrd.Drive) {
+	if m.healthPolicy == nil {
+		return
+	}
+
+	signals := healthscore.Signals{MediaErrors: drive.MediaErrors}
+	if driveCR != nil {
+		signals.IOErrors = driveCR.Spec.IOErrors
+		signals.Latency = benchmarkLatency(driveCR)
+	}
+	drive.HealthScore = m.healthPolicy.Score(signals)
+	drive.Health = healthscore.Worse(drive.Health, m.healthPolicy.Health(drive.HealthScore))
+}
+
+// benchmarkLatency returns the latency of the slowest operation from the last benchmark of the drive,
+// 0 if the drive wasn't benchmarked successfully
+func benchmarkLatency(drive *drivecrd.Drive) time.Duration {
+	value, ok := drive.Annotations[apiV1.DriveBenchmarkResultAnnotationKey]
+	if !ok {
+		return 0
+	}
+	report := &BenchmarkReport{}
+	if err := json.Unmarshal([]byte(value), report); err != nil || report.Result == nil {
+		return 0
+	}
+	latency := math.Max(report.Result.Read.Latency, report.Result.Write.Latency)
+	return time.Duration(latency * float64(time.Microsecond))
+}
+
+// evacuateDrive creates VolumeMove CR without target node for each volume on the drive or on LVG which is
+// based on the drive, controller chooses target nodes. Moves are started only for volumes which aren't used,
+// the others fail and could be recreated after pods are stopped
+func (m *VolumeManager) evacuateDrive(ctx context.Context, drive *drivecrd.Drive) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":  "evacuateDrive",
+		"driveID": drive.Spec.UUID,
+	})
+
+	volumes, err := m.getVolumesOnDrive(drive.Spec.UUID)
+	if err != nil {
+		ll.Errorf("Unable to read volumes on drive: %v", err)
+		return
+	}
+	moved := 0
+	for _, volume := range volumes {
+		if volume.Spec.Ephemeral {
+			continue
+		}
+		move := &volumemovecrd.VolumeMove{
+			TypeMeta:   metaV1.TypeMeta{Kind: apiV1.VolumeMoveKind, APIVersion: apiV1.APIV1Version},
+			ObjectMeta: metaV1.ObjectMeta{Name: evacuationPrefix + volume.Spec.Id},
+			Spec:       volumemovecrd.VolumeMoveSpec{SourceVolumeID: volume.Spec.Id},
+		}
+		// move which already exists for the volume is kept
+		if err := m.k8sClient.CreateCR(ctx, move.Name, move); err != nil {
+			ll.Errorf("Unable to create volume move for volume %s: %v", volume.Spec.Id, err)
+			continue
+		}
+		ll.Infof("Volume %s is being moved off SUSPECT drive", volume.Spec.Id)
+		moved++
+	}
+	m.sendEventForDrive(drive, eventing.WarningType, eventing.DriveEvacuation,
+		"Drive health score is %d, %d volumes are being moved to other nodes.",
+		drive.Spec.HealthScore, moved)
+}
+
+// getVolumesOnDrive returns volumes which are located on the drive or on LVG which is based on the drive
+func (m *VolumeManager) getVolumesOnDrive(driveUUID string) ([]volumecrd.Volume, error) {
+	volumes, err := m.crHelper.GetVolumesByLocation(driveUUID)
+	if err != nil {
+		return nil, err
+	}
+	lvgs, err := m.crHelper.GetLVGCRs(m.nodeID)
+	if err != nil {
+		return nil, err
+	}
+	for _, lvg := range lvgs {
+		if !util.ContainsString(lvg.Spec.Locations, driveUUID) {
+			continue
+		}
+		lvgVolumes, err := m.crHelper.GetVolumesByLocation(lvg.Name)
+		if err != nil {
+			return nil, err
+		}
+		volumes = append(volumes, lvgVolumes...)
+	}
+	return volumes, nil
+}
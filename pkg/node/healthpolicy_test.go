@@ -0,0 +1,127 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/volumemovecrd"
+	"github.com/dell/csi-baremetal/pkg/base/healthscore"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fio"
+)
+
+func TestVolumeManager_applyHealthPolicy(t *testing.T) {
+	vm := prepareSuccessVolumeManager(t)
+
+	// scoring is disabled
+	drive := drive1
+	drive.MediaErrors = 100
+	vm.applyHealthPolicy(&drive, nil)
+	assert.Equal(t, int64(0), drive.HealthScore)
+	assert.Equal(t, apiV1.HealthGood, drive.Health)
+
+	policy, err := healthscore.NewPolicy(healthscore.DefaultPolicy)
+	assert.Nil(t, err)
+	vm.SetHealthPolicy(policy, false)
+
+	// new drive is scored only by media errors
+	drive = drive1
+	drive.MediaErrors = 5
+	vm.applyHealthPolicy(&drive, nil)
+	assert.Equal(t, int64(5), drive.HealthScore)
+	assert.Equal(t, apiV1.HealthGood, drive.Health)
+
+	// I/O errors and benchmark latency are taken from Drive CR
+	driveCR := vm.k8sClient.ConstructDriveCR(drive1.UUID, drive1)
+	driveCR.Spec.IOErrors = 2
+	report := `{"time":"2020-01-01T00:00:00Z","result":{"read":{"latencyUs":500},"write":{"latencyUs":1000000}}}`
+	driveCR.Annotations = map[string]string{apiV1.DriveBenchmarkResultAnnotationKey: report}
+	drive = drive1
+	drive.MediaErrors = 5
+	vm.applyHealthPolicy(&drive, driveCR)
+	assert.Equal(t, int64(17), drive.HealthScore)
+	assert.Equal(t, apiV1.HealthSuspect, drive.Health)
+
+	// health reported by drive manager isn't improved
+	drive = drive1
+	drive.Health = apiV1.HealthBad
+	vm.applyHealthPolicy(&drive, nil)
+	assert.Equal(t, apiV1.HealthBad, drive.Health)
+}
+
+func TestBenchmarkLatency(t *testing.T) {
+	drive := &drivecrd.Drive{}
+	assert.Equal(t, int64(0), int64(benchmarkLatency(drive)))
+
+	drive.Annotations = map[string]string{apiV1.DriveBenchmarkResultAnnotationKey: `{"error":"rejected"}`}
+	assert.Equal(t, int64(0), int64(benchmarkLatency(drive)))
+
+	drive.Annotations[apiV1.DriveBenchmarkResultAnnotationKey] = "not a json"
+	assert.Equal(t, int64(0), int64(benchmarkLatency(drive)))
+
+	report := &BenchmarkReport{Result: &fio.BenchmarkResult{
+		Read:  fio.OperationResult{Latency: 250},
+		Write: fio.OperationResult{Latency: 100},
+	}}
+	value, err := json.Marshal(report)
+	assert.Nil(t, err)
+	drive.Annotations[apiV1.DriveBenchmarkResultAnnotationKey] = string(value)
+	assert.Equal(t, int64(250000), int64(benchmarkLatency(drive)))
+}
+
+func TestVolumeManager_evacuateSuspectDrive(t *testing.T) {
+	vm := prepareSuccessVolumeManagerWithDrives([]*api.Drive{&drive1}, t)
+	policy, err := healthscore.NewPolicy("mediaerror=1,suspect=10,bad=100")
+	assert.Nil(t, err)
+	vm.SetHealthPolicy(policy, true)
+
+	vol := volCR
+	vol.Spec.Location = drive1.UUID
+	vol.Spec.CSIStatus = apiV1.Created
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, vol.Name, &vol))
+
+	// drive manager reports media errors
+	updated := drive1
+	updated.MediaErrors = 10
+	updates, err := vm.updateDrivesCRs(testCtx, []*api.Drive{&updated})
+	assert.Nil(t, err)
+	assert.Len(t, updates.Updated, 1)
+	vm.handleDriveUpdates(testCtx, updates)
+
+	drive := &drivecrd.Drive{}
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive1.UUID, drive))
+	assert.Equal(t, apiV1.HealthSuspect, drive.Spec.Health)
+	assert.Equal(t, int64(10), drive.Spec.HealthScore)
+
+	moves := &volumemovecrd.VolumeMoveList{}
+	assert.Nil(t, vm.k8sClient.ReadList(testCtx, moves))
+	assert.Len(t, moves.Items, 1)
+	assert.Equal(t, evacuationPrefix+vol.Spec.Id, moves.Items[0].Name)
+	assert.Equal(t, vol.Spec.Id, moves.Items[0].Spec.SourceVolumeID)
+	assert.Empty(t, moves.Items[0].Spec.TargetNodeID)
+
+	// drive remains SUSPECT, move isn't created again
+	updates, err = vm.updateDrivesCRs(testCtx, []*api.Drive{&updated})
+	assert.Nil(t, err)
+	assert.Empty(t, updates.Updated)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/inventorycrd"
+)
+
+// updateInventory aggregates hardware information of the node drives into Inventory CR which is named as node ID,
+// CR is created if it doesn't exist and is updated only when drives were changed
+// Returns error if Inventory CR wasn't read, created or updated
+func (m *VolumeManager) updateInventory(ctx context.Context) error {
+	driveCRs, err := m.crHelper.GetDriveCRs(m.nodeID)
+	if err != nil {
+		return err
+	}
+	spec := buildInventorySpec(m.nodeID, driveCRs)
+
+	inventory := &inventorycrd.Inventory{}
+	err = m.k8sClient.ReadCR(ctx, m.nodeID, inventory)
+	switch {
+	case k8sError.IsNotFound(err):
+		inventory = &inventorycrd.Inventory{
+			TypeMeta: metav1.TypeMeta{Kind: apiV1.InventoryKind, APIVersion: apiV1.APIV1Version},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      m.nodeID,
+				Namespace: m.k8sClient.Namespace,
+			},
+			Spec: spec,
+		}
+		return m.k8sClient.CreateCR(ctx, m.nodeID, inventory)
+	case err != nil:
+		return err
+	case reflect.DeepEqual(inventory.Spec, spec):
+		return nil
+	}
+	inventory.Spec = spec
+	return m.k8sClient.UpdateCR(ctx, inventory)
+}
+
+// buildInventorySpec converts Drive CRs to InventorySpec, drives are ordered by serial number
+func buildInventorySpec(nodeID string, driveCRs []drivecrd.Drive) inventorycrd.InventorySpec {
+	spec := inventorycrd.InventorySpec{NodeID: nodeID}
+	for _, d := range driveCRs {
+		spec.Drives = append(spec.Drives, inventorycrd.InventoryDrive{
+			UUID:         d.Spec.UUID,
+			SerialNumber: d.Spec.SerialNumber,
+			VID:          d.Spec.VID,
+			PID:          d.Spec.PID,
+			Type:         d.Spec.Type,
+			Size:         d.Spec.Size,
+			Firmware:     d.Spec.Firmware,
+			WWN:          d.Spec.WWN,
+			Enclosure:    d.Spec.Enclosure,
+			Slot:         d.Spec.Slot,
+			Bay:          d.Spec.Bay,
+			LinkSpeed:    d.Spec.LinkSpeed,
+			Interface:    d.Spec.Interface,
+			Controller:   d.Spec.Controller,
+			Path:         d.Spec.Path,
+			Health:       d.Spec.Health,
+			Status:       d.Spec.Status,
+			IsSystem:     d.Spec.IsSystem,
+		})
+		spec.TotalSize += d.Spec.Size
+	}
+	spec.TotalDrives = len(spec.Drives)
+	sort.Slice(spec.Drives, func(i, j int) bool {
+		return spec.Drives[i].SerialNumber < spec.Drives[j].SerialNumber
+	})
+	return spec
+}
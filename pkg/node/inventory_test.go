@@ -0,0 +1,66 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/inventorycrd"
+)
+
+func TestVolumeManager_updateInventory(t *testing.T) {
+	var (
+		d1        = drive1
+		d2        = drive2
+		vm        *VolumeManager
+		inventory = &inventorycrd.Inventory{}
+	)
+	d1.Firmware, d1.WWN, d1.LinkSpeed, d1.Interface = "SN04", "0x5000c500a1b2c3d4", "6.0 Gb/s", "SATA"
+	vm = prepareSuccessVolumeManagerWithDrives([]*api.Drive{&d2, &d1}, t)
+
+	// inventory is created
+	assert.Nil(t, vm.updateInventory(testCtx))
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, nodeID, inventory))
+	assert.Equal(t, nodeID, inventory.Spec.NodeID)
+	assert.Equal(t, 2, inventory.Spec.TotalDrives)
+	assert.Equal(t, d1.Size+d2.Size, inventory.Spec.TotalSize)
+	assert.Equal(t, d1.SerialNumber, inventory.Spec.Drives[0].SerialNumber)
+	assert.Equal(t, "SN04", inventory.Spec.Drives[0].Firmware)
+	assert.Equal(t, "0x5000c500a1b2c3d4", inventory.Spec.Drives[0].WWN)
+	assert.Equal(t, "6.0 Gb/s", inventory.Spec.Drives[0].LinkSpeed)
+	assert.Equal(t, "SATA", inventory.Spec.Drives[0].Interface)
+	assert.True(t, inventory.Spec.Drives[1].IsSystem)
+
+	// nothing changed, inventory isn't updated
+	version := inventory.ResourceVersion
+	assert.Nil(t, vm.updateInventory(testCtx))
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, nodeID, inventory))
+	assert.Equal(t, version, inventory.ResourceVersion)
+
+	// firmware was upgraded
+	drive := &drivecrd.Drive{}
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, d1.UUID, drive))
+	drive.Spec.Firmware = "SN05"
+	assert.Nil(t, vm.k8sClient.UpdateCR(testCtx, drive))
+	assert.Nil(t, vm.updateInventory(testCtx))
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, nodeID, inventory))
+	assert.Equal(t, "SN05", inventory.Spec.Drives[0].Firmware)
+}
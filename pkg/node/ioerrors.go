@@ -0,0 +1,111 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"time"
+
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/dmesg"
+	"github.com/dell/csi-baremetal/pkg/eventing"
+)
+
+// DefaultIOErrorsFlushInterval is the default interval between two updates of I/O error counters of Drive CRs
+const DefaultIOErrorsFlushInterval = time.Minute
+
+// RunIOErrorWatching spawns goroutines which follow kernel ring buffer and periodically add counted I/O errors
+// of devices to IOErrors of Drive CRs
+func (m *VolumeManager) RunIOErrorWatching(interval time.Duration) {
+	devices := make(chan string, 100)
+	go func() {
+		if err := dmesg.FollowDeviceErrors(dmesg.KmsgFile, devices); err != nil {
+			m.log.WithField("method", "RunIOErrorWatching").Errorf("Unable to follow kernel log: %v", err)
+		}
+	}()
+	go func() {
+		counts := make(map[string]int64)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case device := <-devices:
+				counts[device]++
+			case <-ticker.C:
+				if len(counts) == 0 {
+					continue
+				}
+				ctx, cancelFn := context.WithTimeout(context.Background(), interval)
+				counts = m.AddIOErrors(ctx, counts)
+				cancelFn()
+			}
+		}
+	}()
+}
+
+// AddIOErrors adds amounts of I/O errors of devices to IOErrors of Drive CRs of the node and sends
+// DriveIOErrors event for each updated drive, errors of partitions and NVMe controllers are added to their drives
+// Receives amounts of I/O errors per kernel name of the device
+// Returns amounts which weren't added because Drive CR wasn't updated, they should be added next time
+func (m *VolumeManager) AddIOErrors(ctx context.Context, counts map[string]int64) map[string]int64 {
+	ll := m.log.WithField("method", "AddIOErrors")
+
+	drives, err := m.crHelper.GetDriveCRs(m.nodeID)
+	if err != nil {
+		ll.Errorf("Unable to read drives: %v", err)
+		return counts
+	}
+
+	notAdded := make(map[string]int64)
+	for i := range drives {
+		drive := &drives[i]
+		var (
+			added   int64
+			devices []string
+		)
+		for device, count := range counts {
+			if dmesg.IsDeviceOfDrive(device, drive.Spec.Path) {
+				added += count
+				devices = append(devices, device)
+			}
+		}
+		if added == 0 {
+			continue
+		}
+
+		drive.Spec.IOErrors += added
+		if err := m.k8sClient.UpdateCR(ctx, drive); err != nil {
+			ll.Errorf("Unable to update I/O errors of drive %s: %v", drive.Name, err)
+			for _, device := range devices {
+				notAdded[device] = counts[device]
+			}
+		} else {
+			ll.Warnf("%d I/O errors of drive %s were found in kernel log, total %d",
+				added, drive.Spec.Path, drive.Spec.IOErrors)
+			m.sendEventForDrive(drive, eventing.WarningType, eventing.DriveIOErrors,
+				"%d I/O errors were found in kernel log, total %d.", added, drive.Spec.IOErrors)
+		}
+		for _, device := range devices {
+			delete(counts, device)
+		}
+	}
+
+	// errors of devices which aren't drives of the node, e.g. virtual devices, are dropped
+	for device, count := range counts {
+		ll.Debugf("Drive of device %s with %d I/O errors wasn't found", device, count)
+	}
+	return notAdded
+}
@@ -0,0 +1,68 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/pkg/eventing"
+	"github.com/dell/csi-baremetal/pkg/mocks"
+)
+
+func TestVolumeManager_AddIOErrors(t *testing.T) {
+	vm := prepareSuccessVolumeManagerWithDrives([]*api.Drive{&drive1, &drive2}, t)
+
+	notAdded := vm.AddIOErrors(testCtx, map[string]int64{"sda": 2, "sda1": 1, "sdc": 5})
+	assert.Empty(t, notAdded)
+
+	drive := &drivecrd.Drive{}
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive1.UUID, drive))
+	assert.Equal(t, int64(3), drive.Spec.IOErrors)
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive2.UUID, drive))
+	assert.Equal(t, int64(0), drive.Spec.IOErrors)
+
+	recorder := vm.recorder.(*mocks.NoOpRecorder)
+	assert.Len(t, recorder.Calls, 1)
+	assert.Equal(t, eventing.DriveIOErrors, recorder.Calls[0].Reason)
+
+	// errors are accumulated
+	assert.Empty(t, vm.AddIOErrors(testCtx, map[string]int64{"sda": 1, "sdb": 1}))
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive1.UUID, drive))
+	assert.Equal(t, int64(4), drive.Spec.IOErrors)
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive2.UUID, drive))
+	assert.Equal(t, int64(1), drive.Spec.IOErrors)
+}
+
+func TestVolumeManager_updateDrivesCRsKeepsIOErrors(t *testing.T) {
+	vm := prepareSuccessVolumeManagerWithDrives([]*api.Drive{&drive1}, t)
+	assert.Empty(t, vm.AddIOErrors(testCtx, map[string]int64{"sda": 2}))
+
+	// drive manager reports new firmware
+	updated := drive1
+	updated.Firmware = "2.0"
+	_, err := vm.updateDrivesCRs(testCtx, []*api.Drive{&updated})
+	assert.Nil(t, err)
+
+	drive := &drivecrd.Drive{}
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive1.UUID, drive))
+	assert.Equal(t, "2.0", drive.Spec.Firmware)
+	assert.Equal(t, int64(2), drive.Spec.IOErrors)
+}
@@ -0,0 +1,251 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	coreV1 "k8s.io/api/core/v1"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/eventing"
+)
+
+// DefaultMountCheckInterval is the default interval between two verifications of mounts of published volumes
+const DefaultMountCheckInterval = time.Minute
+
+// RunMountChecking spawns goroutine which periodically verifies that published volumes are still mounted
+// to their target paths, lost mounts are restored if remount is true
+func (m *VolumeManager) RunMountChecking(interval time.Duration, remount bool) {
+	go func() {
+		for {
+			time.Sleep(interval)
+			ctx, cancelFn := context.WithTimeout(context.Background(), interval)
+			if err := m.CheckMounts(ctx, remount); err != nil {
+				m.log.WithField("method", "RunMountChecking").Errorf("Mount verification finished with error: %v", err)
+			}
+			cancelFn()
+		}
+	}()
+}
+
+// CheckMounts verifies that target paths of published volumes are mount points, they are accessible and
+// their file systems weren't remounted read-only by kernel. Result is reflected in Mounted, Writable and Degraded
+// conditions of Volume CR, VolumeMountLost and VolumeReadOnly events are sent when problem is detected.
+// Lost mount of the volume is restored through its staging path if remount is true
+// Returns error if at least one volume wasn't verified
+func (m *VolumeManager) CheckMounts(ctx context.Context, remount bool) error {
+	ll := m.log.WithField("method", "CheckMounts")
+
+	volumes, err := m.crHelper.GetVolumeCRs(m.nodeID)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, v := range volumes {
+		if v.Spec.CSIStatus != apiV1.Published || v.Spec.TargetPath == "" {
+			continue
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := m.checkMount(ctx, v.Name, remount); err != nil {
+			ll.Errorf("Unable to verify mount of volume %s: %v", v.Spec.Id, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("mounts of %d volumes weren't verified", failed)
+	}
+	return nil
+}
+
+// checkMount verifies mount of the volume with provided name and updates its conditions,
+// volume is locked to avoid races with Reconcile
+func (m *VolumeManager) checkMount(ctx context.Context, name string, remount bool) error {
+	m.volMu.LockKey(name)
+	defer func() {
+		_ = m.volMu.UnlockKey(name)
+	}()
+
+	// volume could be unpublished since it was listed
+	volume := &volumecrd.Volume{}
+	if err := m.k8sClient.ReadCR(ctx, name, volume); err != nil {
+		return err
+	}
+	if volume.Spec.CSIStatus != apiV1.Published || volume.Spec.TargetPath == "" {
+		return nil
+	}
+
+	ll := m.log.WithFields(logrus.Fields{
+		"method":   "checkMount",
+		"volumeID": volume.Spec.Id,
+	})
+
+	var (
+		st         = &volume.Status
+		wasLost    = isMountLost(volume)
+		status     = coreV1.ConditionTrue
+		reason     = apiV1.ReasonMountVerified
+		message    string
+		mountError = m.verifyMount(volume.Spec.TargetPath)
+	)
+	if mountError != nil {
+		ll.Warnf("Mount is lost: %v", mountError)
+		if !wasLost {
+			m.sendEventForVolume(volume, eventing.WarningType, eventing.VolumeMountLost,
+				"Volume isn't mounted to %s: %v", volume.Spec.TargetPath, mountError)
+		}
+		status, reason, message = coreV1.ConditionFalse, apiV1.ReasonMountLost, mountError.Error()
+		if remount {
+			if err := m.remount(volume); err != nil {
+				ll.Errorf("Unable to remount volume: %v", err)
+				message = fmt.Sprintf("%s, remount failed: %v", message, err)
+			} else {
+				ll.Infof("Volume was remounted to %s", volume.Spec.TargetPath)
+				m.sendEventForVolume(volume, eventing.InfoType, eventing.VolumeRemounted,
+					"Volume was remounted to %s", volume.Spec.TargetPath)
+				status, reason, message = coreV1.ConditionTrue, apiV1.ReasonRemounted, ""
+			}
+		}
+	} else if wasLost {
+		// mount was restored by kubelet or admin
+		reason = apiV1.ReasonRemounted
+	}
+
+	modified := st.SetCondition(apiV1.ConditionMounted, status, reason, message)
+	if status == coreV1.ConditionTrue {
+		modified = m.checkWritable(volume) || modified
+	}
+	modified = setVolumeConditions(volume) || modified
+	if !modified {
+		return nil
+	}
+	return m.k8sClient.UpdateCRStatus(ctx, volume)
+}
+
+// checkWritable detects file system of the volume which was remounted read-only by kernel because of I/O errors,
+// the last kernel message of the file system is added to Writable condition and VolumeReadOnly event
+// Returns true if status of Volume CR was modified
+func (m *VolumeManager) checkWritable(volume *volumecrd.Volume) bool {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":   "checkWritable",
+		"volumeID": volume.Spec.Id,
+	})
+
+	// volume which was published read-only is expected to be read-only
+	if volume.Spec.Mode != apiV1.ModeFS || volume.Spec.ReadOnly {
+		return false
+	}
+	readOnly, err := m.fsOps.IsReadOnlyMount(volume.Spec.TargetPath)
+	if err != nil {
+		ll.Errorf("Unable to check mount options: %v", err)
+		return false
+	}
+	if !readOnly {
+		return volume.Status.SetCondition(apiV1.ConditionWritable, coreV1.ConditionTrue, apiV1.ReasonReadWrite, "")
+	}
+
+	message := "file system is read-only"
+	device, err := m.getProvisionerForVolume(&volume.Spec).GetVolumePath(volume.Spec)
+	if err == nil {
+		var kernelMessages []string
+		if kernelMessages, err = m.dmesg.GetFSMessages(device); err == nil && len(kernelMessages) > 0 {
+			message = fmt.Sprintf("%s, last kernel message: %s", message, kernelMessages[len(kernelMessages)-1])
+		}
+	}
+	if err != nil {
+		ll.Warnf("Unable to read kernel messages of file system: %v", err)
+	}
+
+	if !isRemountedReadOnly(volume) {
+		ll.Warnf("Volume mounted to %s was remounted read-only: %s", volume.Spec.TargetPath, message)
+		m.sendEventForVolume(volume, eventing.WarningType, eventing.VolumeReadOnly,
+			"Volume mounted to %s was remounted read-only, %s", volume.Spec.TargetPath, message)
+	}
+	return volume.Status.SetCondition(apiV1.ConditionWritable, coreV1.ConditionFalse,
+		apiV1.ReasonRemountedReadOnly, message)
+}
+
+// verifyMount checks that path is a mount point and it is accessible,
+// the last one detects file systems which device was reset or removed
+// Returns error which describes the problem
+func (m *VolumeManager) verifyMount(path string) error {
+	mounted, err := m.fsOps.IsMounted(path)
+	if err != nil {
+		return err
+	}
+	if !mounted {
+		return fmt.Errorf("%s isn't a mount point", path)
+	}
+	if _, err = os.Stat(path); err != nil {
+		return fmt.Errorf("%s isn't accessible: %v", path, err)
+	}
+	return nil
+}
+
+// remount restores mounts of the volume in the same way as NodeStageVolume and NodePublishVolume do,
+// staging path is mounted again if it is lost as well, inaccessible mounts are unmounted first
+func (m *VolumeManager) remount(volume *volumecrd.Volume) error {
+	device, err := m.getProvisionerForVolume(&volume.Spec).GetVolumePath(volume.Spec)
+	if err != nil {
+		return err
+	}
+	// inline volume doesn't have staging path, its device is mounted to target path directly
+	if volume.Spec.Ephemeral || volume.Spec.StagingTargetPath == "" {
+		return m.restoreMount(device, volume.Spec.TargetPath, false, volume.Spec.ReadOnly)
+	}
+	if m.verifyMount(volume.Spec.StagingTargetPath) != nil {
+		if err = m.restoreMount(device, volume.Spec.StagingTargetPath, false, volume.Spec.ReadOnly); err != nil {
+			return fmt.Errorf("unable to remount staging path: %v", err)
+		}
+	}
+	return m.restoreMount(volume.Spec.StagingTargetPath, volume.Spec.TargetPath, true, volume.Spec.ReadOnly)
+}
+
+// restoreMount mounts src to dst, stale mount of dst is unmounted first
+func (m *VolumeManager) restoreMount(src, dst string, bind, readOnly bool) error {
+	mounted, err := m.fsOps.IsMounted(dst)
+	if err != nil {
+		return err
+	}
+	if mounted {
+		if err = m.fsOps.Unmount(dst); err != nil {
+			return err
+		}
+	}
+	return m.fsOps.PrepareAndPerformMount(src, dst, bind, readOnly)
+}
+
+// isMountLost checks whether mount of the published volume was reported as lost
+func isMountLost(volume *volumecrd.Volume) bool {
+	c := volume.Status.GetCondition(apiV1.ConditionMounted)
+	return volume.Spec.CSIStatus == apiV1.Published && c != nil && c.Status == coreV1.ConditionFalse
+}
+
+// isRemountedReadOnly checks whether file system of the published volume was reported as remounted read-only
+func isRemountedReadOnly(volume *volumecrd.Volume) bool {
+	c := volume.Status.GetCondition(apiV1.ConditionWritable)
+	return volume.Spec.CSIStatus == apiV1.Published && c != nil && c.Status == coreV1.ConditionFalse
+}
@@ -0,0 +1,159 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	vcrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/eventing"
+	"github.com/dell/csi-baremetal/pkg/mocks"
+	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
+	mockProv "github.com/dell/csi-baremetal/pkg/mocks/provisioners"
+	p "github.com/dell/csi-baremetal/pkg/node/provisioners"
+)
+
+func prepareMountCheck(t *testing.T, dir string) (*VolumeManager, *mockProv.MockFsOpts, *vcrd.Volume) {
+	vm := prepareSuccessVolumeManager(t)
+	fsOps := &mockProv.MockFsOpts{}
+	pMock := &mockProv.MockProvisioner{}
+	vm.fsOps = fsOps
+	vm.SetProvisioners(map[p.VolumeType]p.Provisioner{p.DriveBasedVolumeType: pMock, p.LVMBasedVolumeType: pMock})
+
+	vol := testVolumeCR1.DeepCopy()
+	vol.Spec.CSIStatus = apiV1.Published
+	vol.Spec.Mode = apiV1.ModeFS
+	vol.Spec.StagingTargetPath = filepath.Join(dir, "staging")
+	vol.Spec.TargetPath = filepath.Join(dir, "target")
+	assert.Nil(t, os.Mkdir(vol.Spec.StagingTargetPath, 0700))
+	assert.Nil(t, os.Mkdir(vol.Spec.TargetPath, 0700))
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, vol.Name, vol))
+	pMock.On("GetVolumePath", vol.Spec).Return("/dev/sda1", nil)
+	return vm, fsOps, vol
+}
+
+func TestVolumeManager_CheckMounts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mounthealth")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	t.Run("Mounted", func(t *testing.T) {
+		vm, fsOps, vol := prepareMountCheck(t, dir)
+		defer os.RemoveAll(vol.Spec.StagingTargetPath)
+		defer os.RemoveAll(vol.Spec.TargetPath)
+		fsOps.On("IsMounted", vol.Spec.TargetPath).Return(true, nil)
+		fsOps.On("IsReadOnlyMount", vol.Spec.TargetPath).Return(false, nil)
+
+		assert.Nil(t, vm.CheckMounts(testCtx, true))
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, vol.Name, vol))
+		assert.True(t, vol.Status.IsConditionTrue(apiV1.ConditionMounted))
+		assert.True(t, vol.Status.IsConditionTrue(apiV1.ConditionWritable))
+		assert.False(t, vol.Status.IsConditionTrue(apiV1.ConditionDegraded))
+		fsOps.AssertNotCalled(t, "PrepareAndPerformMount")
+	})
+
+	t.Run("Lost", func(t *testing.T) {
+		vm, fsOps, vol := prepareMountCheck(t, dir)
+		defer os.RemoveAll(vol.Spec.StagingTargetPath)
+		defer os.RemoveAll(vol.Spec.TargetPath)
+		fsOps.On("IsMounted", vol.Spec.TargetPath).Return(false, nil)
+
+		assert.Nil(t, vm.CheckMounts(testCtx, false))
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, vol.Name, vol))
+		assert.Equal(t, apiV1.ReasonMountLost, vol.Status.GetCondition(apiV1.ConditionMounted).Reason)
+		assert.False(t, vol.Status.IsConditionTrue(apiV1.ConditionMounted))
+		assert.True(t, vol.Status.IsConditionTrue(apiV1.ConditionDegraded))
+
+		// event is sent only once
+		assert.Nil(t, vm.CheckMounts(testCtx, false))
+		recorder := vm.recorder.(*mocks.NoOpRecorder)
+		assert.Len(t, recorder.Calls, 1)
+		assert.Equal(t, eventing.VolumeMountLost, recorder.Calls[0].Reason)
+	})
+
+	t.Run("Remounted", func(t *testing.T) {
+		vm, fsOps, vol := prepareMountCheck(t, dir)
+		defer os.RemoveAll(vol.Spec.StagingTargetPath)
+		// kubelet directory was wiped
+		assert.Nil(t, os.RemoveAll(vol.Spec.TargetPath))
+		fsOps.On("IsMounted", vol.Spec.TargetPath).Return(false, nil)
+		fsOps.On("IsMounted", vol.Spec.StagingTargetPath).Return(true, nil)
+		fsOps.On("PrepareAndPerformMount", vol.Spec.StagingTargetPath, vol.Spec.TargetPath, true, false).
+			Return(nil)
+		fsOps.On("IsReadOnlyMount", vol.Spec.TargetPath).Return(false, nil)
+
+		assert.Nil(t, vm.CheckMounts(testCtx, true))
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, vol.Name, vol))
+		assert.Equal(t, apiV1.ReasonRemounted, vol.Status.GetCondition(apiV1.ConditionMounted).Reason)
+		assert.True(t, vol.Status.IsConditionTrue(apiV1.ConditionMounted))
+		assert.False(t, vol.Status.IsConditionTrue(apiV1.ConditionDegraded))
+		recorder := vm.recorder.(*mocks.NoOpRecorder)
+		assert.Len(t, recorder.Calls, 2)
+		assert.Equal(t, eventing.VolumeRemounted, recorder.Calls[1].Reason)
+	})
+
+	t.Run("Read-only", func(t *testing.T) {
+		vm, fsOps, vol := prepareMountCheck(t, dir)
+		defer os.RemoveAll(vol.Spec.StagingTargetPath)
+		defer os.RemoveAll(vol.Spec.TargetPath)
+		dmesgMock := &mocklu.MockWrapDmesg{}
+		vm.dmesg = dmesgMock
+		fsOps.On("IsMounted", vol.Spec.TargetPath).Return(true, nil)
+		fsOps.On("IsReadOnlyMount", vol.Spec.TargetPath).Return(true, nil)
+		dmesgMock.On("GetFSMessages", "/dev/sda1").
+			Return([]string{"EXT4-fs (sda1): Remounting filesystem read-only"}, nil)
+
+		assert.Nil(t, vm.CheckMounts(testCtx, true))
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, vol.Name, vol))
+		assert.True(t, vol.Status.IsConditionTrue(apiV1.ConditionMounted))
+		assert.False(t, vol.Status.IsConditionTrue(apiV1.ConditionWritable))
+		assert.Contains(t, vol.Status.GetCondition(apiV1.ConditionWritable).Message, "Remounting filesystem read-only")
+		assert.True(t, vol.Status.IsConditionTrue(apiV1.ConditionDegraded))
+
+		// event is sent only once
+		assert.Nil(t, vm.CheckMounts(testCtx, true))
+		recorder := vm.recorder.(*mocks.NoOpRecorder)
+		assert.Len(t, recorder.Calls, 1)
+		assert.Equal(t, eventing.VolumeReadOnly, recorder.Calls[0].Reason)
+		fsOps.AssertNotCalled(t, "PrepareAndPerformMount")
+
+		// volume which was published read-only isn't checked
+		vol.Spec.ReadOnly = true
+		assert.False(t, vm.checkWritable(vol))
+	})
+
+	t.Run("Staging is lost", func(t *testing.T) {
+		vm, fsOps, vol := prepareMountCheck(t, dir)
+		defer os.RemoveAll(vol.Spec.StagingTargetPath)
+		defer os.RemoveAll(vol.Spec.TargetPath)
+		fsOps.On("IsMounted", vol.Spec.TargetPath).Return(false, nil)
+		fsOps.On("IsMounted", vol.Spec.StagingTargetPath).Return(false, nil)
+		fsOps.On("PrepareAndPerformMount", "/dev/sda1", vol.Spec.StagingTargetPath, false, false).
+			Return(testErr)
+
+		assert.Nil(t, vm.CheckMounts(testCtx, true))
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, vol.Name, vol))
+		assert.False(t, vol.Status.IsConditionTrue(apiV1.ConditionMounted))
+		assert.Contains(t, vol.Status.GetCondition(apiV1.ConditionMounted).Message, "remount failed")
+	})
+}
@@ -19,11 +19,9 @@ package node
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strconv"
 	"strings"
-	"sync"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
 	"github.com/golang/protobuf/ptypes/wrappers"
@@ -31,26 +29,32 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
+	storageV1 "k8s.io/api/storage/v1"
 	k8sError "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/utils/keymutex"
+	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
 	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
 	"github.com/dell/csi-baremetal/pkg/base/command"
 	"github.com/dell/csi-baremetal/pkg/base/featureconfig"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
+	"github.com/dell/csi-baremetal/pkg/base/rpc"
 	"github.com/dell/csi-baremetal/pkg/base/util"
 	"github.com/dell/csi-baremetal/pkg/common"
 	"github.com/dell/csi-baremetal/pkg/controller"
 	csibmnodeconst "github.com/dell/csi-baremetal/pkg/crcontrollers/csibmnode/common"
+	"github.com/dell/csi-baremetal/pkg/eventing"
 )
 
 // CSINodeService is the implementation of NodeServer interface from GO CSI specification.
 // Contains VolumeManager in a such way that it is a single instance in the driver
 type CSINodeService struct {
-	svc   common.VolumeOperations
-	reqMu sync.Mutex
+	// capacity of drives is locked by svc, so inline volumes on different drives are processed in parallel
+	svc common.VolumeOperations
 
 	log           *logrus.Entry
 	livenessCheck LivenessHelper
@@ -60,6 +64,15 @@ type CSINodeService struct {
 
 	// used for locking requests on each volume
 	volMu keymutex.KeyMutex
+	// inlineDefaultSize is the size of inline volume which context doesn't contain size
+	inlineDefaultSize int64
+	// maxVolumesPerNode overrides amount of volumes which is reported in NodeGetInfo and limits amount of volumes
+	// which k8s schedules on the node, 0 means that amount is calculated based on drives and LVGs
+	maxVolumesPerNode int64
+	featureChecker    featureconfig.FeatureChecker
+	densityPolicy     *capacityplanner.DensityPolicy
+	// manifest is reported by GetPluginInfo, it is filled before the service starts serving
+	manifest map[string]string
 }
 
 const (
@@ -69,8 +82,15 @@ const (
 	UnknownPodName = "UNKNOWN"
 	// EphemeralKey in volume context means that in node publish request we need to create ephemeral volume
 	EphemeralKey = "csi.storage.k8s.io/ephemeral"
+	// StorageClassNameKey in volume context of inline volume points on StorageClass which storageType is used
+	StorageClassNameKey = "storageClass"
+	// DefaultInlineVolumeSize is the size of inline volume which context doesn't contain size
+	DefaultInlineVolumeSize = int64(util.GBYTE)
 )
 
+// supportedFsTypes contains FS types which could be requested for inline volume
+var supportedFsTypes = []string{string(fs.XFS), string(fs.EXT4), string(fs.EXT3)}
+
 // NewCSINodeService is the constructor for CSINodeService struct
 // Receives an instance of DriveServiceClient to interact with DriveManager, ID of a node where it works, logrus logger
 // and base.KubeClient
@@ -80,27 +100,52 @@ func NewCSINodeService(client api.DriveServiceClient,
 	logger *logrus.Logger,
 	k8sclient *k8s.KubeClient,
 	recorder eventRecorder,
-	featureConf featureconfig.FeatureChecker) *CSINodeService {
+	featureConf featureconfig.FeatureChecker,
+	anyPolicy *capacityplanner.AnyPolicy,
+	densityPolicy *capacityplanner.DensityPolicy) *CSINodeService {
 	e := &command.Executor{}
 	e.SetLogger(logger)
+	manifest := controller.NewManifest(featureConf)
 	s := &CSINodeService{
 		VolumeManager:  *NewVolumeManager(client, e, logger, k8sclient, recorder, nodeID),
-		svc:            common.NewVolumeOperationsImpl(k8sclient, logger, featureConf),
-		IdentityServer: controller.NewIdentityServer(base.PluginName, base.PluginVersion),
+		svc:            common.NewVolumeOperationsImpl(k8sclient, logger, featureConf, anyPolicy, densityPolicy),
+		IdentityServer: controller.NewIdentityServer(base.PluginName, base.PluginVersion, manifest),
 		volMu:          keymutex.NewHashed(0),
 		livenessCheck:  NewLivenessCheckHelper(logger, nil, nil),
+
+		inlineDefaultSize: DefaultInlineVolumeSize,
+		featureChecker:    featureConf,
+		densityPolicy:     densityPolicy,
+		manifest:          manifest,
 	}
 	s.log = logger.WithField("component", "CSINodeService")
 	return s
 }
 
+// SetMaxVolumesPerNode sets maximum amount of volumes which is reported in NodeGetInfo instead of calculated one,
+// 0 means that amount is calculated based on drives and LVGs
+func (s *CSINodeService) SetMaxVolumesPerNode(max int64) {
+	s.maxVolumesPerNode = max
+}
+
+// SetInlineDefaultSize sets the size of inline volume which context doesn't contain size
+func (s *CSINodeService) SetInlineDefaultSize(size int64) {
+	s.inlineDefaultSize = size
+}
+
+// SetDriveMgrBackend sets type of hardware manager which is reported in manifest of GetPluginInfo,
+// it must be called before the service starts serving
+func (s *CSINodeService) SetDriveMgrBackend(backend string) {
+	s.manifest[controller.ManifestDriveManagerKey] = backend
+}
+
 // Probe is the implementation of CSI Spec Probe for IdentityServer.
-// This method checks if CSI driver is ready to serve requests
-// overrides same method from identityServer struct in controller package
+// This method checks if CSI driver is ready to serve requests: initial discovery of drives is completed
+// and liveness check passes, overrides same method from identityServer struct in controller package
 func (s *CSINodeService) Probe(context.Context, *csi.ProbeRequest) (*csi.ProbeResponse, error) {
 	return &csi.ProbeResponse{
 		Ready: &wrappers.BoolValue{
-			Value: s.livenessCheck.Check(),
+			Value: s.initialized && s.livenessCheck.Check(),
 		},
 	}, nil
 }
@@ -111,7 +156,7 @@ func (s *CSINodeService) checkRequestContext(ctx context.Context, logger *logrus
 	case <-ctx.Done():
 		msg := fmt.Sprintf("context is done after volume lock. err: %s", ctx.Err())
 		logger.Warn(msg)
-		return errors.New(msg)
+		return rpc.ToStatus(ctx.Err(), msg)
 	default:
 		logger.Info("Processing request")
 		return nil
@@ -164,7 +209,7 @@ func (s *CSINodeService) NodeStageVolume(ctx context.Context, req *csi.NodeStage
 	if currStatus != apiV1.Created && currStatus != apiV1.VolumeReady && currStatus != apiV1.Published {
 		ll.Errorf("Current volume CR status - %s, expected to be in - [%s, %s, %s]",
 			currStatus, apiV1.Created, apiV1.VolumeReady, apiV1.Published)
-		return nil, fmt.Errorf("corresponding volume CR is in unexpected state - %s",
+		return nil, status.Errorf(codes.FailedPrecondition, "corresponding volume CR is in unexpected state - %s",
 			currStatus)
 	}
 
@@ -182,17 +227,24 @@ func (s *CSINodeService) NodeStageVolume(ctx context.Context, req *csi.NodeStage
 		errToReturn error
 		newStatus   = apiV1.VolumeReady
 	)
-	if err := s.fsOps.PrepareAndPerformMount(partition, targetPath, false); err != nil {
+	// volume with read-only access mode is staged read-only, so writes are rejected for all its publications
+	readOnly := isReadOnlyAccessMode(req.GetVolumeCapability())
+	if err := s.fsOps.PrepareAndPerformMount(partition, targetPath, false, readOnly); err != nil {
 		ll.Errorf("Unable to prepare and mount: %v. Going to set volumes status to failed", err)
 		newStatus = apiV1.Failed
 		resp, errToReturn = nil, status.Error(codes.Internal, "failed to stage volume: mount error")
+		s.sendEventForVolume(volumeCR, eventing.ErrorType, eventing.VolumeMountFailed,
+			"Unable to stage volume to %s: %v", targetPath, err)
 	}
 
 	if currStatus != apiV1.VolumeReady || newStatus == apiV1.Failed {
 		volumeCR.Spec.CSIStatus = newStatus
 		if err := s.crHelper.UpdateVolumeCRSpec(volumeCR.Name, volumeCR.Spec); err != nil {
 			ll.Errorf("Unable to set volume status to %s: %v", newStatus, err)
-			resp, errToReturn = nil, fmt.Errorf("failed to stage volume: update volume CR error")
+			resp, errToReturn = nil, rpc.ToStatus(err, "failed to stage volume: update volume CR error")
+		} else if newStatus == apiV1.VolumeReady {
+			s.sendEventForVolume(volumeCR, eventing.InfoType, eventing.VolumeStaged,
+				"Volume was staged to %s", targetPath)
 		}
 	}
 
@@ -254,15 +306,17 @@ func (s *CSINodeService) NodeUnstageVolume(ctx context.Context, req *csi.NodeUns
 		resp        = &csi.NodeUnstageVolumeResponse{}
 		errToReturn error
 	)
-	if errToReturn = s.fsOps.UnmountWithCheck(req.GetStagingTargetPath()); errToReturn != nil {
+	if err := s.fsOps.UnmountWithCheck(req.GetStagingTargetPath()); err != nil {
 		volumeCR.Spec.CSIStatus = apiV1.Failed
-		resp = nil
+		resp, errToReturn = nil, status.Errorf(codes.Internal, "failed to unstage volume: %v", err)
+		s.sendEventForVolume(volumeCR, eventing.ErrorType, eventing.VolumeUnmountFailed,
+			"Unable to unstage volume from %s: %v", req.GetStagingTargetPath(), err)
 	}
 
 	ctxWithID := context.WithValue(context.Background(), base.RequestUUID, req.GetVolumeId())
 	if updateErr := s.k8sClient.UpdateCR(ctxWithID, volumeCR); updateErr != nil {
 		ll.Errorf("Unable to update volume CR: %v", updateErr)
-		resp, errToReturn = nil, fmt.Errorf("failed to unstage volume: update volume CR error")
+		resp, errToReturn = nil, rpc.ToStatus(updateErr, "failed to unstage volume: update volume CR error")
 	}
 
 	ll.Debugf("Unstaged - %v", errToReturn == nil)
@@ -312,7 +366,7 @@ func (s *CSINodeService) NodePublishVolume(ctx context.Context, req *csi.NodePub
 			inline, err = strconv.ParseBool(val)
 			if err != nil {
 				ll.Errorf("Failed to parse bool: %v", err)
-				return nil, status.Error(codes.Internal, "failed to determine whether volume ephemeral or no")
+				return nil, status.Error(codes.InvalidArgument, "failed to determine whether volume ephemeral or no")
 			}
 		}
 	}
@@ -329,7 +383,7 @@ func (s *CSINodeService) NodePublishVolume(ctx context.Context, req *csi.NodePub
 		vol, err := s.createInlineVolume(ctx, volumeID, req)
 		if err != nil {
 			ll.Errorf("Failed to create inline volume: %v", err)
-			return nil, status.Error(codes.Internal, "unable to create inline volume")
+			return nil, rpc.ToStatus(err, "unable to create inline volume")
 		}
 		srcPath, err = s.getProvisionerForVolume(vol).GetVolumePath(*vol)
 		if err != nil {
@@ -344,7 +398,15 @@ func (s *CSINodeService) NodePublishVolume(ctx context.Context, req *csi.NodePub
 
 	volumeCR := s.crHelper.GetVolumeByID(volumeID)
 	if volumeCR == nil {
-		return nil, status.Error(codes.Internal, "Unable to find volume")
+		return nil, status.Error(codes.NotFound, "Unable to find volume")
+	}
+
+	// volume of generic ephemeral PVC could be used only by the pod for which PVC was created
+	podName, ok := req.GetVolumeContext()[PodNameKey]
+	if ok && !inline && len(volumeCR.Spec.Owners) > 0 && !util.ContainsString(volumeCR.Spec.Owners, podName) {
+		msg := fmt.Sprintf("volume is owned by %v, pod %s can't use it", volumeCR.Spec.Owners, podName)
+		ll.Error(msg)
+		return nil, status.Error(codes.FailedPrecondition, msg)
 	}
 
 	currStatus := volumeCR.Spec.CSIStatus
@@ -362,10 +424,13 @@ func (s *CSINodeService) NodePublishVolume(ctx context.Context, req *csi.NodePub
 		errToReturn error
 	)
 
-	if err := s.fsOps.PrepareAndPerformMount(srcPath, dstPath, bind); err != nil {
+	readOnly := req.GetReadonly() || isReadOnlyAccessMode(req.GetVolumeCapability())
+	if err := s.fsOps.PrepareAndPerformMount(srcPath, dstPath, bind, readOnly); err != nil {
 		ll.Errorf("Unable to mount volume: %v", err)
 		newStatus = apiV1.Failed
-		resp, errToReturn = nil, fmt.Errorf("failed to publish volume: mount error")
+		resp, errToReturn = nil, status.Error(codes.Internal, "failed to publish volume: mount error")
+		s.sendEventForVolume(volumeCR, eventing.ErrorType, eventing.VolumeMountFailed,
+			"Unable to publish volume to %s: %v", dstPath, err)
 	}
 
 	// TODO: need to provide better logic for volumes Owners https://github.com/dell/csi-baremetal/issues/86
@@ -385,14 +450,38 @@ func (s *CSINodeService) NodePublishVolume(ctx context.Context, req *csi.NodePub
 
 	ctxWithID := context.WithValue(context.Background(), base.RequestUUID, volumeID)
 	volumeCR.Spec.CSIStatus = newStatus
+	if newStatus == apiV1.Published {
+		// the last publication defines whether volume is read-only
+		volumeCR.Spec.ReadOnly = readOnly
+		volumeCR.Spec.TargetPath = dstPath
+		if !inline {
+			volumeCR.Spec.StagingTargetPath = srcPath
+		}
+	}
 	if err = s.k8sClient.UpdateCR(ctxWithID, volumeCR); err != nil {
 		ll.Errorf("Unable to update volume CR to %v, error: %v", volumeCR, err)
-		resp, errToReturn = nil, fmt.Errorf("failed to publish volume: update volume CR error")
+		resp, errToReturn = nil, rpc.ToStatus(err, "failed to publish volume: update volume CR error")
+	} else if currStatus != apiV1.Published && newStatus == apiV1.Published {
+		s.sendEventForVolume(volumeCR, eventing.InfoType, eventing.VolumePublished,
+			"Volume was published to %s", dstPath)
 	}
 	return resp, errToReturn
 }
 
+// isReadOnlyAccessMode returns true if access mode of volume capability doesn't allow writes
+func isReadOnlyAccessMode(capability *csi.VolumeCapability) bool {
+	switch capability.GetAccessMode().GetMode() {
+	case csi.VolumeCapability_AccessMode_SINGLE_NODE_READER_ONLY,
+		csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY:
+		return true
+	}
+	return false
+}
+
 // createInlineVolume encapsulate logic for creating inline volumes
+// Volume context may contain size (e.g. 10Gi, default size is used if it's absent), fsType,
+// storageType or name of the driver's StorageClass which storageType is used
+// Returns created volume or InvalidArgument status error if volume context is malformed
 func (s *CSINodeService) createInlineVolume(ctx context.Context, volumeID string, req *csi.NodePublishVolumeRequest) (*api.Volume, error) {
 	ll := s.log.WithFields(logrus.Fields{
 		"method":   "createInlineVolume",
@@ -401,49 +490,60 @@ func (s *CSINodeService) createInlineVolume(ctx context.Context, volumeID string
 
 	var (
 		volumeContext = req.GetVolumeContext() // verified in NodePublishVolume method
-		bytesStr      = volumeContext[base.SizeKey]
+		bytes         = s.inlineDefaultSize
 		fsType        = ""
 		mode          string
 		scl           string
-		bytes         int64
 		err           error
 	)
 
-	if bytes, err = util.StrToBytes(bytesStr); err != nil {
-		return nil, err
+	if bytesStr, ok := volumeContext[base.SizeKey]; ok {
+		if bytes, err = util.StrToBytes(bytesStr); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid size %q: %v", bytesStr, err)
+		}
+	} else {
+		ll.Infof("Size wasn't provided. Will use %d bytes as a default value", bytes)
+	}
+	if bytes <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "size must be positive, got %d", bytes)
 	}
 
 	if accessType, ok := req.GetVolumeCapability().AccessType.(*csi.VolumeCapability_Mount); ok {
 		fsType = strings.ToLower(accessType.Mount.FsType)
+		if fsType == "" {
+			fsType = strings.ToLower(volumeContext[base.FsTypeKey])
+		}
 		if fsType == "" {
 			fsType = base.DefaultFsType
 			ll.Infof("FS type wasn't provide. Will use %s as a default value", fsType)
 		}
+		if !util.ContainsString(supportedFsTypes, fsType) {
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported fsType %q", fsType)
+		}
 		mode = apiV1.ModeFS
 	}
 
-	scl = util.ConvertStorageClass(volumeContext[base.StorageTypeKey])
-	if scl == apiV1.StorageClassAny {
-		scl = apiV1.StorageClassHDD // do not use sc ANY for inline volumes
+	// ANY storage class is resolved in CreateVolume according to the placement policy
+	if scl, err = s.getInlineStorageType(ctx, volumeContext); err != nil {
+		return nil, err
 	}
 
-	s.reqMu.Lock()
 	vol, err := s.svc.CreateVolume(ctx, api.Volume{
 		Id:           volumeID,
 		StorageClass: scl,
+		StorageGroup: volumeContext[base.StorageGroupKey],
 		NodeId:       s.nodeID,
 		Size:         bytes,
 		Ephemeral:    true,
 		Mode:         mode,
 		Type:         fsType,
 	})
-	s.reqMu.Unlock()
 	if err != nil {
 		return nil, err
 	}
 
 	if vol.CSIStatus == apiV1.Creating {
-		if err = s.svc.WaitStatus(ctx, vol.Id, apiV1.Failed, apiV1.Created); err != nil {
+		if err = common.WaitStatusOrAbort(ctx, s.svc, vol.Id, apiV1.Failed, apiV1.Created); err != nil {
 			return nil, err
 		}
 	}
@@ -451,6 +551,38 @@ func (s *CSINodeService) createInlineVolume(ctx context.Context, volumeID string
 	return vol, nil
 }
 
+// getInlineStorageType returns storage type from volume context of inline volume, storageType key takes precedence
+// over StorageClass name, ANY is returned if both are absent
+func (s *CSINodeService) getInlineStorageType(ctx context.Context, volumeContext map[string]string) (string, error) {
+	storageType, ok := volumeContext[base.StorageTypeKey]
+	if !ok {
+		scName, ok := volumeContext[StorageClassNameKey]
+		if !ok {
+			return apiV1.StorageClassAny, nil
+		}
+		sc := &storageV1.StorageClass{}
+		if err := s.k8sClient.Get(ctx, k8sCl.ObjectKey{Name: scName}, sc); err != nil {
+			if k8sError.IsNotFound(err) {
+				return "", status.Errorf(codes.InvalidArgument, "storage class %s not found", scName)
+			}
+			return "", err
+		}
+		if sc.Provisioner != base.PluginName {
+			return "", status.Errorf(codes.InvalidArgument, "storage class %s doesn't belong to %s", scName, base.PluginName)
+		}
+		storageType = sc.Parameters[base.StorageTypeKey]
+	}
+	if storageType == "" {
+		return apiV1.StorageClassAny, nil
+	}
+
+	scl := util.ConvertStorageClass(storageType)
+	if scl != strings.ToUpper(storageType) {
+		return "", status.Errorf(codes.InvalidArgument, "unknown storage type %q", storageType)
+	}
+	return scl, nil
+}
+
 // NodeUnpublishVolume is the implementation of CSI Spec NodePublishVolume. Performs each time pod stops consume a volume.
 // This method unmounts volume with appropriate VolumeID from the TargetPath.
 // Receives golang context and CSI Spec NodeUnpublishVolumeRequest
@@ -498,6 +630,8 @@ func (s *CSINodeService) NodeUnpublishVolume(ctx context.Context, req *csi.NodeU
 	ctxWithID := context.WithValue(context.Background(), base.RequestUUID, req.GetVolumeId())
 	if err := s.fsOps.UnmountWithCheck(req.GetTargetPath()); err != nil {
 		ll.Errorf("Unable to unmount volume: %v", err)
+		s.sendEventForVolume(volumeCR, eventing.ErrorType, eventing.VolumeUnmountFailed,
+			"Unable to unpublish volume from %s: %v", req.GetTargetPath(), err)
 		volumeCR.Spec.CSIStatus = apiV1.Failed
 		if updateErr := s.k8sClient.UpdateCR(ctxWithID, volumeCR); updateErr != nil {
 			ll.Errorf("Unable to set volume CR status to failed: %v", updateErr)
@@ -511,28 +645,24 @@ func (s *CSINodeService) NodeUnpublishVolume(ctx context.Context, req *csi.NodeU
 
 	// k8s doesn't call DeleteVolume for inline volumes, so we perform DeleteVolume operation in Unpublish request
 	if volumeCR.Spec.Ephemeral {
-		s.reqMu.Lock()
 		err := s.svc.DeleteVolume(ctxWithID, req.GetVolumeId())
-		s.reqMu.Unlock()
-
 		if err != nil {
 			if k8sError.IsNotFound(err) {
 				ll.Infof("Volume doesn't exist")
 				return &csi.NodeUnpublishVolumeResponse{}, nil
 			}
 			ll.Errorf("Unable to delete volume: %v", err)
-			return nil, err
+			return nil, rpc.ToStatus(err, "unable to delete volume")
 		}
 
-		if err = s.svc.WaitStatus(ctx, req.VolumeId, apiV1.Failed, apiV1.Removed); err != nil {
-			ll.Warn("Status wasn't reached")
-			return nil, status.Error(codes.Internal, "Unable to delete volume")
+		if err = common.WaitStatusOrAbort(ctx, s.svc, req.VolumeId, apiV1.Failed, apiV1.Removed); err != nil {
+			ll.Warnf("Status wasn't reached: %v", err)
+			return nil, rpc.ToStatus(err, "Unable to delete volume")
 		}
-		s.reqMu.Lock()
 		s.svc.UpdateCRsAfterVolumeDeletion(ctxWithID, req.VolumeId)
-		s.reqMu.Unlock()
 	} else {
 		volumeCR.Spec.CSIStatus = apiV1.VolumeReady
+		volumeCR.Spec.TargetPath = ""
 		if updateErr := s.k8sClient.UpdateCR(ctxWithID, volumeCR); updateErr != nil {
 			ll.Errorf("Unable to set volume CR status to VolumeReady: %v", updateErr)
 		}
@@ -547,13 +677,68 @@ func (s *CSINodeService) NodeGetVolumeStats(ctx context.Context, req *csi.NodeGe
 	return &csi.NodeGetVolumeStatsResponse{}, nil
 }
 
-// NodeExpandVolume returns empty response
+// NodeExpandVolume is the implementation of CSI Spec NodeExpandVolume. This method expands underlying device
+// of the volume up to the size which was set by ControllerExpandVolume and grows file system on it.
+// Xfs is grown through the mount point, so it should be mounted, ext3/ext4 could be grown offline.
+// Receives golang context and CSI Spec NodeExpandVolumeRequest
+// Returns CSI Spec NodeExpandVolumeResponse or error if something went wrong
 func (s *CSINodeService) NodeExpandVolume(ctx context.Context, req *csi.NodeExpandVolumeRequest) (*csi.NodeExpandVolumeResponse, error) {
-	return &csi.NodeExpandVolumeResponse{}, nil
+	ll := s.log.WithFields(logrus.Fields{
+		"method":   "NodeExpandVolume",
+		"volumeID": req.GetVolumeId(),
+	})
+
+	ll.Infof("locking volume on request: %v", req)
+	s.volMu.LockKey(req.GetVolumeId())
+	defer func() {
+		err := s.volMu.UnlockKey(req.GetVolumeId())
+		if err != nil {
+			ll.Warnf("Unlocking  volume with error %s", err)
+		}
+	}()
+	if err := s.checkRequestContext(ctx, ll); err != nil {
+		return nil, err
+	}
+
+	if len(req.GetVolumeId()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Volume ID missing in request")
+	}
+	if len(req.GetVolumePath()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Volume path missing in request")
+	}
+
+	volumeCR := s.crHelper.GetVolumeByID(req.GetVolumeId())
+	if volumeCR == nil {
+		return nil, status.Errorf(codes.NotFound, "Unable to find volume with ID %s", req.GetVolumeId())
+	}
+	vol := volumeCR.Spec
+	if required := req.GetCapacityRange().GetRequiredBytes(); required > vol.Size {
+		return nil, status.Errorf(codes.OutOfRange,
+			"required size %d is greater than volume size %d, volume should be expanded by controller first",
+			required, vol.Size)
+	}
+
+	prov := s.getProvisionerForVolume(&vol)
+	if err := prov.ExpandVolume(vol); err != nil {
+		ll.Errorf("Unable to expand volume: %v", err)
+		return nil, status.Error(codes.Internal, "unable to expand volume")
+	}
+	device, err := prov.GetVolumePath(vol)
+	if err != nil {
+		ll.Errorf("Unable to determine device of volume: %v", err)
+		return nil, status.Error(codes.Internal, "unable to find device of volume")
+	}
+	if err = s.fsOps.GrowFS(fs.FileSystem(vol.Type), device, req.GetVolumePath()); err != nil {
+		ll.Errorf("Unable to grow file system: %v", err)
+		return nil, status.Error(codes.Internal, "unable to grow file system")
+	}
+
+	ll.Infof("Volume was expanded up to %d", vol.Size)
+	return &csi.NodeExpandVolumeResponse{CapacityBytes: vol.Size}, nil
 }
 
 // NodeGetCapabilities is the implementation of CSI Spec NodeGetCapabilities.
-// Provides Node capabilities of CSI driver to k8s. STAGE/UNSTAGE Volume for now.
+// Provides Node capabilities of CSI driver to k8s. STAGE/UNSTAGE and EXPAND Volume for now.
 // Receives golang context and CSI Spec NodeGetCapabilitiesRequest
 // Returns CSI Spec NodeGetCapabilitiesResponse and nil error
 func (s *CSINodeService) NodeGetCapabilities(ctx context.Context, req *csi.NodeGetCapabilitiesRequest) (*csi.NodeGetCapabilitiesResponse, error) {
@@ -564,6 +749,13 @@ func (s *CSINodeService) NodeGetCapabilities(ctx context.Context, req *csi.NodeG
 					Type: csi.NodeServiceCapability_RPC_STAGE_UNSTAGE_VOLUME,
 				},
 			},
+		},
+		{
+			Type: &csi.NodeServiceCapability_Rpc{
+				Rpc: &csi.NodeServiceCapability_RPC{
+					Type: csi.NodeServiceCapability_RPC_EXPAND_VOLUME,
+				},
+			},
 		}},
 	}, nil
 }
@@ -571,7 +763,8 @@ func (s *CSINodeService) NodeGetCapabilities(ctx context.Context, req *csi.NodeG
 // NodeGetInfo is the implementation of CSI Spec NodeGetInfo. It plays a role in CSI Topology feature when Controller
 // chooses a node where to deploy a volume.
 // Receives golang context and CSI Spec NodeGetInfoRequest
-// Returns CSI Spec NodeGetInfoResponse with topology NodeIDAnnotationKey: NodeID and nil error
+// Returns CSI Spec NodeGetInfoResponse with topology NodeIDAnnotationKey: NodeID, MaxVolumesPerNode and nil error.
+// If amount of volumes can't be calculated then 0 which means unlimited is reported
 func (s *CSINodeService) NodeGetInfo(ctx context.Context, req *csi.NodeGetInfoRequest) (*csi.NodeGetInfoResponse, error) {
 	ll := s.log.WithFields(logrus.Fields{
 		"method": "NodeGetInfo",
@@ -583,22 +776,30 @@ func (s *CSINodeService) NodeGetInfo(ctx context.Context, req *csi.NodeGetInfoRe
 		},
 	}
 
-	ll.Infof("NodeGetInfo created topology: %v", topology)
+	maxVolumes, err := s.calculateMaxVolumes()
+	if err != nil {
+		ll.Errorf("Unable to calculate max volumes per node, report unlimited: %v", err)
+		maxVolumes = 0
+	}
+
+	ll.Infof("NodeGetInfo created topology: %v, max volumes per node: %d", topology, maxVolumes)
 
 	return &csi.NodeGetInfoResponse{
 		NodeId:             s.nodeID,
 		AccessibleTopology: &topology,
+		MaxVolumesPerNode:  maxVolumes,
 	}, nil
 }
 
-// Check does the health check and changes the status of the server based on drives cache size
+// Check does the health check and changes the status of the server based on initial discovery and state of
+// dependencies: DriveManager and kubernetes API reachability, discovery and volume operations progress
 func (s *CSINodeService) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
 	ll := s.log.WithFields(logrus.Fields{
 		"method": "Check",
 	})
 
-	if !s.initialized {
-		ll.Info("Node svc is not ready yet")
+	if err := s.updateHealthStatus(ctx); err != nil {
+		ll.Infof("Node svc is not ready: %v", err)
 		return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_NOT_SERVING}, nil
 	}
 
@@ -606,9 +807,9 @@ func (s *CSINodeService) Check(ctx context.Context, req *grpc_health_v1.HealthCh
 }
 
 // Watch is used by clients to receive updates when the svc status changes.
-// Watch only dummy implemented just to satisfy the interface.
-func (s *CSINodeService) Watch(req *grpc_health_v1.HealthCheckRequest, srv grpc_health_v1.Health_WatchServer) error {
-	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+// Status is sent to client when it is changed by Check or by discovery of drives
+func (s *CSINodeService) Watch(_ *grpc_health_v1.HealthCheckRequest, srv grpc_health_v1.Health_WatchServer) error {
+	return s.healthBroadcaster.Watch(srv)
 }
 
 // GetLivenessHelper return instance of livenesshelper used by node service
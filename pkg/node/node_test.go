@@ -37,6 +37,8 @@ import (
 	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/featureconfig"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
+	"github.com/dell/csi-baremetal/pkg/controller"
 	csibmnodeconst "github.com/dell/csi-baremetal/pkg/crcontrollers/csibmnode/common"
 	"github.com/dell/csi-baremetal/pkg/mocks"
 	mockProv "github.com/dell/csi-baremetal/pkg/mocks/provisioners"
@@ -80,7 +82,7 @@ var _ = Describe("CSINodeService NodePublish()", func() {
 			req.VolumeContext[PodNameKey] = testPodName
 
 			fsOps.On("PrepareAndPerformMount",
-				req.GetStagingTargetPath(), req.GetTargetPath(), true).
+				req.GetStagingTargetPath(), req.GetTargetPath(), true, false).
 				Return(nil)
 
 			resp, err := node.NodePublishVolume(testCtx, req)
@@ -92,6 +94,8 @@ var _ = Describe("CSINodeService NodePublish()", func() {
 			err = node.k8sClient.ReadCR(testCtx, testV1ID, volumeCR)
 			Expect(err).To(BeNil())
 			//Expect(volumeCR.Spec.Owners[0]).To(Equal(testPodName))
+			Expect(volumeCR.Spec.TargetPath).To(Equal(req.GetTargetPath()))
+			Expect(volumeCR.Spec.StagingTargetPath).To(Equal(req.GetStagingTargetPath()))
 
 			// publish again such volume
 			resp, err = node.NodePublishVolume(testCtx, req)
@@ -104,6 +108,23 @@ var _ = Describe("CSINodeService NodePublish()", func() {
 			Expect(err).To(BeNil())
 			//Expect(len(volumeCR.Spec.Owners)).To(Equal(1))
 		})
+		It("Should publish volume read-only", func() {
+			req := getNodePublishRequest(testV1ID, targetPath, *testVolumeCap)
+			req.Readonly = true
+
+			fsOps.On("PrepareAndPerformMount",
+				req.GetStagingTargetPath(), req.GetTargetPath(), true, true).
+				Return(nil)
+
+			resp, err := node.NodePublishVolume(testCtx, req)
+			Expect(resp).NotTo(BeNil())
+			Expect(err).To(BeNil())
+
+			volumeCR := &vcrd.Volume{}
+			err = node.k8sClient.ReadCR(testCtx, testV1ID, volumeCR)
+			Expect(err).To(BeNil())
+			Expect(volumeCR.Spec.ReadOnly).To(BeTrue())
+		})
 	})
 
 	Context("NodePublish() failure", func() {
@@ -160,6 +181,18 @@ var _ = Describe("CSINodeService NodePublish()", func() {
 			Expect(resp).To(BeNil())
 			Expect(err).NotTo(BeNil())
 		})
+		It("Should fail, because volume of generic ephemeral PVC is owned by another pod", func() {
+			req := getNodePublishRequest(testV1ID, targetPath, *testVolumeCap)
+			req.VolumeContext[PodNameKey] = testPodName
+			vol1 := testVolumeCR1
+			vol1.Spec.Owners = []string{"another-pod"}
+			err := node.k8sClient.UpdateCR(testCtx, &vol1)
+			Expect(err).To(BeNil())
+
+			resp, err := node.NodePublishVolume(testCtx, req)
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+		})
 		It("Should fail, because of volume CR isn't exist", func() {
 			req := getNodePublishRequest(testV1ID, targetPath, *testVolumeCap)
 			err := node.k8sClient.DeleteCR(testCtx, &testVolumeCR1)
@@ -168,14 +201,13 @@ var _ = Describe("CSINodeService NodePublish()", func() {
 			resp, err := node.NodePublishVolume(testCtx, req)
 			Expect(resp).To(BeNil())
 			Expect(err).NotTo(BeNil())
-			// not a good ide to check error message. better to validate error code.
-			Expect(err.Error()).To(ContainSubstring("Unable to find volume"))
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
 		})
 		It("Should fail, because of PrepareAndPerformMount failed", func() {
 			req := getNodePublishRequest(testV1ID, targetPath, *testVolumeCap)
 
 			fsOps.On("PrepareAndPerformMount",
-				req.GetStagingTargetPath(), req.GetTargetPath(), true).
+				req.GetStagingTargetPath(), req.GetTargetPath(), true, false).
 				Return(errors.New("error mount"))
 
 			resp, err := node.NodePublishVolume(testCtx, req)
@@ -198,7 +230,7 @@ var _ = Describe("CSINodeService NodeStage()", func() {
 			partitionPath := "/partition/path/for/volume1"
 			prov.On("GetVolumePath", testVolume2).Return(partitionPath, nil)
 			fsOps.On("PrepareAndPerformMount",
-				partitionPath, req.GetStagingTargetPath(), false).
+				partitionPath, req.GetStagingTargetPath(), false, false).
 				Return(nil)
 
 			resp, err := node.NodeStageVolume(testCtx, req)
@@ -219,7 +251,7 @@ var _ = Describe("CSINodeService NodeStage()", func() {
 			partitionPath := "/partition/path/for/volume1"
 			prov.On("GetVolumePath", vol1.Spec).Return(partitionPath, nil)
 			fsOps.On("PrepareAndPerformMount",
-				partitionPath, req.GetStagingTargetPath(), false).
+				partitionPath, req.GetStagingTargetPath(), false, false).
 				Return(nil)
 
 			resp, err := node.NodeStageVolume(testCtx, req)
@@ -285,7 +317,7 @@ var _ = Describe("CSINodeService NodeStage()", func() {
 			partitionPath := "/partition/path/for/volume1"
 			prov.On("GetVolumePath", testVolume2).Return(partitionPath, nil)
 			fsOps.On("PrepareAndPerformMount",
-				partitionPath, req.GetStagingTargetPath(), false).
+				partitionPath, req.GetStagingTargetPath(), false, false).
 				Return(errors.New("PrepareAndPerformMount error"))
 
 			resp, err := node.NodeStageVolume(testCtx, req)
@@ -302,7 +334,7 @@ var _ = Describe("CSINodeService NodeStage()", func() {
 			partitionPath := "/partition/path/for/volume1"
 			prov.On("GetVolumePath", vol1.Spec).Return(partitionPath, nil)
 			fsOps.On("PrepareAndPerformMount",
-				partitionPath, req.GetStagingTargetPath(), false).
+				partitionPath, req.GetStagingTargetPath(), false, false).
 				Return(errors.New("mount error"))
 
 			resp, err := node.NodeStageVolume(testCtx, req)
@@ -342,6 +374,7 @@ var _ = Describe("CSINodeService NodeUnPublish()", func() {
 			err = node.k8sClient.ReadCR(testCtx, testV1ID, volumeCR)
 			Expect(err).To(BeNil())
 			Expect(volumeCR.Spec.CSIStatus).To(Equal(apiV1.VolumeReady))
+			Expect(volumeCR.Spec.TargetPath).To(BeEmpty())
 		})
 		//It("Should unpublish volume and don't change volume CR status", func() {
 		//	req := getNodeUnpublishRequest(testV1ID, targetPath)
@@ -529,11 +562,20 @@ var _ = Describe("CSINodeService NodeGetInfo()", func() {
 		val, ok := resp.AccessibleTopology.Segments[csibmnodeconst.NodeIDAnnotationKey]
 		Expect(ok).To(BeTrue())
 		Expect(val).To(Equal(nodeID))
+		Expect(resp.MaxVolumesPerNode).To(Equal(int64(0)))
+	})
+	It("Should return configured max volumes per node", func() {
+		node := newNodeService()
+		node.SetMaxVolumesPerNode(10)
+
+		resp, err := node.NodeGetInfo(testCtx, &csi.NodeGetInfoRequest{})
+		Expect(err).To(BeNil())
+		Expect(resp.MaxVolumesPerNode).To(Equal(int64(10)))
 	})
 })
 
 var _ = Describe("CSINodeService NodeGetCapabilities()", func() {
-	It("Should return STAGE_UNSTAGE_VOLUME capabilities", func() {
+	It("Should return STAGE_UNSTAGE_VOLUME and EXPAND_VOLUME capabilities", func() {
 		node := newNodeService()
 
 		resp, err := node.NodeGetCapabilities(testCtx, &csi.NodeGetCapabilitiesRequest{})
@@ -545,8 +587,59 @@ var _ = Describe("CSINodeService NodeGetCapabilities()", func() {
 				Type: csi.NodeServiceCapability_RPC_STAGE_UNSTAGE_VOLUME,
 			},
 		}
-		Expect(len(capabilities)).To(Equal(1))
+		Expect(len(capabilities)).To(Equal(2))
 		Expect(capabilities[0].Type).To(Equal(expectedCapability))
+		Expect(capabilities[1].GetRpc().GetType()).To(Equal(csi.NodeServiceCapability_RPC_EXPAND_VOLUME))
+	})
+})
+
+var _ = Describe("CSINodeService NodeExpandVolume()", func() {
+	var (
+		volumePath = "/mnt/volume"
+		device     = "/dev/vg/lv"
+	)
+
+	BeforeEach(func() {
+		setVariables()
+	})
+
+	It("Should expand volume and grow file system", func() {
+		prov.On("ExpandVolume", testVolume1).Return(nil).Once()
+		prov.On("GetVolumePath", testVolume1).Return(device, nil).Once()
+		fsOps.On("GrowFS", fs.FileSystem(testVolume1.Type), device, volumePath).Return(nil).Once()
+
+		resp, err := node.NodeExpandVolume(testCtx, &csi.NodeExpandVolumeRequest{
+			VolumeId:   testV1ID,
+			VolumePath: volumePath,
+		})
+		Expect(err).To(BeNil())
+		Expect(resp.CapacityBytes).To(Equal(testVolume1.Size))
+	})
+	It("Should fail when file system wasn't grown", func() {
+		prov.On("ExpandVolume", testVolume1).Return(nil).Once()
+		prov.On("GetVolumePath", testVolume1).Return(device, nil).Once()
+		fsOps.On("GrowFS", fs.FileSystem(testVolume1.Type), device, volumePath).Return(testErr).Once()
+
+		_, err := node.NodeExpandVolume(testCtx, &csi.NodeExpandVolumeRequest{
+			VolumeId:   testV1ID,
+			VolumePath: volumePath,
+		})
+		Expect(status.Code(err)).To(Equal(codes.Internal))
+	})
+	It("Should fail when volume wasn't expanded by controller", func() {
+		_, err := node.NodeExpandVolume(testCtx, &csi.NodeExpandVolumeRequest{
+			VolumeId:      testV1ID,
+			VolumePath:    volumePath,
+			CapacityRange: &csi.CapacityRange{RequiredBytes: testVolume1.Size + 1},
+		})
+		Expect(status.Code(err)).To(Equal(codes.OutOfRange))
+	})
+	It("Should fail with invalid arguments", func() {
+		_, err := node.NodeExpandVolume(testCtx, &csi.NodeExpandVolumeRequest{VolumeId: testV1ID})
+		Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+		_, err = node.NodeExpandVolume(testCtx, &csi.NodeExpandVolumeRequest{VolumeId: "unknown", VolumePath: volumePath})
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
 	})
 })
 
@@ -554,6 +647,7 @@ var _ = Describe("CSINodeService Check()", func() {
 	It("Should return serving", func() {
 		node := newNodeService()
 		node.initialized = true
+		node.health.discovered(time.Now())
 
 		resp, err := node.Check(testCtx, &grpc_health_v1.HealthCheckRequest{})
 		Expect(err).To(BeNil())
@@ -568,6 +662,16 @@ var _ = Describe("CSINodeService Check()", func() {
 		Expect(resp).ToNot(BeNil())
 		Expect(resp.Status).To(Equal(grpc_health_v1.HealthCheckResponse_NOT_SERVING))
 	})
+	It("Should return not serving when DriveManager is unreachable", func() {
+		node := newNodeService()
+		node.initialized = true
+		node.health.discovered(time.Now())
+		node.health.setDriveMgrErr(errors.New("connection refused"))
+
+		resp, err := node.Check(testCtx, &grpc_health_v1.HealthCheckRequest{})
+		Expect(err).To(BeNil())
+		Expect(resp.Status).To(Equal(grpc_health_v1.HealthCheckResponse_NOT_SERVING))
+	})
 })
 
 var _ = Describe("CSINodeService InlineVolumes", func() {
@@ -604,7 +708,7 @@ var _ = Describe("CSINodeService InlineVolumes", func() {
 
 			volOps.On("CreateVolume", mock.Anything, mock.Anything).Return(&createdVolCR.Spec, nil)
 			prov.On("GetVolumePath", createdVolCR.Spec).Return(srcPath, nil)
-			fsOps.On("PrepareAndPerformMount", srcPath, req.GetTargetPath(), false).Return(nil)
+			fsOps.On("PrepareAndPerformMount", srcPath, req.GetTargetPath(), false, false).Return(nil)
 
 			resp, err := node.NodePublishVolume(testCtx, req)
 			Expect(resp).NotTo(BeNil())
@@ -655,12 +759,37 @@ var _ = Describe("CSINodeService InlineVolumes", func() {
 			Expect(err).NotTo(BeNil())
 		})
 
-		It("Should fail with missing size", func() {
+		It("Should use default size when size is missing", func() {
 			req := getNodePublishRequest(testV1ID, targetPath, *testVolumeCap)
 			req.VolumeContext[EphemeralKey] = "true"
+
+			var emptyVol *api.Volume
+			volOps.On("CreateVolume", mock.Anything, mock.Anything).
+				Return(emptyVol, errors.New("error"))
+
 			resp, err := node.NodePublishVolume(testCtx, req)
 			Expect(resp).To(BeNil())
-			Expect(err).NotTo(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.Internal))
+			vol := volOps.Calls[0].Arguments.Get(1).(api.Volume)
+			Expect(vol.Size).To(Equal(DefaultInlineVolumeSize))
+			Expect(vol.StorageClass).To(Equal(apiV1.StorageClassAny))
+		})
+
+		It("Should fail with InvalidArgument for malformed volume context", func() {
+			contexts := []map[string]string{
+				{base.SizeKey: "50Gx"},
+				{base.SizeKey: "0"},
+				{base.StorageTypeKey: "FLOPPY"},
+				{StorageClassNameKey: "not-existing"},
+			}
+			for _, volumeContext := range contexts {
+				req := getNodePublishRequest(testV1ID, targetPath, *testVolumeCap)
+				req.VolumeContext = volumeContext
+				req.VolumeContext[EphemeralKey] = "true"
+				resp, err := node.NodePublishVolume(testCtx, req)
+				Expect(resp).To(BeNil())
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			}
 		})
 	})
 })
@@ -677,12 +806,31 @@ var _ = Describe("CSINodeService Probe()", func() {
 	})
 	It("Should failed", func() {
 		node := newNodeService()
+		node.initialized = true
 		node.livenessCheck = &DummyLivenessHelper{false}
 		resp, err := node.Probe(testCtx, &csi.ProbeRequest{})
 		Expect(err).To(BeNil())
 		Expect(resp).ToNot(BeNil())
 		Expect(resp.Ready.Value).To(Equal(false))
 	})
+	It("Should fail until discovery is done", func() {
+		node := newNodeService()
+		resp, err := node.Probe(testCtx, &csi.ProbeRequest{})
+		Expect(err).To(BeNil())
+		Expect(resp.Ready.Value).To(Equal(false))
+	})
+})
+
+var _ = Describe("CSINodeService GetPluginInfo()", func() {
+	It("Should report version and manifest", func() {
+		node := newNodeService()
+		node.SetDriveMgrBackend("loopback")
+		resp, err := node.GetPluginInfo(testCtx, &csi.GetPluginInfoRequest{})
+		Expect(err).To(BeNil())
+		Expect(resp.VendorVersion).To(Equal(base.PluginVersion))
+		Expect(resp.Manifest[controller.ManifestGitCommitKey]).To(Equal(base.PluginGitCommit))
+		Expect(resp.Manifest[controller.ManifestDriveManagerKey]).To(Equal("loopback"))
+	})
 })
 
 func getNodePublishRequest(volumeID, targetPath string, volumeCap csi.VolumeCapability) *csi.NodePublishVolumeRequest {
@@ -724,7 +872,7 @@ func newNodeService() *CSINodeService {
 		panic(err)
 	}
 	node := NewCSINodeService(client, nodeID, testLogger, kubeClient,
-		new(mocks.NoOpRecorder), featureconfig.NewFeatureConfig())
+		new(mocks.NoOpRecorder), featureconfig.NewFeatureConfig(), nil, nil)
 
 	driveCR1 := node.k8sClient.ConstructDriveCR(disk1.UUID, disk1)
 	driveCR2 := node.k8sClient.ConstructDriveCR(disk2.UUID, disk2)
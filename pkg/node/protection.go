@@ -0,0 +1,136 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
+	"github.com/dell/csi-baremetal/pkg/eventing"
+)
+
+// lostFoundDir is created by mkfs of ext file systems, it doesn't mean that file system contains data
+const lostFoundDir = "lost+found"
+
+// SetDeletionProtection enables or disables protection of volumes which file system isn't empty from removal
+func (m *VolumeManager) SetDeletionProtection(enabled bool) {
+	m.deletionProtection = enabled
+}
+
+// checkRemovalAllowed checks whether deletion protection allows removal of the volume. Removal is allowed if
+// protection is disabled, volume isn't a file system one, it is an inline ephemeral volume, Volume CR has
+// VolumeDataRemovalAnnotationKey annotation or its file system is empty
+// Returns error with the reason why removal isn't allowed, file system which can't be checked is considered
+// as the one with data
+func (m *VolumeManager) checkRemovalAllowed(volume *volumecrd.Volume) error {
+	if !m.deletionProtection || volume.Spec.Mode != apiV1.ModeFS || volume.Spec.Ephemeral {
+		return nil
+	}
+	if _, ok := volume.Annotations[apiV1.VolumeDataRemovalAnnotationKey]; ok {
+		return nil
+	}
+
+	hasData, err := m.volumeHasData(volume)
+	if err != nil {
+		return fmt.Errorf("unable to check file system: %v", err)
+	}
+	if hasData {
+		return fmt.Errorf("file system isn't empty")
+	}
+	return nil
+}
+
+// volumeHasData checks whether file system of the volume contains anything besides lost+found directory,
+// file system which isn't mounted is temporary mounted read-only
+func (m *VolumeManager) volumeHasData(volume *volumecrd.Volume) (bool, error) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":   "volumeHasData",
+		"volumeID": volume.Spec.Id,
+	})
+
+	device, err := m.getProvisionerForVolume(&volume.Spec).GetVolumePath(volume.Spec)
+	if err != nil {
+		return false, err
+	}
+	fsType, err := m.fsOps.GetFSType(device)
+	if err != nil {
+		return false, err
+	}
+	if fsType == "" {
+		ll.Debugf("There is no file system on %s", device)
+		return false, nil
+	}
+
+	mountPoint, err := m.fsOps.FindMountTarget(device)
+	if err != nil {
+		return false, err
+	}
+	if mountPoint == "" {
+		if mountPoint, err = ioutil.TempDir("", "csi-protection-"); err != nil {
+			return false, err
+		}
+		defer func() {
+			_ = os.Remove(mountPoint)
+		}()
+		if err = m.fsOps.Mount(device, mountPoint, fs.ReadOnlyOption); err != nil {
+			return false, err
+		}
+		defer func() {
+			if err := m.fsOps.Unmount(mountPoint); err != nil {
+				ll.Errorf("Unable to unmount %s: %v", mountPoint, err)
+			}
+		}()
+	}
+
+	entries, err := ioutil.ReadDir(mountPoint)
+	if err != nil {
+		return false, err
+	}
+	for _, entry := range entries {
+		if entry.Name() != lostFoundDir {
+			ll.Infof("File system on %s contains %s", device, entry.Name())
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// blockVolumeRemoval returns volume to Created status, so CSI DeleteVolume fails and is retried by CO later
+func (m *VolumeManager) blockVolumeRemoval(ctx context.Context, volume *volumecrd.Volume,
+	reason error) (ctrl.Result, error) {
+	m.log.WithFields(logrus.Fields{
+		"method":   "blockVolumeRemoval",
+		"volumeID": volume.Spec.Id,
+	}).Warnf("Removal of volume is blocked: %v", reason)
+
+	volume.Spec.CSIStatus = apiV1.Created
+	if err := m.k8sClient.UpdateCRWithAttempts(ctx, volume, 10); err != nil {
+		return ctrl.Result{Requeue: true}, err
+	}
+	m.sendEventForVolume(volume, eventing.WarningType, eventing.VolumeRemovalBlocked,
+		"Removal of volume is blocked by deletion protection: %v. Set annotation %s on Volume CR to remove it.",
+		reason, apiV1.VolumeDataRemovalAnnotationKey)
+	return ctrl.Result{}, nil
+}
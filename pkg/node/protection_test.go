@@ -0,0 +1,96 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	vcrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
+	mockProv "github.com/dell/csi-baremetal/pkg/mocks/provisioners"
+	p "github.com/dell/csi-baremetal/pkg/node/provisioners"
+)
+
+func TestVolumeManager_handleRemovingStatusProtected(t *testing.T) {
+	dir, err := ioutil.TempDir("", "protection")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, os.Mkdir(filepath.Join(dir, lostFoundDir), 0700))
+
+	var (
+		vm     = prepareSuccessVolumeManager(t)
+		pMock  = mockProv.GetMockProvisionerSuccess("/dev/sda1")
+		fsOps  = &mockProv.MockFsOpts{}
+		volume = volCR.DeepCopy()
+		stored = &vcrd.Volume{}
+	)
+	volume.Spec.Mode = apiV1.ModeFS
+	volume.Spec.CSIStatus = apiV1.Removing
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, volume.Name, volume))
+	vm.SetProvisioners(map[p.VolumeType]p.Provisioner{p.DriveBasedVolumeType: pMock})
+	vm.fsOps = fsOps
+	vm.SetDeletionProtection(true)
+
+	// file system is mounted and contains data
+	fsOps.On("GetFSType", "/dev/sda1").Return(fs.FileSystem("xfs"), nil)
+	fsOps.On("FindMountTarget", "/dev/sda1").Return(dir, nil).Once()
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "data"), []byte("data"), 0600))
+	res, err := vm.handleRemovingStatus(testCtx, volume)
+	assert.Nil(t, err)
+	assert.Equal(t, ctrl.Result{}, res)
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, volume.Name, stored))
+	assert.Equal(t, apiV1.Created, stored.Spec.CSIStatus)
+	pMock.AssertNotCalled(t, "ReleaseVolume", mock.Anything)
+
+	// removal of data is allowed by annotation
+	stored.Spec.CSIStatus = apiV1.Removing
+	stored.Annotations = map[string]string{apiV1.VolumeDataRemovalAnnotationKey: "true"}
+	assert.Nil(t, vm.checkRemovalAllowed(stored))
+
+	// file system is temporary mounted and contains lost+found only
+	assert.Nil(t, os.Remove(filepath.Join(dir, "data")))
+	fsOps.On("FindMountTarget", "/dev/sda1").Return("", nil)
+	fsOps.On("Mount", "/dev/sda1", mock.Anything, []string{fs.ReadOnlyOption}).Return(nil)
+	fsOps.On("Unmount", mock.Anything).Return(nil)
+	volume.Spec.CSIStatus = apiV1.Removing
+	assert.Nil(t, vm.k8sClient.UpdateCR(testCtx, volume))
+	res, err = vm.handleRemovingStatus(testCtx, volume)
+	assert.Nil(t, err)
+	assert.Equal(t, ctrl.Result{}, res)
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, volume.Name, stored))
+	assert.Equal(t, apiV1.Removed, stored.Spec.CSIStatus)
+	fsOps.AssertNumberOfCalls(t, "Unmount", 1)
+
+	// file system can't be checked
+	fsOps = &mockProv.MockFsOpts{}
+	fsOps.On("GetFSType", "/dev/sda1").Return(fs.FileSystem(""), testErr)
+	vm.fsOps = fsOps
+	assert.NotNil(t, vm.checkRemovalAllowed(volume))
+	// there is no file system
+	fsOps = &mockProv.MockFsOpts{}
+	fsOps.On("GetFSType", "/dev/sda1").Return(fs.FileSystem(""), nil)
+	vm.fsOps = fsOps
+	assert.Nil(t, vm.checkRemovalAllowed(volume))
+}
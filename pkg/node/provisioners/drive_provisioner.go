@@ -24,13 +24,13 @@ import (
 	"github.com/sirupsen/logrus"
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
 	"github.com/dell/csi-baremetal/api/v1/drivecrd"
 	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/command"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsblk"
-	"github.com/dell/csi-baremetal/pkg/base/linuxutils/partitionhelper"
 	"github.com/dell/csi-baremetal/pkg/base/util"
 	uw "github.com/dell/csi-baremetal/pkg/node/provisioners/utilwrappers"
 )
@@ -98,26 +98,13 @@ func (d *DriveProvisioner) PrepareVolume(vol api.Volume) error {
 		return err
 	}
 
-	partUUID, _ := util.GetVolumeUUID(vol.Id)
-	part := uw.Partition{
-		Device:    device,
-		TableType: partitionhelper.PartitionGPT,
-		Label:     DefaultPartitionLabel,
-		Num:       DefaultPartitionNumber,
-		PartUUID:  partUUID,
-		Ephemeral: vol.Ephemeral,
-	}
-
-	ll.Infof("Create partition %v on device %s and set UUID", part, device)
-	partPtr, err := d.partOps.PreparePartition(part)
+	path, err := d.getPartitioner(vol).PreparePartition(device, vol)
 	if err != nil {
-		ll.Errorf("Unable to prepare partition: %v", err)
-		return fmt.Errorf("unable to prepare partition for volume %v", vol)
+		return err
 	}
-	ll.Infof("Partition was created successfully %v", partPtr)
 
 	// create FS
-	return d.fsOps.CreateFS(fs.FileSystem(vol.Type), partPtr.GetFullPath())
+	return d.fsOps.CreateFS(fs.FileSystem(vol.Type), path)
 }
 
 // ReleaseVolume remove FS and partition based on vol attributes.
@@ -143,55 +130,7 @@ func (d *DriveProvisioner) ReleaseVolume(vol api.Volume) error {
 	}
 	ll.Debugf("Got device %s", device)
 
-	var (
-		partUUID, _ = util.GetVolumeUUID(vol.Id)
-		part        = uw.Partition{
-			Device:   device,
-			Num:      DefaultPartitionNumber,
-			PartUUID: partUUID,
-		}
-	)
-
-	// TODO: temporary solution because of ephemeral volumes volume id - https://github.com/dell/csi-baremetal/issues/87
-	if vol.Ephemeral {
-		part.PartUUID, err = d.partOps.GetPartitionUUID(device, DefaultPartitionNumber)
-		if err != nil {
-			return d.wipeDevice(device,
-				fmt.Errorf("unable to determine partition UUID for ephemeral volume: %v", err), ll)
-		}
-	}
-
-	part.Name = d.partOps.SearchPartName(device, part.PartUUID)
-	if part.Name == "" {
-		return d.wipeDevice(device,
-			fmt.Errorf("unable to find partition name for volume %s", vol.Id), ll)
-	}
-
-	// wipe FS on partition
-	if err = d.fsOps.WipeFS(part.GetFullPath()); err != nil {
-		return err
-	}
-
-	err = d.partOps.ReleasePartition(part)
-	if err != nil {
-		return fmt.Errorf("unable to release partition: %v", err)
-	}
-
-	// wipe all superblocks (wipe partition table signature)
-	return d.fsOps.WipeFS(device)
-}
-
-// wipeDevice check is there any partition on device or not,
-// if there are no partition - wipe device and return nil, if any - returns error that had been provided
-// device - device to check, err - error to return, ll - logger for logging
-func (d *DriveProvisioner) wipeDevice(device string, err error, ll *logrus.Entry) error {
-	// DriveProvisioner assumes that there could be only one partition per drive
-	bdevs, sErr := d.listBlk.GetBlockDevices(device)
-	if sErr == nil && (len(bdevs) == 0 || bdevs[0].Children == nil) {
-		ll.Infof("There are no any partition on device %s. Partition has been already removed", device)
-		return d.fsOps.WipeFS(device) // wipe partition table
-	}
-	return err
+	return d.getPartitioner(vol).ReleasePartition(device, vol)
 }
 
 // GetVolumePath constructs full partition path - /dev/DEVICE_NAME+PARTITION_NAME
@@ -215,19 +154,31 @@ func (d *DriveProvisioner) GetVolumePath(vol api.Volume) (string, error) {
 	}
 	ll.Debugf("Got device %s", device)
 
-	var volumeUUID = vol.Id
-	// TODO: temporary solution because of ephemeral volumes volume id - https://github.com/dell/csi-baremetal/issues/87
-	if vol.Ephemeral {
-		volumeUUID, err = d.partOps.GetPartitionUUID(device, DefaultPartitionNumber)
-		if err != nil {
-			return "", fmt.Errorf("unable to determine partition UUID: %v", err)
-		}
+	return d.getPartitioner(vol).GetPartitionPath(device, vol)
+}
+
+// ExpandVolume isn't supported for volumes based on drives
+func (d *DriveProvisioner) ExpandVolume(vol api.Volume) error {
+	return fmt.Errorf("volume %s based on drive can't be expanded", vol.Id)
+}
+
+// getPartitioner returns Partitioner for partition layout of the volume, single partition is used by default
+func (d *DriveProvisioner) getPartitioner(vol api.Volume) Partitioner {
+	switch vol.PartitionLayout {
+	case apiV1.PartitionLayoutWholeDisk:
+		return &wholeDiskPartitioner{fsOps: d.fsOps}
+	case apiV1.PartitionLayoutMulti:
+		return &multiPartitioner{fsOps: d.fsOps, partOps: d.partOps, log: d.log}
 	}
-	volumeUUID, _ = util.GetVolumeUUID(volumeUUID)
+	return &singlePartitioner{listBlk: d.listBlk, fsOps: d.fsOps, partOps: d.partOps, log: d.log}
+}
 
-	partNum := d.partOps.SearchPartName(device, volumeUUID)
-	if partNum == "" {
-		return "", fmt.Errorf("unable to find part name for device %s by uuid %s", device, volumeUUID)
+// getPartitionUUID returns GUID of the partition on which volume is based,
+// GUID of ephemeral volume partition is derived from volume ID
+func getPartitionUUID(vol api.Volume) string {
+	if vol.Ephemeral {
+		return util.GetEphemeralVolumeUUID(vol.Id)
 	}
-	return device + partNum, nil
+	partUUID, _ := util.GetVolumeUUID(vol.Id)
+	return partUUID
 }
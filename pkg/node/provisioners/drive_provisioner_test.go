@@ -29,6 +29,7 @@ import (
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/partitionhelper"
+	"github.com/dell/csi-baremetal/pkg/base/util"
 	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
 	mockProv "github.com/dell/csi-baremetal/pkg/mocks/provisioners"
 	uw "github.com/dell/csi-baremetal/pkg/node/provisioners/utilwrappers"
@@ -160,7 +161,6 @@ func TestDriveProvisioner_ReleaseVolume_Success(t *testing.T) {
 			TableType: "",
 			Label:     "",
 			PartUUID:  testVolume2.Id,
-			Ephemeral: false,
 		}
 	)
 
@@ -281,6 +281,30 @@ func TestDriveProvisioner_GetVolumePath_Success(t *testing.T) {
 	assert.Equal(t, deviceFile+partName, fullPath)
 }
 
+func TestDriveProvisioner_GetVolumePath_Ephemeral(t *testing.T) {
+	var (
+		dp, mockLsblk, mockPH, _ = setupTestDriveProvisioner()
+		deviceFile               = "/dev/sda"
+		partName                 = "p1"
+		vol                      = testVolume2
+	)
+
+	err := dp.k8sClient.CreateCR(testCtx, testDriveCR.Name, &testDriveCR)
+	assert.Nil(t, err)
+
+	// partition GUID of ephemeral volume is derived from volume ID
+	vol.Id = "csi-d6b4a5c3e2f1"
+	vol.Ephemeral = true
+	mockLsblk.On("SearchDrivePath", mock.Anything).Return(deviceFile, nil).Once()
+	mockPH.On("SearchPartName", deviceFile, util.GetEphemeralVolumeUUID(vol.Id)).
+		Return(partName, nil).Once()
+
+	fullPath, err := dp.GetVolumePath(vol)
+	assert.Nil(t, err)
+	assert.Equal(t, deviceFile+partName, fullPath)
+	mockPH.AssertNotCalled(t, "GetPartitionUUID", mock.Anything, mock.Anything)
+}
+
 func TestDriveProvisioner_GetVolumePath_Fail(t *testing.T) {
 	var (
 		dp, mockLsblk, mockPH, _ = setupTestDriveProvisioner()
@@ -121,6 +121,29 @@ func (l *LVMProvisioner) ReleaseVolume(vol api.Volume) error {
 	return l.lvmOps.LVRemove(deviceFile)
 }
 
+// ExpandVolume search volume group based on vol attributes and expands Logical Volume up to vol size
+func (l *LVMProvisioner) ExpandVolume(vol api.Volume) error {
+	ll := l.log.WithFields(logrus.Fields{
+		"method":   "ExpandVolume",
+		"volumeID": vol.Id,
+	})
+
+	deviceFile, err := l.GetVolumePath(vol)
+	if err != nil {
+		return fmt.Errorf("unable to determine full path of the volume: %v", err)
+	}
+
+	// prepare size in megabytes for the argument
+	size, _ := util.ToSizeUnit(vol.Size, util.BYTE, util.MBYTE)
+	sizeStr := strconv.FormatInt(size, 10) + "m"
+
+	ll.Infof("Expanding LV %s up to %s", deviceFile, sizeStr)
+	if err = l.lvmOps.LVExpand(deviceFile, sizeStr); err != nil {
+		return fmt.Errorf("unable to expand LV: %v", err)
+	}
+	return nil
+}
+
 // GetVolumePath search Volume Group name by vol attributes and construct
 // full path to the volume using template: /dev/VG_NAME/LV_NAME
 func (l *LVMProvisioner) GetVolumePath(vol api.Volume) (string, error) {
@@ -27,6 +27,7 @@ import (
 	"github.com/dell/csi-baremetal/pkg/base/command"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
+	"github.com/dell/csi-baremetal/pkg/base/util"
 	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
 	mockProv "github.com/dell/csi-baremetal/pkg/mocks/provisioners"
 )
@@ -173,6 +174,21 @@ func TestLVMProvisioner_GetVolumePath_Success(t *testing.T) {
 	assert.Equal(t, expectedPath, currentPath)
 }
 
+func TestLVMProvisioner_ExpandVolume(t *testing.T) {
+	setupTestLVMProvisioner()
+
+	vol := testVolume1
+	vol.Size = int64(util.GBYTE)
+	devFile := fmt.Sprintf("/dev/%s/%s", vol.Location, vol.Id)
+	lvmOps.On("LVExpand", devFile, "1024m").Return(nil).Once()
+	assert.Nil(t, lp.ExpandVolume(vol))
+
+	lvmOps.On("LVExpand", devFile, "1024m").Return(errTest).Once()
+	err := lp.ExpandVolume(vol)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unable to expand LV")
+}
+
 func TestLVMProvisioner_getVGName_Success(t *testing.T) {
 	setupTestLVMProvisioner()
 
@@ -0,0 +1,237 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provisioners
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsblk"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/partitionhelper"
+	uw "github.com/dell/csi-baremetal/pkg/node/provisioners/utilwrappers"
+)
+
+// Partitioner prepares block device for volume on the drive according to partition layout of the volume
+type Partitioner interface {
+	// PreparePartition creates partition for volume on device if it doesn't exist
+	// Returns full path of block device on which file system could be created
+	PreparePartition(device string, vol api.Volume) (string, error)
+	// ReleasePartition wipes file system and removes partition of volume from device
+	ReleasePartition(device string, vol api.Volume) error
+	// GetPartitionPath returns full path of block device of volume
+	GetPartitionPath(device string, vol api.Volume) (string, error)
+}
+
+// singlePartitioner creates the only GPT partition which takes the whole drive
+type singlePartitioner struct {
+	listBlk lsblk.WrapLsblk
+	fsOps   fs.WrapFS
+	partOps uw.PartitionOperations
+	log     *logrus.Entry
+}
+
+// PreparePartition creates GPT partition table and partition on the whole device
+func (p *singlePartitioner) PreparePartition(device string, vol api.Volume) (string, error) {
+	ll := p.log.WithFields(logrus.Fields{
+		"method":   "PreparePartition",
+		"volumeID": vol.Id,
+	})
+
+	part := uw.Partition{
+		Device:    device,
+		TableType: partitionhelper.PartitionGPT,
+		Label:     DefaultPartitionLabel,
+		Num:       DefaultPartitionNumber,
+		PartUUID:  getPartitionUUID(vol),
+	}
+
+	ll.Infof("Create partition %v on device %s and set UUID", part, device)
+	partPtr, err := p.partOps.PreparePartition(part)
+	if err != nil {
+		ll.Errorf("Unable to prepare partition: %v", err)
+		return "", fmt.Errorf("unable to prepare partition for volume %v", vol)
+	}
+	ll.Infof("Partition was created successfully %v", partPtr)
+	return partPtr.GetFullPath(), nil
+}
+
+// ReleasePartition removes partition and partition table from device
+func (p *singlePartitioner) ReleasePartition(device string, vol api.Volume) error {
+	ll := p.log.WithFields(logrus.Fields{
+		"method":   "ReleasePartition",
+		"volumeID": vol.Id,
+	})
+
+	part := uw.Partition{
+		Device:   device,
+		Num:      DefaultPartitionNumber,
+		PartUUID: getPartitionUUID(vol),
+	}
+	part.Name = p.partOps.SearchPartName(device, part.PartUUID)
+	if part.Name == "" {
+		return p.wipeDevice(device,
+			fmt.Errorf("unable to find partition name for volume %s", vol.Id), ll)
+	}
+	// wipe FS on partition
+	if err := p.fsOps.WipeFS(part.GetFullPath()); err != nil {
+		return err
+	}
+
+	if err := p.partOps.ReleasePartition(part); err != nil {
+		return fmt.Errorf("unable to release partition: %v", err)
+	}
+
+	// wipe all superblocks (wipe partition table signature)
+	return p.fsOps.WipeFS(device)
+}
+
+// wipeDevice check is there any partition on device or not,
+// if there are no partition - wipe device and return nil, if any - returns error that had been provided
+// device - device to check, err - error to return, ll - logger for logging
+func (p *singlePartitioner) wipeDevice(device string, err error, ll *logrus.Entry) error {
+	// singlePartitioner assumes that there could be only one partition per drive
+	bdevs, sErr := p.listBlk.GetBlockDevices(device)
+	if sErr == nil && (len(bdevs) == 0 || bdevs[0].Children == nil) {
+		ll.Infof("There are no any partition on device %s. Partition has been already removed", device)
+		return p.fsOps.WipeFS(device) // wipe partition table
+	}
+	return err
+}
+
+// GetPartitionPath searches partition of volume by its UUID
+func (p *singlePartitioner) GetPartitionPath(device string, vol api.Volume) (string, error) {
+	volumeUUID := getPartitionUUID(vol)
+	partNum := p.partOps.SearchPartName(device, volumeUUID)
+	if partNum == "" {
+		return "", fmt.Errorf("unable to find part name for device %s by uuid %s", device, volumeUUID)
+	}
+	return device + partNum, nil
+}
+
+// wholeDiskPartitioner uses the whole device for volume without partition table
+type wholeDiskPartitioner struct {
+	fsOps fs.WrapFS
+}
+
+// PreparePartition returns device itself
+func (p *wholeDiskPartitioner) PreparePartition(device string, _ api.Volume) (string, error) {
+	return device, nil
+}
+
+// ReleasePartition wipes file system on device
+func (p *wholeDiskPartitioner) ReleasePartition(device string, _ api.Volume) error {
+	return p.fsOps.WipeFS(device)
+}
+
+// GetPartitionPath returns device itself
+func (p *wholeDiskPartitioner) GetPartitionPath(device string, _ api.Volume) (string, error) {
+	return device, nil
+}
+
+// multiPartitioner creates GPT partition of volume size, so several volumes could share the drive
+type multiPartitioner struct {
+	fsOps   fs.WrapFS
+	partOps uw.PartitionOperations
+	log     *logrus.Entry
+}
+
+// PreparePartition creates GPT partition table if device doesn't have partitions
+// and partition of volume size in the largest free block of device
+func (p *multiPartitioner) PreparePartition(device string, vol api.Volume) (string, error) {
+	ll := p.log.WithFields(logrus.Fields{
+		"method":   "PreparePartition",
+		"volumeID": vol.Id,
+	})
+
+	partUUID := getPartitionUUID(vol)
+	if name, err := p.partOps.GetPartitionNameByUUID(device, partUUID); err == nil && name != "" {
+		ll.Infof("Partition %s has already prepared", device+name)
+		return device + name, nil
+	}
+
+	exist, err := p.partOps.IsPartitionExists(device, "")
+	if err != nil {
+		return "", fmt.Errorf("unable to determine partitions existence: %v", err)
+	}
+	if !exist {
+		if err = p.partOps.CreatePartitionTable(device, partitionhelper.PartitionGPT); err != nil {
+			return "", fmt.Errorf("unable to create partition table: %v", err)
+		}
+	}
+
+	ll.Infof("Create partition of size %d on device %s", vol.Size, device)
+	if err = p.partOps.CreatePartitionWithSize(device, DefaultPartitionLabel, partUUID, vol.Size); err != nil {
+		return "", err
+	}
+
+	name := p.partOps.SearchPartName(device, partUUID)
+	if name == "" {
+		return "", fmt.Errorf("unable to determine partition name after it being created on device %s", device)
+	}
+	ll.Infof("Partition %s was created successfully", device+name)
+	return device + name, nil
+}
+
+// ReleasePartition removes partition of volume, partition table is wiped when the last partition is removed
+func (p *multiPartitioner) ReleasePartition(device string, vol api.Volume) error {
+	ll := p.log.WithFields(logrus.Fields{
+		"method":   "ReleasePartition",
+		"volumeID": vol.Id,
+	})
+
+	name := p.partOps.SearchPartName(device, getPartitionUUID(vol))
+	if name == "" {
+		ll.Infof("Partition of volume isn't found on device %s. Partition has been already removed", device)
+		return nil
+	}
+
+	if err := p.fsOps.WipeFS(device + name); err != nil {
+		return err
+	}
+	if err := p.partOps.DeletePartition(device, partitionNumber(name)); err != nil {
+		return fmt.Errorf("unable to release partition: %v", err)
+	}
+
+	exist, err := p.partOps.IsPartitionExists(device, "")
+	if err != nil || exist {
+		return nil
+	}
+	// wipe partition table signature after the last partition
+	return p.fsOps.WipeFS(device)
+}
+
+// GetPartitionPath searches partition of volume by its UUID
+func (p *multiPartitioner) GetPartitionPath(device string, vol api.Volume) (string, error) {
+	volumeUUID := getPartitionUUID(vol)
+	name := p.partOps.SearchPartName(device, volumeUUID)
+	if name == "" {
+		return "", fmt.Errorf("unable to find part name for device %s by uuid %s", device, volumeUUID)
+	}
+	return device + name, nil
+}
+
+// partitionNumber returns number of partition from its name, e.g. "2" for "p2" of /dev/nvme0n1p2
+func partitionNumber(name string) string {
+	i := len(name)
+	for i > 0 && name[i-1] >= '0' && name[i-1] <= '9' {
+		i--
+	}
+	return name[i:]
+}
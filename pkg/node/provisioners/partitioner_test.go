@@ -0,0 +1,137 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provisioners
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/partitionhelper"
+	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
+	mockProv "github.com/dell/csi-baremetal/pkg/mocks/provisioners"
+)
+
+func TestDriveProvisioner_getPartitioner(t *testing.T) {
+	dp, _, _, _ := setupTestDriveProvisioner()
+	vol := testVolume2
+
+	vol.PartitionLayout = ""
+	assert.IsType(t, &singlePartitioner{}, dp.getPartitioner(vol))
+	vol.PartitionLayout = apiV1.PartitionLayoutSingle
+	assert.IsType(t, &singlePartitioner{}, dp.getPartitioner(vol))
+	vol.PartitionLayout = apiV1.PartitionLayoutWholeDisk
+	assert.IsType(t, &wholeDiskPartitioner{}, dp.getPartitioner(vol))
+	vol.PartitionLayout = apiV1.PartitionLayoutMulti
+	assert.IsType(t, &multiPartitioner{}, dp.getPartitioner(vol))
+}
+
+func TestWholeDiskPartitioner(t *testing.T) {
+	var (
+		mockFS = &mocklu.MockWrapFS{}
+		p      = &wholeDiskPartitioner{fsOps: mockFS}
+		device = "/dev/sda"
+	)
+
+	path, err := p.PreparePartition(device, testVolume2)
+	assert.Nil(t, err)
+	assert.Equal(t, device, path)
+
+	path, err = p.GetPartitionPath(device, testVolume2)
+	assert.Nil(t, err)
+	assert.Equal(t, device, path)
+
+	mockFS.On("WipeFS", device).Return(errTest).Once()
+	assert.Equal(t, errTest, p.ReleasePartition(device, testVolume2))
+}
+
+func TestMultiPartitioner_PreparePartition(t *testing.T) {
+	var (
+		mockPH = &mockProv.MockPartitionOps{}
+		p      = &multiPartitioner{fsOps: &mocklu.MockWrapFS{}, partOps: mockPH, log: logrus.NewEntry(testLogger)}
+		device = "/dev/sda"
+	)
+
+	// partition has been already created
+	mockPH.MockWrapPartition.On("GetPartitionNameByUUID", device, testVolume2.Id).Return("2", nil).Once()
+	path, err := p.PreparePartition(device, testVolume2)
+	assert.Nil(t, err)
+	assert.Equal(t, device+"2", path)
+
+	// first partition on the device
+	mockPH.MockWrapPartition.On("GetPartitionNameByUUID", device, testVolume2.Id).Return("", errTest)
+	mockPH.MockWrapPartition.On("IsPartitionExists", device, "").Return(false, nil).Once()
+	mockPH.MockWrapPartition.On("CreatePartitionTable", device, partitionhelper.PartitionGPT).Return(nil).Once()
+	mockPH.MockWrapPartition.On("CreatePartitionWithSize", device, DefaultPartitionLabel, testVolume2.Id, testVolume2.Size).
+		Return(nil).Once()
+	mockPH.On("SearchPartName", device, testVolume2.Id).Return("1").Once()
+	path, err = p.PreparePartition(device, testVolume2)
+	assert.Nil(t, err)
+	assert.Equal(t, device+"1", path)
+
+	// CreatePartitionWithSize failed
+	mockPH.MockWrapPartition.On("IsPartitionExists", device, "").Return(true, nil).Once()
+	mockPH.MockWrapPartition.On("CreatePartitionWithSize", device, DefaultPartitionLabel, testVolume2.Id, testVolume2.Size).
+		Return(errTest).Once()
+	_, err = p.PreparePartition(device, testVolume2)
+	assert.Equal(t, errTest, err)
+}
+
+func TestMultiPartitioner_ReleasePartition(t *testing.T) {
+	var (
+		mockPH = &mockProv.MockPartitionOps{}
+		mockFS = &mocklu.MockWrapFS{}
+		p      = &multiPartitioner{fsOps: mockFS, partOps: mockPH, log: logrus.NewEntry(testLogger)}
+		device = "/dev/nvme0n1"
+	)
+
+	// other partitions remain on the device
+	mockPH.On("SearchPartName", device, testVolume2.Id).Return("p2").Once()
+	mockFS.On("WipeFS", device+"p2").Return(nil).Once()
+	mockPH.MockWrapPartition.On("DeletePartition", device, "2").Return(nil).Once()
+	mockPH.MockWrapPartition.On("IsPartitionExists", device, "").Return(true, nil).Once()
+	assert.Nil(t, p.ReleasePartition(device, testVolume2))
+	mockFS.AssertNotCalled(t, "WipeFS", device)
+
+	// the last partition is removed
+	mockPH.On("SearchPartName", device, testVolume2.Id).Return("p2").Once()
+	mockFS.On("WipeFS", device+"p2").Return(nil).Once()
+	mockPH.MockWrapPartition.On("DeletePartition", device, "2").Return(nil).Once()
+	mockPH.MockWrapPartition.On("IsPartitionExists", device, "").Return(false, nil).Once()
+	mockFS.On("WipeFS", device).Return(nil).Once()
+	assert.Nil(t, p.ReleasePartition(device, testVolume2))
+
+	// partition has been already removed
+	mockPH.On("SearchPartName", device, testVolume2.Id).Return("").Once()
+	assert.Nil(t, p.ReleasePartition(device, testVolume2))
+
+	// DeletePartition failed
+	mockPH.On("SearchPartName", device, testVolume2.Id).Return("p2").Once()
+	mockFS.On("WipeFS", device+"p2").Return(nil).Once()
+	mockPH.MockWrapPartition.On("DeletePartition", device, "2").Return(errTest).Once()
+	err := p.ReleasePartition(device, testVolume2)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to release partition")
+}
+
+func TestPartitionNumber(t *testing.T) {
+	assert.Equal(t, "1", partitionNumber("1"))
+	assert.Equal(t, "12", partitionNumber("p12"))
+	assert.Equal(t, "", partitionNumber(""))
+}
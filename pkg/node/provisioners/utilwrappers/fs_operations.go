@@ -19,6 +19,7 @@ package utilwrappers
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 
@@ -30,7 +31,7 @@ import (
 type FSOperations interface {
 	// PrepareAndPerformMount composite methods which is prepare source and destination directories
 	// and performs mount operation from src to dst
-	PrepareAndPerformMount(src, dst string, bindMount bool) error
+	PrepareAndPerformMount(src, dst string, bindMount, readOnly bool) error
 	// UnmountWithCheck unmount operation
 	UnmountWithCheck(path string) error
 	fs.WrapFS
@@ -53,8 +54,9 @@ func NewFSOperationsImpl(e command.CmdExecutor, log *logrus.Logger) *FSOperation
 // PrepareAndPerformMount (idempotent) implementation of FSOperations method
 // create (if isn't exist) dst folder on node and perform mount from src to dst
 // if bindMount set to true - mount operation will contain "--bind" option
+// if readOnly set to true - dst will be mounted read-only
 // if error occurs and dst has created during current method call then dst will be removed
-func (fsOp *FSOperationsImpl) PrepareAndPerformMount(src, dst string, bindMount bool) error {
+func (fsOp *FSOperationsImpl) PrepareAndPerformMount(src, dst string, bindMount, readOnly bool) error {
 	ll := fsOp.log.WithFields(logrus.Fields{
 		"method": "PrepareAndPerformMount",
 	})
@@ -90,6 +92,9 @@ func (fsOp *FSOperationsImpl) PrepareAndPerformMount(src, dst string, bindMount
 	if bindMount {
 		opts = fs.BindOption
 	}
+	if readOnly {
+		opts = strings.TrimSpace(opts + " " + fs.ReadOnlyOption)
+	}
 	if err := fsOp.Mount(src, dst, opts); err != nil {
 		if wasCreated {
 			_ = fsOp.RmDir(dst)
@@ -18,7 +18,6 @@ package utilwrappers
 
 import (
 	"fmt"
-	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -38,12 +37,8 @@ type PartitionOperations interface {
 	ph.WrapPartition
 }
 
-const (
-	// NumberOfRetriesToSyncPartTable how many times to sync fs tab
-	NumberOfRetriesToSyncPartTable = 3
-	// SleepBetweenRetriesToSyncPartTable default timeout between fs tab sync attempt
-	SleepBetweenRetriesToSyncPartTable = 3 * time.Second
-)
+// NumberOfRetriesToSyncPartTable how many times to sync fs tab
+const NumberOfRetriesToSyncPartTable = 3
 
 // Partition is hold all attributes of partition on block device
 type Partition struct {
@@ -53,7 +48,6 @@ type Partition struct {
 	TableType string
 	Label     string
 	PartUUID  string
-	Ephemeral bool
 }
 
 // GetFullPath return full path of partition, that path could be used for file system operations
@@ -112,12 +106,7 @@ func (d *PartitionOperationsImpl) PreparePartition(p Partition) (*Partition, err
 	}
 	_ = d.SyncPartitionTable(p.Device)
 
-	if p.Ephemeral {
-		p.PartUUID, err = d.GetPartitionUUID(p.Device, p.Num)
-		if err != nil {
-			return nil, fmt.Errorf("unable to get partition UUID for ephemeral volume: %v", err)
-		}
-	} else if err = d.SetPartitionUUID(p.Device, p.Num, p.PartUUID); err != nil {
+	if err = d.SetPartitionUUID(p.Device, p.Num, p.PartUUID); err != nil {
 		return nil, fmt.Errorf("unable to set partition UUID: %v", err)
 	}
 
@@ -162,13 +151,12 @@ func (d *PartitionOperationsImpl) SearchPartName(device, partUUID string) string
 
 	// get partition name
 	for i := 0; i < NumberOfRetriesToSyncPartTable; i++ {
-		// sync partition table
+		// sync partition table, it waits until udev processes partition table change
 		err = d.SyncPartitionTable(device)
 		if err != nil {
 			// log and ignore error
 			ll.Warningf("Unable to sync partition table for device %s", device)
 		}
-		time.Sleep(SleepBetweenRetriesToSyncPartTable)
 		partName, err = d.GetPartitionNameByUUID(device, partUUID)
 		if err != nil {
 			ll.Debugf("unable to find part name: %v", err)
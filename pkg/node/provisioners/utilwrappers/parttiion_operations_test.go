@@ -44,7 +44,6 @@ var (
 		TableType: partitionhelper.PartitionGPT,
 		Label:     DefaultPartitionLabel,
 		PartUUID:  testPartUUID1,
-		Ephemeral: false,
 	}
 )
 
@@ -77,29 +76,20 @@ func TestDriveProvisioner_PreparePartition_Success(t *testing.T) {
 	assert.Equal(t, testPart1, *currentPPtr)
 	mockPH.Calls = []mock.Call{} // flush mock call records
 
-	// setup mocks for scenarios when partition is created and volume whether ephemeral and no
+	// partition is created
 	var partName = "p1"
 	mockPH.On("IsPartitionExists", testPart1.Device, testPart1.Num).
-		Return(false, nil).Twice()
+		Return(false, nil).Once()
 	mockPH.On("CreatePartitionTable", testPart1.Device, testPart1.TableType).
-		Return(nil).Twice()
+		Return(nil).Once()
 	mockPH.On("CreatePartition", testPart1.Device, testPart1.Label).
-		Return(nil).Twice()
-	// if volume Ephemeral
-	var partUUIDForEphemeral = "uuid-eeee"
-	mockPH.On("GetPartitionUUID", testPart1.Device, testPart1.Num).
-		Return(partUUIDForEphemeral, nil).Once()
-
-	// if volume not an Ephemeral
+		Return(nil).Once()
 	mockPH.On("SetPartitionUUID", testPart1.Device, testPart1.Num, testPart1.PartUUID).
 		Return(nil).Once()
-
-	// for each test scenario
 	mockPH.On("SyncPartitionTable", mock.Anything).Return(nil)
-
-	// not ephemeral
 	mockPH.On("GetPartitionNameByUUID", testPart1.Device, testPart1.PartUUID).
 		Return(partName, nil).Once()
+
 	currentPPtr, err = partOps.PreparePartition(testPart1)
 	assert.Nil(t, err)
 	p := testPart1
@@ -107,20 +97,6 @@ func TestDriveProvisioner_PreparePartition_Success(t *testing.T) {
 	assert.Equal(t, p, *currentPPtr)
 	mockPH.AssertCalled(t, "SetPartitionUUID", testPart1.Device, testPart1.Num, testPart1.PartUUID)
 	mockPH.AssertNotCalled(t, "GetPartitionUUID", testPart1.Device, testPart1.Num)
-	mockPH.Calls = []mock.Call{} // flush mock call records
-
-	// ephemeral
-	mockPH.On("GetPartitionNameByUUID", testPart1.Device, partUUIDForEphemeral).
-		Return(partName, nil).Once()
-	pE := testPart1
-	pE.Ephemeral = true
-	currentPPtr, err = partOps.PreparePartition(pE)
-	assert.Nil(t, err)
-	pE.Name = partName
-	pE.PartUUID = partUUIDForEphemeral
-	assert.Equal(t, pE, *currentPPtr)
-	mockPH.AssertCalled(t, "GetPartitionUUID", testPart1.Device, testPart1.Num)
-	mockPH.AssertNotCalled(t, "SetPartitionUUID", testPart1.Device, testPart1.Num, testPart1.PartUUID)
 }
 
 func TestDriveProvisioner_PreparePartition_Failed(t *testing.T) {
@@ -192,7 +168,7 @@ func TestDriveProvisioner_PreparePartition_Failed(t *testing.T) {
 	mockPH.On("SyncPartitionTable", mock.Anything).
 		Return(nil)
 
-	// SetPartitionUUID failed for non-ephemeral
+	// SetPartitionUUID failed
 	mockPH.On("SetPartitionUUID", testPart1.Device, testPart1.Num, testPart1.PartUUID).
 		Return(expectedErr).Once()
 
@@ -200,17 +176,6 @@ func TestDriveProvisioner_PreparePartition_Failed(t *testing.T) {
 	assert.Nil(t, currentPPtr)
 	assert.NotNil(t, err)
 	assert.Contains(t, err.Error(), "unable to set partition UUID")
-
-	// GetPartitionUUID failed for ephemeral
-	mockPH.On("GetPartitionUUID", testPart1.Device, testPart1.Num).
-		Return("", expectedErr).Once()
-
-	pE := testPart1
-	pE.Ephemeral = true
-	currentPPtr, err = partOps.PreparePartition(pE)
-	assert.Nil(t, currentPPtr)
-	assert.NotNil(t, err)
-	assert.Contains(t, err.Error(), "unable to get partition UUID for ephemeral volume")
 }
 
 func TestDriveProvisioner_ReleasePartition_Success(t *testing.T) {
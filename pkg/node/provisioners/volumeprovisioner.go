@@ -41,4 +41,6 @@ type Provisioner interface {
 	ReleaseVolume(volume api.Volume) error
 	// Return full path of device file that represent volume on node
 	GetVolumePath(volume api.Volume) (string, error)
+	// Expand underlying device of volume up to volume size, file system isn't grown
+	ExpandVolume(volume api.Volume) error
 }
@@ -0,0 +1,98 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/eventing"
+)
+
+// recoverVolumes returns volumes which were marked as MISSING while node was down, e.g. during OS reinstallation,
+// back to operation if their storage is found on drives: partition by its GUID or logical volume in LVG.
+// Mounts don't survive reinstallation, so staged and published volumes are flipped back to Created
+// and kubelet stages and publishes them again
+// Returns error if Volume CRs can't be read or some of them can't be updated
+func (m *VolumeManager) recoverVolumes(ctx context.Context) error {
+	ll := m.log.WithField("method", "recoverVolumes")
+
+	volumes, err := m.crHelper.GetVolumeCRs(m.nodeID)
+	if err != nil {
+		return err
+	}
+
+	wasError := false
+	for i := range volumes {
+		volume := &volumes[i]
+		if volume.Spec.OperationalStatus != apiV1.OperationalStatusMissing || !volume.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		if err = m.findVolumeStorage(&volume.Spec); err != nil {
+			ll.Warnf("Storage of volume %s isn't found, it remains %s: %v",
+				volume.Spec.Id, apiV1.OperationalStatusMissing, err)
+			continue
+		}
+
+		prevStatus := volume.Spec.CSIStatus
+		volume.Spec.OperationalStatus = apiV1.OperationalStatusOperative
+		if prevStatus == apiV1.VolumeReady || prevStatus == apiV1.Published {
+			volume.Spec.CSIStatus = apiV1.Created
+		}
+		ll.Infof("Recovering volume %s, CSI status %s -> %s", volume.Spec.Id, prevStatus, volume.Spec.CSIStatus)
+		if err = m.k8sClient.UpdateCR(context.WithValue(ctx, base.RequestUUID, volume.Name), volume); err != nil {
+			ll.Errorf("Unable to update volume %s: %v", volume.Name, err)
+			wasError = true
+			continue
+		}
+		m.sendEventForVolume(volume, eventing.InfoType, eventing.VolumeRecovered,
+			"Volume storage was found on %s, CSI status %s was changed to %s",
+			volume.Spec.Location, prevStatus, volume.Spec.CSIStatus)
+	}
+
+	if wasError {
+		return errors.New("not all volumes were recovered")
+	}
+	return nil
+}
+
+// findVolumeStorage checks whether storage of the volume exists on the node
+// Returns error if partition or logical volume isn't found
+func (m *VolumeManager) findVolumeStorage(vol *api.Volume) error {
+	if util.IsStorageClassLVG(vol.StorageClass) {
+		vgName, err := m.lvmOps.FindVgNameByLvName(vol.Id)
+		if err != nil {
+			return err
+		}
+		if vgName == "" {
+			return fmt.Errorf("logical volume %s isn't found", vol.Id)
+		}
+		return nil
+	}
+
+	// partition is searched by GUID which is derived from volume
+	_, err := m.getProvisionerForVolume(vol).GetVolumePath(*vol)
+	return err
+}
@@ -0,0 +1,86 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	vcrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/eventing"
+	"github.com/dell/csi-baremetal/pkg/mocks"
+	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
+	mockProv "github.com/dell/csi-baremetal/pkg/mocks/provisioners"
+	p "github.com/dell/csi-baremetal/pkg/node/provisioners"
+)
+
+func TestVolumeManager_recoverVolumes(t *testing.T) {
+	var (
+		vm      = prepareSuccessVolumeManager(t)
+		pMock   = &mockProv.MockProvisioner{}
+		lvmMock = &mocklu.MockWrapLVM{}
+		// partition of volume is found on drive
+		found = testVolumeCR1.DeepCopy()
+		// partition of volume isn't found, e.g. drive was wiped
+		lost = testVolumeCR2.DeepCopy()
+		// logical volume is found in LVG
+		lv = testVolumeCR3.DeepCopy()
+		// volume wasn't marked as missing
+		operative = volCR.DeepCopy()
+	)
+	found.Spec.OperationalStatus = apiV1.OperationalStatusMissing
+	found.Spec.CSIStatus = apiV1.Published
+	lost.Spec.OperationalStatus = apiV1.OperationalStatusMissing
+	lv.Spec.OperationalStatus = apiV1.OperationalStatusMissing
+	lv.Spec.StorageClass = apiV1.StorageClassHDDLVG
+	lv.Spec.CSIStatus = apiV1.VolumeReady
+	operative.Spec.OperationalStatus = apiV1.OperationalStatusOperative
+	operative.Spec.CSIStatus = apiV1.Published
+	for _, v := range []*vcrd.Volume{found, lost, lv, operative} {
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, v.Name, v))
+	}
+
+	pMock.On("GetVolumePath", found.Spec).Return("/dev/sda1", nil)
+	pMock.On("GetVolumePath", lost.Spec).Return("", testErr)
+	lvmMock.On("FindVgNameByLvName", lv.Spec.Id).Return("hdd-lvg", nil)
+	vm.SetProvisioners(map[p.VolumeType]p.Provisioner{p.DriveBasedVolumeType: pMock})
+	vm.lvmOps = lvmMock
+
+	assert.Nil(t, vm.recoverVolumes(testCtx))
+
+	volume := &vcrd.Volume{}
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, found.Name, volume))
+	assert.Equal(t, apiV1.OperationalStatusOperative, volume.Spec.OperationalStatus)
+	assert.Equal(t, apiV1.Created, volume.Spec.CSIStatus)
+
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, lost.Name, volume))
+	assert.Equal(t, apiV1.OperationalStatusMissing, volume.Spec.OperationalStatus)
+	assert.Equal(t, apiV1.Created, volume.Spec.CSIStatus)
+
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, lv.Name, volume))
+	assert.Equal(t, apiV1.OperationalStatusOperative, volume.Spec.OperationalStatus)
+	assert.Equal(t, apiV1.Created, volume.Spec.CSIStatus)
+
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, operative.Name, volume))
+	assert.Equal(t, apiV1.Published, volume.Spec.CSIStatus)
+
+	recorder := vm.recorder.(*mocks.NoOpRecorder)
+	assert.Len(t, recorder.Calls, 2)
+	assert.Equal(t, eventing.VolumeRecovered, recorder.Calls[0].Reason)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/dell/csi-baremetal/pkg/base"
+)
+
+// excludedSizeAnnotation holds size of AvailableCapacity which was zeroed when node left the storage pool
+const excludedSizeAnnotation = "baremetal-csi.dellemc.com/excluded-size"
+
+// StoragePoolFilter defines which k8s nodes participate in the storage pool of the driver
+type StoragePoolFilter struct {
+	// NodeName is the name of k8s Node object on which VolumeManager works
+	NodeName string
+	// Selector is matched against labels of k8s Node, all nodes participate if it is nil
+	Selector labels.Selector
+	// ExcludeTaints holds keys of taints which exclude k8s Node from the storage pool
+	ExcludeTaints []string
+}
+
+// SetStoragePoolFilter sets filter which restricts participation of the node in the storage pool
+func (m *VolumeManager) SetStoragePoolFilter(filter *StoragePoolFilter) {
+	m.poolFilter = filter
+}
+
+// isInStoragePool checks whether labels and taints of k8s Node allow it to participate in the storage pool
+// Returns true if filter isn't set or node matches it, error if k8s Node can't be read
+func (m *VolumeManager) isInStoragePool(ctx context.Context) (bool, error) {
+	if m.poolFilter == nil {
+		return true, nil
+	}
+
+	k8sNode := &coreV1.Node{}
+	if err := m.k8sClient.ReadCR(ctx, m.poolFilter.NodeName, k8sNode); err != nil {
+		return false, err
+	}
+
+	if m.poolFilter.Selector != nil && !m.poolFilter.Selector.Matches(labels.Set(k8sNode.Labels)) {
+		return false, nil
+	}
+	for _, taint := range k8sNode.Spec.Taints {
+		for _, key := range m.poolFilter.ExcludeTaints {
+			if taint.Key == key {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// updateACsParticipation zeroes AvailableCapacities of the node when it leaves the storage pool
+// and restores them when it joins the storage pool again
+// Receives golang context and whether node is in the storage pool
+// Returns error if ACs can't be read or updated
+func (m *VolumeManager) updateACsParticipation(ctx context.Context, inPool bool) error {
+	ll := m.log.WithFields(logrus.Fields{
+		"method": "updateACsParticipation",
+		"inPool": inPool,
+	})
+
+	acs, err := m.crHelper.GetACCRs(m.nodeID)
+	if err != nil {
+		return err
+	}
+
+	for i := range acs {
+		ac := &acs[i]
+		saved, excluded := ac.Annotations[excludedSizeAnnotation]
+		switch {
+		case !inPool && !excluded:
+			if ac.Annotations == nil {
+				ac.Annotations = make(map[string]string, 1)
+			}
+			ac.Annotations[excludedSizeAnnotation] = strconv.FormatInt(ac.Spec.Size, 10)
+			ac.Spec.Size = 0
+		case inPool && excluded:
+			size, err := strconv.ParseInt(saved, 10, 64)
+			if err != nil {
+				ll.Errorf("Unable to parse excluded size of AC %s: %v", ac.Name, err)
+				size = 0
+			}
+			// capacity which was released while node was excluded is kept in Spec.Size
+			ac.Spec.Size += size
+			delete(ac.Annotations, excludedSizeAnnotation)
+		default:
+			continue
+		}
+
+		ll.Infof("Updating AC %s, size: %d", ac.Name, ac.Spec.Size)
+		if err = m.k8sClient.UpdateCR(context.WithValue(ctx, base.RequestUUID, ac.Name), ac); err != nil {
+			ll.Errorf("Unable to update AC %s: %v", ac.Name, err)
+			return err
+		}
+	}
+	return nil
+}
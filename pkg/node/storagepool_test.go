@@ -0,0 +1,105 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/pkg/mocks"
+)
+
+const testK8sNodeName = "node-1"
+
+func TestVolumeManager_isInStoragePool(t *testing.T) {
+	vm := prepareSuccessVolumeManager(t)
+	inPool, err := vm.isInStoragePool(testCtx)
+	assert.Nil(t, err)
+	assert.True(t, inPool)
+
+	vm.SetStoragePoolFilter(&StoragePoolFilter{
+		NodeName:      testK8sNodeName,
+		Selector:      labels.SelectorFromSet(labels.Set{"storage": "jbod"}),
+		ExcludeTaints: []string{"storage/exclude"},
+	})
+	_, err = vm.isInStoragePool(testCtx)
+	assert.NotNil(t, err)
+
+	k8sNode := &coreV1.Node{ObjectMeta: k8smetav1.ObjectMeta{Name: testK8sNodeName, Namespace: testNs}}
+	assert.Nil(t, vm.k8sClient.Create(testCtx, k8sNode))
+	inPool, err = vm.isInStoragePool(testCtx)
+	assert.Nil(t, err)
+	assert.False(t, inPool)
+
+	k8sNode.Labels = map[string]string{"storage": "jbod"}
+	assert.Nil(t, vm.k8sClient.Update(testCtx, k8sNode))
+	inPool, err = vm.isInStoragePool(testCtx)
+	assert.Nil(t, err)
+	assert.True(t, inPool)
+
+	k8sNode.Spec.Taints = []coreV1.Taint{{Key: "storage/exclude", Effect: coreV1.TaintEffectNoSchedule}}
+	assert.Nil(t, vm.k8sClient.Update(testCtx, k8sNode))
+	inPool, err = vm.isInStoragePool(testCtx)
+	assert.Nil(t, err)
+	assert.False(t, inPool)
+}
+
+func TestVolumeManager_updateACsParticipation(t *testing.T) {
+	vm := prepareSuccessVolumeManager(t)
+	ac := testAC1.DeepCopy()
+	size := ac.Spec.Size
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, ac.Name, ac))
+
+	assert.Nil(t, vm.updateACsParticipation(testCtx, false))
+	stored := &accrd.AvailableCapacity{}
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, ac.Name, stored))
+	assert.Equal(t, int64(0), stored.Spec.Size)
+
+	// repeated exclusion doesn't lose saved size
+	assert.Nil(t, vm.updateACsParticipation(testCtx, false))
+
+	// capacity of deleted volume is released while node is excluded
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, ac.Name, stored))
+	stored.Spec.Size = 1024
+	assert.Nil(t, vm.k8sClient.UpdateCR(testCtx, stored))
+
+	assert.Nil(t, vm.updateACsParticipation(testCtx, true))
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, ac.Name, stored))
+	assert.Equal(t, size+1024, stored.Spec.Size)
+	assert.NotContains(t, stored.Annotations, excludedSizeAnnotation)
+}
+
+func TestVolumeManager_DiscoverExcludedNode(t *testing.T) {
+	vm := prepareSuccessVolumeManager(t)
+	vm.driveMgrClient = mocks.NewMockDriveMgrClient(getDriveMgrRespBasedOnDrives(drive1, drive2))
+	vm.SetStoragePoolFilter(&StoragePoolFilter{
+		NodeName: testK8sNodeName,
+		Selector: labels.SelectorFromSet(labels.Set{"storage": "jbod"}),
+	})
+	assert.Nil(t, vm.k8sClient.Create(testCtx,
+		&coreV1.Node{ObjectMeta: k8smetav1.ObjectMeta{Name: testK8sNodeName, Namespace: testNs}}))
+
+	assert.Nil(t, vm.Discover())
+	assert.True(t, vm.initialized)
+	assert.Empty(t, getDriveCRsListItems(t, vm.k8sClient))
+	assert.Empty(t, getACCRsListItems(t, vm.k8sClient))
+}
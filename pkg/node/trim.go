@@ -0,0 +1,138 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+)
+
+// DefaultTrimInterval is the default interval between two trims of volumes, it is the same as in fstrim.timer
+const DefaultTrimInterval = 7 * 24 * time.Hour
+
+// RunTrimming spawns goroutine which periodically trims volumes based on SSD and NVMe drives
+func (m *VolumeManager) RunTrimming(interval time.Duration) {
+	go func() {
+		for {
+			time.Sleep(interval)
+			ctx, cancelFn := context.WithTimeout(context.Background(), interval)
+			if err := m.TrimVolumes(ctx); err != nil {
+				m.log.WithField("method", "RunTrimming").Errorf("Trim finished with error: %v", err)
+			}
+			cancelFn()
+		}
+	}()
+}
+
+// TrimVolumes discards unused blocks of file systems of staged volumes which are based on SSD and NVMe drives.
+// Volumes which share the same drive or LVG are trimmed one after another to avoid simultaneous trims on the drive,
+// volumes of different drives are trimmed in parallel
+// Returns error if at least one volume wasn't trimmed
+func (m *VolumeManager) TrimVolumes(ctx context.Context) error {
+	ll := m.log.WithField("method", "TrimVolumes")
+
+	volumes, err := m.crHelper.GetVolumeCRs(m.nodeID)
+	if err != nil {
+		return err
+	}
+
+	// key - volume location which is drive UUID or LVG name
+	byLocation := make(map[string][]volumecrd.Volume)
+	for _, v := range volumes {
+		if isTrimmable(v) {
+			byLocation[v.Spec.Location] = append(byLocation[v.Spec.Location], v)
+		}
+	}
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed int
+	)
+	for location, list := range byLocation {
+		wg.Add(1)
+		go func(location string, list []volumecrd.Volume) {
+			defer wg.Done()
+			for i := range list {
+				if ctx.Err() != nil {
+					return
+				}
+				if err := m.trimVolume(&list[i]); err != nil {
+					ll.Errorf("Unable to trim volume %s on %s: %v", list[i].Spec.Id, location, err)
+					mu.Lock()
+					failed++
+					mu.Unlock()
+				}
+			}
+		}(location, list)
+	}
+	wg.Wait()
+
+	if failed > 0 {
+		return fmt.Errorf("%d volumes weren't trimmed", failed)
+	}
+	return ctx.Err()
+}
+
+// trimVolume runs fstrim on mount point of the volume device, volume which isn't mounted is skipped
+func (m *VolumeManager) trimVolume(volume *volumecrd.Volume) error {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":   "trimVolume",
+		"volumeID": volume.Spec.Id,
+	})
+
+	device, err := m.getProvisionerForVolume(&volume.Spec).GetVolumePath(volume.Spec)
+	if err != nil {
+		return err
+	}
+	mountPoint, err := m.fsOps.FindMountTarget(device)
+	if err != nil {
+		return err
+	}
+	if mountPoint == "" {
+		ll.Debugf("Device %s isn't mounted, skip it", device)
+		return nil
+	}
+	if err = m.fsOps.Trim(mountPoint); err != nil {
+		return err
+	}
+	ll.Infof("File system on %s mounted to %s was trimmed", device, mountPoint)
+	return nil
+}
+
+// isTrimmable checks whether volume is staged file system volume which is based on SSD or NVMe drive
+func isTrimmable(volume volumecrd.Volume) bool {
+	if volume.Spec.Mode != apiV1.ModeFS {
+		return false
+	}
+	if volume.Spec.CSIStatus != apiV1.VolumeReady && volume.Spec.CSIStatus != apiV1.Published {
+		return false
+	}
+	sc := volume.Spec.StorageClass
+	if subSC := util.GetSubStorageClass(sc); subSC != "" {
+		sc = subSC
+	}
+	return sc == apiV1.StorageClassSSD || sc == apiV1.StorageClassNVMe
+}
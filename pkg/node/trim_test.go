@@ -0,0 +1,68 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	vcrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
+	mockProv "github.com/dell/csi-baremetal/pkg/mocks/provisioners"
+	p "github.com/dell/csi-baremetal/pkg/node/provisioners"
+)
+
+func TestVolumeManager_TrimVolumes(t *testing.T) {
+	var (
+		vm     = prepareSuccessVolumeManager(t)
+		pMock  = &mockProv.MockProvisioner{}
+		fsOps  = &mockProv.MockFsOpts{}
+		ssd    = testVolumeCR1.DeepCopy()
+		hdd    = testVolumeCR2.DeepCopy()
+		lv     = testVolumeCR3.DeepCopy()
+		block  = volCR.DeepCopy()
+		staged = []*vcrd.Volume{ssd, hdd, lv, block}
+	)
+	for _, v := range staged {
+		v.Spec.Mode = apiV1.ModeFS
+		v.Spec.CSIStatus = apiV1.Published
+		v.Spec.StorageClass = apiV1.StorageClassSSD
+	}
+	hdd.Spec.StorageClass = apiV1.StorageClassHDD
+	lv.Spec.StorageClass = apiV1.StorageClassNVMeLVG
+	lv.Spec.CSIStatus = apiV1.VolumeReady
+	block.Spec.Mode = apiV1.ModeRAW
+	for _, v := range staged {
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, v.Name, v))
+	}
+
+	pMock.On("GetVolumePath", ssd.Spec).Return("/dev/sda1", nil)
+	pMock.On("GetVolumePath", lv.Spec).Return("/dev/nvme-lvg/"+lv.Spec.Id, nil)
+	fsOps.On("FindMountTarget", "/dev/sda1").Return("/staging/ssd", nil)
+	// logical volume isn't mounted
+	fsOps.On("FindMountTarget", "/dev/nvme-lvg/"+lv.Spec.Id).Return("", nil)
+	fsOps.On("Trim", "/staging/ssd").Return(nil).Once()
+	vm.SetProvisioners(map[p.VolumeType]p.Provisioner{p.DriveBasedVolumeType: pMock, p.LVMBasedVolumeType: pMock})
+	vm.fsOps = fsOps
+
+	assert.Nil(t, vm.TrimVolumes(testCtx))
+	fsOps.AssertNumberOfCalls(t, "Trim", 1)
+
+	fsOps.On("Trim", "/staging/ssd").Return(testErr)
+	assert.NotNil(t, vm.TrimVolumes(testCtx))
+}
@@ -0,0 +1,120 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/common"
+	"github.com/dell/csi-baremetal/pkg/eventing"
+)
+
+// importVolume adopts pre-existing partition or logical volume which is described by Volume CR
+// with apiV1.VolumeImportAnnotationKey annotation: checks that storage exists, deducts its capacity
+// from AvailableCapacity, creates static PV and sets Created status, so data could be consumed through CSI.
+// Partition is described by Volume CR created on drive discovery, logical volume - by Volume CR created by admin,
+// where Id is LV name and Location is LVG CR name
+// Receives golang context and Volume CR in Empty status
+// Returns reconcile result as ctrl.Result or error if something went wrong
+func (m *VolumeManager) importVolume(ctx context.Context, volume *volumecrd.Volume) (ctrl.Result, error) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":   "importVolume",
+		"volumeID": volume.Spec.Id,
+	})
+
+	if volume.Spec.StorageClass == "" {
+		if drive := m.crHelper.GetDriveCRByUUID(volume.Spec.Location); drive != nil {
+			volume.Spec.StorageClass = util.ConvertDriveTypeToStorageClass(drive.Spec.Type)
+		}
+	}
+	if volume.Spec.Mode == "" {
+		volume.Spec.Mode = apiV1.ModeFS
+	}
+
+	if err := m.findVolumeStorage(&volume.Spec); err != nil {
+		ll.Errorf("Storage of volume isn't found: %v", err)
+		// Volume CR should be fixed by admin, it is reconciled again on update
+		m.sendEventForVolume(volume, eventing.ErrorType, eventing.VolumeImportFailed,
+			"Storage of volume isn't found on %s: %v", volume.Spec.Location, err)
+		return ctrl.Result{}, nil
+	}
+
+	if err := m.reserveImportedCapacity(ctx, volume); err != nil {
+		ll.Errorf("Unable to deduct capacity of volume: %v", err)
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	pv := common.ConstructStaticPV(volume, volume.Annotations[apiV1.VolumeImportAnnotationKey])
+	if err := m.k8sClient.CreateCR(ctx, pv.Name, pv); err != nil {
+		ll.Errorf("Unable to create PV %s: %v", pv.Name, err)
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	volume.Spec.CSIStatus = apiV1.Created
+	volume.Spec.OperationalStatus = apiV1.OperationalStatusOperative
+	if err := m.k8sClient.UpdateCR(ctx, volume); err != nil {
+		ll.Errorf("Unable to set volume status to %s: %v", apiV1.Created, err)
+		return ctrl.Result{Requeue: true}, err
+	}
+
+	ll.Infof("Volume was imported as PV %s", pv.Name)
+	m.sendEventForVolume(volume, eventing.InfoType, eventing.VolumeImported,
+		"Volume on %s was imported as PV %s", volume.Spec.Location, pv.Name)
+	return ctrl.Result{}, nil
+}
+
+// reserveImportedCapacity deducts size of imported logical volume from AvailableCapacity of LVG and adds volume
+// to LVG volume refs, AvailableCapacity of drive with imported partition is removed
+// Returns error if CRs can't be read or updated
+func (m *VolumeManager) reserveImportedCapacity(ctx context.Context, volume *volumecrd.Volume) error {
+	if !util.IsStorageClassLVG(volume.Spec.StorageClass) {
+		// partition takes the drive, so it isn't available for other volumes
+		if ac := m.crHelper.GetACByLocation(volume.Spec.Location); ac != nil {
+			return m.k8sClient.DeleteCR(ctx, ac)
+		}
+		return nil
+	}
+
+	lvg := &lvgcrd.LVG{}
+	if err := m.k8sClient.ReadCR(ctx, volume.Spec.Location, lvg); err != nil {
+		return err
+	}
+	// volume refs show that capacity was already deducted on previous attempt
+	if util.ContainsString(lvg.Spec.VolumeRefs, volume.Spec.Id) {
+		return nil
+	}
+
+	if ac := m.crHelper.GetACByLocation(lvg.Name); ac != nil {
+		ac.Spec.Size -= volume.Spec.Size
+		if ac.Spec.Size < 0 {
+			ac.Spec.Size = 0
+		}
+		if err := m.k8sClient.UpdateCR(ctx, ac); err != nil {
+			return err
+		}
+	}
+
+	lvg.Spec.VolumeRefs = append(lvg.Spec.VolumeRefs, volume.Spec.Id)
+	return m.k8sClient.UpdateCR(ctx, lvg)
+}
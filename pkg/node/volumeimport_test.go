@@ -0,0 +1,136 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	vcrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/eventing"
+	"github.com/dell/csi-baremetal/pkg/mocks"
+	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
+	mockProv "github.com/dell/csi-baremetal/pkg/mocks/provisioners"
+	p "github.com/dell/csi-baremetal/pkg/node/provisioners"
+)
+
+const testImportSC = "baremetal-csi-sc-hdd"
+
+func TestVolumeManager_importVolume(t *testing.T) {
+	t.Run("Partition is imported", func(t *testing.T) {
+		var (
+			vm     = prepareSuccessVolumeManager(t)
+			pMock  = &mockProv.MockProvisioner{}
+			volume = importedVolume(testVolumeCR1.DeepCopy())
+			drive  = vm.k8sClient.ConstructDriveCR(disk1.UUID, api.Drive{UUID: disk1.UUID, Type: apiV1.DriveTypeHDD})
+			ac     = testAC1.DeepCopy()
+		)
+		volume.Spec.StorageClass = ""
+		ac.Spec.Location = disk1.UUID
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, drive.Name, drive))
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, ac.Name, ac))
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, volume.Name, volume))
+		pMock.On("GetVolumePath", mock.Anything).Return("/dev/sda1", nil)
+		vm.SetProvisioners(map[p.VolumeType]p.Provisioner{p.DriveBasedVolumeType: pMock})
+
+		res, err := vm.Reconcile(volumeRequest(volume))
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, volume.Name, volume))
+		assert.Equal(t, apiV1.Created, volume.Spec.CSIStatus)
+		assert.Equal(t, apiV1.StorageClassHDD, volume.Spec.StorageClass)
+		assert.NotNil(t, vm.k8sClient.ReadCR(testCtx, ac.Name, &accrd.AvailableCapacity{}))
+
+		pv := &coreV1.PersistentVolume{}
+		assert.Nil(t, vm.k8sClient.Get(testCtx, k8sCl.ObjectKey{Name: volume.Name}, pv))
+		assert.Equal(t, volume.Spec.Id, pv.Spec.CSI.VolumeHandle)
+		assert.Equal(t, testImportSC, pv.Spec.StorageClassName)
+		assert.Equal(t, coreV1.PersistentVolumeReclaimRetain, pv.Spec.PersistentVolumeReclaimPolicy)
+	})
+
+	t.Run("Logical volume is imported", func(t *testing.T) {
+		var (
+			vm      = prepareSuccessVolumeManager(t)
+			lvmMock = &mocklu.MockWrapLVM{}
+			volume  = importedVolume(testVolumeLVGCR.DeepCopy())
+			lvg     = testLVGCR.DeepCopy()
+			ac      = acCR.DeepCopy()
+		)
+		ac.Spec.Location = lvg.Name
+		ac.Spec.Size = volume.Spec.Size * 2
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, lvg.Name, lvg))
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, ac.Name, ac))
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, volume.Name, volume))
+		lvmMock.On("FindVgNameByLvName", volume.Spec.Id).Return(lvg.Name, nil)
+		vm.lvmOps = lvmMock
+
+		_, err := vm.Reconcile(volumeRequest(volume))
+		assert.Nil(t, err)
+
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, volume.Name, volume))
+		assert.Equal(t, apiV1.Created, volume.Spec.CSIStatus)
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, lvg.Name, lvg))
+		assert.Contains(t, lvg.Spec.VolumeRefs, volume.Spec.Id)
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, ac.Name, ac))
+		assert.Equal(t, volume.Spec.Size, ac.Spec.Size)
+
+		// capacity isn't deducted twice
+		assert.Nil(t, vm.reserveImportedCapacity(testCtx, volume))
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, ac.Name, ac))
+		assert.Equal(t, volume.Spec.Size, ac.Spec.Size)
+	})
+
+	t.Run("Storage isn't found", func(t *testing.T) {
+		var (
+			vm      = prepareSuccessVolumeManager(t)
+			lvmMock = &mocklu.MockWrapLVM{}
+			volume  = importedVolume(testVolumeLVGCR.DeepCopy())
+		)
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, volume.Name, volume))
+		lvmMock.On("FindVgNameByLvName", volume.Spec.Id).Return("", testErr)
+		vm.lvmOps = lvmMock
+
+		res, err := vm.Reconcile(volumeRequest(volume))
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, volume.Name, volume))
+		assert.Equal(t, apiV1.Empty, volume.Spec.CSIStatus)
+		recorder := vm.recorder.(*mocks.NoOpRecorder)
+		assert.Equal(t, eventing.VolumeImportFailed, recorder.Calls[0].Reason)
+	})
+}
+
+func importedVolume(volume *vcrd.Volume) *vcrd.Volume {
+	volume.Spec.CSIStatus = apiV1.Empty
+	volume.Annotations = map[string]string{apiV1.VolumeImportAnnotationKey: testImportSC}
+	return volume
+}
+
+func volumeRequest(volume *vcrd.Volume) ctrl.Request {
+	return ctrl.Request{NamespacedName: types.NamespacedName{Namespace: testNs, Name: volume.Name}}
+}
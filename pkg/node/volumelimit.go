@@ -0,0 +1,76 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+	fc "github.com/dell/csi-baremetal/pkg/base/featureconfig"
+)
+
+// calculateMaxVolumes returns amount of volumes which node is able to host, it is reported through NodeGetInfo.
+// Configured maxVolumesPerNode overrides calculation. Otherwise each healthy online drive which isn't used in LVG
+// hosts one volume (or max volumes per drive of densityPolicy if sub-drive allocation is enabled) and each LVG hosts
+// one logical volume per extent, but not more than max LVs per VG of densityPolicy.
+// Returns 0 which means unlimited if there is no information about drives yet, or error if CRs can't be read
+func (s *CSINodeService) calculateMaxVolumes() (int64, error) {
+	if s.maxVolumesPerNode > 0 {
+		return s.maxVolumesPerNode, nil
+	}
+
+	drives, err := s.crHelper.GetDriveCRs(s.nodeID)
+	if err != nil {
+		return 0, err
+	}
+	lvgs, err := s.crHelper.GetLVGCRs(s.nodeID)
+	if err != nil {
+		return 0, err
+	}
+
+	volumesPerDrive := int64(1)
+	if s.featureChecker != nil && s.featureChecker.IsEnabled(fc.FeatureSubDriveAllocation) {
+		volumesPerDrive = int64(s.densityPolicy.MaxVolumesPerDrive())
+	}
+
+	lvgLocations := make(map[string]struct{})
+	var limit int64
+	for _, lvg := range lvgs {
+		for _, location := range lvg.Spec.Locations {
+			lvgLocations[location] = struct{}{}
+		}
+		if lvg.Spec.Status == apiV1.Failed {
+			continue
+		}
+		lvs := lvg.Spec.Size / capacityplanner.DefaultPESize
+		if max := int64(s.densityPolicy.MaxLVsPerVG()); max > 0 && lvs > max {
+			lvs = max
+		}
+		limit += lvs
+	}
+
+	for _, drive := range drives {
+		if drive.Spec.Health != apiV1.HealthGood || drive.Spec.Status != apiV1.DriveStatusOnline {
+			continue
+		}
+		if _, inLVG := lvgLocations[drive.Spec.UUID]; inLVG {
+			continue
+		}
+		limit += volumesPerDrive
+	}
+
+	return limit, nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+	"github.com/dell/csi-baremetal/pkg/base/featureconfig"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/mocks"
+)
+
+func TestCSINodeService_calculateMaxVolumes(t *testing.T) {
+	kubeClient, err := k8s.GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+	featureConf := featureconfig.NewFeatureConfig()
+	s := NewCSINodeService(mocks.NewMockDriveMgrClient(nil), nodeID, testLogger, kubeClient,
+		new(mocks.NoOpRecorder), featureConf, nil, nil)
+
+	limit, err := s.calculateMaxVolumes()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), limit)
+
+	for _, d := range []*drivecrd.Drive{
+		kubeClient.ConstructDriveCR(drive1.UUID, drive1),
+		kubeClient.ConstructDriveCR(drive2.UUID, drive2),
+	} {
+		assert.Nil(t, kubeClient.CreateCR(testCtx, d.Name, d))
+	}
+	limit, err = s.calculateMaxVolumes()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), limit)
+
+	featureConf.Update(featureconfig.FeatureSubDriveAllocation, true)
+	limit, err = s.calculateMaxVolumes()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2*capacityplanner.MaxSubDrivePartitions), limit)
+	featureConf.Update(featureconfig.FeatureSubDriveAllocation, false)
+
+	// drive1 is used in LVG, it hosts one logical volume per extent
+	lvg := testLVGCR
+	assert.Nil(t, kubeClient.CreateCR(testCtx, lvg.Name, &lvg))
+	limit, err = s.calculateMaxVolumes()
+	assert.Nil(t, err)
+	assert.Equal(t, 1+testLVGCR.Spec.Size/capacityplanner.DefaultPESize, limit)
+
+	s.densityPolicy, err = capacityplanner.NewDensityPolicy(0, 100)
+	assert.Nil(t, err)
+	limit, err = s.calculateMaxVolumes()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(101), limit)
+
+	s.SetMaxVolumesPerNode(10)
+	limit, err = s.calculateMaxVolumes()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), limit)
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -44,7 +45,10 @@ import (
 	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
 	"github.com/dell/csi-baremetal/pkg/base/command"
+	"github.com/dell/csi-baremetal/pkg/base/healthscore"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/dmesg"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fio"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsblk"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lvm"
 	ph "github.com/dell/csi-baremetal/pkg/base/linuxutils/partitionhelper"
@@ -55,7 +59,10 @@ import (
 	"github.com/dell/csi-baremetal/pkg/node/provisioners/utilwrappers"
 )
 
-const volumeFinalizer = "dell.emc.csi/volume-cleanup"
+const (
+	volumeFinalizer = "dell.emc.csi/volume-cleanup"
+	driveFinalizer  = "dell.emc.csi/drive-cleanup"
+)
 
 // eventRecorder interface for sending events
 type eventRecorder interface {
@@ -82,6 +89,10 @@ type VolumeManager struct {
 	lvmOps lvm.WrapLVM
 	// uses for running lsblk util
 	listBlk lsblk.WrapLsblk
+	// uses for running I/O benchmarks of drives
+	fio fio.WrapFio
+	// uses for reading kernel messages of file systems
+	dmesg dmesg.WrapDmesg
 
 	// uses for searching suitable Available Capacity
 	acProvider common.AvailableCapacityOperations
@@ -93,6 +104,10 @@ type VolumeManager struct {
 	discoverLvgSSD bool
 	// whether VolumeManager was initialized or no, uses for health probes
 	initialized bool
+	// state of dependencies, uses for health probes
+	health *healthState
+	// broadcasts serving status to clients of health Watch
+	healthBroadcaster *util.HealthBroadcaster
 	// general logger
 	log *logrus.Entry
 	// sink where we write events
@@ -102,6 +117,22 @@ type VolumeManager struct {
 	// systemDrivesUUIDs represent system drive uuids, used to avoid unnecessary calls to Kubernetes API.
 	// We use slice in case of RAID and multiple system disks
 	systemDrivesUUIDs []string
+	// restricts participation of the node in the storage pool, node always participates if it is nil
+	poolFilter *StoragePoolFilter
+	// UUIDs of drives which are being benchmarked, AC isn't created for such drives
+	benchmarks   map[string]struct{}
+	benchmarksMu sync.Mutex
+	// whether removal of volumes which file system isn't empty requires VolumeDataRemovalAnnotationKey annotation
+	deletionProtection bool
+	// IP on which data of volumes moved to the node is received
+	volumeMoveIP string
+	// names of VolumeMove CRs which data is being sent or received by the node
+	volumeMoves   map[string]struct{}
+	volumeMovesMu sync.Mutex
+	// calculates health score of drives, health of drives is reported by drive manager only if it is nil
+	healthPolicy *healthscore.Policy
+	// whether volumes of drives which became SUSPECT are moved to other nodes
+	evacuateSuspect bool
 }
 
 // driveStates internal struct, holds info about drive updates
@@ -162,6 +193,8 @@ func NewVolumeManager(
 		fsOps:             utilwrappers.NewFSOperationsImpl(executor, logger),
 		lvmOps:            lvm.NewLVM(executor, logger),
 		listBlk:           lsblk.NewLSBLK(logger),
+		fio:               fio.NewFIO(executor),
+		dmesg:             dmesg.NewDmesg(executor),
 		partOps:           ph.NewWrapPartitionImpl(executor, logger),
 		nodeID:            nodeID,
 		log:               logger.WithField("component", "VolumeManager"),
@@ -169,6 +202,10 @@ func NewVolumeManager(
 		discoverLvgSSD:    true,
 		volMu:             keymutex.NewHashed(0),
 		systemDrivesUUIDs: make([]string, 0),
+		health:            &healthState{},
+		healthBroadcaster: util.NewHealthBroadcaster(),
+		benchmarks:        make(map[string]struct{}),
+		volumeMoves:       make(map[string]struct{}),
 	}
 	return vm
 }
@@ -206,6 +243,12 @@ func (m *VolumeManager) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	if err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	if setVolumeConditions(volume) {
+		if err := m.k8sClient.UpdateCRStatus(ctx, volume); err != nil {
+			ll.Errorf("Unable to update Volume status: %v", err)
+			return ctrl.Result{Requeue: true}, err
+		}
+	}
 	if volume.DeletionTimestamp.IsZero() {
 		if !util.ContainsString(volume.ObjectMeta.Finalizers, volumeFinalizer) && volume.Spec.CSIStatus != apiV1.Empty {
 			ll.Debug("Appending finalizer for volume")
@@ -217,9 +260,10 @@ func (m *VolumeManager) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		}
 	} else {
 		switch volume.Spec.CSIStatus {
-		case apiV1.Created:
+		case apiV1.Created, apiV1.Retained, apiV1.Failed:
+			// volume in Failed status could have partially created storage, clean it up as well
+			ll.Debugf("Change volume status from %s to Removing", volume.Spec.CSIStatus)
 			volume.Spec.CSIStatus = apiV1.Removing
-			ll.Debug("Change volume status from Created to Removing")
 		case apiV1.Removing:
 		case apiV1.Removed:
 			if util.ContainsString(volume.ObjectMeta.Finalizers, volumeFinalizer) {
@@ -237,6 +281,9 @@ func (m *VolumeManager) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		}
 	}
 	ll.Infof("Processing for status %s", volume.Spec.CSIStatus)
+	if volume.Spec.CSIStatus == apiV1.Creating || volume.Spec.CSIStatus == apiV1.Removing {
+		defer m.health.startOperation(volume.Spec.Id)()
+	}
 	switch volume.Spec.CSIStatus {
 	case apiV1.Creating:
 		if util.IsStorageClassLVG(volume.Spec.StorageClass) {
@@ -245,6 +292,11 @@ func (m *VolumeManager) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return m.prepareVolume(ctx, volume)
 	case apiV1.Removing:
 		return m.handleRemovingStatus(ctx, volume)
+	case apiV1.Empty:
+		if _, ok := volume.Annotations[apiV1.VolumeImportAnnotationKey]; ok {
+			return m.importVolume(ctx, volume)
+		}
+		return ctrl.Result{}, nil
 	default:
 		return ctrl.Result{}, nil
 	}
@@ -270,6 +322,8 @@ func (m *VolumeManager) handleCreatingVolumeInLVG(ctx context.Context, volume *v
 			volume.Spec.CSIStatus = apiV1.Failed
 			err = m.k8sClient.UpdateCR(ctx, volume)
 			if err == nil {
+				m.sendEventForVolume(volume, eventing.ErrorType, eventing.VolumeCreationFailed,
+					"Underlying LVG %s doesn't exist", volume.Spec.Location)
 				return ctrl.Result{}, nil // no need to retry
 			}
 			ll.Errorf("Unable to update volume CR and set status to failed: %v", err)
@@ -281,6 +335,12 @@ func (m *VolumeManager) handleCreatingVolumeInLVG(ctx context.Context, volume *v
 	switch lvg.Spec.Status {
 	case apiV1.Creating:
 		ll.Debugf("Underlying LVG %s is still being created", lvg.Name)
+		if volume.Status.SetCondition(apiV1.ConditionProgressing, apiV1.ConditionStatusFromBool(true),
+			apiV1.ReasonWaitingForLVG, fmt.Sprintf("LVG %s is being created", lvg.Name)) {
+			if err = m.k8sClient.UpdateCRStatus(ctx, volume); err != nil {
+				ll.Warnf("Unable to update Volume status: %v", err)
+			}
+		}
 		return ctrl.Result{Requeue: true, RequeueAfter: base.DefaultRequeueForVolume}, nil
 	case apiV1.Failed:
 		ll.Errorf("Underlying LVG %s has reached failed status. Unable to create volume on failed lvg.", lvg.Name)
@@ -290,6 +350,8 @@ func (m *VolumeManager) handleCreatingVolumeInLVG(ctx context.Context, volume *v
 			// retry because of volume status wasn't updated
 			return ctrl.Result{Requeue: true, RequeueAfter: base.DefaultRequeueForVolume}, err
 		}
+		m.sendEventForVolume(volume, eventing.ErrorType, eventing.VolumeCreationFailed,
+			"Underlying LVG %s is in %s status", lvg.Name, lvg.Spec.Status)
 		return ctrl.Result{}, nil // no need to retry
 	case apiV1.Created:
 		// add volume ID to LVG.Spec.VolumeRefs
@@ -317,7 +379,17 @@ func (m *VolumeManager) prepareVolume(ctx context.Context, volume *volumecrd.Vol
 
 	newStatus := apiV1.Created
 
-	err := m.getProvisionerForVolume(&volume.Spec).PrepareVolume(volume.Spec)
+	var err error
+	// fail fast if drive was removed after volume had been planned on it
+	if drive := m.getRemovingDrive(volume); drive != nil {
+		err = fmt.Errorf("drive %s is being removed", drive.Spec.SerialNumber)
+	} else if err = m.getProvisionerForVolume(&volume.Spec).PrepareVolume(volume.Spec); err != nil {
+		if res, retry := m.retryVolumeOperation(ctx, volume, err); retry {
+			ll.Warnf("Unable to create volume size of %d bytes: %v. Attempt %d will be retried",
+				volume.Spec.Size, err, volume.Status.Retries)
+			return res, nil
+		}
+	}
 	if err != nil {
 		ll.Errorf("Unable to create volume size of %d bytes: %v. Set volume status to Failed", volume.Spec.Size, err)
 		newStatus = apiV1.Failed
@@ -329,9 +401,28 @@ func (m *VolumeManager) prepareVolume(ctx context.Context, volume *volumecrd.Vol
 		return ctrl.Result{Requeue: true}, updateErr
 	}
 
+	if err != nil {
+		m.sendEventForVolume(volume, eventing.ErrorType, eventing.VolumeCreationFailed,
+			"Unable to create volume: %v", err)
+	} else {
+		m.sendEventForVolume(volume, eventing.InfoType, eventing.VolumeCreated, "Volume was created")
+	}
+
 	return ctrl.Result{}, err
 }
 
+// getRemovingDrive returns drive CR which is the location of volume if it is being removed or nil otherwise
+func (m *VolumeManager) getRemovingDrive(volume *volumecrd.Volume) *drivecrd.Drive {
+	if volume.Spec.LocationType != apiV1.LocationTypeDrive {
+		return nil
+	}
+	drive := m.crHelper.GetDriveCRByUUID(volume.Spec.Location)
+	if drive == nil || drive.Spec.OperationalStatus != apiV1.DriveOpStatusRemoving {
+		return nil
+	}
+	return drive
+}
+
 // handleRemovingStatus handles volume CR with removing CSIStatus - removed real storage (partition/lv) and
 // update corresponding volume CR's CSIStatus
 // uses as a step for Reconcile for Volume CR
@@ -341,11 +432,19 @@ func (m *VolumeManager) handleRemovingStatus(ctx context.Context, volume *volume
 		"volumeID": volume.Name,
 	})
 
+	if reason := m.checkRemovalAllowed(volume); reason != nil {
+		return m.blockVolumeRemoval(ctx, volume, reason)
+	}
+
 	var (
 		err       error
 		newStatus string
 	)
 	if err = m.getProvisionerForVolume(&volume.Spec).ReleaseVolume(volume.Spec); err != nil {
+		if res, retry := m.retryVolumeOperation(ctx, volume, err); retry {
+			ll.Warnf("Failed to remove volume: %v. Attempt %d will be retried", err, volume.Status.Retries)
+			return res, nil
+		}
 		ll.Errorf("Failed to remove volume - %s. Error: %v. Set status to Failed", volume.Spec.Id, err)
 		newStatus = apiV1.Failed
 	} else {
@@ -357,9 +456,47 @@ func (m *VolumeManager) handleRemovingStatus(ctx context.Context, volume *volume
 		ll.Error("Unable to set new status for volume")
 		return ctrl.Result{Requeue: true}, updateErr
 	}
+
+	if err != nil {
+		m.sendEventForVolume(volume, eventing.ErrorType, eventing.VolumeRemovalFailed,
+			"Unable to remove volume: %v", err)
+	} else {
+		m.sendEventForVolume(volume, eventing.InfoType, eventing.VolumeRemoved, "Volume was removed")
+	}
 	return ctrl.Result{}, err
 }
 
+// retryVolumeOperation increments number of failed attempts of volume creation or removal and shows error
+// of the last attempt in Progressing condition, the next attempt is performed with exponential backoff
+// Returns reconcile result and true if operation should be retried or false if attempts are exhausted
+func (m *VolumeManager) retryVolumeOperation(ctx context.Context, volume *volumecrd.Volume, opErr error) (ctrl.Result, bool) {
+	if volume.Status.Retries >= base.DefaultVolumeRetries {
+		return ctrl.Result{}, false
+	}
+
+	volume.Status.Retries++
+	volume.Status.SetCondition(apiV1.ConditionProgressing, apiV1.ConditionStatusFromBool(true), apiV1.ReasonRetrying,
+		fmt.Sprintf("attempt %d failed: %v", volume.Status.Retries, opErr))
+	if err := m.k8sClient.UpdateCRStatus(ctx, volume); err != nil {
+		m.log.WithField("volumeID", volume.Spec.Id).Errorf("Unable to update Volume status: %v", err)
+		return ctrl.Result{Requeue: true}, true
+	}
+	return ctrl.Result{RequeueAfter: volumeBackoff(volume.Status.Retries)}, true
+}
+
+// volumeBackoff returns interval before the next attempt of volume operation,
+// it is doubled after each failed attempt up to MaxRequeueForVolume
+func volumeBackoff(retries int32) time.Duration {
+	backoff := base.DefaultRequeueForVolume
+	for i := int32(1); i < retries && backoff < base.MaxRequeueForVolume; i++ {
+		backoff *= 2
+	}
+	if backoff > base.MaxRequeueForVolume {
+		backoff = base.MaxRequeueForVolume
+	}
+	return backoff
+}
+
 // SetupWithManager registers VolumeManager to ControllerManager
 func (m *VolumeManager) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
@@ -403,7 +540,33 @@ func (m *VolumeManager) isCorrespondedToNodePredicate(obj runtime.Object) bool {
 func (m *VolumeManager) Discover() error {
 	ctx, cancelFn := context.WithTimeout(context.Background(), DiscoverDrivesTimeout)
 	defer cancelFn()
+	// Watch clients receive NOT_SERVING until re-discovery is finished
+	m.health.setDiscovering(true)
+	_ = m.updateHealthStatus(context.Background())
+	defer func() {
+		m.health.setDiscovering(false)
+		_ = m.updateHealthStatus(context.Background())
+	}()
+
+	if m.poolFilter != nil {
+		inPool, err := m.isInStoragePool(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to check whether node is in storage pool: %v", err)
+		}
+		if err = m.updateACsParticipation(ctx, inPool); err != nil {
+			return fmt.Errorf("updateACsParticipation return error: %v", err)
+		}
+		if !inPool {
+			// node keeps serving existing volumes, but doesn't discover drives and advertises zero capacity
+			m.log.WithField("method", "Discover").Info("Node doesn't match storage pool filter, skip discovery")
+			m.health.discovered(time.Now())
+			m.initialized = true
+			return nil
+		}
+	}
+
 	drivesResponse, err := m.driveMgrClient.GetDrivesList(ctx, &api.DrivesRequest{NodeId: m.nodeID})
+	m.health.setDriveMgrErr(err)
 	if err != nil {
 		return err
 	}
@@ -414,6 +577,26 @@ func (m *VolumeManager) Discover() error {
 	}
 	m.handleDriveUpdates(ctx, updates)
 
+	if err = m.handleDrivesDeletion(ctx); err != nil {
+		m.log.WithField("method", "Discover").
+			Errorf("unable to handle deleted drives: %v", err)
+	}
+
+	if err = m.handleDrivesActions(ctx); err != nil {
+		m.log.WithField("method", "Discover").
+			Errorf("unable to handle drives actions: %v", err)
+	}
+
+	if err = m.updateDrivesConditions(ctx); err != nil {
+		m.log.WithField("method", "Discover").
+			Errorf("unable to update drives conditions: %v", err)
+	}
+
+	if err = m.updateInventory(ctx); err != nil {
+		m.log.WithField("method", "Discover").
+			Errorf("unable to update inventory: %v", err)
+	}
+
 	if m.discoverLvgSSD {
 		if err = m.discoverLVGOnSystemDrive(); err != nil {
 			m.log.WithField("method", "Discover").
@@ -425,10 +608,16 @@ func (m *VolumeManager) Discover() error {
 		return fmt.Errorf("discoverVolumeCRs return error: %v", err)
 	}
 
+	if err = m.recoverVolumes(ctx); err != nil {
+		m.log.WithField("method", "Discover").
+			Errorf("unable to recover missing volumes: %v", err)
+	}
+
 	if err = m.discoverAvailableCapacity(ctx); err != nil {
 		return fmt.Errorf("discoverAvailableCapacity return error: %v", err)
 	}
 
+	m.health.discovered(time.Now())
 	m.initialized = true
 	return nil
 }
@@ -463,6 +652,7 @@ func (m *VolumeManager) updateDrivesCRs(ctx context.Context, drivesFromMgr []*ap
 			// If drive CR already exist, try to update, if drive was changed
 			if m.drivesAreTheSame(drivePtr, &driveCR.Spec) {
 				exist = true
+				m.applyHealthPolicy(drivePtr, &driveCR)
 				if driveCR.Equals(drivePtr) {
 					updates.AddNotChanged(&driveCR)
 				} else {
@@ -470,6 +660,14 @@ func (m *VolumeManager) updateDrivesCRs(ctx context.Context, drivesFromMgr []*ap
 					drivePtr.UUID = driveCR.Spec.UUID
 					toUpdate := driveCR
 					toUpdate.Spec = *drivePtr
+					toUpdate.Spec.OperationalStatus = driveCR.Spec.OperationalStatus
+					// drive manager doesn't know about errors from kernel log
+					toUpdate.Spec.IOErrors = driveCR.Spec.IOErrors
+					// drive which was reported as removed is inserted back
+					if toUpdate.Spec.OperationalStatus == apiV1.DriveOpStatusRemoving &&
+						toUpdate.Spec.Status == apiV1.DriveStatusOnline {
+						toUpdate.Spec.OperationalStatus = apiV1.DriveOpStatusOperative
+					}
 					if toUpdate.Spec.Health != apiV1.HealthGood {
 						toUpdate.Spec.OperationalStatus = apiV1.DriveOpStatusReleasing
 					}
@@ -486,6 +684,7 @@ func (m *VolumeManager) updateDrivesCRs(ctx context.Context, drivesFromMgr []*ap
 		}
 		if !exist && drivePtr.SerialNumber != "" {
 			// Drive CR is not exist, try to create it
+			m.applyHealthPolicy(drivePtr, nil)
 			toCreateSpec := *drivePtr
 			toCreateSpec.NodeId = m.nodeID
 			toCreateSpec.UUID = uuid.New().String()
@@ -500,6 +699,7 @@ func (m *VolumeManager) updateDrivesCRs(ctx context.Context, drivesFromMgr []*ap
 			}
 			toCreateSpec.IsSystem = isSystem
 			driveCR := m.k8sClient.ConstructDriveCR(toCreateSpec.UUID, toCreateSpec)
+			driveCR.Finalizers = []string{driveFinalizer}
 			if err := m.k8sClient.CreateCR(ctx, driveCR.Name, driveCR); err != nil {
 				ll.Errorf("Failed to create drive CR %v, error: %v", driveCR, err)
 			}
@@ -533,9 +733,10 @@ func (m *VolumeManager) updateDrivesCRs(ctx context.Context, drivesFromMgr []*ap
 			ll.Warnf("Set status OFFLINE for drive %v", d.Spec)
 			previousState := d.DeepCopy()
 			toUpdate := d
-			// TODO: which operational status should be in case when there is drive CR that doesn't have corresponding drive from drivemgr response
+			// drive is going away, volumes shouldn't be allocated on it
 			toUpdate.Spec.Status = apiV1.DriveStatusOffline
 			toUpdate.Spec.Health = apiV1.HealthUnknown
+			toUpdate.Spec.OperationalStatus = apiV1.DriveOpStatusRemoving
 			if err := m.k8sClient.UpdateCR(ctx, &toUpdate); err != nil {
 				ll.Errorf("Failed to update drive CR %v, error %v", toUpdate, err)
 				updates.AddNotChanged(previousState)
@@ -550,10 +751,168 @@ func (m *VolumeManager) updateDrivesCRs(ctx context.Context, drivesFromMgr []*ap
 func (m *VolumeManager) handleDriveUpdates(ctx context.Context, updates *driveUpdates) {
 	for _, updDrive := range updates.Updated {
 		m.handleDriveStatusChange(ctx, &updDrive.CurrentState.Spec)
+		if m.evacuateSuspect && updDrive.CurrentState.Spec.Health == apiV1.HealthSuspect &&
+			updDrive.PreviousState.Spec.Health == apiV1.HealthGood {
+			m.evacuateDrive(ctx, updDrive.CurrentState)
+		}
 	}
 	m.createEventsForDriveUpdates(updates)
 }
 
+// handleDrivesDeletion appends finalizer to Drive CRs of the node and releases Drive CRs that are being deleted.
+// Drive CR is released (AC that points on drive is removed and finalizer is removed) only when there are no
+// Volume CRs or LVG CRs that use that drive, otherwise Drive CR remains in deleting state
+// Returns error if at least one Drive CR was handled badly
+func (m *VolumeManager) handleDrivesDeletion(ctx context.Context) error {
+	ll := m.log.WithField("method", "handleDrivesDeletion")
+
+	driveCRs, err := m.crHelper.GetDriveCRs(m.nodeID)
+	if err != nil {
+		return err
+	}
+
+	var wasError = false
+	for _, drive := range driveCRs {
+		drive := drive
+		if drive.DeletionTimestamp.IsZero() {
+			if !util.ContainsString(drive.Finalizers, driveFinalizer) {
+				drive.Finalizers = append(drive.Finalizers, driveFinalizer)
+				if err = m.k8sClient.UpdateCR(ctx, &drive); err != nil {
+					ll.Errorf("Unable to append finalizer %s to Drive %s: %v", driveFinalizer, drive.Name, err)
+					wasError = true
+				}
+			}
+			continue
+		}
+
+		if !util.ContainsString(drive.Finalizers, driveFinalizer) {
+			continue
+		}
+		if m.crHelper.GetVolumeByLocation(drive.Spec.UUID) != nil || m.isDriveInLVG(drive.Spec) {
+			ll.Warnf("Drive %s is still in use, it will be released after all volumes on it are removed", drive.Name)
+			continue
+		}
+		if ac := m.crHelper.GetACByLocation(drive.Spec.UUID); ac != nil {
+			if err = m.k8sClient.DeleteCR(ctx, ac); err != nil && !k8sError.IsNotFound(err) {
+				ll.Errorf("Unable to delete AC %s for drive %s: %v", ac.Name, drive.Name, err)
+				wasError = true
+				continue
+			}
+		}
+		drive.Finalizers = util.RemoveString(drive.Finalizers, driveFinalizer)
+		if err = m.k8sClient.UpdateCR(ctx, &drive); err != nil {
+			ll.Errorf("Unable to remove finalizer from Drive %s: %v", drive.Name, err)
+			wasError = true
+			continue
+		}
+		ll.Infof("Drive %s was released", drive.Name)
+	}
+
+	if wasError {
+		return errors.New("not all drives were handled")
+	}
+	return nil
+}
+
+// handleDrivesActions handles locate, replacement and benchmark requests that were set as annotations on Drive CRs,
+// annotation is removed from Drive CR after request was handled
+// Returns error if at least one request wasn't handled
+func (m *VolumeManager) handleDrivesActions(ctx context.Context) error {
+	ll := m.log.WithField("method", "handleDrivesActions")
+
+	driveCRs, err := m.crHelper.GetDriveCRs(m.nodeID)
+	if err != nil {
+		return err
+	}
+
+	var wasError = false
+	for _, drive := range driveCRs {
+		drive := drive
+		if _, benchmarkRequested := drive.Annotations[apiV1.DriveBenchmarkAnnotationKey]; benchmarkRequested {
+			if err = m.handleBenchmarkRequest(ctx, &drive); err != nil {
+				ll.Errorf("Unable to handle benchmark request for drive %s: %v", drive.Name, err)
+				wasError = true
+			}
+		}
+		locate, locateRequested := drive.Annotations[apiV1.DriveLocateAnnotationKey]
+		_, replaceRequested := drive.Annotations[apiV1.DriveReplacementAnnotationKey]
+		if !locateRequested && !replaceRequested {
+			continue
+		}
+
+		if replaceRequested {
+			ll.Infof("Releasing drive %s in enclosure %s slot %s for replacement",
+				drive.Name, drive.Spec.Enclosure, drive.Spec.Slot)
+			drive.Spec.OperationalStatus = apiV1.DriveOpStatusReleasing
+			if ac := m.crHelper.GetACByLocation(drive.Spec.UUID); ac != nil {
+				if err = m.k8sClient.DeleteCR(ctx, ac); err != nil && !k8sError.IsNotFound(err) {
+					ll.Errorf("Unable to delete AC %s for drive %s: %v", ac.Name, drive.Name, err)
+					wasError = true
+					continue
+				}
+			}
+			// drive should be found by operator before replacement
+			locate, locateRequested = apiV1.DriveLocateStart, true
+		}
+
+		if locateRequested {
+			action := apiV1.LocateStart
+			if locate == apiV1.DriveLocateStop {
+				action = apiV1.LocateStop
+			}
+			resp, err := m.driveMgrClient.Locate(ctx, &api.DriveLocateRequest{
+				DriveSerialNumber: drive.Spec.SerialNumber,
+				Action:            action,
+			})
+			if err != nil {
+				ll.Errorf("Unable to locate drive %s: %v", drive.Name, err)
+				wasError = true
+				continue
+			}
+			drive.Spec.LEDState = strconv.Itoa(int(resp.GetStatus()))
+		}
+
+		delete(drive.Annotations, apiV1.DriveLocateAnnotationKey)
+		delete(drive.Annotations, apiV1.DriveReplacementAnnotationKey)
+		if err = m.k8sClient.UpdateCR(ctx, &drive); err != nil {
+			ll.Errorf("Unable to update Drive %s: %v", drive.Name, err)
+			wasError = true
+		}
+	}
+
+	if wasError {
+		return errors.New("not all drives actions were handled")
+	}
+	return nil
+}
+
+// updateDrivesConditions updates status conditions of the Drive CRs on the node
+// Returns error if at least one Drive CR status wasn't updated
+func (m *VolumeManager) updateDrivesConditions(ctx context.Context) error {
+	driveCRs, err := m.crHelper.GetDriveCRs(m.nodeID)
+	if err != nil {
+		return err
+	}
+
+	var wasError = false
+	for _, drive := range driveCRs {
+		drive := drive
+		if !setDriveConditions(&drive) {
+			continue
+		}
+		if err = m.k8sClient.UpdateCRStatus(ctx, &drive); err != nil {
+			m.log.WithField("method", "updateDrivesConditions").
+				Errorf("Unable to update status of Drive %s: %v", drive.Name, err)
+			wasError = true
+		}
+	}
+
+	if wasError {
+		return errors.New("not all drives statuses were updated")
+	}
+	return nil
+}
+
 // isDriveInLVG check whether drive is a part of some LVG or no
 func (m *VolumeManager) isDriveInLVG(d api.Drive) bool {
 	lvgs, err := m.crHelper.GetLVGCRs(m.nodeID)
@@ -697,12 +1056,18 @@ func (m *VolumeManager) discoverAvailableCapacity(ctx context.Context) error {
 		acsLocations = make(map[string]*accrd.AvailableCapacity, len(acs))
 		// key - volume.Spec.Location that is Drive.Spec.UUID or LVG.Spec.Name (don't need to use info about LVG here)
 		volumeLocations = make(map[string]struct{})
+		// key - Drive.Spec.UUID, value - space of drive which is taken by sub-drive partitions of volumes
+		subDriveUsage = make(map[string]int64)
 	)
 	for _, ac := range acs {
 		ac := ac
 		acsLocations[ac.Spec.Location] = &ac
 	}
 	for _, v := range volumes {
+		if v.Spec.PartitionLayout == apiV1.PartitionLayoutMulti {
+			subDriveUsage[v.Spec.Location] += capacityplanner.SubDriveAllocatedSize(v.Spec.Size)
+			continue
+		}
 		volumeLocations[v.Spec.Location] = struct{}{}
 	}
 
@@ -712,6 +1077,14 @@ func (m *VolumeManager) discoverAvailableCapacity(ctx context.Context) error {
 			// AC that points on such drive was removed before (if they had existed)
 			continue
 		}
+		if !drive.DeletionTimestamp.IsZero() {
+			// drive is being deleted, AC should not be created for it
+			continue
+		}
+		if m.isBenchmarkRunning(drive.Spec.UUID) {
+			// drive is exclusively used by benchmark, AC will be created after it is finished
+			continue
+		}
 		// check whether there is Volume CR that points on same drive
 		if _, volumeExist := volumeLocations[drive.Spec.UUID]; volumeExist {
 			// check whether appropriate AC exists or not
@@ -728,9 +1101,17 @@ func (m *VolumeManager) discoverAvailableCapacity(ctx context.Context) error {
 			continue
 		}
 
+		size := drive.Spec.Size
+		// drive is shared by volumes, AC keeps the rest of its space
+		if used, ok := subDriveUsage[drive.Spec.UUID]; ok {
+			if size -= used; size < capacityplanner.AcSizeMinThresholdBytes {
+				continue
+			}
+		}
+
 		// create AC based on drive
 		capacity := &api.AvailableCapacity{
-			Size:         drive.Spec.Size,
+			Size:         size,
 			Location:     drive.Spec.UUID,
 			StorageClass: util.ConvertDriveTypeToStorageClass(drive.Spec.Type),
 			NodeId:       m.nodeID,
@@ -888,20 +1269,34 @@ func (m *VolumeManager) handleDriveStatusChange(ctx context.Context, drive *api.
 		}
 	}
 
-	// Set disk's health status to volume CR
-	vol := m.crHelper.GetVolumeByLocation(drive.UUID)
-	if vol != nil {
-		ll.Infof("Setting updated status %s to volume %s", drive.Health, vol.Name)
+	// Set disk's health status to volume CRs, volume on removed drive is suspect
+	// drive could be shared by several volumes with sub-drive partitions
+	volumes, err := m.crHelper.GetVolumesByLocation(drive.UUID)
+	if err != nil {
+		ll.Errorf("Unable to read volumes on drive: %v", err)
+	}
+	for i := range volumes {
+		vol := &volumes[i]
+		health := drive.Health
+		if drive.Status == apiV1.DriveStatusOffline {
+			health = apiV1.HealthSuspect
+		}
+		ll.Infof("Setting updated status %s to volume %s", health, vol.Name)
 		// save previous health state
 		prevHealthState := vol.Spec.Health
-		vol.Spec.Health = drive.Health
+		vol.Spec.Health = health
 		if err := m.k8sClient.UpdateCR(ctx, vol); err != nil {
 			ll.Errorf("Failed to update volume CR's %s health status: %v", vol.Name, err)
 		}
-		if vol.Spec.Health == apiV1.HealthBad {
+		switch {
+		case vol.Spec.Health == apiV1.HealthBad:
 			m.recorder.Eventf(vol, eventing.WarningType, eventing.VolumeBadHealth,
 				"Volume health transitioned from %s to %s. Inherited from %s drive on %s)",
 				prevHealthState, vol.Spec.Health, drive.Health, drive.NodeId)
+		case vol.Spec.Health == apiV1.HealthSuspect && prevHealthState != apiV1.HealthSuspect:
+			m.recorder.Eventf(vol, eventing.WarningType, eventing.VolumeSuspectHealth,
+				"Volume health transitioned from %s to %s. Drive %s on %s is %s",
+				prevHealthState, vol.Spec.Health, drive.SerialNumber, drive.NodeId, drive.Status)
 		}
 	}
 
@@ -947,6 +1342,12 @@ func (m *VolumeManager) createACIfFreeSpace(location string, sc string, size int
 		return nil
 	}
 	ll.Infof("There is no available space on %s", location)
+	lvg := &lvgcrd.LVG{}
+	if err := m.k8sClient.ReadCR(context.Background(), location, lvg); err == nil {
+		m.recorder.Eventf(lvg, eventing.WarningType, eventing.LVGCapacityExhausted,
+			"There is no available space on LVG %s, free space %d bytes is less than threshold %d bytes",
+			location, size, capacityplanner.AcSizeMinThresholdBytes)
+	}
 	return nil
 }
 
@@ -1018,12 +1419,104 @@ func (m *VolumeManager) sendEventForDrive(drive *drivecrd.Drive, eventtype, reas
 	m.recorder.Eventf(drive, eventtype, reason, messageFmt, args...)
 }
 
+func (m *VolumeManager) sendEventForVolume(volume *volumecrd.Volume, eventtype, reason, messageFmt string,
+	args ...interface{}) {
+	messageFmt += prepareVolumeDescription(volume)
+	m.recorder.Eventf(volume, eventtype, reason, messageFmt, args...)
+}
+
+func prepareVolumeDescription(volume *volumecrd.Volume) string {
+	return fmt.Sprintf(". Volume Details: Node='%s', Location='%s', StorageClass='%s', Size='%d'",
+		volume.Spec.NodeId, volume.Spec.Location, volume.Spec.StorageClass, volume.Spec.Size)
+}
+
+// setVolumeConditions sets observed generation and conditions of Volume CR based on its CSIStatus, health
+// and operational status
+// Returns true if status was modified
+func setVolumeConditions(volume *volumecrd.Volume) bool {
+	var (
+		st       = &volume.Status
+		modified = st.SetObservedGeneration(volume.Generation)
+		reason   = conditionReason(volume.Spec.CSIStatus)
+	)
+
+	provisioned := false
+	switch volume.Spec.CSIStatus {
+	case apiV1.Created, apiV1.Retained, apiV1.VolumeReady, apiV1.Published:
+		provisioned = true
+	}
+	degraded := volume.Spec.Health == apiV1.HealthBad || volume.Spec.Health == apiV1.HealthSuspect ||
+		volume.Spec.OperationalStatus == apiV1.OperationalStatusMissing ||
+		volume.Spec.OperationalStatus == apiV1.OperationalStatusInoperative
+	healthMsg := fmt.Sprintf("health %s, operational status %s", volume.Spec.Health, volume.Spec.OperationalStatus)
+	if isMountLost(volume) {
+		degraded = true
+		healthMsg += ", mount is lost"
+	}
+	if isRemountedReadOnly(volume) {
+		degraded = true
+		healthMsg += ", file system was remounted read-only"
+	}
+
+	modified = st.SetCondition(apiV1.ConditionProvisioned, apiV1.ConditionStatusFromBool(provisioned), reason, "") || modified
+	modified = st.SetCondition(apiV1.ConditionPublished,
+		apiV1.ConditionStatusFromBool(volume.Spec.CSIStatus == apiV1.Published), reason, "") || modified
+	modified = st.SetCondition(apiV1.ConditionDegraded, apiV1.ConditionStatusFromBool(degraded), reason, healthMsg) || modified
+	modified = st.SetCondition(apiV1.ConditionReady,
+		apiV1.ConditionStatusFromBool(provisioned && !degraded), reason, healthMsg) || modified
+
+	// phase of creation or removal is set during reconcile, so only start and end of operation are handled here
+	inProgress := volume.Spec.CSIStatus == apiV1.Creating || volume.Spec.CSIStatus == apiV1.Removing
+	switch {
+	case inProgress && !st.IsConditionTrue(apiV1.ConditionProgressing):
+		modified = st.SetCondition(apiV1.ConditionProgressing, apiV1.ConditionStatusFromBool(true), reason, "") || modified
+	case !inProgress:
+		modified = st.SetCondition(apiV1.ConditionProgressing, apiV1.ConditionStatusFromBool(false), reason, "") || modified
+		if st.Retries != 0 {
+			st.Retries = 0
+			modified = true
+		}
+	}
+	return modified
+}
+
+// setDriveConditions sets observed generation and conditions of Drive CR based on its health and status
+// Returns true if status was modified
+func setDriveConditions(drive *drivecrd.Drive) bool {
+	var (
+		st       = &drive.Status
+		modified = st.SetObservedGeneration(drive.Generation)
+		degraded = drive.Spec.Health != apiV1.HealthGood
+		online   = drive.Spec.Status == apiV1.DriveStatusOnline
+		reason   = conditionReason(drive.Spec.Status)
+		msg      = fmt.Sprintf("health %s, status %s", drive.Spec.Health, drive.Spec.Status)
+	)
+
+	modified = st.SetCondition(apiV1.ConditionDegraded, apiV1.ConditionStatusFromBool(degraded), reason, msg) || modified
+	modified = st.SetCondition(apiV1.ConditionReady,
+		apiV1.ConditionStatusFromBool(online && !degraded), reason, msg) || modified
+	return modified
+}
+
+// conditionReason converts CR status to CamelCase reason of condition
+func conditionReason(status string) string {
+	switch {
+	case status == "":
+		return "Unknown"
+	case strings.ToUpper(status) == status:
+		return strings.Title(strings.ToLower(status))
+	default:
+		return strings.ToUpper(status[:1]) + status[1:]
+	}
+}
+
 func prepareDriveDescription(drive *drivecrd.Drive) string {
 	return fmt.Sprintf(" Drive Details: SN='%s', Node='%s',"+
 		" Type='%s', Model='%s %s',"+
-		" Size='%d', Firmware='%s'",
+		" Size='%d', Firmware='%s', Enclosure='%s', Slot='%s'",
 		drive.Spec.SerialNumber, drive.Spec.NodeId, drive.Spec.Type,
-		drive.Spec.VID, drive.Spec.PID, drive.Spec.Size, drive.Spec.Firmware)
+		drive.Spec.VID, drive.Spec.PID, drive.Spec.Size, drive.Spec.Firmware,
+		drive.Spec.Enclosure, drive.Spec.Slot)
 }
 
 // isDriveSystem check whether drive is system
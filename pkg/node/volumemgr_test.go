@@ -40,6 +40,7 @@ import (
 	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
 	vcrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsblk"
@@ -269,6 +270,9 @@ func TestVolumeManager_prepareVolume(t *testing.T) {
 	err = vm.k8sClient.ReadCR(testCtx, req.Name, volume)
 	assert.Nil(t, err)
 	assert.Equal(t, volume.Spec.CSIStatus, apiV1.Created)
+	recorder := vm.recorder.(*mocks.NoOpRecorder)
+	assert.Len(t, recorder.Calls, 1)
+	assert.Equal(t, eventing.VolumeCreated, recorder.Calls[0].Reason)
 
 	// failed to update
 	vm = prepareSuccessVolumeManager(t)
@@ -278,18 +282,56 @@ func TestVolumeManager_prepareVolume(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.True(t, res.Requeue)
 
-	// PrepareVolume failed
-	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, volCR.Name, &volCR))
+	// PrepareVolume failed, attempt is retried with backoff
+	testVol = volCR
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, testVol.Name, &testVol))
 	pMock = &mockProv.MockProvisioner{}
 	pMock.On("PrepareVolume", volCR.Spec).Return(testErr)
 	vm.SetProvisioners(map[p.VolumeType]p.Provisioner{p.DriveBasedVolumeType: pMock})
 
-	res, err = vm.prepareVolume(testCtx, &volCR)
+	res, err = vm.prepareVolume(testCtx, &testVol)
+	assert.Nil(t, err)
+	assert.Equal(t, ctrl.Result{RequeueAfter: base.DefaultRequeueForVolume}, res)
+	err = vm.k8sClient.ReadCR(testCtx, req.Name, volume)
+	assert.Nil(t, err)
+	assert.NotEqual(t, apiV1.Failed, volume.Spec.CSIStatus)
+	assert.Equal(t, int32(1), volume.Status.Retries)
+	assert.Equal(t, apiV1.ReasonRetrying, volume.Status.GetCondition(apiV1.ConditionProgressing).Reason)
+
+	// PrepareVolume failed, attempts are exhausted
+	testVol.Status.Retries = base.DefaultVolumeRetries
+	res, err = vm.prepareVolume(testCtx, &testVol)
 	assert.NotNil(t, err)
 	assert.Equal(t, res, ctrl.Result{})
 	err = vm.k8sClient.ReadCR(testCtx, req.Name, volume)
 	assert.Nil(t, err)
 	assert.Equal(t, volume.Spec.CSIStatus, apiV1.Failed)
+	recorder = vm.recorder.(*mocks.NoOpRecorder)
+	assert.Len(t, recorder.Calls, 1)
+	assert.Equal(t, eventing.VolumeCreationFailed, recorder.Calls[0].Reason)
+
+	// drive is being removed, PrepareVolume isn't called
+	vm = prepareSuccessVolumeManager(t)
+	testVol = volCR
+	testVol.Spec.LocationType = apiV1.LocationTypeDrive
+	testVol.Spec.Location = driveUUID
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, testVol.Name, &testVol))
+	removingDrive := vm.k8sClient.ConstructDriveCR(testVol.Spec.Location, api.Drive{
+		UUID:              testVol.Spec.Location,
+		NodeId:            nodeID,
+		OperationalStatus: apiV1.DriveOpStatusRemoving,
+	})
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, removingDrive.Name, removingDrive))
+	pMock = &mockProv.MockProvisioner{}
+	vm.SetProvisioners(map[p.VolumeType]p.Provisioner{p.DriveBasedVolumeType: pMock})
+
+	res, err = vm.prepareVolume(testCtx, &testVol)
+	assert.NotNil(t, err)
+	assert.Equal(t, res, ctrl.Result{})
+	pMock.AssertNotCalled(t, "PrepareVolume", mock.Anything)
+	err = vm.k8sClient.ReadCR(testCtx, req.Name, volume)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.Failed, volume.Spec.CSIStatus)
 }
 
 func TestVolumeManager_handleRemovingStatus(t *testing.T) {
@@ -324,15 +366,21 @@ func TestVolumeManager_handleRemovingStatus(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.True(t, res.Requeue)
 
-	// ReleaseVolume failed
+	// ReleaseVolume failed, attempt is retried with backoff
 	testVol = volCR
-	testVol.Spec.CSIStatus = apiV1.Removing
-	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, volCR.Name, &volCR))
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, testVol.Name, &testVol))
 	pMock = &mockProv.MockProvisioner{}
 	pMock.On("ReleaseVolume", volCR.Spec).Return(testErr)
 	vm.SetProvisioners(map[p.VolumeType]p.Provisioner{p.DriveBasedVolumeType: pMock})
 
-	res, err = vm.handleRemovingStatus(testCtx, &volCR)
+	res, err = vm.handleRemovingStatus(testCtx, &testVol)
+	assert.Nil(t, err)
+	assert.Equal(t, ctrl.Result{RequeueAfter: base.DefaultRequeueForVolume}, res)
+	assert.Equal(t, int32(1), testVol.Status.Retries)
+
+	// ReleaseVolume failed, attempts are exhausted
+	testVol.Status.Retries = base.DefaultVolumeRetries
+	res, err = vm.handleRemovingStatus(testCtx, &testVol)
 	assert.NotNil(t, err)
 	assert.Equal(t, res, ctrl.Result{})
 	err = vm.k8sClient.ReadCR(testCtx, req.Name, volume)
@@ -412,6 +460,7 @@ func TestVolumeManager_handleCreatingVolumeInLVG(t *testing.T) {
 	res, err = vm.handleCreatingVolumeInLVG(testCtx, &testVol)
 	assert.Nil(t, err)
 	assert.Equal(t, expectedResRequeue, res)
+	assert.Equal(t, apiV1.ReasonWaitingForLVG, testVol.Status.GetCondition(apiV1.ConditionProgressing).Reason)
 
 	// LVG in failed state and volume is updated successfully
 	vm = prepareSuccessVolumeManager(t)
@@ -707,6 +756,30 @@ func TestVolumeManager_DiscoverAvailableCapacityDriveUnhealthy(t *testing.T) {
 	assert.Equal(t, 1, len(acList.Items))
 }
 
+func TestVolumeManager_discoverAvailableCapacity_SubDrive(t *testing.T) {
+	var (
+		vm  = prepareSuccessVolumeManager(t)
+		vol = testVolume1
+	)
+
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, drive1.UUID, vm.k8sClient.ConstructDriveCR(drive1.UUID, drive1)))
+	vol.Location = drive1.UUID
+	vol.NodeId = vm.nodeID
+	vol.Size = int64(util.GBYTE)
+	vol.PartitionLayout = apiV1.PartitionLayoutMulti
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, vol.Id, vm.k8sClient.ConstructVolumeCR(vol.Id, vol)))
+
+	// AC of drive shared by volumes keeps free space of the drive
+	assert.Nil(t, vm.discoverAvailableCapacity(testCtx))
+	acs := getACCRsListItems(t, vm.k8sClient)
+	assert.Len(t, acs, 1)
+	assert.Equal(t, drive1.Size-capacityplanner.SubDriveAllocatedSize(vol.Size), acs[0].Spec.Size)
+
+	// existing AC isn't removed
+	assert.Nil(t, vm.discoverAvailableCapacity(testCtx))
+	assert.Len(t, getACCRsListItems(t, vm.k8sClient), 1)
+}
+
 func TestVolumeManager_updatesDrivesCRs_Success(t *testing.T) {
 	vm := prepareSuccessVolumeManager(t)
 	driveMgrRespDrives := getDriveMgrRespBasedOnDrives(drive1, drive2)
@@ -731,9 +804,20 @@ func TestVolumeManager_updatesDrivesCRs_Success(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, vm.crHelper.GetDriveCRByUUID(driveMgrRespDrives[0].UUID).Spec.Health, apiV1.HealthUnknown)
 	assert.Equal(t, vm.crHelper.GetDriveCRByUUID(driveMgrRespDrives[0].UUID).Spec.Status, apiV1.DriveStatusOffline)
+	assert.Equal(t, apiV1.DriveOpStatusRemoving,
+		vm.crHelper.GetDriveCRByUUID(driveMgrRespDrives[0].UUID).Spec.OperationalStatus)
 	assert.Len(t, updates.Updated, 1)
 	assert.Len(t, updates.NotChanged, 1)
 
+	// removed drive is inserted back
+	driveMgrRespDrives[0].Health = apiV1.HealthGood
+	driveMgrRespDrives[0].Status = apiV1.DriveStatusOnline
+	updates, err = vm.updateDrivesCRs(testCtx, driveMgrRespDrives)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.DriveOpStatusOperative,
+		vm.crHelper.GetDriveCRByUUID(driveMgrRespDrives[0].UUID).Spec.OperationalStatus)
+	assert.Len(t, updates.Updated, 1)
+
 	vm = prepareSuccessVolumeManager(t)
 	driveCRs, err = vm.crHelper.GetDriveCRs(vm.nodeID)
 	assert.Nil(t, err)
@@ -834,6 +918,14 @@ func TestVolumeManager_handleDriveStatusChange(t *testing.T) {
 	err = vm.k8sClient.ReadCR(testCtx, testID, rVolume)
 	assert.Nil(t, err)
 	assert.Equal(t, apiV1.HealthBad, rVolume.Spec.Health)
+
+	// Check volume on removed drive is suspect
+	drive.Status = apiV1.DriveStatusOffline
+	drive.Health = apiV1.HealthUnknown
+	vm.handleDriveStatusChange(testCtx, &drive)
+	err = vm.k8sClient.ReadCR(testCtx, testID, rVolume)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.HealthSuspect, rVolume.Spec.Health)
 }
 
 func Test_discoverLVGOnSystemDrive_LVGAlreadyExists(t *testing.T) {
@@ -1050,6 +1142,126 @@ func TestVolumeManager_isDriveIsInLVG(t *testing.T) {
 	assert.False(t, vm.isDriveInLVG(drive2))
 }
 
+func TestVolumeManager_handleDrivesDeletion(t *testing.T) {
+	var (
+		vm    *VolumeManager
+		drive = &drivecrd.Drive{}
+	)
+
+	// finalizer is appended
+	vm = prepareSuccessVolumeManagerWithDrives([]*api.Drive{&drive1}, t)
+	assert.Nil(t, vm.handleDrivesDeletion(testCtx))
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive1.UUID, drive))
+	assert.Contains(t, drive.Finalizers, driveFinalizer)
+
+	// drive is in use by volume, finalizer remains
+	vol := volCR.DeepCopy()
+	vol.Spec.Location = drive1.UUID
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, vol.Name, vol))
+	drive.DeletionTimestamp = &v1.Time{Time: time.Now()}
+	assert.Nil(t, vm.k8sClient.UpdateCR(testCtx, drive))
+	assert.Nil(t, vm.handleDrivesDeletion(testCtx))
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive1.UUID, drive))
+	assert.Contains(t, drive.Finalizers, driveFinalizer)
+
+	// drive isn't in use, AC and finalizer are removed
+	assert.Nil(t, vm.k8sClient.DeleteCR(testCtx, vol))
+	ac := acCR.DeepCopy()
+	ac.Spec.Location = drive1.UUID
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, ac.Name, ac))
+	assert.Nil(t, vm.handleDrivesDeletion(testCtx))
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive1.UUID, drive))
+	assert.NotContains(t, drive.Finalizers, driveFinalizer)
+	assert.True(t, k8sError.IsNotFound(vm.k8sClient.ReadCR(testCtx, ac.Name, &accrd.AvailableCapacity{})))
+}
+
+func TestVolumeManager_handleDrivesActions(t *testing.T) {
+	var (
+		vm    *VolumeManager
+		drive = &drivecrd.Drive{}
+	)
+
+	// locate is requested
+	vm = prepareSuccessVolumeManagerWithDrives([]*api.Drive{&drive1}, t)
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive1.UUID, drive))
+	drive.Annotations = map[string]string{apiV1.DriveLocateAnnotationKey: apiV1.DriveLocateStart}
+	assert.Nil(t, vm.k8sClient.UpdateCR(testCtx, drive))
+	assert.Nil(t, vm.handleDrivesActions(testCtx))
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive1.UUID, drive))
+	assert.Equal(t, strconv.Itoa(int(apiV1.LocateStatusOn)), drive.Spec.LEDState)
+	assert.NotContains(t, drive.Annotations, apiV1.DriveLocateAnnotationKey)
+
+	// replacement is requested, AC is removed
+	ac := acCR.DeepCopy()
+	ac.Spec.Location = drive1.UUID
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, ac.Name, ac))
+	drive.Annotations = map[string]string{apiV1.DriveReplacementAnnotationKey: "true"}
+	drive.Spec.LEDState = ""
+	assert.Nil(t, vm.k8sClient.UpdateCR(testCtx, drive))
+	assert.Nil(t, vm.handleDrivesActions(testCtx))
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive1.UUID, drive))
+	assert.Equal(t, apiV1.DriveOpStatusReleasing, drive.Spec.OperationalStatus)
+	assert.Equal(t, strconv.Itoa(int(apiV1.LocateStatusOn)), drive.Spec.LEDState)
+	assert.Empty(t, drive.Annotations)
+	assert.True(t, k8sError.IsNotFound(vm.k8sClient.ReadCR(testCtx, ac.Name, &accrd.AvailableCapacity{})))
+}
+
+func Test_setVolumeConditions(t *testing.T) {
+	vol := volCR.DeepCopy()
+	vol.Generation = 3
+	vol.Spec.CSIStatus = apiV1.Published
+	vol.Spec.Health = apiV1.HealthGood
+	vol.Spec.OperationalStatus = apiV1.OperationalStatusOperative
+
+	assert.True(t, setVolumeConditions(vol))
+	assert.Equal(t, int64(3), vol.Status.ObservedGeneration)
+	assert.True(t, vol.Status.IsConditionTrue(apiV1.ConditionProvisioned))
+	assert.True(t, vol.Status.IsConditionTrue(apiV1.ConditionPublished))
+	assert.True(t, vol.Status.IsConditionTrue(apiV1.ConditionReady))
+	assert.False(t, vol.Status.IsConditionTrue(apiV1.ConditionDegraded))
+	assert.Equal(t, "Published", vol.Status.GetCondition(apiV1.ConditionReady).Reason)
+	// nothing changed
+	assert.False(t, setVolumeConditions(vol))
+
+	vol.Spec.Health = apiV1.HealthBad
+	assert.True(t, setVolumeConditions(vol))
+	assert.True(t, vol.Status.IsConditionTrue(apiV1.ConditionDegraded))
+	assert.False(t, vol.Status.IsConditionTrue(apiV1.ConditionReady))
+}
+
+func Test_setVolumeConditions_Progressing(t *testing.T) {
+	vol := volCR.DeepCopy()
+	vol.Spec.CSIStatus = apiV1.Creating
+
+	assert.True(t, setVolumeConditions(vol))
+	assert.True(t, vol.Status.IsConditionTrue(apiV1.ConditionProgressing))
+	assert.Equal(t, "Creating", vol.Status.GetCondition(apiV1.ConditionProgressing).Reason)
+
+	// phase which was set during reconcile is kept
+	vol.Status.SetCondition(apiV1.ConditionProgressing, apiV1.ConditionStatusFromBool(true), apiV1.ReasonRetrying, "")
+	vol.Status.Retries = 2
+	assert.False(t, setVolumeConditions(vol))
+	assert.Equal(t, apiV1.ReasonRetrying, vol.Status.GetCondition(apiV1.ConditionProgressing).Reason)
+
+	// operation is finished
+	vol.Spec.CSIStatus = apiV1.Created
+	assert.True(t, setVolumeConditions(vol))
+	assert.False(t, vol.Status.IsConditionTrue(apiV1.ConditionProgressing))
+	assert.Equal(t, int32(0), vol.Status.Retries)
+}
+
+func Test_volumeBackoff(t *testing.T) {
+	assert.Equal(t, base.DefaultRequeueForVolume, volumeBackoff(1))
+	assert.Equal(t, 4*base.DefaultRequeueForVolume, volumeBackoff(3))
+	assert.Equal(t, base.MaxRequeueForVolume, volumeBackoff(100))
+}
+
+func Test_conditionReason(t *testing.T) {
+	assert.Equal(t, "Unknown", conditionReason(""))
+	assert.Equal(t, "VolumeReady", conditionReason(apiV1.VolumeReady))
+	assert.Equal(t, "Online", conditionReason(apiV1.DriveStatusOnline))
+}
+
 func prepareSuccessVolumeManager(t *testing.T) *VolumeManager {
 	c := mocks.NewMockDriveMgrClient(nil)
 	// create map of commands which must be mocked
@@ -0,0 +1,361 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/api/v1/volumemovecrd"
+)
+
+const (
+	// VolumeMoveInterval is the interval between two checks of VolumeMove CRs
+	VolumeMoveInterval = 10 * time.Second
+	// volumeMoveAcceptTimeout is the time during which receiver waits for connection of the source node
+	volumeMoveAcceptTimeout = 10 * time.Minute
+	// volumeMoveDialTimeout is the time during which source node tries to connect to the receiver
+	volumeMoveDialTimeout = 30 * time.Second
+	// volumeMoveTokenLen is the length of receiver token in bytes before hex encoding
+	volumeMoveTokenLen = 16
+)
+
+// RunVolumeMoving spawns goroutine which periodically sends or receives data of volumes of the node which are
+// moved through VolumeMove CRs. Receiver listens on provided IP which must be reachable from other nodes
+func (m *VolumeManager) RunVolumeMoving(ip string) {
+	m.volumeMoveIP = ip
+	go func() {
+		for {
+			time.Sleep(VolumeMoveInterval)
+			ctx, cancelFn := context.WithTimeout(context.Background(), VolumeMoveInterval)
+			if err := m.HandleVolumeMoves(ctx); err != nil {
+				m.log.WithField("method", "RunVolumeMoving").Errorf("Unable to handle volume moves: %v", err)
+			}
+			cancelFn()
+		}
+	}()
+}
+
+// HandleVolumeMoves starts receiver of data for moves in RECEIVING phase which target volume is located on the node
+// and sender of data for moves in COPYING phase which source volume is located on the node. Data is copied
+// as raw content of the volume device, so file system of the source volume is copied as is
+// Returns error if VolumeMove CRs can't be read
+func (m *VolumeManager) HandleVolumeMoves(ctx context.Context) error {
+	ll := m.log.WithField("method", "HandleVolumeMoves")
+
+	moves := &volumemovecrd.VolumeMoveList{}
+	if err := m.k8sClient.ReadList(ctx, moves); err != nil {
+		return err
+	}
+
+	active := make(map[string]struct{})
+	for i := range moves.Items {
+		move := &moves.Items[i]
+		if move.Spec.Phase != apiV1.VolumeMoveReceiving && move.Spec.Phase != apiV1.VolumeMoveCopying {
+			continue
+		}
+		active[move.Name] = struct{}{}
+		if m.isVolumeMoveRunning(move.Name) {
+			continue
+		}
+		if err := m.handleVolumeMove(ctx, move); err != nil {
+			ll.Errorf("Unable to handle volume move %s in phase %s: %v", move.Name, move.Spec.Phase, err)
+		}
+	}
+	m.pruneVolumeMoves(active)
+	return nil
+}
+
+// handleVolumeMove starts receiver or sender of data if volume of the move is located on the node
+func (m *VolumeManager) handleVolumeMove(ctx context.Context, move *volumemovecrd.VolumeMove) error {
+	target, err := m.readMovedVolume(ctx, move.Spec.TargetVolumeID)
+	if err != nil {
+		return err
+	}
+
+	if move.Spec.Phase == apiV1.VolumeMoveReceiving {
+		if target == nil || target.Spec.NodeId != m.nodeID {
+			return nil
+		}
+		return m.startVolumeReceiver(ctx, move, target)
+	}
+
+	if target != nil && target.Spec.NodeId == m.nodeID {
+		// receiver lives only in memory, it is lost if node was restarted during copying
+		return m.setVolumeMovePhase(ctx, move.Name, apiV1.VolumeMoveFailed, "receiver of data was stopped")
+	}
+	source, err := m.readMovedVolume(ctx, move.Spec.SourceVolumeID)
+	if err != nil || source == nil || source.Spec.NodeId != m.nodeID {
+		return err
+	}
+	return m.startVolumeSender(ctx, move, source)
+}
+
+// readMovedVolume reads Volume CR of the move, returns nil if volume doesn't exist
+func (m *VolumeManager) readMovedVolume(ctx context.Context, volumeID string) (*volumecrd.Volume, error) {
+	if volumeID == "" {
+		return nil, nil
+	}
+	volume := &volumecrd.Volume{}
+	if err := m.k8sClient.ReadCR(ctx, volumeID, volume); err != nil {
+		if k8sError.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return volume, nil
+}
+
+// startVolumeReceiver opens listener for data of the move, publishes its address and token in VolumeMove CR
+// and writes received data to the device of target volume in background
+func (m *VolumeManager) startVolumeReceiver(ctx context.Context, move *volumemovecrd.VolumeMove,
+	target *volumecrd.Volume) error {
+	ll := m.log.WithFields(logrus.Fields{
+		"method": "startVolumeReceiver",
+		"move":   move.Name,
+	})
+
+	device, err := m.getProvisionerForVolume(&target.Spec).GetVolumePath(target.Spec)
+	if err != nil {
+		return m.setVolumeMovePhase(ctx, move.Name, apiV1.VolumeMoveFailed,
+			fmt.Sprintf("unable to find device of target volume: %v", err))
+	}
+	token, err := generateVolumeMoveToken()
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort(m.volumeMoveIP, "0"))
+	if err != nil {
+		return err
+	}
+
+	m.setVolumeMoveRunning(move.Name, true)
+	move.Spec.ReceiverAddress = listener.Addr().String()
+	move.Spec.ReceiverToken = token
+	move.Spec.Phase = apiV1.VolumeMoveCopying
+	if err = m.k8sClient.UpdateCR(ctx, move); err != nil {
+		_ = listener.Close()
+		m.setVolumeMoveRunning(move.Name, false)
+		return err
+	}
+
+	ll.Infof("Waiting for data of volume %s on %s", move.Spec.SourceVolumeID, move.Spec.ReceiverAddress)
+	go func() {
+		size, err := receiveVolumeData(listener, token, device, volumeMoveAcceptTimeout)
+		ctx, cancelFn := context.WithTimeout(context.Background(), VolumeMoveInterval)
+		defer cancelFn()
+		if err != nil {
+			ll.Errorf("Unable to receive data into %s: %v", device, err)
+			err = m.setVolumeMovePhase(ctx, move.Name, apiV1.VolumeMoveFailed,
+				fmt.Sprintf("unable to receive data: %v", err))
+		} else {
+			ll.Infof("%d bytes were written to %s", size, device)
+			err = m.setVolumeMovePhase(ctx, move.Name, apiV1.VolumeMoveRebinding, "")
+		}
+		if err != nil {
+			ll.Errorf("Unable to update VolumeMove CR: %v", err)
+		}
+	}()
+	return nil
+}
+
+// startVolumeSender sends content of the device of source volume to the receiver in background
+func (m *VolumeManager) startVolumeSender(ctx context.Context, move *volumemovecrd.VolumeMove,
+	source *volumecrd.Volume) error {
+	ll := m.log.WithFields(logrus.Fields{
+		"method": "startVolumeSender",
+		"move":   move.Name,
+	})
+
+	device, err := m.getProvisionerForVolume(&source.Spec).GetVolumePath(source.Spec)
+	if err != nil {
+		return m.setVolumeMovePhase(ctx, move.Name, apiV1.VolumeMoveFailed,
+			fmt.Sprintf("unable to find device of source volume: %v", err))
+	}
+
+	// sender stays marked as running until phase is changed by receiver, so data isn't sent twice
+	m.setVolumeMoveRunning(move.Name, true)
+	ll.Infof("Sending data of %s to %s", device, move.Spec.ReceiverAddress)
+	go func() {
+		size, err := sendVolumeData(move.Spec.ReceiverAddress, move.Spec.ReceiverToken, device)
+		if err == nil {
+			ll.Infof("%d bytes were sent from %s", size, device)
+			return
+		}
+		ll.Errorf("Unable to send data of %s: %v", device, err)
+		ctx, cancelFn := context.WithTimeout(context.Background(), VolumeMoveInterval)
+		defer cancelFn()
+		if err = m.setVolumeMovePhase(ctx, move.Name, apiV1.VolumeMoveFailed,
+			fmt.Sprintf("unable to send data: %v", err)); err != nil {
+			ll.Errorf("Unable to update VolumeMove CR: %v", err)
+		}
+	}()
+	return nil
+}
+
+// setVolumeMovePhase sets phase and message of VolumeMove CR, failed move isn't changed
+func (m *VolumeManager) setVolumeMovePhase(ctx context.Context, name, phase, message string) error {
+	move := &volumemovecrd.VolumeMove{}
+	if err := m.k8sClient.ReadCR(ctx, name, move); err != nil {
+		return err
+	}
+	if move.Spec.Phase == apiV1.VolumeMoveFailed {
+		return nil
+	}
+	move.Spec.Phase = phase
+	move.Spec.Message = message
+	return m.k8sClient.UpdateCR(ctx, move)
+}
+
+func (m *VolumeManager) isVolumeMoveRunning(name string) bool {
+	m.volumeMovesMu.Lock()
+	defer m.volumeMovesMu.Unlock()
+	_, ok := m.volumeMoves[name]
+	return ok
+}
+
+func (m *VolumeManager) setVolumeMoveRunning(name string, running bool) {
+	m.volumeMovesMu.Lock()
+	defer m.volumeMovesMu.Unlock()
+	if running {
+		m.volumeMoves[name] = struct{}{}
+	} else {
+		delete(m.volumeMoves, name)
+	}
+}
+
+// pruneVolumeMoves forgets moves which are no longer in RECEIVING or COPYING phase
+func (m *VolumeManager) pruneVolumeMoves(active map[string]struct{}) {
+	m.volumeMovesMu.Lock()
+	defer m.volumeMovesMu.Unlock()
+	for name := range m.volumeMoves {
+		if _, ok := active[name]; !ok {
+			delete(m.volumeMoves, name)
+		}
+	}
+}
+
+// generateVolumeMoveToken returns random hex encoded token which source node sends before data
+func generateVolumeMoveToken() (string, error) {
+	buf := make([]byte, volumeMoveTokenLen)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// sendVolumeData streams content of the device to the receiver. Stream starts with token and size of data
+// as big-endian uint64, receiver confirms that data was written with a single byte
+// Returns amount of sent bytes
+func sendVolumeData(address, token, device string) (int64, error) {
+	f, err := os.Open(device)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	conn, err := net.DialTimeout("tcp", address, volumeMoveDialTimeout)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	header := make([]byte, len(token)+8)
+	copy(header, token)
+	binary.BigEndian.PutUint64(header[len(token):], uint64(size))
+	if _, err = conn.Write(header); err != nil {
+		return 0, err
+	}
+	sent, err := io.CopyN(conn, f, size)
+	if err != nil {
+		return sent, err
+	}
+	if _, err = io.ReadFull(conn, make([]byte, 1)); err != nil {
+		return sent, fmt.Errorf("receiver didn't confirm data: %v", err)
+	}
+	return sent, nil
+}
+
+// receiveVolumeData accepts single connection from the sender, checks its token and writes data to the device,
+// listener is closed after connection is accepted or timeout is over
+// Returns amount of written bytes
+func receiveVolumeData(listener net.Listener, token, device string, timeout time.Duration) (int64, error) {
+	if tcpListener, ok := listener.(*net.TCPListener); ok {
+		_ = tcpListener.SetDeadline(time.Now().Add(timeout))
+	}
+	conn, err := listener.Accept()
+	_ = listener.Close()
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	header := make([]byte, len(token)+8)
+	if _, err = io.ReadFull(conn, header); err != nil {
+		return 0, err
+	}
+	if subtle.ConstantTimeCompare(header[:len(token)], []byte(token)) != 1 {
+		return 0, fmt.Errorf("sender %s provided wrong token", conn.RemoteAddr())
+	}
+	size := int64(binary.BigEndian.Uint64(header[len(token):]))
+
+	f, err := os.OpenFile(device, os.O_WRONLY, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	capacity, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+	if size > capacity {
+		return 0, fmt.Errorf("data of %d bytes doesn't fit into %d bytes of %s", size, capacity, device)
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	written, err := io.CopyN(f, conn, size)
+	if err != nil {
+		return written, err
+	}
+	if err = f.Sync(); err != nil {
+		return written, err
+	}
+	_, err = conn.Write([]byte{1})
+	return written, err
+}
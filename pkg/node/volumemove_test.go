@@ -0,0 +1,141 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/volumemovecrd"
+	"github.com/dell/csi-baremetal/pkg/mocks"
+	mockProv "github.com/dell/csi-baremetal/pkg/mocks/provisioners"
+	p "github.com/dell/csi-baremetal/pkg/node/provisioners"
+)
+
+// prepareVolumeMoveFiles creates source file with data and empty target file of double size in temp dir
+func prepareVolumeMoveFiles(t *testing.T) (dir, source, target string, data []byte) {
+	dir, err := ioutil.TempDir("", "volumemove")
+	assert.Nil(t, err)
+	data = bytes.Repeat([]byte("volume-data"), 1000)
+	source = filepath.Join(dir, "source")
+	assert.Nil(t, ioutil.WriteFile(source, data, 0600))
+	target = filepath.Join(dir, "target")
+	assert.Nil(t, ioutil.WriteFile(target, make([]byte, 2*len(data)), 0600))
+	return dir, source, target, data
+}
+
+func TestVolumeManager_HandleVolumeMoves(t *testing.T) {
+	dir, sourcePath, targetPath, data := prepareVolumeMoveFiles(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		sourceVM = prepareSuccessVolumeManager(t)
+		targetVM = NewVolumeManager(mocks.NewMockDriveMgrClient(nil), mocks.NewMockExecutor(nil), testLogger,
+			sourceVM.k8sClient, new(mocks.NoOpRecorder), "target-node")
+		pMock  = &mockProv.MockProvisioner{}
+		source = sourceVM.k8sClient.ConstructVolumeCR("pvc-source", api.Volume{Id: "pvc-source", NodeId: nodeID,
+			CSIStatus: apiV1.Created})
+		target = sourceVM.k8sClient.ConstructVolumeCR("pvc-target", api.Volume{Id: "pvc-target",
+			NodeId: "target-node", CSIStatus: apiV1.Created})
+		move = &volumemovecrd.VolumeMove{
+			ObjectMeta: metaV1.ObjectMeta{Name: "move", Namespace: testNs},
+			Spec: volumemovecrd.VolumeMoveSpec{SourceVolumeID: "pvc-source", TargetNodeID: "target-node",
+				TargetVolumeID: "pvc-target", Phase: apiV1.VolumeMoveReceiving},
+		}
+	)
+	assert.Nil(t, sourceVM.k8sClient.CreateCR(testCtx, source.Name, source))
+	assert.Nil(t, sourceVM.k8sClient.CreateCR(testCtx, target.Name, target))
+	assert.Nil(t, sourceVM.k8sClient.CreateCR(testCtx, move.Name, move))
+	pMock.On("GetVolumePath", source.Spec).Return(sourcePath, nil)
+	pMock.On("GetVolumePath", target.Spec).Return(targetPath, nil)
+	provisioners := map[p.VolumeType]p.Provisioner{p.DriveBasedVolumeType: pMock, p.LVMBasedVolumeType: pMock}
+	sourceVM.SetProvisioners(provisioners)
+	targetVM.SetProvisioners(provisioners)
+	targetVM.volumeMoveIP = "127.0.0.1"
+
+	// receiver is started on target node only
+	assert.Nil(t, sourceVM.HandleVolumeMoves(testCtx))
+	assert.Nil(t, sourceVM.k8sClient.ReadCR(testCtx, move.Name, move))
+	assert.Equal(t, apiV1.VolumeMoveReceiving, move.Spec.Phase)
+	assert.Nil(t, targetVM.HandleVolumeMoves(testCtx))
+	assert.Nil(t, sourceVM.k8sClient.ReadCR(testCtx, move.Name, move))
+	assert.Equal(t, apiV1.VolumeMoveCopying, move.Spec.Phase)
+	assert.NotEmpty(t, move.Spec.ReceiverAddress)
+	assert.NotEmpty(t, move.Spec.ReceiverToken)
+
+	// source node sends data, receiver moves VolumeMove to rebinding
+	assert.Nil(t, sourceVM.HandleVolumeMoves(testCtx))
+	for i := 0; i < 50 && move.Spec.Phase == apiV1.VolumeMoveCopying; i++ {
+		time.Sleep(100 * time.Millisecond)
+		assert.Nil(t, sourceVM.k8sClient.ReadCR(testCtx, move.Name, move))
+	}
+	assert.Equal(t, apiV1.VolumeMoveRebinding, move.Spec.Phase)
+	received, err := ioutil.ReadFile(targetPath)
+	assert.Nil(t, err)
+	assert.Equal(t, data, received[:len(data)])
+
+	// finished move is forgotten
+	assert.Nil(t, targetVM.HandleVolumeMoves(testCtx))
+	assert.False(t, targetVM.isVolumeMoveRunning(move.Name))
+
+	// receiver of target node was restarted during copying
+	move.Spec.Phase = apiV1.VolumeMoveCopying
+	assert.Nil(t, sourceVM.k8sClient.UpdateCR(testCtx, move))
+	assert.Nil(t, targetVM.HandleVolumeMoves(testCtx))
+	assert.Nil(t, sourceVM.k8sClient.ReadCR(testCtx, move.Name, move))
+	assert.Equal(t, apiV1.VolumeMoveFailed, move.Spec.Phase)
+}
+
+func Test_receiveVolumeData(t *testing.T) {
+	dir, sourcePath, targetPath, _ := prepareVolumeMoveFiles(t)
+	defer os.RemoveAll(dir)
+	receive := func(token, device string) <-chan error {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.Nil(t, err)
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := receiveVolumeData(listener, token, device, time.Second)
+			errCh <- err
+		}()
+		_, err = sendVolumeData(listener.Addr().String(), "sender-token", sourcePath)
+		assert.NotNil(t, err)
+		return errCh
+	}
+
+	// wrong token
+	assert.NotNil(t, <-receive("other-token-", targetPath))
+	// data doesn't fit into the device
+	smallPath := filepath.Join(dir, "small")
+	assert.Nil(t, ioutil.WriteFile(smallPath, make([]byte, 10), 0600))
+	assert.NotNil(t, <-receive("sender-token", smallPath))
+
+	// nobody connected in time
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	_, err = receiveVolumeData(listener, "token", targetPath, 100*time.Millisecond)
+	assert.NotNil(t, err)
+}
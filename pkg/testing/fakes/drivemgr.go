@@ -0,0 +1,105 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakes
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+)
+
+// DriveServiceClient is the in-memory implementation of api.DriveServiceClient which replaces client
+// of DriveMgr gRPC server, it returns configured drives and keeps state of their LEDs
+type DriveServiceClient struct {
+	sync.Mutex
+	drives  []*api.Drive
+	locates map[string]int32
+	err     error
+}
+
+var _ api.DriveServiceClient = &DriveServiceClient{}
+
+// NewDriveServiceClient is the constructor for DriveServiceClient struct
+// Receives drives which are returned by GetDrivesList
+// Returns an instance of DriveServiceClient
+func NewDriveServiceClient(drives ...*api.Drive) *DriveServiceClient {
+	return &DriveServiceClient{drives: drives, locates: make(map[string]int32)}
+}
+
+// SetDrives replaces drives which are returned by GetDrivesList, e.g. to simulate drive removal
+func (c *DriveServiceClient) SetDrives(drives ...*api.Drive) {
+	c.Lock()
+	defer c.Unlock()
+	c.drives = drives
+}
+
+// FailWith makes all calls return err until it is reset by nil, e.g. to simulate unavailable DriveMgr
+func (c *DriveServiceClient) FailWith(err error) {
+	c.Lock()
+	defer c.Unlock()
+	c.err = err
+}
+
+// GetDrivesList returns copies of configured drives with node ID from request
+func (c *DriveServiceClient) GetDrivesList(_ context.Context, in *api.DrivesRequest,
+	_ ...grpc.CallOption) (*api.DrivesResponse, error) {
+	c.Lock()
+	defer c.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	disks := make([]*api.Drive, 0, len(c.drives))
+	for _, d := range c.drives {
+		drive := *d
+		drive.NodeId = in.GetNodeId()
+		disks = append(disks, &drive)
+	}
+	return &api.DrivesResponse{Disks: disks}, nil
+}
+
+// Locate changes and returns state of LED of drive with provided serial number
+func (c *DriveServiceClient) Locate(_ context.Context, in *api.DriveLocateRequest,
+	_ ...grpc.CallOption) (*api.DriveLocateResponse, error) {
+	c.Lock()
+	defer c.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	found := false
+	for _, d := range c.drives {
+		if d.SerialNumber == in.GetDriveSerialNumber() {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, status.Errorf(codes.NotFound, "drive %s isn't found", in.GetDriveSerialNumber())
+	}
+	switch in.GetAction() {
+	case apiV1.LocateStart:
+		c.locates[in.GetDriveSerialNumber()] = apiV1.LocateStatusOn
+	case apiV1.LocateStop:
+		c.locates[in.GetDriveSerialNumber()] = apiV1.LocateStatusOff
+	}
+	return &api.DriveLocateResponse{Status: c.locates[in.GetDriveSerialNumber()]}, nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
+)
+
+var testCtx = context.Background()
+
+func TestVolumeOperations(t *testing.T) {
+	vo := NewVolumeOperations("node1")
+
+	volume, err := vo.CreateVolume(testCtx, api.Volume{Id: "pvc-1", Size: 100})
+	assert.Nil(t, err)
+	assert.Equal(t, "node1", volume.NodeId)
+	assert.Equal(t, apiV1.Created, volume.CSIStatus)
+	assert.Nil(t, vo.WaitStatus(testCtx, "pvc-1", apiV1.Created))
+
+	// existing volume is returned
+	volume, err = vo.CreateVolume(testCtx, api.Volume{Id: "pvc-1", Size: 200})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(100), volume.Size)
+
+	assert.Nil(t, vo.DeleteVolume(testCtx, "pvc-1"))
+	assert.NotNil(t, vo.WaitStatus(testCtx, "pvc-1", apiV1.Created))
+	vo.UpdateCRsAfterVolumeDeletion(testCtx, "pvc-1")
+	assert.Nil(t, vo.GetVolume("pvc-1"))
+	assert.True(t, k8sError.IsNotFound(vo.DeleteVolume(testCtx, "pvc-1")))
+
+	injected := errors.New("error")
+	vo.FailWith("CreateVolume", injected)
+	_, err = vo.CreateVolume(testCtx, api.Volume{Id: "pvc-2"})
+	assert.Equal(t, injected, err)
+}
+
+func TestDriveServiceClient(t *testing.T) {
+	client := NewDriveServiceClient(&api.Drive{SerialNumber: "hdd1"})
+
+	resp, err := client.GetDrivesList(testCtx, &api.DrivesRequest{NodeId: "node1"})
+	assert.Nil(t, err)
+	assert.Equal(t, []*api.Drive{{SerialNumber: "hdd1", NodeId: "node1"}}, resp.Disks)
+
+	locate, err := client.Locate(testCtx, &api.DriveLocateRequest{DriveSerialNumber: "hdd1", Action: apiV1.LocateStart})
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocateStatusOn, locate.Status)
+	_, err = client.Locate(testCtx, &api.DriveLocateRequest{DriveSerialNumber: "hdd2", Action: apiV1.LocateStart})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	client.SetDrives()
+	resp, err = client.GetDrivesList(testCtx, &api.DrivesRequest{NodeId: "node1"})
+	assert.Nil(t, err)
+	assert.Empty(t, resp.Disks)
+
+	client.FailWith(status.Error(codes.Unavailable, "unavailable"))
+	_, err = client.GetDrivesList(testCtx, &api.DrivesRequest{NodeId: "node1"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestProvisioner(t *testing.T) {
+	p := NewProvisioner("/dev/fake")
+	volume := api.Volume{Id: "pvc-1"}
+
+	_, err := p.GetVolumePath(volume)
+	assert.NotNil(t, err)
+
+	assert.Nil(t, p.PrepareVolume(volume))
+	path, err := p.GetVolumePath(volume)
+	assert.Nil(t, err)
+	assert.Equal(t, "/dev/fake/pvc-1", path)
+
+	assert.Nil(t, p.ReleaseVolume(volume))
+	assert.False(t, p.IsPrepared(volume.Id))
+}
+
+func TestFS(t *testing.T) {
+	f := NewFS()
+
+	assert.NotNil(t, f.Mount("/dev/sda1", "/staging"))
+	assert.Nil(t, f.CreateFS(fs.XFS, "/dev/sda1"))
+	assert.Nil(t, f.Mount("/dev/sda1", "/staging"))
+	assert.Nil(t, f.Mount("/staging", "/target", fs.BindOption, fs.ReadOnlyOption))
+
+	source, err := f.FindMountPoint("/target")
+	assert.Nil(t, err)
+	assert.Equal(t, "/dev/sda1", source)
+	readOnly, err := f.IsReadOnlyMount("/target")
+	assert.Nil(t, err)
+	assert.True(t, readOnly)
+
+	assert.Nil(t, f.Unmount("/target"))
+	mounted, err := f.IsMounted("/target")
+	assert.Nil(t, err)
+	assert.False(t, mounted)
+}
+
+func TestLVM(t *testing.T) {
+	l := NewLVM(1024 * 1024 * 1024)
+
+	assert.NotNil(t, l.VGCreate("vg", "/dev/sda"))
+	assert.Nil(t, l.PVCreate("/dev/sda"))
+	assert.Nil(t, l.VGCreate("vg", "/dev/sda"))
+	assert.Nil(t, l.LVCreate("lv1", "512m", "vg"))
+	assert.NotNil(t, l.LVCreate("lv2", "1g", "vg"))
+
+	free, err := l.GetVgFreeSpace("vg")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(512*1024*1024), free)
+	assert.NotNil(t, l.VGRemove("vg"))
+
+	assert.Nil(t, l.LVRemove("/dev/vg/lv1"))
+	assert.Nil(t, l.VGRemove("vg"))
+	assert.Nil(t, l.PVRemove("/dev/sda"))
+}
@@ -0,0 +1,204 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakes
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
+)
+
+// mount is a record of FS mount table
+type mount struct {
+	source   string
+	readOnly bool
+}
+
+// FS is the in-memory implementation of fs.WrapFS which keeps directories, file systems of devices
+// and mount table instead of calling system utils
+type FS struct {
+	sync.Mutex
+	// FreeSpace is returned by GetFSSpace for mounted paths
+	FreeSpace   int64
+	dirs        map[string]struct{}
+	fileSystems map[string]fs.FileSystem
+	mounts      map[string]mount
+}
+
+var _ fs.WrapFS = &FS{}
+
+// NewFS is the constructor for FS struct
+// Returns an instance of FS without directories, file systems and mounts
+func NewFS() *FS {
+	return &FS{
+		dirs:        make(map[string]struct{}),
+		fileSystems: make(map[string]fs.FileSystem),
+		mounts:      make(map[string]mount),
+	}
+}
+
+// GetFSSpace returns FreeSpace if src is mounted
+func (f *FS) GetFSSpace(src string) (int64, error) {
+	f.Lock()
+	defer f.Unlock()
+	if _, ok := f.mounts[src]; !ok {
+		return 0, fmt.Errorf("%s isn't mounted", src)
+	}
+	return f.FreeSpace, nil
+}
+
+// MkDir creates directory
+func (f *FS) MkDir(src string) error {
+	f.Lock()
+	defer f.Unlock()
+	f.dirs[src] = struct{}{}
+	return nil
+}
+
+// RmDir removes directory, it fails if something is mounted to it
+func (f *FS) RmDir(src string) error {
+	f.Lock()
+	defer f.Unlock()
+	if _, ok := f.mounts[src]; ok {
+		return fmt.Errorf("%s is busy", src)
+	}
+	delete(f.dirs, src)
+	return nil
+}
+
+// CreateFS creates file system of provided type on device
+func (f *FS) CreateFS(fsType fs.FileSystem, device string) error {
+	f.Lock()
+	defer f.Unlock()
+	f.fileSystems[device] = fsType
+	return nil
+}
+
+// GrowFS checks that device has file system of provided type
+func (f *FS) GrowFS(fsType fs.FileSystem, device, _ string) error {
+	f.Lock()
+	defer f.Unlock()
+	if f.fileSystems[device] != fsType {
+		return fmt.Errorf("device %s doesn't have %s file system", device, fsType)
+	}
+	return nil
+}
+
+// WipeFS removes file system from device
+func (f *FS) WipeFS(device string) error {
+	f.Lock()
+	defer f.Unlock()
+	delete(f.fileSystems, device)
+	return nil
+}
+
+// GetFSType returns type of file system on device or empty string
+func (f *FS) GetFSType(device string) (fs.FileSystem, error) {
+	f.Lock()
+	defer f.Unlock()
+	return f.fileSystems[device], nil
+}
+
+// IsMounted checks whether something is mounted to path
+func (f *FS) IsMounted(src string) (bool, error) {
+	f.Lock()
+	defer f.Unlock()
+	_, ok := f.mounts[src]
+	return ok, nil
+}
+
+// FindMountPoint returns source which is mounted to target
+func (f *FS) FindMountPoint(target string) (string, error) {
+	f.Lock()
+	defer f.Unlock()
+	m, ok := f.mounts[target]
+	if !ok {
+		return "", fmt.Errorf("%s isn't mounted", target)
+	}
+	return m.source, nil
+}
+
+// FindMountTarget returns the first target to which device is mounted
+func (f *FS) FindMountTarget(device string) (string, error) {
+	f.Lock()
+	defer f.Unlock()
+	for target, m := range f.mounts {
+		if m.source == device {
+			return target, nil
+		}
+	}
+	return "", fmt.Errorf("%s isn't mounted", device)
+}
+
+// IsReadOnlyMount checks whether path is mounted read-only
+func (f *FS) IsReadOnlyMount(path string) (bool, error) {
+	f.Lock()
+	defer f.Unlock()
+	m, ok := f.mounts[path]
+	if !ok {
+		return false, fmt.Errorf("%s isn't mounted", path)
+	}
+	return m.readOnly, nil
+}
+
+// Trim checks that mountPoint is mounted
+func (f *FS) Trim(mountPoint string) error {
+	f.Lock()
+	defer f.Unlock()
+	if _, ok := f.mounts[mountPoint]; !ok {
+		return fmt.Errorf("%s isn't mounted", mountPoint)
+	}
+	return nil
+}
+
+// Mount mounts src to dst, bind mount of path which isn't mounted or isn't a directory fails as well as mount of
+// device without file system
+func (f *FS) Mount(src, dst string, opts ...string) error {
+	f.Lock()
+	defer f.Unlock()
+	if _, ok := f.mounts[dst]; ok {
+		return fmt.Errorf("%s is already mounted", dst)
+	}
+	options := strings.Join(opts, " ")
+	bind := strings.Contains(options, fs.BindOption)
+	source := src
+	switch {
+	case bind:
+		if m, ok := f.mounts[src]; ok {
+			source = m.source
+		} else if _, ok := f.dirs[src]; !ok {
+			return fmt.Errorf("%s doesn't exist", src)
+		}
+	case f.fileSystems[src] == "":
+		return fmt.Errorf("device %s doesn't have file system", src)
+	}
+	f.mounts[dst] = mount{source: source, readOnly: strings.Contains(options, fs.ReadOnlyOption)}
+	return nil
+}
+
+// Unmount unmounts path
+func (f *FS) Unmount(src string) error {
+	f.Lock()
+	defer f.Unlock()
+	if _, ok := f.mounts[src]; !ok {
+		return fmt.Errorf("%s isn't mounted", src)
+	}
+	delete(f.mounts, src)
+	return nil
+}
@@ -0,0 +1,235 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lvm"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+)
+
+// LVM is the in-memory implementation of lvm.WrapLVM which keeps PVs, VGs and LVs instead of calling lvm util
+type LVM struct {
+	sync.Mutex
+	// VGSize is size of created VGs in bytes
+	VGSize int64
+	pvs    map[string]string           // PV -> VG
+	vgs    map[string]map[string]int64 // VG -> LV -> size
+}
+
+var _ lvm.WrapLVM = &LVM{}
+
+// NewLVM is the constructor for LVM struct
+// Receives size of created VGs in bytes
+// Returns an instance of LVM without PVs, VGs and LVs
+func NewLVM(vgSize int64) *LVM {
+	return &LVM{
+		VGSize: vgSize,
+		pvs:    make(map[string]string),
+		vgs:    make(map[string]map[string]int64),
+	}
+}
+
+// PVCreate creates PV on device
+func (l *LVM) PVCreate(dev string) error {
+	l.Lock()
+	defer l.Unlock()
+	if _, ok := l.pvs[dev]; !ok {
+		l.pvs[dev] = ""
+	}
+	return nil
+}
+
+// PVRemove removes PV which doesn't belong to VG
+func (l *LVM) PVRemove(name string) error {
+	l.Lock()
+	defer l.Unlock()
+	if l.pvs[name] != "" {
+		return fmt.Errorf("PV %s belongs to VG %s", name, l.pvs[name])
+	}
+	delete(l.pvs, name)
+	return nil
+}
+
+// VGCreate creates VG from existing PVs
+func (l *LVM) VGCreate(name string, pvs ...string) error {
+	l.Lock()
+	defer l.Unlock()
+	if _, ok := l.vgs[name]; ok {
+		return nil
+	}
+	for _, pv := range pvs {
+		if vg, ok := l.pvs[pv]; !ok || vg != "" {
+			return fmt.Errorf("PV %s doesn't exist or belongs to VG", pv)
+		}
+	}
+	for _, pv := range pvs {
+		l.pvs[pv] = name
+	}
+	l.vgs[name] = make(map[string]int64)
+	return nil
+}
+
+// VGRemove removes VG without LVs, its PVs are kept
+func (l *LVM) VGRemove(name string) error {
+	l.Lock()
+	defer l.Unlock()
+	if len(l.vgs[name]) > 0 {
+		return fmt.Errorf("VG %s contains LVs", name)
+	}
+	delete(l.vgs, name)
+	for pv, vg := range l.pvs {
+		if vg == name {
+			l.pvs[pv] = ""
+		}
+	}
+	return nil
+}
+
+// LVCreate creates LV of size in format of lvm util, e.g. 100m, if VG has enough free space
+func (l *LVM) LVCreate(name, size, vgName string) error {
+	l.Lock()
+	defer l.Unlock()
+	lvs, ok := l.vgs[vgName]
+	if !ok {
+		return fmt.Errorf("VG %s doesn't exist", vgName)
+	}
+	bytes, err := util.StrToBytes(size)
+	if err != nil {
+		return err
+	}
+	if bytes > l.freeSpace(vgName) {
+		return fmt.Errorf("VG %s doesn't have enough free space", vgName)
+	}
+	lvs[name] = bytes
+	return nil
+}
+
+// LVRemove removes LV by its full name /dev/<VG>/<LV>
+func (l *LVM) LVRemove(fullLVName string) error {
+	l.Lock()
+	defer l.Unlock()
+	vg, lv := splitLVName(fullLVName)
+	if _, ok := l.vgs[vg][lv]; !ok {
+		return fmt.Errorf("LV %s doesn't exist", fullLVName)
+	}
+	delete(l.vgs[vg], lv)
+	return nil
+}
+
+// LVExpand sets size of LV by its full name /dev/<VG>/<LV>
+func (l *LVM) LVExpand(fullLVName, size string) error {
+	l.Lock()
+	defer l.Unlock()
+	vg, lv := splitLVName(fullLVName)
+	current, ok := l.vgs[vg][lv]
+	if !ok {
+		return fmt.Errorf("LV %s doesn't exist", fullLVName)
+	}
+	bytes, err := util.StrToBytes(size)
+	if err != nil {
+		return err
+	}
+	if bytes-current > l.freeSpace(vg) {
+		return fmt.Errorf("VG %s doesn't have enough free space", vg)
+	}
+	l.vgs[vg][lv] = bytes
+	return nil
+}
+
+// IsVGContainsLVs checks whether VG has LVs
+func (l *LVM) IsVGContainsLVs(vgName string) bool {
+	l.Lock()
+	defer l.Unlock()
+	return len(l.vgs[vgName]) > 0
+}
+
+// RemoveOrphanPVs removes PVs which don't belong to VG
+func (l *LVM) RemoveOrphanPVs() error {
+	l.Lock()
+	defer l.Unlock()
+	for pv, vg := range l.pvs {
+		if vg == "" {
+			delete(l.pvs, pv)
+		}
+	}
+	return nil
+}
+
+// FindVgNameByLvName returns name of VG which contains LV
+func (l *LVM) FindVgNameByLvName(lvName string) (string, error) {
+	l.Lock()
+	defer l.Unlock()
+	for vg, lvs := range l.vgs {
+		if _, ok := lvs[lvName]; ok {
+			return vg, nil
+		}
+	}
+	return "", fmt.Errorf("LV %s doesn't exist", lvName)
+}
+
+// GetVgFreeSpace returns free space of VG in bytes
+func (l *LVM) GetVgFreeSpace(vgName string) (int64, error) {
+	l.Lock()
+	defer l.Unlock()
+	if _, ok := l.vgs[vgName]; !ok {
+		return 0, fmt.Errorf("VG %s doesn't exist", vgName)
+	}
+	return l.freeSpace(vgName), nil
+}
+
+// IsLVGExists checks whether VG exists
+func (l *LVM) IsLVGExists(lvName string) (bool, error) {
+	l.Lock()
+	defer l.Unlock()
+	_, ok := l.vgs[lvName]
+	return ok, nil
+}
+
+// GetLVsInVG returns sorted names of LVs in VG
+func (l *LVM) GetLVsInVG(vgName string) ([]string, error) {
+	l.Lock()
+	defer l.Unlock()
+	lvs := make([]string, 0, len(l.vgs[vgName]))
+	for lv := range l.vgs[vgName] {
+		lvs = append(lvs, lv)
+	}
+	sort.Strings(lvs)
+	return lvs, nil
+}
+
+// freeSpace returns size of VG without sizes of its LVs, lock must be held
+func (l *LVM) freeSpace(vgName string) int64 {
+	free := l.VGSize
+	for _, size := range l.vgs[vgName] {
+		free -= size
+	}
+	return free
+}
+
+// splitLVName splits full name of LV /dev/<VG>/<LV> to VG and LV names
+func splitLVName(fullLVName string) (string, string) {
+	parts := strings.Split(strings.TrimPrefix(fullLVName, "/dev/"), "/")
+	if len(parts) != 2 {
+		return "", fullLVName
+	}
+	return parts[0], parts[1]
+}
@@ -0,0 +1,91 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakes
+
+import (
+	"fmt"
+	"path"
+	"sync"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	"github.com/dell/csi-baremetal/pkg/node/provisioners"
+)
+
+// Provisioner is the in-memory implementation of provisioners.Provisioner, prepared volumes are represented
+// by device paths in DevDir
+type Provisioner struct {
+	sync.Mutex
+	// DevDir is the directory of device paths of prepared volumes
+	DevDir  string
+	volumes map[string]api.Volume
+}
+
+var _ provisioners.Provisioner = &Provisioner{}
+
+// NewProvisioner is the constructor for Provisioner struct
+// Receives directory of device paths of prepared volumes
+// Returns an instance of Provisioner
+func NewProvisioner(devDir string) *Provisioner {
+	return &Provisioner{DevDir: devDir, volumes: make(map[string]api.Volume)}
+}
+
+// PrepareVolume prepares volume
+func (p *Provisioner) PrepareVolume(volume api.Volume) error {
+	p.Lock()
+	defer p.Unlock()
+	p.volumes[volume.Id] = volume
+	return nil
+}
+
+// ReleaseVolume releases volume
+func (p *Provisioner) ReleaseVolume(volume api.Volume) error {
+	p.Lock()
+	defer p.Unlock()
+	delete(p.volumes, volume.Id)
+	return nil
+}
+
+// GetVolumePath returns device path of prepared volume
+func (p *Provisioner) GetVolumePath(volume api.Volume) (string, error) {
+	p.Lock()
+	defer p.Unlock()
+	if _, ok := p.volumes[volume.Id]; !ok {
+		return "", fmt.Errorf("volume %s isn't prepared", volume.Id)
+	}
+	return path.Join(p.DevDir, volume.Id), nil
+}
+
+// ExpandVolume sets size of prepared volume
+func (p *Provisioner) ExpandVolume(volume api.Volume) error {
+	p.Lock()
+	defer p.Unlock()
+	prepared, ok := p.volumes[volume.Id]
+	if !ok {
+		return fmt.Errorf("volume %s isn't prepared", volume.Id)
+	}
+	prepared.Size = volume.Size
+	p.volumes[volume.Id] = prepared
+	return nil
+}
+
+// IsPrepared checks whether volume with provided ID is prepared
+func (p *Provisioner) IsPrepared(volumeID string) bool {
+	p.Lock()
+	defer p.Unlock()
+	_, ok := p.volumes[volumeID]
+	return ok
+}
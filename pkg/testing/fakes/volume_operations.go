@@ -0,0 +1,153 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fakes contains in-memory implementations of driver interfaces, they keep state between calls
+// instead of expectations of mocks, so projects which embed the driver could unit-test against it
+// without Kubernetes API, DriveMgr gRPC server, drives and system utils
+package fakes
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/common"
+)
+
+// volumesResource is used in NotFound errors, so callers could handle them with k8sError.IsNotFound
+var volumesResource = schema.GroupResource{Group: apiV1.CSICRsGroupVersion, Resource: "volumes"}
+
+// VolumeOperations is the in-memory implementation of common.VolumeOperations, volumes are created
+// in Created status immediately
+type VolumeOperations struct {
+	sync.Mutex
+	// NodeID is set to volumes which are created without node
+	NodeID  string
+	volumes map[string]*api.Volume
+	errors  map[string]error
+}
+
+var _ common.VolumeOperations = &VolumeOperations{}
+
+// NewVolumeOperations is the constructor for VolumeOperations struct
+// Receives ID of node which is used for volumes without node
+// Returns an instance of VolumeOperations
+func NewVolumeOperations(nodeID string) *VolumeOperations {
+	return &VolumeOperations{
+		NodeID:  nodeID,
+		volumes: make(map[string]*api.Volume),
+		errors:  make(map[string]error),
+	}
+}
+
+// FailWith makes provided method, e.g. CreateVolume, return err until it is reset by nil
+func (vo *VolumeOperations) FailWith(method string, err error) {
+	vo.Lock()
+	defer vo.Unlock()
+	vo.errors[method] = err
+}
+
+// GetVolume returns copy of volume with provided ID or nil if it doesn't exist
+func (vo *VolumeOperations) GetVolume(volumeID string) *api.Volume {
+	vo.Lock()
+	defer vo.Unlock()
+	if v, ok := vo.volumes[volumeID]; ok {
+		volume := *v
+		return &volume
+	}
+	return nil
+}
+
+// CreateVolume creates volume in Created status or returns existing one
+func (vo *VolumeOperations) CreateVolume(_ context.Context, v api.Volume) (*api.Volume, error) {
+	vo.Lock()
+	defer vo.Unlock()
+	if err := vo.errors["CreateVolume"]; err != nil {
+		return nil, err
+	}
+	if existing, ok := vo.volumes[v.Id]; ok {
+		volume := *existing
+		return &volume, nil
+	}
+	if v.NodeId == "" {
+		v.NodeId = vo.NodeID
+	}
+	if v.StorageClass == "" || v.StorageClass == apiV1.StorageClassAny {
+		v.StorageClass = apiV1.StorageClassHDD
+	}
+	if v.Location == "" {
+		v.Location = fmt.Sprintf("fake-drive-%s", v.Id)
+	}
+	v.LocationType = apiV1.LocationTypeDrive
+	v.CSIStatus = apiV1.Created
+	v.Health = apiV1.HealthGood
+	v.OperationalStatus = apiV1.OperationalStatusOperative
+	vo.volumes[v.Id] = &v
+	volume := v
+	return &volume, nil
+}
+
+// DeleteVolume sets Removed status of volume, returns NotFound error if volume doesn't exist
+func (vo *VolumeOperations) DeleteVolume(_ context.Context, volumeID string) error {
+	vo.Lock()
+	defer vo.Unlock()
+	if err := vo.errors["DeleteVolume"]; err != nil {
+		return err
+	}
+	volume, ok := vo.volumes[volumeID]
+	if !ok {
+		return k8sError.NewNotFound(volumesResource, volumeID)
+	}
+	if volume.CSIStatus == apiV1.Failed {
+		return status.Error(codes.Internal, "volume has reached failed status")
+	}
+	volume.CSIStatus = apiV1.Removed
+	return nil
+}
+
+// UpdateCRsAfterVolumeDeletion removes volume
+func (vo *VolumeOperations) UpdateCRsAfterVolumeDeletion(_ context.Context, volumeID string) {
+	vo.Lock()
+	defer vo.Unlock()
+	delete(vo.volumes, volumeID)
+}
+
+// WaitStatus returns nil if volume is in one of provided statuses, status doesn't change in background,
+// so error is returned otherwise
+func (vo *VolumeOperations) WaitStatus(_ context.Context, volumeID string, statuses ...string) error {
+	vo.Lock()
+	defer vo.Unlock()
+	if err := vo.errors["WaitStatus"]; err != nil {
+		return err
+	}
+	volume, ok := vo.volumes[volumeID]
+	if !ok {
+		return k8sError.NewNotFound(volumesResource, volumeID)
+	}
+	for _, s := range statuses {
+		if volume.CSIStatus == s {
+			return nil
+		}
+	}
+	return fmt.Errorf("volume %s is in %s status", volumeID, volume.CSIStatus)
+}
@@ -0,0 +1,204 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	admissionV1beta1 "k8s.io/api/admission/v1beta1"
+	coreV1 "k8s.io/api/core/v1"
+	storageV1 "k8s.io/api/storage/v1"
+	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+)
+
+const (
+	// MutatePath is the path on which Mutator is served
+	MutatePath = "/mutate-csi-baremetal"
+
+	// selectedNodeAnnotation is set on PVC by kube-scheduler when volume binding mode is WaitForFirstConsumer
+	selectedNodeAnnotation = "volume.kubernetes.io/selected-node"
+	// NodeAnnotation is set on PVC of the driver with the name of the node which was chosen for volume
+	NodeAnnotation = "pvc.csi-baremetal.dell.com/node"
+	// ResolvedFromAnnotation is set on PVC which StorageClass with ANY storage type was replaced with concrete one
+	ResolvedFromAnnotation = "pvc.csi-baremetal.dell.com/resolved-from"
+)
+
+// Mutator is a mutating admission webhook which fills defaults in StorageClasses and PVCs of the driver
+type Mutator struct {
+	k8sClient *k8s.KubeClient
+	anyPolicy *capacityplanner.AnyPolicy
+	log       *logrus.Entry
+}
+
+// NewMutator is the constructor for Mutator struct
+// Receives an instance of base.KubeClient and logrus logger
+// Returns an instance of Mutator
+func NewMutator(k8sClient *k8s.KubeClient, logger *logrus.Logger) *Mutator {
+	return &Mutator{
+		k8sClient: k8sClient,
+		anyPolicy: capacityplanner.NewDefaultAnyPolicy(),
+		log:       logger.WithField("component", "Mutator"),
+	}
+}
+
+// SetupWithManager registers Mutator in webhook server of controller manager
+func (m *Mutator) SetupWithManager(mgr manager.Manager) {
+	mgr.GetWebhookServer().Register(MutatePath, &webhook.Admission{Handler: m})
+}
+
+// Handle is the admission.Handler implementation:
+// StorageClass of the driver gets default fsType and storageType parameters on creation,
+// PVC with ANY storage type gets concrete StorageClass with available capacity on creation,
+// PVC gets NodeAnnotation when node is selected by scheduler
+func (m *Mutator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	ll := m.log.WithFields(logrus.Fields{
+		"method": "Handle",
+		"kind":   req.Kind.Kind,
+		"name":   req.Name,
+	})
+
+	var (
+		obj interface{}
+		err error
+	)
+	switch req.Kind.Kind {
+	case "StorageClass":
+		if req.Operation != admissionV1beta1.Create {
+			return admission.Allowed("")
+		}
+		sc := &storageV1.StorageClass{}
+		if err = json.Unmarshal(req.Object.Raw, sc); err == nil {
+			m.defaultStorageClass(sc)
+			obj = sc
+		}
+	case "PersistentVolumeClaim":
+		pvc := &coreV1.PersistentVolumeClaim{}
+		if err = json.Unmarshal(req.Object.Raw, pvc); err == nil {
+			err = m.mutatePVC(ctx, pvc, req.Operation)
+			obj = pvc
+		}
+	default:
+		return admission.Allowed("")
+	}
+	if err != nil {
+		ll.Errorf("Unable to handle %s request: %v", req.Operation, err)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	mutated, err := json.Marshal(obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, mutated)
+}
+
+// defaultStorageClass sets default fsType and storageType parameters if StorageClass belongs to the driver
+func (m *Mutator) defaultStorageClass(sc *storageV1.StorageClass) {
+	if sc.Provisioner != base.PluginName {
+		return
+	}
+	if sc.Parameters == nil {
+		sc.Parameters = make(map[string]string, 2)
+	}
+	if sc.Parameters[base.FsTypeKey] == "" {
+		sc.Parameters[base.FsTypeKey] = base.DefaultFsType
+	}
+	if sc.Parameters[base.StorageTypeKey] == "" {
+		sc.Parameters[base.StorageTypeKey] = apiV1.StorageClassAny
+	}
+}
+
+// mutatePVC resolves ANY storage type on PVC creation and sets NodeAnnotation on PVC update
+func (m *Mutator) mutatePVC(ctx context.Context, pvc *coreV1.PersistentVolumeClaim,
+	operation admissionV1beta1.Operation) error {
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return nil
+	}
+	sc := &storageV1.StorageClass{}
+	if err := m.k8sClient.Get(ctx, k8sCl.ObjectKey{Name: *pvc.Spec.StorageClassName}, sc); err != nil {
+		return k8sCl.IgnoreNotFound(err)
+	}
+	if sc.Provisioner != base.PluginName {
+		return nil
+	}
+
+	switch operation {
+	case admissionV1beta1.Create:
+		if sc.Parameters[base.StorageTypeKey] == apiV1.StorageClassAny {
+			return m.resolveAnyStorageType(ctx, pvc)
+		}
+	case admissionV1beta1.Update:
+		if node, ok := pvc.Annotations[selectedNodeAnnotation]; ok && pvc.Annotations[NodeAnnotation] != node {
+			pvc.Annotations[NodeAnnotation] = node
+		}
+	}
+	return nil
+}
+
+// resolveAnyStorageType replaces StorageClass of PVC with StorageClass of the driver with concrete storage type
+// which has enough capacity for PVC, PVC isn't modified if there is no such StorageClass
+func (m *Mutator) resolveAnyStorageType(ctx context.Context, pvc *coreV1.PersistentVolumeClaim) error {
+	ll := m.log.WithFields(logrus.Fields{
+		"method": "resolveAnyStorageType",
+		"pvc":    pvc.Namespace + "/" + pvc.Name,
+	})
+
+	scList := &storageV1.StorageClassList{}
+	if err := m.k8sClient.List(ctx, scList); err != nil {
+		return err
+	}
+	// key - storage type, value - StorageClass name
+	classes := make(map[string]string)
+	for _, sc := range scList.Items {
+		if sc.Provisioner == base.PluginName {
+			if _, ok := classes[sc.Parameters[base.StorageTypeKey]]; !ok {
+				classes[sc.Parameters[base.StorageTypeKey]] = sc.Name
+			}
+		}
+	}
+
+	capacity, err := capacityplanner.NewACReader(m.k8sClient, m.log, false).ReadCapacity(ctx)
+	if err != nil {
+		return err
+	}
+	size := pvc.Spec.Resources.Requests[coreV1.ResourceStorage]
+	storageType := m.anyPolicy.Resolve(size.Value(), capacity, "")
+	scName, ok := classes[storageType]
+	if storageType == apiV1.StorageClassAny || !ok {
+		ll.Infof("Concrete storage type wasn't found, StorageClass %s is kept", *pvc.Spec.StorageClassName)
+		return nil
+	}
+
+	ll.Infof("StorageClass %s was resolved to %s", *pvc.Spec.StorageClassName, scName)
+	if pvc.Annotations == nil {
+		pvc.Annotations = make(map[string]string, 1)
+	}
+	pvc.Annotations[ResolvedFromAnnotation] = *pvc.Spec.StorageClassName
+	pvc.Spec.StorageClassName = &scName
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionV1beta1 "k8s.io/api/admission/v1beta1"
+	coreV1 "k8s.io/api/core/v1"
+	storageV1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+)
+
+var (
+	anySC = "csi-any"
+	hddSC = "csi-hdd"
+	ssdSC = "csi-ssd"
+)
+
+func TestMutator_Handle_StorageClass(t *testing.T) {
+	m := setupMutator(t)
+
+	sc := &storageV1.StorageClass{ObjectMeta: metaV1.ObjectMeta{Name: "new-sc"}, Provisioner: base.PluginName}
+	resp := m.Handle(testCtx, request(t, admissionV1beta1.Create, "StorageClass", sc, nil))
+	assert.True(t, resp.Allowed)
+	assert.Len(t, resp.Patches, 1)
+
+	sc.Provisioner = "other"
+	resp = m.Handle(testCtx, request(t, admissionV1beta1.Create, "StorageClass", sc, nil))
+	assert.True(t, resp.Allowed)
+	assert.Empty(t, resp.Patches)
+}
+
+func TestMutator_mutatePVC(t *testing.T) {
+	m := setupMutator(t)
+
+	t.Run("ANY is resolved to storage type with capacity", func(t *testing.T) {
+		pvc := testPVC(anySC, "100Mi")
+		assert.Nil(t, m.mutatePVC(testCtx, pvc, admissionV1beta1.Create))
+		assert.Equal(t, hddSC, *pvc.Spec.StorageClassName)
+		assert.Equal(t, anySC, pvc.Annotations[ResolvedFromAnnotation])
+	})
+
+	t.Run("ANY is kept if there is no capacity", func(t *testing.T) {
+		pvc := testPVC(anySC, "100Gi")
+		assert.Nil(t, m.mutatePVC(testCtx, pvc, admissionV1beta1.Create))
+		assert.Equal(t, anySC, *pvc.Spec.StorageClassName)
+	})
+
+	t.Run("Concrete storage class isn't changed", func(t *testing.T) {
+		pvc := testPVC(ssdSC, "100Mi")
+		assert.Nil(t, m.mutatePVC(testCtx, pvc, admissionV1beta1.Create))
+		assert.Equal(t, ssdSC, *pvc.Spec.StorageClassName)
+	})
+
+	t.Run("Selected node is annotated", func(t *testing.T) {
+		pvc := testPVC(hddSC, "100Mi")
+		pvc.Annotations = map[string]string{selectedNodeAnnotation: "node-1"}
+		assert.Nil(t, m.mutatePVC(testCtx, pvc, admissionV1beta1.Update))
+		assert.Equal(t, "node-1", pvc.Annotations[NodeAnnotation])
+	})
+}
+
+func setupMutator(t *testing.T) *Mutator {
+	k8sClient, err := k8s.GetFakeKubeClient("", testLogger)
+	assert.Nil(t, err)
+
+	for name, storageType := range map[string]string{
+		anySC: apiV1.StorageClassAny, hddSC: apiV1.StorageClassHDD, ssdSC: apiV1.StorageClassSSD} {
+		sc := &storageV1.StorageClass{
+			ObjectMeta:  metaV1.ObjectMeta{Name: name},
+			Provisioner: base.PluginName,
+			Parameters:  map[string]string{base.StorageTypeKey: storageType},
+		}
+		assert.Nil(t, k8sClient.Create(testCtx, sc))
+	}
+	ac := k8sClient.ConstructACCR("ac-1", api.AvailableCapacity{Location: "drive-1", NodeId: "node-1",
+		StorageClass: apiV1.StorageClassHDD, Size: int64(util.GBYTE)})
+	assert.Nil(t, k8sClient.CreateCR(testCtx, ac.Name, ac))
+
+	return NewMutator(k8sClient, testLogger)
+}
+
+func testPVC(sc, size string) *coreV1.PersistentVolumeClaim {
+	return &coreV1.PersistentVolumeClaim{
+		ObjectMeta: metaV1.ObjectMeta{Name: "pvc", Namespace: "default"},
+		Spec: coreV1.PersistentVolumeClaimSpec{
+			StorageClassName: &sc,
+			Resources: coreV1.ResourceRequirements{
+				Requests: coreV1.ResourceList{coreV1.ResourceStorage: resource.MustParse(size)},
+			},
+		},
+	}
+}
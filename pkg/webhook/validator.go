@@ -0,0 +1,199 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook contains admission webhooks of the driver
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	admissionV1beta1 "k8s.io/api/admission/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+)
+
+// ValidatePath is the path on which CRValidator is served
+const ValidatePath = "/validate-csi-baremetal"
+
+// CRValidator is a validating admission webhook which rejects malformed edits of Volume, LVG and Drive CRs
+type CRValidator struct {
+	log *logrus.Entry
+}
+
+// NewCRValidator is the constructor for CRValidator struct
+// Receives logrus logger
+// Returns an instance of CRValidator
+func NewCRValidator(logger *logrus.Logger) *CRValidator {
+	return &CRValidator{
+		log: logger.WithField("component", "CRValidator"),
+	}
+}
+
+// SetupWithManager registers CRValidator in webhook server of controller manager
+func (v *CRValidator) SetupWithManager(m manager.Manager) {
+	m.GetWebhookServer().Register(ValidatePath, &webhook.Admission{Handler: v})
+}
+
+// Handle is the admission.Handler implementation, it validates Volume, LVG and Drive CRs,
+// other kinds and DELETE operations are allowed
+func (v *CRValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	ll := v.log.WithFields(logrus.Fields{
+		"method": "Handle",
+		"kind":   req.Kind.Kind,
+		"name":   req.Name,
+	})
+
+	if req.Operation != admissionV1beta1.Create && req.Operation != admissionV1beta1.Update {
+		return admission.Allowed("")
+	}
+
+	var err error
+	switch req.Kind.Kind {
+	case "Volume":
+		err = validate(req, &volumecrd.Volume{}, &volumecrd.Volume{}, func(obj, old interface{}) error {
+			return validateVolume(obj.(*volumecrd.Volume), old.(*volumecrd.Volume))
+		})
+	case "LVG":
+		err = validate(req, &lvgcrd.LVG{}, &lvgcrd.LVG{}, func(obj, old interface{}) error {
+			return validateLVG(obj.(*lvgcrd.LVG), old.(*lvgcrd.LVG))
+		})
+	case "Drive":
+		err = validate(req, &drivecrd.Drive{}, &drivecrd.Drive{}, func(obj, old interface{}) error {
+			return validateDrive(obj.(*drivecrd.Drive), old.(*drivecrd.Drive))
+		})
+	default:
+		return admission.Allowed("")
+	}
+
+	if err != nil {
+		ll.Warnf("%s request was denied: %v", req.Operation, err)
+		if _, ok := err.(decodeError); ok {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+// decodeError is returned when object from admission request can't be decoded
+type decodeError struct {
+	error
+}
+
+// validate decodes object and old object (for UPDATE) from request and calls validation function,
+// old object is nil for CREATE requests
+func validate(req admission.Request, obj, old interface{}, validateFn func(obj, old interface{}) error) error {
+	if err := json.Unmarshal(req.Object.Raw, obj); err != nil {
+		return decodeError{err}
+	}
+	if req.Operation != admissionV1beta1.Update {
+		return validateFn(obj, nil)
+	}
+	if err := json.Unmarshal(req.OldObject.Raw, old); err != nil {
+		return decodeError{err}
+	}
+	return validateFn(obj, old)
+}
+
+// validateVolume checks Volume CR fields, old is nil for creation
+func validateVolume(volume, old *volumecrd.Volume) error {
+	if volume.Spec.Size < 0 {
+		return fmt.Errorf("size must not be negative, got %d", volume.Spec.Size)
+	}
+	if err := validateStorageClass(volume.Spec.StorageClass); err != nil {
+		return err
+	}
+	if old == nil {
+		return nil
+	}
+	return checkImmutable(map[string][2]string{
+		"Location":     {old.Spec.Location, volume.Spec.Location},
+		"LocationType": {old.Spec.LocationType, volume.Spec.LocationType},
+		"NodeId":       {old.Spec.NodeId, volume.Spec.NodeId},
+		"StorageClass": {old.Spec.StorageClass, volume.Spec.StorageClass},
+	})
+}
+
+// validateLVG checks LVG CR fields, old is nil for creation
+func validateLVG(lvg, old *lvgcrd.LVG) error {
+	if lvg.Spec.Size < 0 {
+		return fmt.Errorf("size must not be negative, got %d", lvg.Spec.Size)
+	}
+	switch lvg.Spec.FailureDomain {
+	case "", apiV1.FailureDomainEnclosure, apiV1.FailureDomainController:
+	default:
+		return fmt.Errorf("unknown failure domain %s, expected %s or %s", lvg.Spec.FailureDomain,
+			apiV1.FailureDomainEnclosure, apiV1.FailureDomainController)
+	}
+	if old == nil {
+		return nil
+	}
+	fields := map[string][2]string{
+		"Node":          {old.Spec.Node, lvg.Spec.Node},
+		"FailureDomain": {old.Spec.FailureDomain, lvg.Spec.FailureDomain},
+	}
+	// locations of LVG on system drive are set by LVG controller during creation
+	if old.Spec.Status != apiV1.Creating {
+		fields["Locations"] = [2]string{fmt.Sprint(old.Spec.Locations), fmt.Sprint(lvg.Spec.Locations)}
+	}
+	return checkImmutable(fields)
+}
+
+// validateDrive checks Drive CR fields, old is nil for creation
+func validateDrive(drive, old *drivecrd.Drive) error {
+	if drive.Spec.Size < 0 {
+		return fmt.Errorf("size must not be negative, got %d", drive.Spec.Size)
+	}
+	if old == nil {
+		return nil
+	}
+	return checkImmutable(map[string][2]string{
+		"UUID":         {old.Spec.UUID, drive.Spec.UUID},
+		"SerialNumber": {old.Spec.SerialNumber, drive.Spec.SerialNumber},
+		"NodeId":       {old.Spec.NodeId, drive.Spec.NodeId},
+		"Type":         {old.Spec.Type, drive.Spec.Type},
+	})
+}
+
+// validateStorageClass checks that storage class is known by the driver
+func validateStorageClass(sc string) error {
+	if util.ConvertStorageClass(sc) != sc {
+		return fmt.Errorf("unknown storage class %q", sc)
+	}
+	return nil
+}
+
+// checkImmutable returns error if at least one field was changed
+// Receives map where key - field name, value - old and new values of the field
+func checkImmutable(fields map[string][2]string) error {
+	for name, values := range fields {
+		if values[0] != values[1] {
+			return fmt.Errorf("field %s is immutable, %q can't be changed to %q", name, values[0], values[1])
+		}
+	}
+	return nil
+}
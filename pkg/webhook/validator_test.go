@@ -0,0 +1,164 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	admissionV1beta1 "k8s.io/api/admission/v1beta1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+)
+
+var (
+	testLogger = logrus.New()
+	testCtx    = context.Background()
+
+	testVolume = volumecrd.Volume{
+		ObjectMeta: metaV1.ObjectMeta{Name: "pvc-1"},
+		Spec: api.Volume{Id: "pvc-1", Location: "drive-1", NodeId: "node-1",
+			StorageClass: apiV1.StorageClassHDD, Size: 1024},
+	}
+	testLVG = lvgcrd.LVG{
+		ObjectMeta: metaV1.ObjectMeta{Name: "lvg-1"},
+		Spec: api.LogicalVolumeGroup{Name: "lvg-1", Node: "node-1", Locations: []string{"drive-1"},
+			Status: apiV1.Created, Size: 1024},
+	}
+	testDrive = drivecrd.Drive{
+		ObjectMeta: metaV1.ObjectMeta{Name: "drive-1"},
+		Spec:       api.Drive{UUID: "drive-1", SerialNumber: "SN1", NodeId: "node-1", Size: 1024},
+	}
+)
+
+func TestCRValidator_Handle(t *testing.T) {
+	v := NewCRValidator(testLogger)
+
+	t.Run("Valid volume is allowed", func(t *testing.T) {
+		resp := v.Handle(testCtx, request(t, admissionV1beta1.Create, "Volume", &testVolume, nil))
+		assert.True(t, resp.Allowed)
+	})
+
+	t.Run("Negative size is denied", func(t *testing.T) {
+		vol := testVolume.DeepCopy()
+		vol.Spec.Size = -1
+		resp := v.Handle(testCtx, request(t, admissionV1beta1.Create, "Volume", vol, nil))
+		assert.False(t, resp.Allowed)
+
+		drive := testDrive.DeepCopy()
+		drive.Spec.Size = -1
+		resp = v.Handle(testCtx, request(t, admissionV1beta1.Update, "Drive", drive, &testDrive))
+		assert.False(t, resp.Allowed)
+	})
+
+	t.Run("Unknown storage class is denied", func(t *testing.T) {
+		vol := testVolume.DeepCopy()
+		vol.Spec.StorageClass = "FLOPPY"
+		resp := v.Handle(testCtx, request(t, admissionV1beta1.Create, "Volume", vol, nil))
+		assert.False(t, resp.Allowed)
+	})
+
+	t.Run("Immutable fields", func(t *testing.T) {
+		vol := testVolume.DeepCopy()
+		vol.Spec.Location = "drive-2"
+		resp := v.Handle(testCtx, request(t, admissionV1beta1.Update, "Volume", vol, &testVolume))
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Reason, "Location")
+
+		vol = testVolume.DeepCopy()
+		vol.Spec.CSIStatus = apiV1.Created
+		resp = v.Handle(testCtx, request(t, admissionV1beta1.Update, "Volume", vol, &testVolume))
+		assert.True(t, resp.Allowed)
+
+		drive := testDrive.DeepCopy()
+		drive.Spec.SerialNumber = "SN2"
+		resp = v.Handle(testCtx, request(t, admissionV1beta1.Update, "Drive", drive, &testDrive))
+		assert.False(t, resp.Allowed)
+	})
+
+	t.Run("LVG locations", func(t *testing.T) {
+		lvg := testLVG.DeepCopy()
+		lvg.Spec.Locations = []string{"drive-2"}
+		resp := v.Handle(testCtx, request(t, admissionV1beta1.Update, "LVG", lvg, &testLVG))
+		assert.False(t, resp.Allowed)
+
+		// locations are set during creation
+		creating := testLVG.DeepCopy()
+		creating.Spec.Status = apiV1.Creating
+		resp = v.Handle(testCtx, request(t, admissionV1beta1.Update, "LVG", lvg, creating))
+		assert.True(t, resp.Allowed)
+	})
+
+	t.Run("LVG failure domain", func(t *testing.T) {
+		lvg := testLVG.DeepCopy()
+		lvg.Spec.FailureDomain = "rack"
+		resp := v.Handle(testCtx, request(t, admissionV1beta1.Create, "LVG", lvg, nil))
+		assert.False(t, resp.Allowed)
+
+		lvg.Spec.FailureDomain = apiV1.FailureDomainEnclosure
+		resp = v.Handle(testCtx, request(t, admissionV1beta1.Create, "LVG", lvg, nil))
+		assert.True(t, resp.Allowed)
+
+		resp = v.Handle(testCtx, request(t, admissionV1beta1.Update, "LVG", lvg, &testLVG))
+		assert.False(t, resp.Allowed)
+		assert.Contains(t, resp.Result.Reason, "FailureDomain")
+	})
+
+	t.Run("Other kinds and deletion are allowed", func(t *testing.T) {
+		resp := v.Handle(testCtx, request(t, admissionV1beta1.Create, "AvailableCapacity", &testVolume, nil))
+		assert.True(t, resp.Allowed)
+
+		resp = v.Handle(testCtx, admission.Request{AdmissionRequest: admissionV1beta1.AdmissionRequest{
+			Operation: admissionV1beta1.Delete,
+			Kind:      metaV1.GroupVersionKind{Kind: "Volume"},
+		}})
+		assert.True(t, resp.Allowed)
+	})
+
+	t.Run("Malformed object", func(t *testing.T) {
+		req := request(t, admissionV1beta1.Create, "Volume", &testVolume, nil)
+		req.Object.Raw = []byte("{")
+		resp := v.Handle(testCtx, req)
+		assert.False(t, resp.Allowed)
+	})
+}
+
+func request(t *testing.T, op admissionV1beta1.Operation, kind string, obj, old interface{}) admission.Request {
+	req := admission.Request{AdmissionRequest: admissionV1beta1.AdmissionRequest{
+		Operation: op,
+		Kind:      metaV1.GroupVersionKind{Kind: kind},
+	}}
+	raw, err := json.Marshal(obj)
+	assert.Nil(t, err)
+	req.Object = runtime.RawExtension{Raw: raw}
+	if old != nil {
+		raw, err = json.Marshal(old)
+		assert.Nil(t, err)
+		req.OldObject = runtime.RawExtension{Raw: raw}
+	}
+	return req
+}
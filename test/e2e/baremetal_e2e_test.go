@@ -46,6 +46,8 @@ func registerBMDriverFlags(flags *flag.FlagSet) {
 		true, "Wait for scheduler restart")
 	flags.BoolVar(&common.BMDriverTestContext.BMDeployCSIBMNodeOperator, "bm-deploy-csi-bm-node-operator",
 		true, "Deploy controller for CSIBMNode CRs")
+	flags.StringVar(&common.BMDriverTestContext.BMJBODProfile, "bm-jbod-profile", common.DefaultJBODProfile,
+		"Drives which are emulated by loop devices on each node in JBOD tests, e.g. hdd=4x150Mi,ssd=2x150Mi")
 }
 
 func init() {
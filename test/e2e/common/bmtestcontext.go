@@ -26,6 +26,7 @@ type BMDriverTestContextType struct {
 	BMDeploySchedulerPatcher  bool
 	BMWaitSchedulerRestart    bool
 	BMDeployCSIBMNodeOperator bool
+	BMJBODProfile             string
 }
 
 var BMDriverTestContext BMDriverTestContextType
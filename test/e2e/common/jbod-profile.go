@@ -0,0 +1,148 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+)
+
+// DefaultJBODProfile is JBOD profile which is emulated on each node when bm-jbod-profile flag isn't set
+const DefaultJBODProfile = "hdd=3x150Mi,ssd=1x150Mi"
+
+// JBODDrives is a group of drives of the same type and size in JBOD profile
+type JBODDrives struct {
+	DriveType string
+	Count     int
+	Size      string
+}
+
+// JBODProfile describes drives of each node which are emulated by loop devices of LoopBackManager
+type JBODProfile struct {
+	Drives []JBODDrives
+}
+
+// ParseJBODProfile parses profile in format <drive type>=<count>x<size>,... e.g. hdd=4x150Mi,ssd=2x300Mi
+// Returns JBODProfile or error if format is invalid
+func ParseJBODProfile(str string) (*JBODProfile, error) {
+	profile := &JBODProfile{}
+	for _, group := range strings.Split(str, ",") {
+		kv := strings.SplitN(strings.TrimSpace(group), "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("drives %s must be in format <drive type>=<count>x<size>", group)
+		}
+		driveType := strings.ToUpper(kv[0])
+		switch driveType {
+		case apiV1.DriveTypeHDD, apiV1.DriveTypeSSD, apiV1.DriveTypeNVMe:
+		default:
+			return nil, fmt.Errorf("unsupported drive type %s", kv[0])
+		}
+		countAndSize := strings.SplitN(kv[1], "x", 2)
+		if len(countAndSize) != 2 {
+			return nil, fmt.Errorf("drives %s must be in format <drive type>=<count>x<size>", group)
+		}
+		count, err := strconv.Atoi(countAndSize[0])
+		if err != nil || count <= 0 {
+			return nil, fmt.Errorf("count of drives %s must be positive integer", group)
+		}
+		if _, err := util.StrToBytes(countAndSize[1]); err != nil {
+			return nil, fmt.Errorf("invalid size of drives %s: %v", group, err)
+		}
+		profile.Drives = append(profile.Drives, JBODDrives{DriveType: driveType, Count: count, Size: countAndSize[1]})
+	}
+	return profile, nil
+}
+
+// DriveCount returns amount of drives with provided type on each node, all drives are counted if type is empty
+func (p *JBODProfile) DriveCount(driveType string) int {
+	count := 0
+	for _, drives := range p.Drives {
+		if driveType == "" || strings.EqualFold(drives.DriveType, driveType) {
+			count += drives.Count
+		}
+	}
+	return count
+}
+
+// DriveTypes returns types of drives in the profile in order of their appearance
+func (p *JBODProfile) DriveTypes() []string {
+	var types []string
+	for _, drives := range p.Drives {
+		if !util.ContainsString(types, drives.DriveType) {
+			types = append(types, drives.DriveType)
+		}
+	}
+	return types
+}
+
+// LoopBackManagerConfig builds config of LoopBackManager which emulates the profile on provided nodes,
+// serial numbers of drives are JBOD<drive type><index> on each node
+func (p *JBODProfile) LoopBackManagerConfig(nodeNames []string) LoopBackManagerConfig {
+	driveCount := p.DriveCount("")
+	config := LoopBackManagerConfig{DefaultDriveCount: &driveCount}
+	for _, nodeName := range nodeNames {
+		var (
+			name   = nodeName
+			drives []LoopBackManagerConfigDevice
+			index  = make(map[string]int)
+		)
+		for _, group := range p.Drives {
+			for i := 0; i < group.Count; i++ {
+				var (
+					serialNumber = fmt.Sprintf("JBOD%s%d", group.DriveType, index[group.DriveType])
+					driveType    = group.DriveType
+					size         = group.Size
+				)
+				index[group.DriveType]++
+				drives = append(drives, LoopBackManagerConfigDevice{
+					SerialNumber: &serialNumber,
+					DriveType:    &driveType,
+					Size:         &size,
+				})
+			}
+		}
+		config.Nodes = append(config.Nodes, LoopBackManagerConfigNode{
+			NodeID:     &name,
+			DriveCount: &driveCount,
+			Drives:     drives,
+		})
+	}
+	return config
+}
+
+// SetDriveHealth sets health of drive with provided serial number on provided node in config of LoopBackManager
+// Returns false if config doesn't contain the drive
+func SetDriveHealth(config *LoopBackManagerConfig, nodeName, serialNumber, health string) bool {
+	for i := range config.Nodes {
+		node := &config.Nodes[i]
+		if node.NodeID == nil || *node.NodeID != nodeName {
+			continue
+		}
+		for j := range node.Drives {
+			drive := &node.Drives[j]
+			if drive.SerialNumber != nil && *drive.SerialNumber == serialNumber {
+				drive.Health = &health
+				return true
+			}
+		}
+	}
+	return false
+}
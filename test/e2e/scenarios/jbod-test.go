@@ -0,0 +1,208 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scenarios
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/kubernetes/test/e2e/framework"
+	e2elog "k8s.io/kubernetes/test/e2e/framework/log"
+	e2enode "k8s.io/kubernetes/test/e2e/framework/node"
+	e2epod "k8s.io/kubernetes/test/e2e/framework/pod"
+	"k8s.io/kubernetes/test/e2e/storage/testsuites"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/test/e2e/common"
+)
+
+const (
+	// time during which PVC which exceeds capacity of JBOD must stay Pending
+	capacityExhaustionCheckTime = time.Minute
+	// maximum time to wait until capacity of removed volume is returned to AC
+	capacityReleaseTimeout = time.Minute * 2
+)
+
+// DefineJBODTestSuite defines tests of full PVC lifecycle, drive failure and capacity exhaustion on nodes
+// which drives are emulated by loop devices according to JBOD profile from bm-jbod-profile flag
+func DefineJBODTestSuite(driver testsuites.TestDriver) {
+	ginkgo.Context("Baremetal-csi JBOD profile tests", func() {
+		jbodTest(driver)
+	})
+}
+
+// jbodTest checks behavior of driver on JBOD of configured shape
+func jbodTest(driver testsuites.TestDriver) {
+	var (
+		testPODs      []*corev1.Pod
+		testPVCs      []*corev1.PersistentVolumeClaim
+		scByDriveType map[string]*storagev1.StorageClass
+		lmConf        common.LoopBackManagerConfig
+		profile       *common.JBODProfile
+		nodeNames     []string
+		driverCleanup func()
+		ns            string
+		f             = framework.NewDefaultFramework("jbod")
+	)
+
+	init := func() {
+		var (
+			perTestConf *testsuites.PerTestConfig
+			err         error
+		)
+		ns = f.Namespace.Name
+		testPODs, testPVCs = nil, nil
+
+		profile, err = common.ParseJBODProfile(common.BMDriverTestContext.BMJBODProfile)
+		framework.ExpectNoError(err)
+		nodes, err := e2enode.GetReadySchedulableNodesOrDie(f.ClientSet)
+		framework.ExpectNoError(err)
+		nodeNames = nil
+		for _, node := range nodes.Items {
+			nodeNames = append(nodeNames, node.Name)
+		}
+		lmConf = profile.LoopBackManagerConfig(nodeNames)
+		applyLMConfig(f, &lmConf)
+
+		perTestConf, driverCleanup = driver.PrepareTest(f)
+
+		scByDriveType = make(map[string]*storagev1.StorageClass)
+		for _, driveType := range profile.DriveTypes() {
+			sc := driver.(*baremetalDriver).GetStorageClassWithStorageType(perTestConf,
+				util.ConvertDriveTypeToStorageClass(driveType))
+			sc.Name += "-" + strings.ToLower(driveType)
+			sc, err = f.ClientSet.StorageV1().StorageClasses().Create(sc)
+			framework.ExpectNoError(err)
+			scByDriveType[driveType] = sc
+		}
+	}
+
+	cleanup := func() {
+		e2elog.Logf("Starting cleanup for test JBOD")
+		common.CleanupAfterCustomTest(f, driverCleanup, testPODs, testPVCs)
+	}
+
+	createPodWithPVC := func(driveType, name string) (*corev1.Pod, *corev1.PersistentVolumeClaim) {
+		pvc, err := f.ClientSet.CoreV1().PersistentVolumeClaims(ns).Create(constructPVC(ns,
+			driver.(testsuites.DynamicPVTestDriver).GetClaimSize(), scByDriveType[driveType].Name, name))
+		framework.ExpectNoError(err)
+		testPVCs = append(testPVCs, pvc)
+		pod, err := e2epod.CreatePod(f.ClientSet, ns, nil, []*corev1.PersistentVolumeClaim{pvc},
+			false, "sleep 3600")
+		framework.ExpectNoError(err)
+		testPODs = append(testPODs, pod)
+		return pod, pvc
+	}
+
+	ginkgo.It("should provision, use and release volume on drives of each type", func() {
+		init()
+		defer cleanup()
+
+		for _, driveType := range profile.DriveTypes() {
+			acCount := len(getUObjList(f, common.ACGVR).Items)
+			pod, pvc := createPodWithPVC(driveType, pvcName+"-"+strings.ToLower(driveType))
+
+			pv, err := framework.GetBoundPV(f.ClientSet, pvc)
+			framework.ExpectNoError(err)
+			volume, found := getUObj(f, common.VolumeGVR, pv.Spec.CSI.VolumeHandle)
+			Expect(found).To(BeTrue())
+			storageClass, _, err := unstructured.NestedString(volume.Object, "spec", "StorageClass")
+			framework.ExpectNoError(err)
+			Expect(storageClass).To(Equal(util.ConvertDriveTypeToStorageClass(driveType)))
+
+			e2elog.Logf("Release volume %s", volume.GetName())
+			framework.ExpectNoError(e2epod.DeletePodWithWait(f.ClientSet, pod))
+			framework.ExpectNoError(framework.DeletePersistentVolumeClaim(f.ClientSet, pvc.Name, ns))
+			framework.ExpectNoError(framework.WaitForPersistentVolumeDeleted(f.ClientSet, pv.Name,
+				5*time.Second, 2*time.Minute))
+			testPODs, testPVCs = nil, nil
+
+			deadline := time.Now().Add(capacityReleaseTimeout)
+			for len(getUObjList(f, common.ACGVR).Items) != acCount {
+				if time.Now().After(deadline) {
+					framework.Failf("capacity of volume %s isn't released", volume.GetName())
+				}
+				time.Sleep(time.Second * 5)
+			}
+		}
+	})
+
+	ginkgo.It("volume should become unhealthy when its drive fails", func() {
+		init()
+		defer cleanup()
+
+		driveType := profile.DriveTypes()[0]
+		_, pvc := createPodWithPVC(driveType, pvcName)
+		pv, err := framework.GetBoundPV(f.ClientSet, pvc)
+		framework.ExpectNoError(err)
+		volume, found := getUObj(f, common.VolumeGVR, pv.Spec.CSI.VolumeHandle)
+		Expect(found).To(BeTrue())
+		location, _, err := unstructured.NestedString(volume.Object, "spec", "Location")
+		framework.ExpectNoError(err)
+		nodeID, _, err := unstructured.NestedString(volume.Object, "spec", "NodeId")
+		framework.ExpectNoError(err)
+		nodeName, err := findNodeNameByUID(f, nodeID)
+		framework.ExpectNoError(err)
+		drive, found := getUObj(f, common.DriveGVR, location)
+		Expect(found).To(BeTrue())
+		serialNumber, _, err := unstructured.NestedString(drive.Object, "spec", "SerialNumber")
+		framework.ExpectNoError(err)
+
+		Expect(common.SetDriveHealth(&lmConf, nodeName, serialNumber, apiV1.HealthBad)).To(BeTrue())
+		applyLMConfig(f, &lmConf)
+
+		waitForObjStateChange(f, common.DriveGVR, drive.GetName(), driveStateChangeTimeout,
+			apiV1.HealthBad, "spec", "Health")
+		waitForObjStateChange(f, common.VolumeGVR, volume.GetName(), driveStateChangeTimeout,
+			apiV1.HealthBad, "spec", "Health")
+	})
+
+	ginkgo.It("should not provision volumes beyond capacity of JBOD", func() {
+		init()
+		defer cleanup()
+
+		// each volume takes the whole drive, so amount of drives of the type on all nodes is the limit
+		driveType := profile.DriveTypes()[0]
+		limit := profile.DriveCount(driveType) * len(nodeNames)
+		for i := 0; i < limit; i++ {
+			createPodWithPVC(driveType, pvcName+"-"+strings.ToLower(driveType)+"-"+strconv.Itoa(i))
+		}
+
+		pvc, err := f.ClientSet.CoreV1().PersistentVolumeClaims(ns).Create(constructPVC(ns,
+			driver.(testsuites.DynamicPVTestDriver).GetClaimSize(), scByDriveType[driveType].Name, pvcName+"-exceeding"))
+		framework.ExpectNoError(err)
+		testPVCs = append(testPVCs, pvc)
+		pod, err := f.ClientSet.CoreV1().Pods(ns).Create(
+			e2epod.MakePod(ns, nil, []*corev1.PersistentVolumeClaim{pvc}, false, "sleep 3600"))
+		framework.ExpectNoError(err)
+		testPODs = append(testPODs, pod)
+
+		Consistently(func() corev1.PersistentVolumeClaimPhase {
+			claim, err := f.ClientSet.CoreV1().PersistentVolumeClaims(ns).Get(pvc.Name, metav1.GetOptions{})
+			framework.ExpectNoError(err)
+			return claim.Status.Phase
+		}, capacityExhaustionCheckTime, 5*time.Second).Should(Equal(corev1.ClaimPending))
+	})
+}
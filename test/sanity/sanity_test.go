@@ -38,6 +38,8 @@ import (
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsblk"
 	"github.com/dell/csi-baremetal/pkg/base/rpc"
 	"github.com/dell/csi-baremetal/pkg/controller"
+	"github.com/dell/csi-baremetal/pkg/drivemgr"
+	"github.com/dell/csi-baremetal/pkg/drivemgr/fakemgr"
 	"github.com/dell/csi-baremetal/pkg/mocks"
 	"github.com/dell/csi-baremetal/pkg/mocks/provisioners"
 	"github.com/dell/csi-baremetal/pkg/node"
@@ -82,8 +84,28 @@ func skipIfNotSanity(t *testing.T) {
 	}
 }
 
+// TestDeployedDriverWithSanity runs sanity test suite against already deployed driver, e.g. on kind cluster.
+// Endpoints of Node and Controller services are passed through SANITY_NODE_ENDPOINT and SANITY_CONTROLLER_ENDPOINT
+func TestDeployedDriverWithSanity(t *testing.T) {
+	skipIfNotSanity(t)
+	nodeAddress := os.Getenv("SANITY_NODE_ENDPOINT")
+	if nodeAddress == "" {
+		t.Skip("Skipping Sanity testing of deployed driver")
+	}
+
+	config := sanity.NewTestConfig()
+	config.Address = nodeAddress
+	config.ControllerAddress = os.Getenv("SANITY_CONTROLLER_ENDPOINT")
+	config.JUnitFile = "report-deployed.xml"
+
+	sanity.Test(t, config)
+}
+
 func TestDriverWithSanity(t *testing.T) {
 	skipIfNotSanity(t)
+	if os.Getenv("SANITY_NODE_ENDPOINT") != "" {
+		t.Skip("Skipping Sanity testing of in-process driver")
+	}
 
 	// Node and Controller must share Fake k8s client because sanity tests don't run under k8s env.
 	kubeClient, err := k8s.GetFakeKubeClient(testNs, logrus.New())
@@ -113,11 +135,11 @@ func TestDriverWithSanity(t *testing.T) {
 func newControllerSvc(kubeClient *k8s.KubeClient) {
 	ll, _ := base.InitLogger("", base.DebugLevel)
 
-	controllerService := controller.NewControllerService(kubeClient, ll, featureconfig.NewFeatureConfig())
+	controllerService := controller.NewControllerService(kubeClient, ll, featureconfig.NewFeatureConfig(), nil, nil, nil, nil)
 
 	csiControllerServer := rpc.NewServerRunner(nil, controllerEndpoint, ll)
 
-	csi.RegisterIdentityServer(csiControllerServer.GRPCServer, controller.NewIdentityServer(driverName, version))
+	csi.RegisterIdentityServer(csiControllerServer.GRPCServer, controller.NewIdentityServer(driverName, version, nil))
 	csi.RegisterControllerServer(csiControllerServer.GRPCServer, controllerService)
 
 	ll.Info("Starting CSIControllerService")
@@ -158,14 +180,14 @@ func newNodeSvc(kubeClient *k8s.KubeClient, nodeReady chan<- bool) {
 	}
 }
 
-// prepareNodeMock prepares instance of Node service with mocked drivemgr and mocked executor
+// prepareNodeMock prepares instance of Node service with in-memory drivemgr and mocked executor
 func prepareNodeMock(kubeClient *k8s.KubeClient, log *logrus.Logger) *node.CSINodeService {
-	c := mocks.NewMockDriveMgrClient(testDrives)
+	c := drivemgr.NewLocalClient(log, fakemgr.New(log, testDrives))
 	e := mocks.NewMockExecutor(map[string]mocks.CmdOut{fmt.Sprintf(lsblk.CmdTmpl, ""): {Stdout: mocks.LsblkTwoDevicesStr}})
 	e.SetSuccessIfNotFound(true)
 
 	nodeService := node.NewCSINodeService(nil, nodeId, log, kubeClient,
-		new(mocks.NoOpRecorder), featureconfig.NewFeatureConfig())
+		new(mocks.NoOpRecorder), featureconfig.NewFeatureConfig(), nil, nil)
 
 	nodeService.VolumeManager = *node.NewVolumeManager(c, e, log, kubeClient, new(mocks.NoOpRecorder), nodeId)
 
@@ -18,6 +18,9 @@ BLD_SHA          := $(shell echo ${BUILD_REL_B})
 RELEASE_STR      := ${BLD_CNT}.${BLD_SHA}
 FULL_VERSION     := ${PRODUCT_VERSION}-${RELEASE_STR}
 TAG              := ${FULL_VERSION}
+# version and commit reported by Identity service of the plugin
+LDFLAGS          := -ldflags "-X github.com/dell/csi-baremetal/pkg/base.PluginVersion=${FULL_VERSION} \
+					-X github.com/dell/csi-baremetal/pkg/base.PluginGitCommit=${BLD_SHA}"
 
 ### third-party components version
 CSI_PROVISIONER_TAG := v1.2.2
@@ -41,6 +44,7 @@ EXTENDER         := extender
 EXTENDER_PATCHER := scheduler-patcher
 CSI_BM_NODE      := csibmnode
 PLUGIN           := plugin
+CLI              := csibm
 
 BASE_DRIVE_MGR     := basemgr
 LOOPBACK_DRIVE_MGR := loopbackmgr